@@ -0,0 +1,68 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package capabilities tracks the capabilities required by the channel config
+// at the Channel, Orderer and Application levels, and the behaviors of this
+// binary which are gated by them. A capability is only ever added to a channel
+// once every node processing that level of the config supports it, so that all
+// nodes switch to the new behavior at the same block.
+package capabilities
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	cb "github.com/hyperledger/fabric/protos/common"
+)
+
+var logger = flogging.MustGetLogger("common/capabilities")
+
+// provider is implemented by each config level to declare the capabilities
+// this binary supports for that level
+type provider interface {
+	// HasCapability should report whether the binary supports this capability
+	HasCapability(capability string) bool
+
+	// Type is used to make error messages more legible
+	Type() string
+}
+
+// registry is a common structure intended to be used to support specific
+// aspects of capabilities, such as orderer, application, and channel
+type registry struct {
+	provider     provider
+	capabilities map[string]*cb.Capability
+}
+
+func newRegistry(p provider, capabilities map[string]*cb.Capability) *registry {
+	return &registry{
+		provider:     p,
+		capabilities: capabilities,
+	}
+}
+
+// Supported checks that all of the required capabilities are supported by this binary
+func (r *registry) Supported() error {
+	for capabilityName := range r.capabilities {
+		if r.provider.HasCapability(capabilityName) {
+			logger.Debugf("%s capability %s is supported and is enabled", r.provider.Type(), capabilityName)
+			continue
+		}
+
+		return fmt.Errorf("%s capability %s is required but not supported", r.provider.Type(), capabilityName)
+	}
+	return nil
+}
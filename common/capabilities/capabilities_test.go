@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capabilities
+
+import (
+	"testing"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelCapabilities(t *testing.T) {
+	cp := NewChannelProvider(nil)
+	assert.NoError(t, cp.Supported())
+
+	assert.False(t, cp.TLSBinding())
+
+	cp = NewChannelProvider(map[string]*cb.Capability{ChannelV1_1: {}})
+	assert.NoError(t, cp.Supported())
+	assert.False(t, cp.TLSBinding())
+
+	cp = NewChannelProvider(map[string]*cb.Capability{ChannelV1_1: {}, ChannelTLSBinding: {}})
+	assert.NoError(t, cp.Supported())
+	assert.True(t, cp.TLSBinding())
+
+	cp = NewChannelProvider(map[string]*cb.Capability{"FakeCapability": {}})
+	err := cp.Supported()
+	assert.Error(t, err)
+	assert.Equal(t, "Channel capability FakeCapability is required but not supported", err.Error())
+}
+
+func TestOrdererCapabilities(t *testing.T) {
+	op := NewOrdererProvider(nil)
+	assert.NoError(t, op.Supported())
+	assert.False(t, op.PredictableChannelTemplate())
+
+	op = NewOrdererProvider(map[string]*cb.Capability{OrdererV1_1: {}})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.PredictableChannelTemplate())
+	assert.False(t, op.OrgEndpoints())
+
+	op = NewOrdererProvider(map[string]*cb.Capability{OrdererOrgEndpoints: {}})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.OrgEndpoints())
+
+	op = NewOrdererProvider(map[string]*cb.Capability{OrdererV1_1: {}, "FakeCapability": {}})
+	assert.Error(t, op.Supported())
+}
+
+func TestApplicationCapabilities(t *testing.T) {
+	ap := NewApplicationProvider(nil)
+	assert.NoError(t, ap.Supported())
+	assert.False(t, ap.ForbidDuplicateTXIdInBlock())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{ApplicationV1_1: {}})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.ForbidDuplicateTXIdInBlock())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{"FakeCapability": {}})
+	assert.Error(t, ap.Supported())
+}
@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capabilities
+
+import (
+	cb "github.com/hyperledger/fabric/protos/common"
+)
+
+const (
+	channelTypeName = "Channel"
+
+	// ChannelV1_1 is the capabilities string for standard new non-backwards compatible
+	// fabric v1.1 channel capabilities
+	ChannelV1_1 = "V1_1"
+
+	// ChannelTLSBinding is the capabilities string for requiring the messages sent
+	// on the channel to be bound to the TLS certificate of the client
+	ChannelTLSBinding = "TLS_BINDING"
+)
+
+// ChannelProvider provides capabilities information for channel level config
+type ChannelProvider struct {
+	*registry
+	tlsBinding bool
+}
+
+// NewChannelProvider creates a channel capabilities provider
+func NewChannelProvider(capabilities map[string]*cb.Capability) *ChannelProvider {
+	cp := &ChannelProvider{}
+	cp.registry = newRegistry(cp, capabilities)
+	_, cp.tlsBinding = capabilities[ChannelTLSBinding]
+	return cp
+}
+
+// Type returns a descriptive string for logging purposes
+func (cp *ChannelProvider) Type() string {
+	return channelTypeName
+}
+
+// HasCapability returns true if the capability is supported by this binary
+func (cp *ChannelProvider) HasCapability(capability string) bool {
+	switch capability {
+	// Add new capability names here
+	case ChannelV1_1:
+		return true
+	case ChannelTLSBinding:
+		return true
+	default:
+		return false
+	}
+}
+
+// TLSBinding specifies whether the proposals, broadcast and deliver requests of the channel
+// must carry the hash of the TLS certificate the client presented on the gRPC stream
+func (cp *ChannelProvider) TLSBinding() bool {
+	return cp.tlsBinding
+}
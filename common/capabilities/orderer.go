@@ -0,0 +1,80 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capabilities
+
+import (
+	cb "github.com/hyperledger/fabric/protos/common"
+)
+
+const (
+	ordererTypeName = "Orderer"
+
+	// OrdererV1_1 is the capabilities string for standard new non-backwards compatible
+	// fabric v1.1 orderer capabilities
+	OrdererV1_1 = "V1_1"
+
+	// OrdererOrgEndpoints is the capabilities string for allowing the orderer orgs
+	// to define the addresses of their own ordering service nodes
+	OrdererOrgEndpoints = "ORG_ENDPOINTS"
+)
+
+// OrdererProvider provides capabilities information for orderer level config
+type OrdererProvider struct {
+	*registry
+	v11          bool
+	orgEndpoints bool
+}
+
+// NewOrdererProvider creates an orderer capabilities provider
+func NewOrdererProvider(capabilities map[string]*cb.Capability) *OrdererProvider {
+	op := &OrdererProvider{}
+	op.registry = newRegistry(op, capabilities)
+	_, op.v11 = capabilities[OrdererV1_1]
+	_, op.orgEndpoints = capabilities[OrdererOrgEndpoints]
+	return op
+}
+
+// Type returns a descriptive string for logging purposes
+func (op *OrdererProvider) Type() string {
+	return ordererTypeName
+}
+
+// HasCapability returns true if the capability is supported by this binary
+func (op *OrdererProvider) HasCapability(capability string) bool {
+	switch capability {
+	// Add new capability names here
+	case OrdererV1_1:
+		return true
+	case OrdererOrgEndpoints:
+		return true
+	default:
+		return false
+	}
+}
+
+// PredictableChannelTemplate specifies whether the v1.0 undesirable behavior of setting
+// the /Channel group's mod_policy to "" when creating a new channel from the system
+// channel template should be fixed or not
+func (op *OrdererProvider) PredictableChannelTemplate() bool {
+	return op.v11
+}
+
+// OrgEndpoints specifies whether the orderer orgs may define the addresses of their
+// own ordering service nodes, which nodes without this capability do not understand
+func (op *OrdererProvider) OrgEndpoints() bool {
+	return op.orgEndpoints
+}
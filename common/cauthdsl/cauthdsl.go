@@ -18,9 +18,13 @@ package cauthdsl
 
 import (
 	"fmt"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
 	mb "github.com/hyperledger/fabric/protos/msp"
@@ -29,31 +33,50 @@ import (
 var cauthdslLogger = flogging.MustGetLogger("cauthdsl")
 var deserializedIdentites = make(map[string]msp.Identity)
 
+// signatureCache, when set, lets signature gates skip verifying signatures
+// which have already been verified. It holds a *msp.SignatureCache so that it
+// may be swapped while policies are being evaluated.
+var signatureCache atomic.Value
+
+// SetSignatureCache sets the cache of verified signatures consulted when
+// evaluating signature policies, or disables it if cache is nil.
+func SetSignatureCache(cache *msp.SignatureCache) {
+	signatureCache.Store(cache)
+}
+
+// evaluator evaluates a compiled policy against a set of signatures, marking the
+// signatures it consumes in used, and returns the trace of the evaluation
+type evaluator func(signedData []*cb.SignedData, used []bool) (bool, *policies.EvaluationTrace)
+
 // compile recursively builds a go evaluatable function corresponding to the policy specified
-func compile(policy *cb.SignaturePolicy, identities []*mb.MSPPrincipal, deserializer msp.IdentityDeserializer) (func([]*cb.SignedData, []bool) bool, error) {
+func compile(policy *cb.SignaturePolicy, identities []*mb.MSPPrincipal, deserializer msp.IdentityDeserializer) (evaluator, error) {
 	if policy == nil {
 		return nil, fmt.Errorf("Empty policy element")
 	}
 
 	switch t := policy.Type.(type) {
 	case *cb.SignaturePolicy_NOutOf_:
-		policies := make([]func([]*cb.SignedData, []bool) bool, len(t.NOutOf.Rules))
+		compiledPolicies := make([]evaluator, len(t.NOutOf.Rules))
 		for i, policy := range t.NOutOf.Rules {
 			compiledPolicy, err := compile(policy, identities, deserializer)
 			if err != nil {
 				return nil, err
 			}
-			policies[i] = compiledPolicy
+			compiledPolicies[i] = compiledPolicy
 
 		}
-		return func(signedData []*cb.SignedData, used []bool) bool {
+		rule := fmt.Sprintf("%d out of %d", t.NOutOf.N, len(t.NOutOf.Rules))
+		return func(signedData []*cb.SignedData, used []bool) (bool, *policies.EvaluationTrace) {
 			grepKey := time.Now().UnixNano()
 			cauthdslLogger.Debugf("%p gate %d evaluation starts", signedData, grepKey)
+			trace := &policies.EvaluationTrace{Rule: rule}
 			verified := int32(0)
 			_used := make([]bool, len(used))
-			for _, policy := range policies {
+			for _, policy := range compiledPolicies {
 				copy(_used, used)
-				if policy(signedData, _used) {
+				ok, subTrace := policy(signedData, _used)
+				trace.Children = append(trace.Children, subTrace)
+				if ok {
 					verified++
 					if verified >= t.NOutOf.N {
 						break
@@ -62,52 +85,87 @@ func compile(policy *cb.SignaturePolicy, identities []*mb.MSPPrincipal, deserial
 				}
 			}
 
-			if verified >= t.NOutOf.N {
+			trace.Satisfied = verified >= t.NOutOf.N
+			if trace.Satisfied {
 				cauthdslLogger.Debugf("%p gate %d evaluation succeeds", signedData, grepKey)
 			} else {
 				cauthdslLogger.Debugf("%p gate %d evaluation fails", signedData, grepKey)
 			}
 
-			return verified >= t.NOutOf.N
+			return trace.Satisfied, trace
 		}, nil
 	case *cb.SignaturePolicy_SignedBy:
 		if t.SignedBy < 0 || t.SignedBy >= int32(len(identities)) {
 			return nil, fmt.Errorf("identity index out of range, requested %v, but identies length is %d", t.SignedBy, len(identities))
 		}
 		signedByID := identities[t.SignedBy]
-		return func(signedData []*cb.SignedData, used []bool) bool {
+		rule := "signed by " + principalString(signedByID)
+		return func(signedData []*cb.SignedData, used []bool) (bool, *policies.EvaluationTrace) {
 			cauthdslLogger.Debugf("%p signed by %d principal evaluation starts (used %v)", signedData, t.SignedBy, used)
+			trace := &policies.EvaluationTrace{Rule: rule}
 			for i, sd := range signedData {
 				if used[i] {
 					cauthdslLogger.Debugf("%p skipping identity %d because it has already been used", signedData, i)
+					trace.Signatures = append(trace.Signatures, &policies.SignatureTrace{Index: i, Outcome: "already used by another gate"})
 					continue
 				}
 
 				identity, err := deserializer.DeserializeIdentity(sd.Identity)
 				if err != nil {
 					cauthdslLogger.Errorf("Principal deserialization failed: (%s) for identity %v", err, sd.Identity)
+					trace.Signatures = append(trace.Signatures, &policies.SignatureTrace{Index: i, Outcome: fmt.Sprintf("could not be deserialized: %s", err)})
 					continue
 				}
+				sigTrace := &policies.SignatureTrace{Index: i, MSPID: identity.GetMSPIdentifier()}
+				trace.Signatures = append(trace.Signatures, sigTrace)
 
 				err = identity.SatisfiesPrincipal(signedByID)
 				if err != nil {
 					cauthdslLogger.Debugf("%p identity %d does not satisfy principal: %s", signedData, i, err)
+					sigTrace.Outcome = fmt.Sprintf("does not satisfy principal: %s", err)
 					continue
 				}
 				cauthdslLogger.Debugf("%p principal matched by identity %d", signedData, i)
-				err = identity.Verify(sd.Data, sd.Signature)
+				cache, _ := signatureCache.Load().(*msp.SignatureCache)
+				err = cache.Verify(identity, sd.Identity, sd.Data, sd.Signature)
 				if err != nil {
 					cauthdslLogger.Debugf("%p signature for identity %d is invalid: %s", signedData, i, err)
+					sigTrace.Outcome = fmt.Sprintf("has an invalid signature: %s", err)
 					continue
 				}
 				cauthdslLogger.Debugf("%p principal evaluation succeeds for identity %d", signedData, i)
+				sigTrace.Outcome = "matched"
 				used[i] = true
-				return true
+				trace.Satisfied = true
+				return true, trace
 			}
 			cauthdslLogger.Debugf("%p principal evaluation fails", signedData)
-			return false
+			if len(signedData) == 0 {
+				trace.Reason = "no signatures supplied"
+			}
+			return false, trace
 		}, nil
 	default:
 		return nil, fmt.Errorf("Unknown type: %T:%v", t, t)
 	}
 }
+
+// principalString returns a short human readable form of a principal for evaluation traces
+func principalString(principal *mb.MSPPrincipal) string {
+	switch principal.PrincipalClassification {
+	case mb.MSPPrincipal_ROLE:
+		role := &mb.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err != nil {
+			return "malformed role principal"
+		}
+		return fmt.Sprintf("%s.%s", role.MspIdentifier, strings.ToLower(role.Role.String()))
+	case mb.MSPPrincipal_ORGANIZATION_UNIT:
+		ou := &mb.OrganizationUnit{}
+		if err := proto.Unmarshal(principal.Principal, ou); err != nil {
+			return "malformed organization unit principal"
+		}
+		return fmt.Sprintf("%s.%s", ou.MspIdentifier, ou.OrganizationalUnitIdentifier)
+	default:
+		return fmt.Sprintf("%s principal", principal.PrincipalClassification)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
 	mb "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
 
 	"github.com/golang/protobuf/proto"
 	logging "github.com/op/go-logging"
@@ -108,17 +109,38 @@ func TestSimpleSignature(t *testing.T) {
 		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
 	}
 
-	if !spe(toSignedData([][]byte{nil}, [][]byte{signers[0]}, [][]byte{validSignature})) {
+	if ok, _ := spe(toSignedData([][]byte{nil}, [][]byte{signers[0]}, [][]byte{validSignature})); !ok {
 		t.Errorf("Expected authentication to succeed with valid signatures")
 	}
-	if spe(toSignedData([][]byte{nil}, [][]byte{signers[0]}, [][]byte{invalidSignature})) {
+	if ok, _ := spe(toSignedData([][]byte{nil}, [][]byte{signers[0]}, [][]byte{invalidSignature})); ok {
 		t.Errorf("Expected authentication to fail given the invalid signature")
 	}
-	if spe(toSignedData([][]byte{nil}, [][]byte{signers[1]}, [][]byte{validSignature})) {
+	if ok, _ := spe(toSignedData([][]byte{nil}, [][]byte{signers[1]}, [][]byte{validSignature})); ok {
 		t.Errorf("Expected authentication to fail because signers[1] is not authorized in the policy, despite his valid signature")
 	}
 }
 
+func TestSetSignatureCacheConcurrently(t *testing.T) {
+	defer SetSignatureCache(nil)
+
+	policy := Envelope(SignedBy(0), signers)
+	spe, err := compile(policy.Rule, policy.Identities, &mockDeserializer{})
+	assert.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			SetSignatureCache(msp.NewSignatureCache(10))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		ok, _ := spe(toSignedData([][]byte{nil}, [][]byte{signers[0]}, [][]byte{validSignature}))
+		assert.True(t, ok)
+	}
+	<-done
+}
+
 func TestMultipleSignature(t *testing.T) {
 	policy := Envelope(And(SignedBy(0), SignedBy(1)), signers)
 
@@ -127,13 +149,13 @@ func TestMultipleSignature(t *testing.T) {
 		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
 	}
 
-	if !spe(toSignedData(msgs, signers, [][]byte{validSignature, validSignature})) {
+	if ok, _ := spe(toSignedData(msgs, signers, [][]byte{validSignature, validSignature})); !ok {
 		t.Errorf("Expected authentication to succeed with  valid signatures")
 	}
-	if spe(toSignedData(msgs, signers, [][]byte{validSignature, invalidSignature})) {
+	if ok, _ := spe(toSignedData(msgs, signers, [][]byte{validSignature, invalidSignature})); ok {
 		t.Errorf("Expected authentication to fail given one of two invalid signatures")
 	}
-	if spe(toSignedData(msgs, [][]byte{signers[0], signers[0]}, [][]byte{validSignature, validSignature})) {
+	if ok, _ := spe(toSignedData(msgs, [][]byte{signers[0], signers[0]}, [][]byte{validSignature, validSignature})); ok {
 		t.Errorf("Expected authentication to fail because although there were two valid signatures, one was duplicated")
 	}
 }
@@ -146,19 +168,19 @@ func TestComplexNestedSignature(t *testing.T) {
 		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
 	}
 
-	if !spe(toSignedData(moreMsgs, append(signers, [][]byte{[]byte("signer0")}...), [][]byte{validSignature, validSignature, validSignature})) {
+	if ok, _ := spe(toSignedData(moreMsgs, append(signers, [][]byte{[]byte("signer0")}...), [][]byte{validSignature, validSignature, validSignature})); !ok {
 		t.Errorf("Expected authentication to succeed with valid signatures")
 	}
-	if !spe(toSignedData(moreMsgs, [][]byte{[]byte("signer0"), []byte("signer0"), []byte("signer0")}, [][]byte{validSignature, validSignature, validSignature})) {
+	if ok, _ := spe(toSignedData(moreMsgs, [][]byte{[]byte("signer0"), []byte("signer0"), []byte("signer0")}, [][]byte{validSignature, validSignature, validSignature})); !ok {
 		t.Errorf("Expected authentication to succeed with valid signatures")
 	}
-	if spe(toSignedData(msgs, signers, [][]byte{validSignature, validSignature})) {
+	if ok, _ := spe(toSignedData(msgs, signers, [][]byte{validSignature, validSignature})); ok {
 		t.Errorf("Expected authentication to fail with too few signatures")
 	}
-	if spe(toSignedData(moreMsgs, append(signers, [][]byte{[]byte("signer0")}...), [][]byte{validSignature, invalidSignature, validSignature})) {
+	if ok, _ := spe(toSignedData(moreMsgs, append(signers, [][]byte{[]byte("signer0")}...), [][]byte{validSignature, invalidSignature, validSignature})); ok {
 		t.Errorf("Expected authentication failure as the signature of signer[1] was invalid")
 	}
-	if spe(toSignedData(moreMsgs, append(signers, [][]byte{[]byte("signer1")}...), [][]byte{validSignature, validSignature, validSignature})) {
+	if ok, _ := spe(toSignedData(moreMsgs, append(signers, [][]byte{[]byte("signer1")}...), [][]byte{validSignature, validSignature, validSignature})); ok {
 		t.Errorf("Expected authentication failure as there was a signature from signer[0] missing")
 	}
 }
@@ -179,3 +201,42 @@ func TestNilSignaturePolicyEnvelope(t *testing.T) {
 	_, err := compile(nil, nil, &mockDeserializer{})
 	assert.Error(t, err, "Fail to compile")
 }
+
+func TestEvaluationTrace(t *testing.T) {
+	policy := Envelope(And(SignedBy(0), SignedBy(1)), signers)
+
+	spe, err := compile(policy.Rule, policy.Identities, &mockDeserializer{})
+	assert.NoError(t, err)
+
+	ok, trace := spe(toSignedData(msgs, signers, [][]byte{validSignature, invalidSignature}))
+	assert.False(t, ok)
+	assert.Equal(t, "2 out of 2", trace.Rule)
+	assert.False(t, trace.Satisfied)
+	assert.Len(t, trace.Children, 2)
+
+	first := trace.Children[0]
+	assert.True(t, first.Satisfied)
+	assert.Equal(t, "signed by IDENTITY principal", first.Rule)
+	assert.Len(t, first.Signatures, 1)
+	assert.Equal(t, "matched", first.Signatures[0].Outcome)
+	assert.Equal(t, "Mock", first.Signatures[0].MSPID)
+
+	second := trace.Children[1]
+	assert.False(t, second.Satisfied)
+	assert.Len(t, second.Signatures, 2)
+	assert.Equal(t, "already used by another gate", second.Signatures[0].Outcome)
+	assert.Contains(t, second.Signatures[1].Outcome, "invalid signature")
+}
+
+func TestPrincipalString(t *testing.T) {
+	assert.Equal(t, "Org1MSP.admin", principalString(SignedByMspAdmin("Org1MSP").Identities[0]))
+	assert.Equal(t, "Org1MSP.member", principalString(SignedByMspMember("Org1MSP").Identities[0]))
+	assert.Equal(t, "Org1MSP.peers", principalString(&mb.MSPPrincipal{
+		PrincipalClassification: mb.MSPPrincipal_ORGANIZATION_UNIT,
+		Principal:               utils.MarshalOrPanic(&mb.OrganizationUnit{MspIdentifier: "Org1MSP", OrganizationalUnitIdentifier: "peers"}),
+	}))
+	assert.Equal(t, "malformed role principal", principalString(&mb.MSPPrincipal{
+		PrincipalClassification: mb.MSPPrincipal_ROLE,
+		Principal:               []byte("garbage"),
+	}))
+}
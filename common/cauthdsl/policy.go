@@ -17,7 +17,6 @@ limitations under the License.
 package cauthdsl
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/hyperledger/fabric/common/policies"
@@ -25,6 +24,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/msp"
+	logging "github.com/op/go-logging"
 )
 
 type provider struct {
@@ -61,7 +61,7 @@ func (pr *provider) NewPolicy(data []byte) (policies.Policy, proto.Message, erro
 }
 
 type policy struct {
-	evaluator func([]*cb.SignedData, []bool) bool
+	evaluator evaluator
 }
 
 // Evaluate takes a set of SignedData and evaluates whether this set of signatures satisfies the policy
@@ -70,9 +70,15 @@ func (p *policy) Evaluate(signatureSet []*cb.SignedData) error {
 		return fmt.Errorf("No such policy")
 	}
 
-	ok := p.evaluator(signatureSet, make([]bool, len(signatureSet)))
+	ok, trace := p.evaluator(signatureSet, make([]bool, len(signatureSet)))
 	if !ok {
-		return errors.New("Failed to authenticate policy")
+		if cauthdslLogger.IsEnabledFor(logging.DEBUG) {
+			cauthdslLogger.Debugf("Signature policy evaluation failed:\n%s", trace.Tree())
+		}
+		return &policies.EvaluationError{
+			Message: "Failed to authenticate policy",
+			Trace:   trace,
+		}
 	}
 	return nil
 }
@@ -99,6 +99,9 @@ func TestReject(t *testing.T) {
 	if err == nil {
 		t.Fatal("Should have errored evaluating the rejectAll policy")
 	}
+	if _, ok := policies.TraceFromError(err); !ok {
+		t.Error("Evaluation error should carry the trace of the evaluation")
+	}
 }
 
 func TestRejectOnUnknown(t *testing.T) {
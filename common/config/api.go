@@ -43,10 +43,59 @@ type ApplicationOrg interface {
 	AnchorPeers() []*pb.AnchorPeer
 }
 
+// OrdererOrg stores the per org orderer config
+type OrdererOrg interface {
+	Org
+
+	// Endpoints returns the addresses of the ordering service nodes of the org
+	Endpoints() []string
+}
+
+// ChannelCapabilities defines the capabilities for a channel
+type ChannelCapabilities interface {
+	// Supported returns an error if there are unknown capabilities in this channel which are required
+	Supported() error
+
+	// TLSBinding specifies whether the proposals, broadcast and deliver requests of the channel
+	// must carry the hash of the TLS certificate the client presented on the gRPC stream
+	TLSBinding() bool
+}
+
+// ApplicationCapabilities defines the capabilities for the application portion of a channel
+type ApplicationCapabilities interface {
+	// Supported returns an error if there are unknown capabilities in this channel which are required
+	Supported() error
+
+	// ForbidDuplicateTXIdInBlock specifies whether two transactions with the same TXId are permitted
+	// in the same block or whether we mark the second one as TxValidationCode_DUPLICATE_TXID
+	ForbidDuplicateTXIdInBlock() bool
+}
+
+// OrdererCapabilities defines the capabilities for the orderer portion of a channel
+type OrdererCapabilities interface {
+	// Supported returns an error if there are unknown capabilities in this channel which are required
+	Supported() error
+
+	// PredictableChannelTemplate specifies whether the v1.0 undesirable behavior of setting the /Channel
+	// group's mod_policy to "" when creating a new channel from the system channel template should be fixed
+	PredictableChannelTemplate() bool
+
+	// OrgEndpoints specifies whether the orderer orgs may define the addresses of their own
+	// ordering service nodes
+	OrgEndpoints() bool
+}
+
 // Application stores the common shared application config
 type Application interface {
 	// Organizations returns a map of org ID to ApplicationOrg
 	Organizations() map[string]ApplicationOrg
+
+	// PolicyRefForAPI returns the policy referenced by the channel ACLs for
+	// the given resource, or the empty string if there is none
+	PolicyRefForAPI(resName string) string
+
+	// Capabilities defines the capabilities for the application portion of a channel
+	Capabilities() ApplicationCapabilities
 }
 
 // Channel gives read only access to the channel configuration
@@ -61,6 +110,13 @@ type Channel interface {
 
 	// OrdererAddresses returns the list of valid orderer addresses to connect to to invoke Broadcast/Deliver
 	OrdererAddresses() []string
+
+	// TransactionTimestampWindow returns how far the timestamp of an endorser transaction
+	// may be from the orderer's clock and from the block timestamp, 0 if unchecked
+	TransactionTimestampWindow() time.Duration
+
+	// Capabilities defines the capabilities for a channel
+	Capabilities() ChannelCapabilities
 }
 
 // Consortiums represents the set of consortiums serviced by an ordering service
@@ -80,6 +136,13 @@ type Orderer interface {
 	// ConsensusType returns the configured consensus type
 	ConsensusType() string
 
+	// ConsensusMetadata returns the metadata the consenter resumes the channel
+	// from after a change of consensus type
+	ConsensusMetadata() []byte
+
+	// ConsensusState returns whether the channel is in normal operation or in maintenance
+	ConsensusState() ab.ConsensusType_State
+
 	// BatchSize returns the maximum number of messages to include in a block
 	BatchSize() *ab.BatchSize
 
@@ -89,13 +152,19 @@ type Orderer interface {
 	// MaxChannelsCount returns the maximum count of channels to allow for an ordering network
 	MaxChannelsCount() uint64
 
+	// BroadcastRateLimits returns the channel overrides of the orderer Broadcast rate limits
+	BroadcastRateLimits() *ab.BroadcastRateLimits
+
 	// KafkaBrokers returns the addresses (IP:port notation) of a set of "bootstrap"
 	// Kafka brokers, i.e. this is not necessarily the entire set of Kafka brokers
 	// used for ordering
 	KafkaBrokers() []string
 
 	// Organizations returns the organizations for the ordering service
-	Organizations() map[string]Org
+	Organizations() map[string]OrdererOrg
+
+	// Capabilities defines the capabilities for the orderer portion of a channel
+	Capabilities() OrdererCapabilities
 }
 
 type ValueProposer interface {
@@ -19,14 +19,26 @@ package config
 import (
 	"fmt"
 
+	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/config/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
 const (
 	// ApplicationGroupKey is the group name for the Application config
 	ApplicationGroupKey = "Application"
+
+	// ACLsKey is the key name for the ACLs ConfigValue
+	ACLsKey = "ACLs"
 )
 
+// ApplicationProtos is where the proposed application configuration is unmarshaled into
+type ApplicationProtos struct {
+	ACLs         *pb.ACLs
+	Capabilities *cb.Capabilities
+}
+
 // ApplicationGroup represents the application config group
 type ApplicationGroup struct {
 	*Proposer
@@ -36,6 +48,7 @@ type ApplicationGroup struct {
 
 type ApplicationConfig struct {
 	*standardValues
+	protos *ApplicationProtos
 
 	applicationGroup *ApplicationGroup
 	applicationOrgs  map[string]ApplicationOrg
@@ -61,17 +74,18 @@ func (ag *ApplicationGroup) Allocate() Values {
 }
 
 func NewApplicationConfig(ag *ApplicationGroup) *ApplicationConfig {
-	sv, err := NewStandardValues(&(struct{}{}))
+	ac := &ApplicationConfig{
+		applicationGroup: ag,
+		protos:           &ApplicationProtos{},
+	}
+
+	var err error
+	ac.standardValues, err = NewStandardValues(ac.protos)
 	if err != nil {
 		logger.Panicf("Programming error: %s", err)
 	}
 
-	return &ApplicationConfig{
-		applicationGroup: ag,
-
-		// Currently there are no config values
-		standardValues: sv,
-	}
+	return ac
 }
 
 func (ac *ApplicationConfig) Validate(tx interface{}, groups map[string]ValueProposer) error {
@@ -83,6 +97,12 @@ func (ac *ApplicationConfig) Validate(tx interface{}, groups map[string]ValuePro
 			return fmt.Errorf("Application sub-group %s was not an ApplicationOrgGroup, actually %T", key, value)
 		}
 	}
+
+	for resName, apiResource := range ac.protos.ACLs.Acls {
+		if apiResource == nil || apiResource.PolicyRef == "" {
+			return fmt.Errorf("ACL for resource %s does not reference a policy", resName)
+		}
+	}
 	return nil
 }
 
@@ -94,3 +114,19 @@ func (ac *ApplicationConfig) Commit() {
 func (ac *ApplicationConfig) Organizations() map[string]ApplicationOrg {
 	return ac.applicationOrgs
 }
+
+// PolicyRefForAPI returns the policy referenced for the given resource name,
+// or the empty string if the channel config does not define an ACL for it
+func (ac *ApplicationConfig) PolicyRefForAPI(resName string) string {
+	apiResource, ok := ac.protos.ACLs.Acls[resName]
+	if !ok || apiResource == nil {
+		return ""
+	}
+
+	return apiResource.PolicyRef
+}
+
+// Capabilities returns the capabilities the application has for this channel
+func (ac *ApplicationConfig) Capabilities() ApplicationCapabilities {
+	return capabilities.NewApplicationProvider(ac.protos.Capabilities.Capabilities)
+}
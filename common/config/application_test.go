@@ -19,7 +19,11 @@ package config
 import (
 	"testing"
 
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+
 	logging "github.com/op/go-logging"
+	"github.com/stretchr/testify/assert"
 )
 
 func init() {
@@ -29,3 +33,25 @@ func init() {
 func TestApplicationInterface(t *testing.T) {
 	_ = Application((*ApplicationGroup)(nil))
 }
+
+func TestApplicationACLs(t *testing.T) {
+	ac := NewApplicationConfig(&ApplicationGroup{})
+	assert.Equal(t, "", ac.PolicyRefForAPI("lscc/GetChaincodes"), "No ACLs were configured")
+
+	acls := TemplateACLs(map[string]string{"lscc/GetChaincodes": "/Channel/Application/Admins"})
+	value := acls.Groups[ApplicationGroupKey].Values[ACLsKey]
+	_, err := ac.Deserialize(ACLsKey, value.Value)
+	assert.NoError(t, err)
+	assert.NoError(t, ac.Validate(nil, map[string]ValueProposer{}))
+	assert.Equal(t, "/Channel/Application/Admins", ac.PolicyRefForAPI("lscc/GetChaincodes"))
+	assert.Equal(t, "", ac.PolicyRefForAPI("qscc/GetBlockByNumber"))
+
+	ac.protos.ACLs = &pb.ACLs{Acls: map[string]*pb.APIResource{"lscc/GetChaincodes": {}}}
+	assert.Error(t, ac.Validate(nil, map[string]ValueProposer{}), "ACL without a policy reference should be rejected")
+}
+
+func TestApplicationACLsTemplate(t *testing.T) {
+	cg := TemplateACLs(map[string]string{"qscc/GetChainInfo": "/Channel/Application/Readers"})
+	assert.IsType(t, &cb.ConfigGroup{}, cg)
+	assert.Len(t, cg.Groups[ApplicationGroupKey].Values, 1)
+}
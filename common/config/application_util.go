@@ -22,6 +22,15 @@ import (
 	"github.com/hyperledger/fabric/protos/utils"
 )
 
+func applicationGroupValue(key string, value []byte) *cb.ConfigGroup {
+	result := cb.NewConfigGroup()
+	result.Groups[ApplicationGroupKey] = cb.NewConfigGroup()
+	result.Groups[ApplicationGroupKey].Values[key] = &cb.ConfigValue{
+		Value: value,
+	}
+	return result
+}
+
 func applicationConfigGroup(orgID string, key string, value []byte) *cb.ConfigGroup {
 	result := cb.NewConfigGroup()
 	result.Groups[ApplicationGroupKey] = cb.NewConfigGroup()
@@ -36,3 +45,18 @@ func applicationConfigGroup(orgID string, key string, value []byte) *cb.ConfigGr
 func TemplateAnchorPeers(orgID string, anchorPeers []*pb.AnchorPeer) *cb.ConfigGroup {
 	return applicationConfigGroup(orgID, AnchorPeersKey, utils.MarshalOrPanic(&pb.AnchorPeers{AnchorPeers: anchorPeers}))
 }
+
+// TemplateACLs creates a headerless config item representing the ACLs for the application
+func TemplateACLs(acls map[string]string) *cb.ConfigGroup {
+	apiResources := make(map[string]*pb.APIResource)
+	for resName, policyRef := range acls {
+		apiResources[resName] = &pb.APIResource{PolicyRef: policyRef}
+	}
+
+	return applicationGroupValue(ACLsKey, utils.MarshalOrPanic(&pb.ACLs{Acls: apiResources}))
+}
+
+// TemplateApplicationCapabilities creates a config item representing the capabilities required of the peers
+func TemplateApplicationCapabilities(capabilities []string) *cb.ConfigGroup {
+	return applicationGroupValue(CapabilitiesKey, capabilitiesValue(capabilities))
+}
@@ -19,8 +19,10 @@ package config
 import (
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/config/msp"
 	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -40,6 +42,13 @@ const (
 	// OrdererAddressesKey is the cb.ConfigItem type key name for the OrdererAddresses message
 	OrdererAddressesKey = "OrdererAddresses"
 
+	// TransactionTimestampWindowKey is the cb.ConfigItem type key name for the TransactionTimestampWindow message
+	TransactionTimestampWindowKey = "TransactionTimestampWindow"
+
+	// CapabilitiesKey is the name of the key which refers to capabilities, it appears at the channel,
+	// application, and orderer levels and this constant is used for all three.
+	CapabilitiesKey = "Capabilities"
+
 	// GroupKey is the name of the channel group
 	ChannelGroupKey = "Channel"
 )
@@ -56,14 +65,20 @@ type ChannelValues interface {
 
 	// OrdererAddresses returns the list of valid orderer addresses to connect to to invoke Broadcast/Deliver
 	OrdererAddresses() []string
+
+	// TransactionTimestampWindow returns how far the timestamp of an endorser transaction
+	// may be from the orderer's clock and from the block timestamp, 0 if unchecked
+	TransactionTimestampWindow() time.Duration
 }
 
 // ChannelProtos is where the proposed configuration is unmarshaled into
 type ChannelProtos struct {
-	HashingAlgorithm          *cb.HashingAlgorithm
-	BlockDataHashingStructure *cb.BlockDataHashingStructure
-	OrdererAddresses          *cb.OrdererAddresses
-	Consortium                *cb.Consortium
+	HashingAlgorithm           *cb.HashingAlgorithm
+	BlockDataHashingStructure  *cb.BlockDataHashingStructure
+	OrdererAddresses           *cb.OrdererAddresses
+	TransactionTimestampWindow *cb.TransactionTimestampWindow
+	Consortium                 *cb.Consortium
+	Capabilities               *cb.Capabilities
 }
 
 type channelConfigSetter struct {
@@ -133,7 +148,8 @@ type ChannelConfig struct {
 	*standardValues
 	protos *ChannelProtos
 
-	hashingAlgorithm func(input []byte) []byte
+	hashingAlgorithm           func(input []byte) []byte
+	transactionTimestampWindow time.Duration
 
 	appConfig         *ApplicationGroup
 	ordererConfig     *OrdererGroup
@@ -169,6 +185,17 @@ func (cc *ChannelConfig) OrdererAddresses() []string {
 	return cc.protos.OrdererAddresses.Addresses
 }
 
+// TransactionTimestampWindow returns how far the timestamp of an endorser transaction
+// may be from the orderer's clock and from the block timestamp, 0 if unchecked
+func (cc *ChannelConfig) TransactionTimestampWindow() time.Duration {
+	return cc.transactionTimestampWindow
+}
+
+// Capabilities returns information about the available capabilities for this channel
+func (cc *ChannelConfig) Capabilities() ChannelCapabilities {
+	return capabilities.NewChannelProvider(cc.protos.Capabilities.Capabilities)
+}
+
 // ConsortiumName returns the name of the consortium this channel was created under
 func (cc *ChannelConfig) ConsortiumName() string {
 	return cc.protos.Consortium.Name
@@ -181,6 +208,7 @@ func (cc *ChannelConfig) Validate(tx interface{}, groups map[string]ValuePropose
 		cc.validateHashingAlgorithm,
 		cc.validateBlockDataHashingStructure,
 		cc.validateOrdererAddresses,
+		cc.validateTransactionTimestampWindow,
 	} {
 		if err := validator(); err != nil {
 			return err
@@ -239,3 +267,18 @@ func (cc *ChannelConfig) validateOrdererAddresses() error {
 	}
 	return nil
 }
+
+func (cc *ChannelConfig) validateTransactionTimestampWindow() error {
+	if cc.protos.TransactionTimestampWindow.Window == "" {
+		return nil
+	}
+	var err error
+	cc.transactionTimestampWindow, err = time.ParseDuration(cc.protos.TransactionTimestampWindow.Window)
+	if err != nil {
+		return fmt.Errorf("Attempted to set the transaction timestamp window to an invalid value: %s", err)
+	}
+	if cc.transactionTimestampWindow <= 0 {
+		return fmt.Errorf("Attempted to set the transaction timestamp window to a non-positive value: %s", cc.transactionTimestampWindow)
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"math"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/common/util"
@@ -60,9 +61,10 @@ func TestChannelConfig(t *testing.T) {
 	assert.NotNil(t, cc, "ChannelConfig should not be nil")
 
 	cc.protos = &ChannelProtos{
-		HashingAlgorithm:          &cb.HashingAlgorithm{Name: bccsp.SHA256},
-		BlockDataHashingStructure: &cb.BlockDataHashingStructure{Width: math.MaxUint32},
-		OrdererAddresses:          &cb.OrdererAddresses{Addresses: []string{"127.0.0.1:7050"}},
+		HashingAlgorithm:           &cb.HashingAlgorithm{Name: bccsp.SHA256},
+		BlockDataHashingStructure:  &cb.BlockDataHashingStructure{Width: math.MaxUint32},
+		OrdererAddresses:           &cb.OrdererAddresses{Addresses: []string{"127.0.0.1:7050"}},
+		TransactionTimestampWindow: &cb.TransactionTimestampWindow{},
 	}
 
 	ag := NewApplicationGroup(nil)
@@ -131,6 +133,22 @@ func TestOrdererAddresses(t *testing.T) {
 	assert.Equal(t, "127.0.0.1:7050", cc.OrdererAddresses()[0], "Unexpected orderer address returned")
 }
 
+func TestTransactionTimestampWindow(t *testing.T) {
+	cc := &ChannelConfig{protos: &ChannelProtos{TransactionTimestampWindow: &cb.TransactionTimestampWindow{}}}
+	assert.NoError(t, cc.validateTransactionTimestampWindow(), "Window may be left unset")
+	assert.Equal(t, time.Duration(0), cc.TransactionTimestampWindow(), "Unset window should disable the check")
+
+	cc = &ChannelConfig{protos: &ChannelProtos{TransactionTimestampWindow: &cb.TransactionTimestampWindow{Window: "soon"}}}
+	assert.Error(t, cc.validateTransactionTimestampWindow(), "Unparseable window supplied")
+
+	cc = &ChannelConfig{protos: &ChannelProtos{TransactionTimestampWindow: &cb.TransactionTimestampWindow{Window: "-1m"}}}
+	assert.Error(t, cc.validateTransactionTimestampWindow(), "Negative window supplied")
+
+	cc = &ChannelConfig{protos: &ChannelProtos{TransactionTimestampWindow: &cb.TransactionTimestampWindow{Window: "15m"}}}
+	assert.NoError(t, cc.validateTransactionTimestampWindow(), "Valid window supplied")
+	assert.Equal(t, 15*time.Minute, cc.TransactionTimestampWindow(), "Unexpected window returned")
+}
+
 func TestConsortiumName(t *testing.T) {
 	cc := &ChannelConfig{protos: &ChannelProtos{Consortium: &cb.Consortium{Name: "TestConsortium"}}}
 	assert.Equal(t, "TestConsortium", cc.ConsortiumName(), "Unexpected consortium name returned")
@@ -142,5 +160,6 @@ func TestChannelUtils(t *testing.T) {
 	_ = DefaultHashingAlgorithm()
 	_ = DefaultBlockDataHashingStructure()
 	_ = DefaultOrdererAddresses()
+	_ = TemplateTransactionTimestampWindow("15m")
 
 }
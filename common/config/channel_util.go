@@ -34,6 +34,24 @@ func configGroup(key string, value []byte) *cb.ConfigGroup {
 	return result
 }
 
+// capabilitiesValue marshals the Capabilities message requiring the given capabilities
+func capabilitiesValue(capabilities []string) []byte {
+	c := &cb.Capabilities{
+		Capabilities: make(map[string]*cb.Capability),
+	}
+
+	for _, capability := range capabilities {
+		c.Capabilities[capability] = &cb.Capability{}
+	}
+
+	return utils.MarshalOrPanic(c)
+}
+
+// TemplateChannelCapabilities creates a ConfigGroup representing the capabilities required at the channel level
+func TemplateChannelCapabilities(capabilities []string) *cb.ConfigGroup {
+	return configGroup(CapabilitiesKey, capabilitiesValue(capabilities))
+}
+
 // TemplateConsortiumName creates a ConfigGroup representing the ConsortiumName
 func TemplateConsortium(name string) *cb.ConfigGroup {
 	return configGroup(ConsortiumKey, utils.MarshalOrPanic(&cb.Consortium{Name: name}))
@@ -72,3 +90,8 @@ func TemplateOrdererAddresses(addresses []string) *cb.ConfigGroup {
 func DefaultOrdererAddresses() *cb.ConfigGroup {
 	return TemplateOrdererAddresses(defaultOrdererAddresses)
 }
+
+// TemplateTransactionTimestampWindow creates a headerless config item representing the transaction timestamp window
+func TemplateTransactionTimestampWindow(window string) *cb.ConfigGroup {
+	return configGroup(TransactionTimestampWindowKey, utils.MarshalOrPanic(&cb.TransactionTimestampWindow{Window: window}))
+}
@@ -17,13 +17,16 @@ limitations under the License.
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/config/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 )
 
@@ -47,6 +50,9 @@ const (
 
 	// KafkaBrokersKey is the cb.ConfigItem type key name for the KafkaBrokers message
 	KafkaBrokersKey = "KafkaBrokers"
+
+	// BroadcastRateLimitsKey is the cb.ConfigItem type key name for the BroadcastRateLimits message
+	BroadcastRateLimitsKey = "BroadcastRateLimits"
 )
 
 // OrdererProtos is used as the source of the OrdererConfig
@@ -56,6 +62,8 @@ type OrdererProtos struct {
 	BatchTimeout        *ab.BatchTimeout
 	KafkaBrokers        *ab.KafkaBrokers
 	ChannelRestrictions *ab.ChannelRestrictions
+	Capabilities        *cb.Capabilities
+	BroadcastRateLimits *ab.BroadcastRateLimits
 }
 
 // Config is stores the orderer component configuration
@@ -75,9 +83,9 @@ func NewOrdererGroup(mspConfig *msp.MSPConfigHandler) *OrdererGroup {
 	return og
 }
 
-// NewGroup returns an Org instance
+// NewGroup returns an OrdererOrg instance
 func (og *OrdererGroup) NewGroup(name string) (ValueProposer, error) {
-	return NewOrganizationGroup(name, og.mspConfig), nil
+	return NewOrdererOrgGroup(name, og.mspConfig), nil
 }
 
 func (og *OrdererGroup) Allocate() Values {
@@ -89,7 +97,7 @@ type OrdererConfig struct {
 	*standardValues
 	protos       *OrdererProtos
 	ordererGroup *OrdererGroup
-	orgs         map[string]Org
+	orgs         map[string]OrdererOrg
 
 	batchTimeout time.Duration
 }
@@ -119,6 +127,17 @@ func (oc *OrdererConfig) ConsensusType() string {
 	return oc.protos.ConsensusType.Type
 }
 
+// ConsensusMetadata returns the metadata the consenter resumes the channel
+// from after a change of consensus type
+func (oc *OrdererConfig) ConsensusMetadata() []byte {
+	return oc.protos.ConsensusType.Metadata
+}
+
+// ConsensusState returns whether the channel is in normal operation or in maintenance
+func (oc *OrdererConfig) ConsensusState() ab.ConsensusType_State {
+	return oc.protos.ConsensusType.State
+}
+
 // BatchSize returns the maximum number of messages to include in a block
 func (oc *OrdererConfig) BatchSize() *ab.BatchSize {
 	return oc.protos.BatchSize
@@ -136,13 +155,24 @@ func (oc *OrdererConfig) KafkaBrokers() []string {
 	return oc.protos.KafkaBrokers.Brokers
 }
 
+// Capabilities returns the capabilities the ordering network has for this channel
+func (oc *OrdererConfig) Capabilities() OrdererCapabilities {
+	return capabilities.NewOrdererProvider(oc.protos.Capabilities.Capabilities)
+}
+
 // MaxChannelsCount returns the maximum count of channels this orderer supports
 func (oc *OrdererConfig) MaxChannelsCount() uint64 {
 	return oc.protos.ChannelRestrictions.MaxCount
 }
 
+// BroadcastRateLimits returns the Broadcast rate limits of this channel, the
+// limits which are not set default to the ones the orderer is configured with
+func (oc *OrdererConfig) BroadcastRateLimits() *ab.BroadcastRateLimits {
+	return oc.protos.BroadcastRateLimits
+}
+
 // Organizations returns a map of the orgs in the channel
-func (oc *OrdererConfig) Organizations() map[string]Org {
+func (oc *OrdererConfig) Organizations() map[string]OrdererOrg {
 	return oc.orgs
 }
 
@@ -152,28 +182,65 @@ func (oc *OrdererConfig) Validate(tx interface{}, groups map[string]ValuePropose
 		oc.validateBatchSize,
 		oc.validateBatchTimeout,
 		oc.validateKafkaBrokers,
+		oc.validateBroadcastRateLimits,
 	} {
 		if err := validator(); err != nil {
 			return err
 		}
 	}
 
-	var ok bool
-	oc.orgs = make(map[string]Org)
+	oc.orgs = make(map[string]OrdererOrg)
 	for key, value := range groups {
-		oc.orgs[key], ok = value.(*OrganizationGroup)
+		oog, ok := value.(*OrdererOrgGroup)
 		if !ok {
-			return fmt.Errorf("Organization sub-group %s was not an OrgGroup, actually %T", key, value)
+			return fmt.Errorf("Organization sub-group %s was not an OrdererOrgGroup, actually %T", key, value)
+		}
+		if err := oc.validateOrgEndpoints(tx, oog); err != nil {
+			return err
 		}
+		oc.orgs[key] = oog
 	}
 
 	return nil
 }
 
+// validateOrgEndpoints rejects the endpoints proposed for an org unless the orderer
+// capabilities allow them, as nodes without the capability fail to parse the config
+func (oc *OrdererConfig) validateOrgEndpoints(tx interface{}, oog *OrdererOrgGroup) error {
+	if oc.Capabilities().OrgEndpoints() {
+		return nil
+	}
+	// The org config has been validated already, but not committed yet
+	ooc, ok := oog.pendingValues(tx).(*OrdererOrgConfig)
+	if ok && len(ooc.Endpoints()) > 0 {
+		return fmt.Errorf("Endpoints of org %s require the %s orderer capability", oog.name, capabilities.OrdererOrgEndpoints)
+	}
+	return nil
+}
+
 func (oc *OrdererConfig) validateConsensusType() error {
-	if oc.ordererGroup.OrdererConfig != nil && oc.ordererGroup.ConsensusType() != oc.protos.ConsensusType.Type {
+	if _, ok := ab.ConsensusType_State_name[int32(oc.protos.ConsensusType.State)]; !ok {
+		return fmt.Errorf("Attempted to set the consensus state to an unknown value: %d", oc.protos.ConsensusType.State)
+	}
+
+	if oc.ordererGroup.OrdererConfig == nil {
 		// The first config we accept the consensus type regardless
-		return fmt.Errorf("Attempted to change the consensus type from %s to %s after init", oc.ordererGroup.ConsensusType(), oc.protos.ConsensusType.Type)
+		return nil
+	}
+
+	current := oc.ordererGroup.OrdererConfig.protos.ConsensusType
+	proposed := oc.protos.ConsensusType
+	if current.Type == proposed.Type && bytes.Equal(current.Metadata, proposed.Metadata) {
+		return nil
+	}
+
+	// The consensus type may only be migrated while the channel is, and stays, in
+	// maintenance, so that no transaction but config updates is in flight
+	if current.State != ab.ConsensusType_STATE_MAINTENANCE || proposed.State != ab.ConsensusType_STATE_MAINTENANCE {
+		if current.Type != proposed.Type {
+			return fmt.Errorf("Attempted to change the consensus type from %s to %s outside of maintenance", current.Type, proposed.Type)
+		}
+		return fmt.Errorf("Attempted to change the consensus metadata of %s outside of maintenance", current.Type)
 	}
 	return nil
 }
@@ -215,6 +282,20 @@ func (oc *OrdererConfig) validateKafkaBrokers() error {
 	return nil
 }
 
+func (oc *OrdererConfig) validateBroadcastRateLimits() error {
+	rateLimits := oc.protos.BroadcastRateLimits
+	for name, limit := range map[string]*ab.RateLimit{
+		"channel": rateLimits.Channel,
+		"msp":     rateLimits.Msp,
+		"client":  rateLimits.Client,
+	} {
+		if limit != nil && limit.Rate == 0 && limit.Burst != 0 {
+			return fmt.Errorf("Attempted to set a burst of %d for the %s broadcast rate limit without a rate", limit.Burst, name)
+		}
+	}
+	return nil
+}
+
 // This does just a barebones sanity check.
 func brokerEntrySeemsValid(broker string) bool {
 	if !strings.Contains(broker, ":") {
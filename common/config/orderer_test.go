@@ -44,6 +44,43 @@ func TestConsensusType(t *testing.T) {
 		protos:       &OrdererProtos{ConsensusType: &ab.ConsensusType{Type: "foo"}},
 	}
 	assert.Error(t, oc.validateConsensusType(), "Should have failed to change consensus type")
+
+	oc = &OrdererConfig{ordererGroup: &OrdererGroup{}, protos: &OrdererProtos{ConsensusType: &ab.ConsensusType{Type: "foo", State: 2}}}
+	assert.Error(t, oc.validateConsensusType(), "Should have failed to set an unknown consensus state")
+}
+
+func TestConsensusTypeMigration(t *testing.T) {
+	normal := ab.ConsensusType_STATE_NORMAL
+	maintenance := ab.ConsensusType_STATE_MAINTENANCE
+
+	for _, tc := range []struct {
+		name     string
+		current  *ab.ConsensusType
+		proposed *ab.ConsensusType
+		valid    bool
+	}{
+		{"EnterMaintenance", &ab.ConsensusType{Type: "kafka", State: normal}, &ab.ConsensusType{Type: "kafka", State: maintenance}, true},
+		{"Migrate", &ab.ConsensusType{Type: "kafka", State: maintenance}, &ab.ConsensusType{Type: "solo", State: maintenance}, true},
+		{"MigrateWithMetadata", &ab.ConsensusType{Type: "solo", State: maintenance}, &ab.ConsensusType{Type: "kafka", Metadata: []byte("offset"), State: maintenance}, true},
+		{"Rollback", &ab.ConsensusType{Type: "solo", State: maintenance}, &ab.ConsensusType{Type: "kafka", State: maintenance}, true},
+		{"ExitMaintenance", &ab.ConsensusType{Type: "solo", State: maintenance}, &ab.ConsensusType{Type: "solo", State: normal}, true},
+		{"MigrateOutsideMaintenance", &ab.ConsensusType{Type: "kafka", State: normal}, &ab.ConsensusType{Type: "solo", State: normal}, false},
+		{"MigrateEnteringMaintenance", &ab.ConsensusType{Type: "kafka", State: normal}, &ab.ConsensusType{Type: "solo", State: maintenance}, false},
+		{"MigrateExitingMaintenance", &ab.ConsensusType{Type: "kafka", State: maintenance}, &ab.ConsensusType{Type: "solo", State: normal}, false},
+		{"MetadataOutsideMaintenance", &ab.ConsensusType{Type: "kafka", State: normal}, &ab.ConsensusType{Type: "kafka", Metadata: []byte("offset"), State: normal}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			oc := &OrdererConfig{
+				ordererGroup: &OrdererGroup{OrdererConfig: &OrdererConfig{protos: &OrdererProtos{ConsensusType: tc.current}}},
+				protos:       &OrdererProtos{ConsensusType: tc.proposed},
+			}
+			if tc.valid {
+				assert.NoError(t, oc.validateConsensusType())
+			} else {
+				assert.Error(t, oc.validateConsensusType())
+			}
+		})
+	}
 }
 
 func TestBatchSize(t *testing.T) {
@@ -83,3 +120,20 @@ func TestKafkaBrokers(t *testing.T) {
 	oc = &OrdererConfig{protos: &OrdererProtos{KafkaBrokers: &ab.KafkaBrokers{Brokers: []string{"127.0.0.1", "foo.bar", "127.0.0.1:-1", "localhost:65536", "foo.bar.:9092", ".127.0.0.1:9092", "-foo.bar:9092"}}}}
 	assert.Error(t, oc.validateKafkaBrokers(), "Invalid kafka brokers")
 }
+
+func TestBroadcastRateLimits(t *testing.T) {
+	oc := &OrdererConfig{protos: &OrdererProtos{BroadcastRateLimits: &ab.BroadcastRateLimits{}}}
+	assert.NoError(t, oc.validateBroadcastRateLimits(), "Unset rate limits")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BroadcastRateLimits: &ab.BroadcastRateLimits{
+		Channel: &ab.RateLimit{Rate: 100, Burst: 200},
+		Msp:     &ab.RateLimit{Rate: 0},
+	}}}
+	assert.NoError(t, oc.validateBroadcastRateLimits(), "Valid rate limits")
+	assert.Equal(t, uint32(100), oc.BroadcastRateLimits().Channel.Rate)
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BroadcastRateLimits: &ab.BroadcastRateLimits{
+		Client: &ab.RateLimit{Rate: 0, Burst: 10},
+	}}}
+	assert.Error(t, oc.validateBroadcastRateLimits(), "Burst without a rate")
+}
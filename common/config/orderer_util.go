@@ -31,6 +31,16 @@ func ordererConfigGroup(key string, value []byte) *cb.ConfigGroup {
 	return result
 }
 
+func ordererOrgConfigGroup(orgID string, key string, value []byte) *cb.ConfigGroup {
+	result := cb.NewConfigGroup()
+	result.Groups[OrdererGroupKey] = cb.NewConfigGroup()
+	result.Groups[OrdererGroupKey].Groups[orgID] = cb.NewConfigGroup()
+	result.Groups[OrdererGroupKey].Groups[orgID].Values[key] = &cb.ConfigValue{
+		Value: value,
+	}
+	return result
+}
+
 // TemplateConsensusType creates a headerless config item representing the consensus type
 func TemplateConsensusType(typeValue string) *cb.ConfigGroup {
 	return ordererConfigGroup(ConsensusTypeKey, utils.MarshalOrPanic(&ab.ConsensusType{Type: typeValue}))
@@ -51,7 +61,22 @@ func TemplateChannelRestrictions(maxChannels uint64) *cb.ConfigGroup {
 	return ordererConfigGroup(ChannelRestrictionsKey, utils.MarshalOrPanic(&ab.ChannelRestrictions{MaxCount: maxChannels}))
 }
 
+// TemplateBroadcastRateLimits creates a config group with the channel overrides of the Broadcast rate limits
+func TemplateBroadcastRateLimits(rateLimits *ab.BroadcastRateLimits) *cb.ConfigGroup {
+	return ordererConfigGroup(BroadcastRateLimitsKey, utils.MarshalOrPanic(rateLimits))
+}
+
 // TemplateKafkaBrokers creates a headerless config item representing the kafka brokers
 func TemplateKafkaBrokers(brokers []string) *cb.ConfigGroup {
 	return ordererConfigGroup(KafkaBrokersKey, utils.MarshalOrPanic(&ab.KafkaBrokers{Brokers: brokers}))
 }
+
+// TemplateOrdererCapabilities creates a config group representing the capabilities required of the orderer
+func TemplateOrdererCapabilities(capabilities []string) *cb.ConfigGroup {
+	return ordererConfigGroup(CapabilitiesKey, capabilitiesValue(capabilities))
+}
+
+// TemplateOrdererOrgEndpoints creates a headerless config item representing the endpoints of an orderer org
+func TemplateOrdererOrgEndpoints(orgID string, endpoints []string) *cb.ConfigGroup {
+	return ordererOrgConfigGroup(orgID, EndpointsKey, utils.MarshalOrPanic(&cb.OrdererAddresses{Addresses: endpoints}))
+}
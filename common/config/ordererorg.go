@@ -0,0 +1,87 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"fmt"
+
+	mspconfig "github.com/hyperledger/fabric/common/config/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+)
+
+// Orderer org config keys
+const (
+	// EndpointsKey is the key name for the Endpoints ConfigValue
+	EndpointsKey = "Endpoints"
+)
+
+type OrdererOrgProtos struct {
+	Endpoints *cb.OrdererAddresses
+}
+
+type OrdererOrgConfig struct {
+	*OrganizationConfig
+	protos *OrdererOrgProtos
+
+	ordererOrgGroup *OrdererOrgGroup
+}
+
+// OrdererOrgGroup defines the configuration for an orderer org
+type OrdererOrgGroup struct {
+	*Proposer
+	*OrganizationGroup
+	*OrdererOrgConfig
+}
+
+// NewOrdererOrgGroup creates a new OrdererOrgGroup
+func NewOrdererOrgGroup(id string, mspConfig *mspconfig.MSPConfigHandler) *OrdererOrgGroup {
+	oog := &OrdererOrgGroup{
+		OrganizationGroup: NewOrganizationGroup(id, mspConfig),
+	}
+	oog.Proposer = NewProposer(oog)
+	return oog
+}
+
+// Endpoints returns the addresses of the ordering service nodes of the org,
+// the channel wide orderer addresses are used if the org has none
+func (ooc *OrdererOrgConfig) Endpoints() []string {
+	return ooc.protos.Endpoints.Addresses
+}
+
+func (oog *OrdererOrgGroup) Allocate() Values {
+	return NewOrdererOrgConfig(oog)
+}
+
+func (ooc *OrdererOrgConfig) Commit() {
+	ooc.ordererOrgGroup.OrdererOrgConfig = ooc
+	ooc.OrganizationConfig.Commit()
+}
+
+func NewOrdererOrgConfig(oog *OrdererOrgGroup) *OrdererOrgConfig {
+	ooc := &OrdererOrgConfig{
+		protos:             &OrdererOrgProtos{},
+		OrganizationConfig: NewOrganizationConfig(oog.OrganizationGroup),
+
+		ordererOrgGroup: oog,
+	}
+	var err error
+	ooc.standardValues, err = NewStandardValues(ooc.protos, ooc.OrganizationConfig.protos)
+	if err != nil {
+		logger.Panicf("Programming error: %s", err)
+	}
+
+	return ooc
+}
+
+func (ooc *OrdererOrgConfig) Validate(tx interface{}, groups map[string]ValueProposer) error {
+	for _, endpoint := range ooc.protos.Endpoints.Addresses {
+		if !brokerEntrySeemsValid(endpoint) {
+			return fmt.Errorf("Invalid endpoint entry for org %s: %s", ooc.ordererOrgGroup.name, endpoint)
+		}
+	}
+	return ooc.OrganizationConfig.Validate(tx, groups)
+}
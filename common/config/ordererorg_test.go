@@ -0,0 +1,63 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/capabilities"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrdererOrgInterface(t *testing.T) {
+	_ = ValueProposer(NewOrdererOrgGroup("id", nil))
+	_ = OrdererOrg(NewOrdererOrgGroup("id", nil))
+}
+
+func TestOrdererOrgEndpoints(t *testing.T) {
+	oog := NewOrdererOrgGroup("org1", nil)
+	ooc := NewOrdererOrgConfig(oog)
+	assert.Empty(t, ooc.Endpoints())
+
+	endpoints := []string{"orderer0.org1:7050", "orderer1.org1:7050"}
+	_, err := ooc.Deserialize(EndpointsKey, utils.MarshalOrPanic(&cb.OrdererAddresses{Addresses: endpoints}))
+	assert.NoError(t, err)
+	assert.Equal(t, endpoints, ooc.Endpoints())
+
+	_, err = ooc.Deserialize(EndpointsKey, utils.MarshalOrPanic(&cb.OrdererAddresses{Addresses: []string{"orderer0.org1"}}))
+	assert.NoError(t, err)
+	assert.Error(t, ooc.Validate(nil, nil), "Should have rejected an endpoint without a port")
+}
+
+func TestOrdererOrgEndpointsCapability(t *testing.T) {
+	endpoints := utils.MarshalOrPanic(&cb.OrdererAddresses{Addresses: []string{"orderer0.org1:7050"}})
+
+	propose := func(capabilities map[string]*cb.Capability) error {
+		og := NewOrdererGroup(nil)
+		tx := t
+		vd, groups, err := og.BeginValueProposals(tx, []string{"org1"})
+		assert.NoError(t, err)
+		defer og.RollbackProposals(tx)
+		_, err = vd.Deserialize(CapabilitiesKey, utils.MarshalOrPanic(&cb.Capabilities{Capabilities: capabilities}))
+		assert.NoError(t, err)
+
+		oog := groups[0].(*OrdererOrgGroup)
+		ovd, _, err := oog.BeginValueProposals(tx, nil)
+		assert.NoError(t, err)
+		defer oog.RollbackProposals(tx)
+		_, err = ovd.Deserialize(EndpointsKey, endpoints)
+		assert.NoError(t, err)
+
+		return vd.(*OrdererConfig).validateOrgEndpoints(tx, oog)
+	}
+
+	assert.Error(t, propose(nil), "Should have rejected org endpoints without the capability")
+	assert.NoError(t, propose(map[string]*cb.Capability{capabilities.OrdererOrgEndpoints: {}}))
+}
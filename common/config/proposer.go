@@ -131,6 +131,18 @@ func (p *Proposer) PreCommit(tx interface{}) error {
 	return pending.allocated.Validate(tx, pending.groups)
 }
 
+// pendingValues returns the values proposed by the given tx, or nil if it has
+// not been begun
+func (p *Proposer) pendingValues(tx interface{}) Values {
+	p.pendingLock.RLock()
+	defer p.pendingLock.RUnlock()
+	pending, ok := p.pending[tx]
+	if !ok {
+		return nil
+	}
+	return pending.allocated
+}
+
 // RollbackProposals called when a config proposal is abandoned
 func (p *Proposer) RollbackProposals(tx interface{}) {
 	p.pendingLock.Lock()
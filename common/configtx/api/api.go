@@ -38,6 +38,10 @@ type Manager interface {
 	// Validate attempts to validate a new configtx against the current config state
 	ProposeConfigUpdate(configtx *cb.Envelope) (*cb.ConfigEnvelope, error)
 
+	// EvaluateUpdatePolicies validates a config update against the current config state
+	// and evaluates the modification policies it must satisfy against its signatures
+	EvaluateUpdatePolicies(configUpdateEnv *cb.ConfigUpdateEnvelope) ([]*PolicyRequirement, error)
+
 	// ChainID retrieves the chain ID associated with this manager
 	ChainID() string
 
@@ -48,6 +52,19 @@ type Manager interface {
 	Sequence() uint64
 }
 
+// PolicyRequirement is the modification policy of a config element modified by a
+// config update, and whether the signatures of the update satisfy it
+type PolicyRequirement struct {
+	// Key is the fully qualified path of the modified element
+	Key string
+
+	// ModPolicy is the modification policy of the element, as set in the current config
+	ModPolicy string
+
+	// Err is the reason the policy is not satisfied, nil when it is
+	Err error
+}
+
 // Resources is the common set of config resources for all channels
 // Depending on whether chain is used at the orderer or at the peer, other
 // config resources may be available
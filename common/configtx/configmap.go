@@ -112,7 +112,8 @@ func configMapToConfig(configMap map[string]comparable) (*cb.ConfigGroup, error)
 }
 
 // recurseConfigMap is used only internally by configMapToConfig
-// Note, this function mutates the cb.Config* entrieswithin configMap, so errors are generally fatal
+// Note, this function copies the cb.ConfigGroup entries within configMap rather than
+// mutating them, as they are shared with the current config
 func recurseConfigMap(path string, configMap map[string]comparable) (*cb.ConfigGroup, error) {
 	groupPath := GroupPrefix + path
 	group, ok := configMap[groupPath]
@@ -124,12 +125,26 @@ func recurseConfigMap(path string, configMap map[string]comparable) (*cb.ConfigG
 		return nil, fmt.Errorf("ConfigGroup not found at group path: %s", groupPath)
 	}
 
+	newConfigGroup := &cb.ConfigGroup{
+		Version:   group.ConfigGroup.Version,
+		ModPolicy: group.ConfigGroup.ModPolicy,
+	}
+	if group.Groups != nil {
+		newConfigGroup.Groups = make(map[string]*cb.ConfigGroup, len(group.Groups))
+	}
+	if group.Values != nil {
+		newConfigGroup.Values = make(map[string]*cb.ConfigValue, len(group.Values))
+	}
+	if group.Policies != nil {
+		newConfigGroup.Policies = make(map[string]*cb.ConfigPolicy, len(group.Policies))
+	}
+
 	for key, _ := range group.Groups {
 		updatedGroup, err := recurseConfigMap(path+PathSeparator+key, configMap)
 		if err != nil {
 			return nil, err
 		}
-		group.Groups[key] = updatedGroup
+		newConfigGroup.Groups[key] = updatedGroup
 	}
 
 	for key, _ := range group.Values {
@@ -141,7 +156,7 @@ func recurseConfigMap(path string, configMap map[string]comparable) (*cb.ConfigG
 		if value.ConfigValue == nil {
 			return nil, fmt.Errorf("ConfigValue not found at value path: %s", valuePath)
 		}
-		group.Values[key] = value.ConfigValue
+		newConfigGroup.Values[key] = value.ConfigValue
 	}
 
 	for key, _ := range group.Policies {
@@ -153,8 +168,8 @@ func recurseConfigMap(path string, configMap map[string]comparable) (*cb.ConfigG
 		if policy.ConfigPolicy == nil {
 			return nil, fmt.Errorf("ConfigPolicy not found at policy path: %s", policyPath)
 		}
-		group.Policies[key] = policy.ConfigPolicy
+		newConfigGroup.Policies[key] = policy.ConfigPolicy
 	}
 
-	return group.ConfigGroup, nil
+	return newConfigGroup, nil
 }
@@ -19,6 +19,7 @@ package provisional
 import (
 	"fmt"
 
+	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/config"
 	configvaluesmsp "github.com/hyperledger/fabric/common/config/msp"
@@ -126,6 +127,7 @@ func New(conf *genesisconfig.Profile) Generator {
 			policies.TemplateImplicitMetaMajorityPolicy([]string{config.OrdererGroupKey}, configvaluesmsp.AdminsPolicyKey),
 		}
 
+		orgEndpoints := false
 		for _, org := range conf.Orderer.Organizations {
 			mspConfig, err := msp.GetVerifyingMspConfig(org.MSPDir, org.ID)
 			if err != nil {
@@ -136,6 +138,14 @@ func New(conf *genesisconfig.Profile) Generator {
 					mspConfig, org.AdminPrincipal == genesisconfig.AdminRoleAdminPrincipal,
 				),
 			)
+			if len(org.OrdererEndpoints) > 0 {
+				bs.ordererGroups = append(bs.ordererGroups, config.TemplateOrdererOrgEndpoints(org.Name, org.OrdererEndpoints))
+				orgEndpoints = true
+			}
+		}
+		// The orderer orgs may only define their endpoints with the capability
+		if orgEndpoints {
+			bs.ordererGroups = append(bs.ordererGroups, config.TemplateOrdererCapabilities([]string{capabilities.OrdererOrgEndpoints}))
 		}
 
 		switch conf.Orderer.OrdererType {
@@ -18,7 +18,9 @@ package configtx
 
 import (
 	"fmt"
+	"sort"
 
+	"github.com/hyperledger/fabric/common/configtx/api"
 	"github.com/hyperledger/fabric/common/policies"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -54,33 +56,59 @@ func ComputeDeltaSet(readSet, writeSet map[string]comparable) map[string]compara
 	return result
 }
 
-func (cm *configManager) verifyDeltaSet(deltaSet map[string]comparable, signedData []*cb.SignedData) error {
+// evaluateDeltaSet checks the versions of the delta set against the current config
+// and evaluates the modification policies of the existing elements it modifies
+func (cm *configManager) evaluateDeltaSet(deltaSet map[string]comparable, signedData []*cb.SignedData) ([]*api.PolicyRequirement, error) {
 	if len(deltaSet) == 0 {
-		return fmt.Errorf("Delta set was empty.  Update would have no effect.")
+		return nil, fmt.Errorf("Delta set was empty.  Update would have no effect.")
+	}
+
+	keys := make([]string, 0, len(deltaSet))
+	for key := range deltaSet {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 
-	for key, value := range deltaSet {
+	var requirements []*api.PolicyRequirement
+	for _, key := range keys {
+		value := deltaSet[key]
 		existing, ok := cm.current.configMap[key]
 		if !ok {
 			if value.version() != 0 {
-				return fmt.Errorf("Attempted to set key %s to version %d, but key does not exist", key, value.version())
+				return nil, fmt.Errorf("Attempted to set key %s to version %d, but key does not exist", key, value.version())
 			} else {
 				continue
 			}
 
 		}
 		if value.version() != existing.version()+1 {
-			return fmt.Errorf("Attempt to set key %s to version %d, but key is at version %d", key, value.version(), existing.version())
+			return nil, fmt.Errorf("Attempt to set key %s to version %d, but key is at version %d", key, value.version(), existing.version())
 		}
 
 		policy, ok := cm.policyForItem(existing)
 		if !ok {
-			return fmt.Errorf("Unexpected missing policy %s for item %s", existing.modPolicy(), key)
+			return nil, fmt.Errorf("Unexpected missing policy %s for item %s", existing.modPolicy(), key)
 		}
 
-		// Ensure the policy is satisfied
-		if err := policy.Evaluate(signedData); err != nil {
-			return fmt.Errorf("Policy for %s not satisfied: %s", key, err)
+		requirements = append(requirements, &api.PolicyRequirement{
+			Key:       key,
+			ModPolicy: existing.modPolicy(),
+			Err:       policy.Evaluate(signedData),
+		})
+	}
+	return requirements, nil
+}
+
+func (cm *configManager) verifyDeltaSet(deltaSet map[string]comparable, signedData []*cb.SignedData) error {
+	requirements, err := cm.evaluateDeltaSet(deltaSet, signedData)
+	if err != nil {
+		return err
+	}
+
+	// Ensure the policies are satisfied
+	for _, requirement := range requirements {
+		if requirement.Err != nil {
+			return fmt.Errorf("Policy for %s not satisfied: %s", requirement.Key, requirement.Err)
 		}
 	}
 	return nil
@@ -98,49 +126,75 @@ func verifyFullProposedConfig(writeSet, fullProposedConfig map[string]comparable
 // authorizeUpdate validates that all modified config has the corresponding modification policies satisfied by the signature set
 // it returns a map of the modified config
 func (cm *configManager) authorizeUpdate(configUpdateEnv *cb.ConfigUpdateEnvelope) (map[string]comparable, error) {
+	writeSet, deltaSet, signedData, err := cm.computeUpdate(configUpdateEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cm.verifyDeltaSet(deltaSet, signedData); err != nil {
+		return nil, fmt.Errorf("Error validating DeltaSet: %s", err)
+	}
+
+	fullProposedConfig := cm.computeUpdateResult(deltaSet)
+	if err := verifyFullProposedConfig(writeSet, fullProposedConfig); err != nil {
+		return nil, fmt.Errorf("Full config did not verify: %s", err)
+	}
+
+	return fullProposedConfig, nil
+}
+
+// EvaluateUpdatePolicies validates a config update against the current config and
+// evaluates the modification policies of the elements it modifies against its signatures
+func (cm *configManager) EvaluateUpdatePolicies(configUpdateEnv *cb.ConfigUpdateEnvelope) ([]*api.PolicyRequirement, error) {
+	_, deltaSet, signedData, err := cm.computeUpdate(configUpdateEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	requirements, err := cm.evaluateDeltaSet(deltaSet, signedData)
+	if err != nil {
+		return nil, fmt.Errorf("Error validating DeltaSet: %s", err)
+	}
+	return requirements, nil
+}
+
+// computeUpdate validates the read set of a config update against the current config
+// and returns its write set, the delta set it applies and the data signed over it
+func (cm *configManager) computeUpdate(configUpdateEnv *cb.ConfigUpdateEnvelope) (map[string]comparable, map[string]comparable, []*cb.SignedData, error) {
 	if configUpdateEnv == nil {
-		return nil, fmt.Errorf("Cannot process nil ConfigUpdateEnvelope")
+		return nil, nil, nil, fmt.Errorf("Cannot process nil ConfigUpdateEnvelope")
 	}
 
 	configUpdate, err := UnmarshalConfigUpdate(configUpdateEnv.ConfigUpdate)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	if configUpdate.ChannelId != cm.current.channelID {
-		return nil, fmt.Errorf("Update not for correct channel: %s for %s", configUpdate.ChannelId, cm.current.channelID)
+		return nil, nil, nil, fmt.Errorf("Update not for correct channel: %s for %s", configUpdate.ChannelId, cm.current.channelID)
 	}
 
 	readSet, err := MapConfig(configUpdate.ReadSet)
 	if err != nil {
-		return nil, fmt.Errorf("Error mapping ReadSet: %s", err)
+		return nil, nil, nil, fmt.Errorf("Error mapping ReadSet: %s", err)
 	}
 	err = cm.current.verifyReadSet(readSet)
 	if err != nil {
-		return nil, fmt.Errorf("Error validating ReadSet: %s", err)
+		return nil, nil, nil, fmt.Errorf("Error validating ReadSet: %s", err)
 	}
 
 	writeSet, err := MapConfig(configUpdate.WriteSet)
 	if err != nil {
-		return nil, fmt.Errorf("Error mapping WriteSet: %s", err)
+		return nil, nil, nil, fmt.Errorf("Error mapping WriteSet: %s", err)
 	}
 
 	deltaSet := ComputeDeltaSet(readSet, writeSet)
 	signedData, err := configUpdateEnv.AsSignedData()
 	if err != nil {
-		return nil, err
-	}
-
-	if err = cm.verifyDeltaSet(deltaSet, signedData); err != nil {
-		return nil, fmt.Errorf("Error validating DeltaSet: %s", err)
+		return nil, nil, nil, err
 	}
 
-	fullProposedConfig := cm.computeUpdateResult(deltaSet)
-	if err := verifyFullProposedConfig(writeSet, fullProposedConfig); err != nil {
-		return nil, fmt.Errorf("Full config did not verify: %s", err)
-	}
-
-	return fullProposedConfig, nil
+	return writeSet, deltaSet, signedData, nil
 }
 
 func (cm *configManager) policyForItem(item comparable) (policies.Policy, bool) {
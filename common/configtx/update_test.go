@@ -20,9 +20,12 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/configtx/api"
 	mockconfigtx "github.com/hyperledger/fabric/common/mocks/configtx"
 	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/common/policies"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -182,3 +185,60 @@ func TestPolicyForItem(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, policy, fooPolicy, "Should have found relative foo policy for foo group")
 }
+
+func TestEvaluateUpdatePolicies(t *testing.T) {
+	initializer := defaultInitializer()
+	cm, err := NewManagerImpl(
+		makeEnvelopeConfig(defaultChain,
+			makeConfigPair("foo", "foo", 0, []byte("foo")),
+			makeConfigPair("bar", "bar", 0, []byte("bar")),
+		),
+		initializer, nil)
+	assert.NoError(t, err)
+
+	initializer.Resources.PolicyManagerVal.PolicyMap = map[string]policies.Policy{
+		"foo": &mockpolicies.Policy{Err: fmt.Errorf("err")},
+		"bar": &mockpolicies.Policy{},
+	}
+
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{
+		ConfigUpdate: utils.MarshalOrPanic(&cb.ConfigUpdate{
+			ChannelId: defaultChain,
+			ReadSet:   makeConfigSet(),
+			WriteSet: makeConfigSet(
+				makeConfigPair("foo", "foo", 1, []byte("foo")),
+				makeConfigPair("bar", "bar", 1, []byte("bar")),
+				makeConfigPair("baz", "baz", 0, []byte("baz")),
+			),
+		}),
+	}
+
+	t.Run("Green path", func(t *testing.T) {
+		requirements, err := cm.EvaluateUpdatePolicies(configUpdateEnv)
+		assert.NoError(t, err)
+		assert.Equal(t, []*api.PolicyRequirement{
+			{Key: "[Values] /Channel/bar", ModPolicy: "bar"},
+			{Key: "[Values] /Channel/foo", ModPolicy: "foo", Err: fmt.Errorf("err")},
+		}, requirements, "Should have evaluated the policies of the modified elements only")
+	})
+
+	t.Run("Wrong channel", func(t *testing.T) {
+		_, err := cm.EvaluateUpdatePolicies(&cb.ConfigUpdateEnvelope{
+			ConfigUpdate: utils.MarshalOrPanic(&cb.ConfigUpdate{
+				ChannelId: "wrongChain",
+				WriteSet:  makeConfigSet(makeConfigPair("foo", "foo", 1, []byte("foo"))),
+			}),
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Version skip", func(t *testing.T) {
+		_, err := cm.EvaluateUpdatePolicies(&cb.ConfigUpdateEnvelope{
+			ConfigUpdate: utils.MarshalOrPanic(&cb.ConfigUpdate{
+				ChannelId: defaultChain,
+				WriteSet:  makeConfigSet(makeConfigPair("foo", "foo", 2, []byte("foo"))),
+			}),
+		})
+		assert.Error(t, err)
+	})
+}
@@ -55,6 +55,12 @@ type BlockStoreProvider interface {
 	OpenBlockStore(ledgerid string) (BlockStore, error)
 	Exists(ledgerid string) (bool, error)
 	List() ([]string, error)
+	Remove(ledgerid string) error
+	// DropIndex deletes the index of a BlockStore, which gets rebuilt from
+	// the stored blocks when the BlockStore is opened again
+	DropIndex(ledgerid string) error
+	// Rollback truncates a BlockStore after block blockNum
+	Rollback(ledgerid string, blockNum uint64) error
 	Close()
 }
 
@@ -73,3 +79,20 @@ type BlockStore interface {
 	RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
 	Shutdown()
 }
+
+// ArchivableBlockStore is a BlockStore whose oldest blocks can be moved out of
+// the store, one block file at a time
+type ArchivableBlockStore interface {
+	BlockStore
+	// ArchiveBlockfiles passes every block file holding only blocks below
+	// blockNum to archive, oldest first, along with the numbers of the first
+	// and last blocks it holds. archive is expected to move the file out of
+	// the store. It returns the number of the first block left in the store.
+	ArchiveBlockfiles(blockNum uint64, archive func(path string, firstBlockNum, lastBlockNum uint64) error) (uint64, error)
+	// FirstBlockNumber returns the number of the first block left in the store
+	FirstBlockNumber() (uint64, error)
+	// BootstrapFromBlock adds block as the first block of an empty store, so
+	// that a chain can be started from a block other than its genesis block.
+	// The blocks preceding it are reported as archived.
+	BootstrapFromBlock(block *common.Block) error
+}
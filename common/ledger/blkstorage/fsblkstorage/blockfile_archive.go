@@ -0,0 +1,160 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+)
+
+// archiveBlockfiles passes the block files which only hold blocks below
+// blockNum to archive, oldest first, and returns the number of the first block
+// left in the store. The files are expected to be moved out of the store by
+// archive, a file left in place is passed again on the next call.
+func (mgr *blockfileMgr) archiveBlockfiles(blockNum uint64, archive func(path string, firstBlockNum, lastBlockNum uint64) error) (uint64, error) {
+	mgr.archiveLock.Lock()
+	defer mgr.archiveLock.Unlock()
+
+	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
+	if err != nil {
+		return 0, err
+	}
+	fileNum, err := mgr.firstBlockfileNum()
+	if err != nil {
+		return 0, err
+	}
+	if fileNum >= loc.fileSuffixNum && mgr.firstArchivedBlockNum != nil {
+		return *mgr.firstArchivedBlockNum, nil
+	}
+
+	// The file holding blockNum, and so the current one, is never archived
+	for ; fileNum < loc.fileSuffixNum; fileNum++ {
+		path := deriveBlockfilePath(mgr.rootDir, fileNum)
+		_, numBlocks, err := scanForLastCompleteBlock(mgr.rootDir, fileNum, 0)
+		if err != nil {
+			return 0, err
+		}
+		if numBlocks == 0 {
+			// A block larger than the maximum file size leaves an empty file
+			// behind it
+			if err = os.Remove(path); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		firstBlockNum, _, err := firstBlockNumInFile(mgr.rootDir, fileNum)
+		if err != nil {
+			return 0, err
+		}
+		lastBlockNum := firstBlockNum + uint64(numBlocks) - 1
+		logger.Debugf("Archiving block file [%d] holding blocks [%d] to [%d]", fileNum, firstBlockNum, lastBlockNum)
+		if err = archive(path, firstBlockNum, lastBlockNum); err != nil {
+			return 0, err
+		}
+	}
+
+	// Appending blocks does not change the first block left, which is cached
+	// until block files are archived again
+	mgr.firstFileNum = nil
+	firstBlockNum, err := mgr.firstBlockNumber()
+	if err != nil {
+		return 0, err
+	}
+	mgr.firstArchivedBlockNum = &firstBlockNum
+	return firstBlockNum, nil
+}
+
+// bootstrapFromBlock adds block as the first block of an empty store, the
+// blocks preceding it are reported as archived
+func (mgr *blockfileMgr) bootstrapFromBlock(block *common.Block) error {
+	if !mgr.cpInfo.isChainEmpty {
+		return fmt.Errorf("Cannot bootstrap a block store holding %d blocks", mgr.getBlockchainInfo().Height)
+	}
+	mgr.bcInfo.Store(&common.BlockchainInfo{Height: block.Header.Number})
+	if err := mgr.addBlock(block); err != nil {
+		mgr.bcInfo.Store(&common.BlockchainInfo{})
+		return err
+	}
+	return nil
+}
+
+// getFirstBlockNumber returns the number of the first block left in the store
+func (mgr *blockfileMgr) getFirstBlockNumber() (uint64, error) {
+	mgr.archiveLock.Lock()
+	defer mgr.archiveLock.Unlock()
+	return mgr.firstBlockNumber()
+}
+
+// firstBlockNumber returns the number of the first block left in the store
+func (mgr *blockfileMgr) firstBlockNumber() (uint64, error) {
+	fileNum, err := mgr.firstBlockfileNum()
+	if err != nil {
+		return 0, err
+	}
+	for ; fileNum <= mgr.cpInfo.latestFileChunkSuffixNum; fileNum++ {
+		blockNum, found, err := firstBlockNumInFile(mgr.rootDir, fileNum)
+		if err != nil || found {
+			return blockNum, err
+		}
+	}
+	return mgr.getBlockchainInfo().Height, nil
+}
+
+// firstBlockfileNum returns the lowest suffix of the block files in the store
+func (mgr *blockfileMgr) firstBlockfileNum() (int, error) {
+	if mgr.firstFileNum != nil {
+		return *mgr.firstFileNum, nil
+	}
+	infos, err := ioutil.ReadDir(mgr.rootDir)
+	if err != nil {
+		return 0, err
+	}
+	first := mgr.cpInfo.latestFileChunkSuffixNum
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasPrefix(info.Name(), blockfilePrefix) {
+			continue
+		}
+		num, err := strconv.Atoi(strings.TrimPrefix(info.Name(), blockfilePrefix))
+		if err != nil {
+			continue
+		}
+		if num < first {
+			first = num
+		}
+	}
+	mgr.firstFileNum = &first
+	return first, nil
+}
+
+// firstBlockNumInFile returns the number of the first block of a block file,
+// and whether the file holds any block
+func firstBlockNumInFile(rootDir string, fileNum int) (uint64, bool, error) {
+	exists, _, err := util.FileExists(deriveBlockfilePath(rootDir, fileNum))
+	if err != nil || !exists {
+		return 0, false, err
+	}
+	stream, err := newBlockfileStream(rootDir, fileNum, 0)
+	if err != nil {
+		return 0, false, err
+	}
+	defer stream.close()
+	blockBytes, err := stream.nextBlockBytes()
+	if err != nil || blockBytes == nil {
+		return 0, false, err
+	}
+	info, err := extractSerializedBlockInfo(blockBytes)
+	if err != nil {
+		return 0, false, err
+	}
+	return info.blockHeader.Number, true, nil
+}
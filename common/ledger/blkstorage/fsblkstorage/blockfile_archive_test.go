@@ -0,0 +1,115 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+)
+
+func TestBlockfileMgrArchiveBlockfiles(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	blockBytes, _, err := serializeBlock(blocks[1])
+	testutil.AssertNoError(t, err, "")
+
+	// Leave room for about two blocks per file
+	env := newTestEnv(t, NewConf(testPath(), 2*len(blockBytes)+16))
+	defer env.Cleanup()
+	archiveDir := testPath()
+	defer os.RemoveAll(archiveDir)
+
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	mgr := blkfileMgrWrapper.blockfileMgr
+
+	firstBlockNum, err := mgr.firstBlockNumber()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, firstBlockNum, uint64(0))
+
+	var archivedBlockNum uint64
+	archive := func(path string, firstBlockNum, lastBlockNum uint64) error {
+		// Block files are archived in order
+		testutil.AssertEquals(t, firstBlockNum, archivedBlockNum)
+		archivedBlockNum = lastBlockNum + 1
+		return os.Rename(path, filepath.Join(archiveDir, filepath.Base(path)))
+	}
+	firstBlockNum, err = mgr.archiveBlockfiles(12, archive)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, firstBlockNum, archivedBlockNum)
+	testutil.AssertEquals(t, firstBlockNum > 0, true)
+	testutil.AssertEquals(t, firstBlockNum <= 12, true)
+
+	// The blocks left in the store are unaffected
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[firstBlockNum:], firstBlockNum)
+	_, err = mgr.retrieveBlockByNumber(firstBlockNum - 1)
+	testutil.AssertError(t, err, "Expected the archived block to be unavailable")
+
+	// Archiving up to the same block is a no-op
+	unchangedBlockNum, err := mgr.archiveBlockfiles(12, func(path string, firstBlockNum, lastBlockNum uint64) error {
+		t.Fatalf("Unexpected archiving of block file %s", path)
+		return nil
+	})
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, unchangedBlockNum, firstBlockNum)
+
+	// The first block left is found again on restart
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+	reopenedEnv := newTestEnv(t, NewConf(env.provider.conf.blockStorageDir, 2*len(blockBytes)+16))
+	defer reopenedEnv.provider.Close()
+	blkfileMgrWrapper = newTestBlockfileWrapper(reopenedEnv, "testLedger")
+	defer blkfileMgrWrapper.close()
+	reopenedBlockNum, err := blkfileMgrWrapper.blockfileMgr.firstBlockNumber()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, reopenedBlockNum, firstBlockNum)
+	testutil.AssertEquals(t, blkfileMgrWrapper.blockfileMgr.getBlockchainInfo().Height, uint64(20))
+}
+
+func TestBlockfileMgrFirstBlockNumberEmptyChain(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+
+	firstBlockNum, err := blkfileMgrWrapper.blockfileMgr.firstBlockNumber()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, firstBlockNum, uint64(0))
+}
+
+func TestBlockfileMgrBootstrapFromBlock(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	mgr := blkfileMgrWrapper.blockfileMgr
+
+	testutil.AssertNoError(t, mgr.bootstrapFromBlock(blocks[5]), "")
+	blkfileMgrWrapper.addBlocks(blocks[6:])
+	testutil.AssertEquals(t, mgr.getBlockchainInfo().Height, uint64(10))
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[5:], 5)
+	_, err := mgr.retrieveBlockByNumber(4)
+	testutil.AssertError(t, err, "Expected the blocks preceding the bootstrap block to be unavailable")
+	firstBlockNum, err := mgr.firstBlockNumber()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, firstBlockNum, uint64(5))
+
+	// A store holding blocks cannot be bootstrapped
+	testutil.AssertError(t, mgr.bootstrapFromBlock(blocks[9]), "Expected bootstrapping a non empty store to fail")
+
+	// The store starts from the bootstrap block on restart
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+	reopenedEnv := newTestEnv(t, NewConf(env.provider.conf.blockStorageDir, 0))
+	defer reopenedEnv.provider.Close()
+	blkfileMgrWrapper = newTestBlockfileWrapper(reopenedEnv, "testLedger")
+	defer blkfileMgrWrapper.close()
+	testutil.AssertEquals(t, blkfileMgrWrapper.blockfileMgr.getBlockchainInfo().Height, uint64(10))
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[5:], 5)
+}
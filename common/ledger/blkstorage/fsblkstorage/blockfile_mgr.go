@@ -36,6 +36,9 @@ var logger = flogging.MustGetLogger("fsblkstorage")
 
 const (
 	blockfilePrefix = "blockfile_"
+	// progressInterval is the number of blocks between two progress reports
+	// while rebuilding the index
+	progressInterval = 1000
 )
 
 var (
@@ -51,6 +54,12 @@ type blockfileMgr struct {
 	cpInfoCond        *sync.Cond
 	currentFileWriter *blockfileWriter
 	bcInfo            atomic.Value
+
+	// archiveLock guards the archiving of block files and the first block
+	// file and block number cached meanwhile
+	archiveLock           sync.Mutex
+	firstFileNum          *int
+	firstArchivedBlockNum *uint64
 }
 
 /*
@@ -112,8 +121,10 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	if err != nil {
 		panic(fmt.Sprintf("Could not get block file info for current block file from db: %s", err))
 	}
-	if cpInfo == nil { //if no cpInfo stored in db initiate to zero
-		cpInfo = &checkpointInfo{0, 0, true, 0}
+	if cpInfo == nil { //if no cpInfo stored in db, construct it from the block files, if any
+		if cpInfo, err = constructCheckpointInfoFromBlockFiles(rootDir); err != nil {
+			panic(fmt.Sprintf("Could not construct checkpoint info from the block files: %s", err))
+		}
 		err = mgr.saveCurrentInfo(cpInfo, true)
 		if err != nil {
 			panic(fmt.Sprintf("Could not save next block file info to db: %s", err))
@@ -204,7 +215,12 @@ func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
 	}
 	//Updates the checkpoint info for the actual last block number stored and it's end location
 	if cpInfo.isChainEmpty {
-		cpInfo.lastBlockNumber = uint64(numBlocks - 1)
+		// The first block of a bootstrapped store is not the genesis block
+		firstBlockNum, _, err := firstBlockNumInFile(rootDir, cpInfo.latestFileChunkSuffixNum)
+		if err != nil {
+			panic(fmt.Sprintf("Could not read the first block of file [%s]: %s", filePath, err))
+		}
+		cpInfo.lastBlockNumber = firstBlockNum + uint64(numBlocks-1)
 	} else {
 		cpInfo.lastBlockNumber += uint64(numBlocks)
 	}
@@ -325,8 +341,11 @@ func (mgr *blockfileMgr) syncIndex() error {
 		}
 		indexEmpty = true
 	}
-	//initialize index to file number:zero, offset:zero and blockNum:0
-	startFileNum := 0
+	//initialize index to the first block file left in the store, offset:zero and blockNum:0
+	startFileNum, err := mgr.firstBlockfileNum()
+	if err != nil {
+		return err
+	}
 	startOffset := 0
 	blockNum := uint64(0)
 	skipFirstBlock := false
@@ -352,6 +371,10 @@ func (mgr *blockfileMgr) syncIndex() error {
 	var blockBytes []byte
 	var blockPlacementInfo *blockPlacementInfo
 
+	if indexEmpty && !mgr.cpInfo.isChainEmpty {
+		logger.Infof("Rebuilding the index from the block files, up to block [%d]", mgr.cpInfo.lastBlockNumber)
+	}
+
 	if skipFirstBlock {
 		if blockBytes, _, err = stream.nextBlockBytesAndPlacementInfo(); err != nil {
 			return err
@@ -397,6 +420,9 @@ func (mgr *blockfileMgr) syncIndex() error {
 		if err = mgr.index.indexBlock(blockIdxInfo); err != nil {
 			return err
 		}
+		if indexEmpty && (blockIdxInfo.blockNum+1)%progressInterval == 0 {
+			logger.Infof("Indexed block [%d] of [%d]", blockIdxInfo.blockNum, mgr.cpInfo.lastBlockNumber)
+		}
 		blockNum++
 	}
 	return nil
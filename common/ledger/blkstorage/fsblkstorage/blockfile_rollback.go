@@ -0,0 +1,150 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// rollback truncates the block files after block blockNum. The index and the
+// checkpoint info are dropped beforehand, so that the store is rebuilt from
+// the block files left when it is opened again. The files are removed from the
+// last one down before the file holding blockNum is truncated, so that an
+// interrupted rollback leaves a chain without gaps, which it can be run on
+// again. The manager must not be used afterwards
+func (mgr *blockfileMgr) rollback(blockNum uint64) error {
+	bcInfo := mgr.getBlockchainInfo()
+	if bcInfo.Height == 0 || blockNum > bcInfo.Height-1 {
+		return fmt.Errorf("Block number [%d] is beyond the last block of the store, the height of the store is [%d]", blockNum, bcInfo.Height)
+	}
+	firstBlockNum, err := mgr.getFirstBlockNumber()
+	if err != nil {
+		return err
+	}
+	if blockNum < firstBlockNum {
+		return fmt.Errorf("Block number [%d] has been archived, the first block left in the store is [%d]", blockNum, firstBlockNum)
+	}
+
+	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
+	if err != nil {
+		return err
+	}
+	stream, err := newBlockfileStream(mgr.rootDir, loc.fileSuffixNum, int64(loc.offset))
+	if err != nil {
+		return err
+	}
+	_, err = stream.nextBlockBytes()
+	endOffset := stream.currentOffset
+	stream.close()
+	if err != nil {
+		return err
+	}
+
+	if err = mgr.db.DeleteAll(true); err != nil {
+		return err
+	}
+	for fileNum := mgr.cpInfo.latestFileChunkSuffixNum; fileNum > loc.fileSuffixNum; fileNum-- {
+		if err = os.Remove(deriveBlockfilePath(mgr.rootDir, fileNum)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	logger.Infof("Truncating block file [%d] at offset [%d], after block [%d]", loc.fileSuffixNum, endOffset, blockNum)
+	return os.Truncate(deriveBlockfilePath(mgr.rootDir, loc.fileSuffixNum), endOffset)
+}
+
+// constructCheckpointInfoFromBlockFiles scans the block files to construct the
+// checkpoint info of a store whose index has been dropped
+func constructCheckpointInfoFromBlockFiles(rootDir string) (*checkpointInfo, error) {
+	firstFileNum, lastFileNum, found, err := blockfileNumRange(rootDir)
+	if err != nil || !found {
+		return &checkpointInfo{0, 0, true, 0}, err
+	}
+	cpInfo := &checkpointInfo{latestFileChunkSuffixNum: lastFileNum, isChainEmpty: true}
+	for fileNum := lastFileNum; fileNum >= firstFileNum; fileNum-- {
+		lastBlockBytes, endOffset, err := scanForLastBlock(rootDir, fileNum)
+		if err != nil {
+			return nil, err
+		}
+		if fileNum == lastFileNum {
+			cpInfo.latestFileChunksize = int(endOffset)
+		}
+		if lastBlockBytes == nil {
+			continue
+		}
+		info, err := extractSerializedBlockInfo(lastBlockBytes)
+		if err != nil {
+			return nil, err
+		}
+		cpInfo.lastBlockNumber = info.blockHeader.Number
+		cpInfo.isChainEmpty = false
+		break
+	}
+	logger.Infof("Constructed checkpoint from the block files: %s", cpInfo)
+	return cpInfo, nil
+}
+
+// scanForLastBlock returns the bytes of the last complete block of a block
+// file, if any, and the offset at which it ends
+func scanForLastBlock(rootDir string, fileNum int) ([]byte, int64, error) {
+	stream, err := newBlockfileStream(rootDir, fileNum, 0)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+	defer stream.close()
+	var lastBlockBytes []byte
+	var endOffset int64
+	for {
+		blockBytes, err := stream.nextBlockBytes()
+		if err == ErrUnexpectedEndOfBlockfile {
+			// a crash happened while appending the last block
+			break
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		if blockBytes == nil {
+			break
+		}
+		lastBlockBytes = blockBytes
+		endOffset = stream.currentOffset
+	}
+	return lastBlockBytes, endOffset, nil
+}
+
+// blockfileNumRange returns the lowest and highest suffixes of the block files
+// in rootDir, and whether there is any
+func blockfileNumRange(rootDir string) (int, int, bool, error) {
+	infos, err := ioutil.ReadDir(rootDir)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	first, last, found := 0, 0, false
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasPrefix(info.Name(), blockfilePrefix) {
+			continue
+		}
+		num, err := strconv.Atoi(strings.TrimPrefix(info.Name(), blockfilePrefix))
+		if err != nil {
+			continue
+		}
+		if !found || num < first {
+			first = num
+		}
+		if !found || num > last {
+			last = num
+		}
+		found = true
+	}
+	return first, last, found, nil
+}
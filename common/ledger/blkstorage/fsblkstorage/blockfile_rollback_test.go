@@ -0,0 +1,145 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+)
+
+func TestBlockfileMgrRollback(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	blockBytes, _, err := serializeBlock(blocks[1])
+	testutil.AssertNoError(t, err, "")
+
+	// Leave room for about two blocks per file
+	env := newTestEnv(t, NewConf(testPath(), 2*len(blockBytes)+16))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	blkfileMgrWrapper.close()
+
+	testutil.AssertError(t, env.provider.Rollback("testLedger", 20), "Expected an error rolling back beyond the last block")
+	testutil.AssertNoError(t, env.provider.Rollback("testLedger", 7), "")
+
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	testutil.AssertEquals(t, blkfileMgrWrapper.blockfileMgr.getBlockchainInfo().Height, uint64(8))
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[:8], 0)
+	blkfileMgrWrapper.testGetBlockByHash(blocks[:8])
+	_, err = blkfileMgrWrapper.blockfileMgr.retrieveBlockByHash(blocks[8].Header.Hash())
+	testutil.AssertError(t, err, "Expected the block rolled back to be unavailable")
+
+	// The blocks rolled back can be committed again
+	blkfileMgrWrapper.addBlocks(blocks[8:])
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0)
+}
+
+func TestBlockfileMgrRollbackInterrupted(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	blockBytes, _, err := serializeBlock(blocks[1])
+	testutil.AssertNoError(t, err, "")
+
+	env := newTestEnv(t, NewConf(testPath(), 2*len(blockBytes)+16))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	blkfileMgrWrapper.close()
+
+	// Simulate a rollback to block 7 interrupted after dropping the index and
+	// removing the last block file
+	testutil.AssertNoError(t, env.provider.DropIndex("testLedger"), "")
+	rootDir := env.provider.conf.getLedgerBlockDir("testLedger")
+	_, lastFileNum, _, err := blockfileNumRange(rootDir)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertNoError(t, os.Remove(deriveBlockfilePath(rootDir, lastFileNum)), "")
+
+	// The store is rebuilt with the blocks left, without any gap
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	height := blkfileMgrWrapper.blockfileMgr.getBlockchainInfo().Height
+	if height <= 8 || height >= 20 {
+		t.Fatalf("Expected the blocks of the files left to be available, got height %d", height)
+	}
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[:height], 0)
+	blkfileMgrWrapper.close()
+
+	// The rollback completes when run again
+	testutil.AssertNoError(t, env.provider.Rollback("testLedger", 7), "")
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	testutil.AssertEquals(t, blkfileMgrWrapper.blockfileMgr.getBlockchainInfo().Height, uint64(8))
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[:8], 0)
+}
+
+func TestBlockfileMgrRollbackArchivedBlock(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	blockBytes, _, err := serializeBlock(blocks[1])
+	testutil.AssertNoError(t, err, "")
+
+	env := newTestEnv(t, NewConf(testPath(), 2*len(blockBytes)+16))
+	defer env.Cleanup()
+	archiveDir := testPath()
+	defer os.RemoveAll(archiveDir)
+
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	firstBlockNum, err := blkfileMgrWrapper.blockfileMgr.archiveBlockfiles(12, func(path string, firstBlockNum, lastBlockNum uint64) error {
+		return os.Rename(path, filepath.Join(archiveDir, filepath.Base(path)))
+	})
+	testutil.AssertNoError(t, err, "")
+	blkfileMgrWrapper.close()
+
+	testutil.AssertError(t, env.provider.Rollback("testLedger", firstBlockNum-1), "Expected an error rolling back to an archived block")
+	testutil.AssertNoError(t, env.provider.Rollback("testLedger", firstBlockNum), "")
+
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	testutil.AssertEquals(t, blkfileMgrWrapper.blockfileMgr.getBlockchainInfo().Height, firstBlockNum+1)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[firstBlockNum:firstBlockNum+1], firstBlockNum)
+}
+
+func TestBlockfileMgrDropIndex(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	blockBytes, _, err := serializeBlock(blocks[1])
+	testutil.AssertNoError(t, err, "")
+
+	env := newTestEnv(t, NewConf(testPath(), 2*len(blockBytes)+16))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks[:15])
+	blkfileMgrWrapper.close()
+
+	testutil.AssertNoError(t, env.provider.DropIndex("testLedger"), "")
+
+	// The checkpoint info and the index are rebuilt from the block files
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	testutil.AssertEquals(t, blkfileMgrWrapper.blockfileMgr.getBlockchainInfo().Height, uint64(15))
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[:15], 0)
+	blkfileMgrWrapper.testGetBlockByHash(blocks[:15])
+	blkfileMgrWrapper.addBlocks(blocks[15:])
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0)
+}
+
+func TestBlockfileMgrDropIndexEmptyStore(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.close()
+
+	testutil.AssertNoError(t, env.provider.DropIndex("testLedger"), "")
+
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	testutil.AssertEquals(t, blkfileMgrWrapper.blockfileMgr.getBlockchainInfo().Height, uint64(0))
+	blocks := testutil.ConstructTestBlocks(t, 3)
+	blkfileMgrWrapper.addBlocks(blocks)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0)
+}
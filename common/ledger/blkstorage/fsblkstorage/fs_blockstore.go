@@ -86,6 +86,22 @@ func (store *fsBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
 }
 
+// ArchiveBlockfiles passes the block files which only hold blocks below
+// blockNum to archive, and returns the number of the first block left
+func (store *fsBlockStore) ArchiveBlockfiles(blockNum uint64, archive func(path string, firstBlockNum, lastBlockNum uint64) error) (uint64, error) {
+	return store.fileMgr.archiveBlockfiles(blockNum, archive)
+}
+
+// FirstBlockNumber returns the number of the first block left in the store
+func (store *fsBlockStore) FirstBlockNumber() (uint64, error) {
+	return store.fileMgr.getFirstBlockNumber()
+}
+
+// BootstrapFromBlock adds block as the first block of an empty store
+func (store *fsBlockStore) BootstrapFromBlock(block *common.Block) error {
+	return store.fileMgr.bootstrapFromBlock(block)
+}
+
 // Shutdown shuts down the block store
 func (store *fsBlockStore) Shutdown() {
 	logger.Debugf("closing fs blockStore:%s", store.id)
@@ -17,6 +17,8 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"os"
+
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
@@ -59,6 +61,30 @@ func (p *FsBlockstoreProvider) List() ([]string, error) {
 	return util.ListSubdirs(p.conf.getChainsDir())
 }
 
+// Remove deletes the block files and the index entries of the BlockStore with
+// given id. Any BlockStore opened for this id must be shut down beforehand
+func (p *FsBlockstoreProvider) Remove(ledgerid string) error {
+	if err := p.leveldbProvider.GetDBHandle(ledgerid).DeleteAll(true); err != nil {
+		return err
+	}
+	return os.RemoveAll(p.conf.getLedgerBlockDir(ledgerid))
+}
+
+// DropIndex deletes the index of the BlockStore with given id, which gets rebuilt
+// from the block files when the BlockStore is opened again. Any BlockStore opened
+// for this id must be shut down beforehand
+func (p *FsBlockstoreProvider) DropIndex(ledgerid string) error {
+	return p.leveldbProvider.GetDBHandle(ledgerid).DeleteAll(true)
+}
+
+// Rollback truncates the BlockStore with given id after block blockNum. Any
+// BlockStore opened for this id must be shut down beforehand
+func (p *FsBlockstoreProvider) Rollback(ledgerid string, blockNum uint64) error {
+	mgr := newBlockfileMgr(ledgerid, p.conf, p.indexConfig, p.leveldbProvider.GetDBHandle(ledgerid))
+	defer mgr.close()
+	return mgr.rollback(blockNum)
+}
+
 // Close closes the FsBlockstoreProvider
 func (p *FsBlockstoreProvider) Close() {
 	p.leveldbProvider.Close()
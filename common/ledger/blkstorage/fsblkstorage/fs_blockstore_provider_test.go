@@ -150,3 +150,38 @@ func TestBlockStoreProvider(t *testing.T) {
 func constructLedgerid(id int) string {
 	return fmt.Sprintf("ledger_%d", id)
 }
+
+func TestBlockStoreProviderRemove(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+
+	provider := env.provider
+	store1, _ := provider.OpenBlockStore("ledger1")
+	defer store1.Shutdown()
+	store2, _ := provider.OpenBlockStore("ledger2")
+
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	for _, b := range blocks {
+		store1.AddBlock(b)
+		store2.AddBlock(b)
+	}
+
+	store2.Shutdown()
+	testutil.AssertNoError(t, provider.Remove("ledger2"), "")
+
+	exists, err := provider.Exists("ledger2")
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, exists, false)
+
+	storeNames, _ := provider.List()
+	testutil.AssertEquals(t, storeNames, []string{"ledger1"})
+	checkBlocks(t, blocks, store1)
+
+	// Re-creating the removed ledger starts from an empty chain
+	store2, _ = provider.OpenBlockStore("ledger2")
+	defer store2.Shutdown()
+	bcInfo, _ := store2.GetBlockchainInfo()
+	testutil.AssertEquals(t, bcInfo.Height, uint64(0))
+	_, err = store2.RetrieveBlockByHash(blocks[0].Header.Hash())
+	testutil.AssertEquals(t, err, blkstorage.ErrNotFoundInIndex)
+}
@@ -96,6 +96,20 @@ func (h *DBHandle) WriteBatch(batch *UpdateBatch, sync bool) error {
 	return nil
 }
 
+// DeleteAll deletes all the keys of the named db
+func (h *DBHandle) DeleteAll(sync bool) error {
+	itr := h.GetIterator(nil, nil)
+	levelBatch := &leveldb.Batch{}
+	for itr.Next() {
+		levelBatch.Delete(constructLevelKey(h.dbName, itr.Key()))
+	}
+	itr.Release()
+	if err := itr.Error(); err != nil {
+		return err
+	}
+	return h.db.WriteBatch(levelBatch, sync)
+}
+
 // GetIterator gets an handle to iterator. The iterator should be released after the use.
 // The resultset contains all the keys that are present in the db between the startKey (inclusive) and the endKey (exclusive).
 // A nil startKey represents the first available key and a nil endKey represent a logical key after the last available key
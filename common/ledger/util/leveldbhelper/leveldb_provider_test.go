@@ -90,6 +90,28 @@ func TestBatchedUpdates(t *testing.T) {
 	}
 }
 
+func TestDeleteAll(t *testing.T) {
+	env := newTestProviderEnv(t, testDBPath)
+	defer env.cleanup()
+	p := env.provider
+
+	db1 := p.GetDBHandle("db1")
+	db10 := p.GetDBHandle("db10")
+	for i := 0; i < 20; i++ {
+		db1.Put([]byte(createTestKey(i)), []byte(createTestValue("db1", i)), false)
+		db10.Put([]byte(createTestKey(i)), []byte(createTestValue("db10", i)), false)
+	}
+
+	testutil.AssertNoError(t, db1.DeleteAll(true), "")
+	itr1 := db1.GetIterator(nil, nil)
+	defer itr1.Release()
+	testutil.AssertEquals(t, itr1.Next(), false)
+
+	// A db whose name starts with the name of the deleted db is left untouched
+	itr2 := db10.GetIterator(nil, nil)
+	checkItrResults(t, itr2, createTestKeys(0, 19), createTestValues("db10", 0, 19))
+}
+
 func testDBBasicWriteAndReads(t *testing.T, dbNames ...string) {
 	env := newTestProviderEnv(t, testDBPath)
 	defer env.cleanup()
@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"github.com/hyperledger/fabric/common/config"
+)
+
+// Application is a mock implementation of config.Application
+type Application struct {
+	// OrganizationsVal is returned as the result of Organizations()
+	OrganizationsVal map[string]config.ApplicationOrg
+	// PolicyRefsVal is looked up for the result of PolicyRefForAPI()
+	PolicyRefsVal map[string]string
+	// CapabilitiesVal is returned as the result of Capabilities()
+	CapabilitiesVal config.ApplicationCapabilities
+}
+
+// Organizations returns OrganizationsVal
+func (a *Application) Organizations() map[string]config.ApplicationOrg {
+	return a.OrganizationsVal
+}
+
+// PolicyRefForAPI returns the entry of PolicyRefsVal for the resource
+func (a *Application) PolicyRefForAPI(resName string) string {
+	return a.PolicyRefsVal[resName]
+}
+
+// Capabilities returns CapabilitiesVal
+func (a *Application) Capabilities() config.ApplicationCapabilities {
+	return a.CapabilitiesVal
+}
+
+// ApplicationCapabilities mocks the config.ApplicationCapabilities interface
+type ApplicationCapabilities struct {
+	// SupportedErr is returned by Supported()
+	SupportedErr error
+
+	// ForbidDuplicateTXIdInBlockVal is returned by ForbidDuplicateTXIdInBlock()
+	ForbidDuplicateTXIdInBlockVal bool
+}
+
+// Supported returns SupportedErr
+func (ac *ApplicationCapabilities) Supported() error {
+	return ac.SupportedErr
+}
+
+// ForbidDuplicateTXIdInBlock returns ForbidDuplicateTXIdInBlockVal
+func (ac *ApplicationCapabilities) ForbidDuplicateTXIdInBlock() bool {
+	return ac.ForbidDuplicateTXIdInBlockVal
+}
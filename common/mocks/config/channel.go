@@ -16,7 +16,12 @@ limitations under the License.
 
 package config
 
-import "github.com/hyperledger/fabric/common/util"
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/common/config"
+	"github.com/hyperledger/fabric/common/util"
+)
 
 func nearIdentityHash(input []byte) []byte {
 	return util.ConcatenateBytes([]byte("FakeHash("), input, []byte(""))
@@ -30,6 +35,10 @@ type Channel struct {
 	BlockDataHashingStructureWidthVal uint32
 	// OrdererAddressesVal is returned as the result of OrdererAddresses()
 	OrdererAddressesVal []string
+	// TransactionTimestampWindowVal is returned as the result of TransactionTimestampWindow()
+	TransactionTimestampWindowVal time.Duration
+	// CapabilitiesVal is returned as the result of Capabilities()
+	CapabilitiesVal config.ChannelCapabilities
 }
 
 // HashingAlgorithm returns the HashingAlgorithmVal if set, otherwise a fake simple hash function
@@ -49,3 +58,32 @@ func (scm *Channel) BlockDataHashingStructureWidth() uint32 {
 func (scm *Channel) OrdererAddresses() []string {
 	return scm.OrdererAddressesVal
 }
+
+// TransactionTimestampWindow returns the TransactionTimestampWindowVal
+func (scm *Channel) TransactionTimestampWindow() time.Duration {
+	return scm.TransactionTimestampWindowVal
+}
+
+// Capabilities returns CapabilitiesVal
+func (scm *Channel) Capabilities() config.ChannelCapabilities {
+	return scm.CapabilitiesVal
+}
+
+// ChannelCapabilities mocks the config.ChannelCapabilities interface
+type ChannelCapabilities struct {
+	// SupportedErr is returned by Supported()
+	SupportedErr error
+
+	// TLSBindingVal is returned by TLSBinding()
+	TLSBindingVal bool
+}
+
+// Supported returns SupportedErr
+func (cc *ChannelCapabilities) Supported() error {
+	return cc.SupportedErr
+}
+
+// TLSBinding returns TLSBindingVal
+func (cc *ChannelCapabilities) TLSBinding() bool {
+	return cc.TLSBindingVal
+}
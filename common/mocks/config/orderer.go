@@ -27,6 +27,10 @@ import (
 type Orderer struct {
 	// ConsensusTypeVal is returned as the result of ConsensusType()
 	ConsensusTypeVal string
+	// ConsensusMetadataVal is returned as the result of ConsensusMetadata()
+	ConsensusMetadataVal []byte
+	// ConsensusStateVal is returned as the result of ConsensusState()
+	ConsensusStateVal ab.ConsensusType_State
 	// BatchSizeVal is returned as the result of BatchSize()
 	BatchSizeVal *ab.BatchSize
 	// BatchTimeoutVal is returned as the result of BatchTimeout()
@@ -36,7 +40,11 @@ type Orderer struct {
 	// MaxChannelsCountVal is returns as the result of MaxChannelsCount()
 	MaxChannelsCountVal uint64
 	// OrganizationsVal is returned as the result of Organizations()
-	OrganizationsVal map[string]config.Org
+	OrganizationsVal map[string]config.OrdererOrg
+	// CapabilitiesVal is returned as the result of Capabilities()
+	CapabilitiesVal config.OrdererCapabilities
+	// BroadcastRateLimitsVal is returned as the result of BroadcastRateLimits()
+	BroadcastRateLimitsVal *ab.BroadcastRateLimits
 }
 
 // ConsensusType returns the ConsensusTypeVal
@@ -44,6 +52,16 @@ func (scm *Orderer) ConsensusType() string {
 	return scm.ConsensusTypeVal
 }
 
+// ConsensusMetadata returns the ConsensusMetadataVal
+func (scm *Orderer) ConsensusMetadata() []byte {
+	return scm.ConsensusMetadataVal
+}
+
+// ConsensusState returns the ConsensusStateVal
+func (scm *Orderer) ConsensusState() ab.ConsensusType_State {
+	return scm.ConsensusStateVal
+}
+
 // BatchSize returns the BatchSizeVal
 func (scm *Orderer) BatchSize() *ab.BatchSize {
 	return scm.BatchSizeVal
@@ -64,7 +82,44 @@ func (scm *Orderer) MaxChannelsCount() uint64 {
 	return scm.MaxChannelsCountVal
 }
 
+// BroadcastRateLimits returns BroadcastRateLimitsVal
+func (scm *Orderer) BroadcastRateLimits() *ab.BroadcastRateLimits {
+	return scm.BroadcastRateLimitsVal
+}
+
 // Organizations returns OrganizationsVal
-func (scm *Orderer) Organizations() map[string]config.Org {
+func (scm *Orderer) Organizations() map[string]config.OrdererOrg {
 	return scm.OrganizationsVal
 }
+
+// Capabilities returns CapabilitiesVal
+func (scm *Orderer) Capabilities() config.OrdererCapabilities {
+	return scm.CapabilitiesVal
+}
+
+// OrdererCapabilities mocks the config.OrdererCapabilities interface
+type OrdererCapabilities struct {
+	// SupportedErr is returned by Supported()
+	SupportedErr error
+
+	// PredictableChannelTemplateVal is returned by PredictableChannelTemplate()
+	PredictableChannelTemplateVal bool
+
+	// OrgEndpointsVal is returned by OrgEndpoints()
+	OrgEndpointsVal bool
+}
+
+// Supported returns SupportedErr
+func (oc *OrdererCapabilities) Supported() error {
+	return oc.SupportedErr
+}
+
+// PredictableChannelTemplate returns PredictableChannelTemplateVal
+func (oc *OrdererCapabilities) PredictableChannelTemplate() bool {
+	return oc.PredictableChannelTemplateVal
+}
+
+// OrgEndpoints returns OrgEndpointsVal
+func (oc *OrdererCapabilities) OrgEndpoints() bool {
+	return oc.OrgEndpointsVal
+}
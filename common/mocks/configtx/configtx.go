@@ -18,6 +18,7 @@ package configtx
 
 import (
 	"github.com/hyperledger/fabric/common/config"
+	configtxapi "github.com/hyperledger/fabric/common/configtx/api"
 	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/msp"
@@ -58,12 +59,12 @@ func (r *Resources) ChannelConfig() config.Channel {
 
 // Returns the OrdererConfigVal
 func (r *Resources) OrdererConfig() (config.Orderer, bool) {
-	return r.OrdererConfigVal, r.OrdererConfigVal == nil
+	return r.OrdererConfigVal, r.OrdererConfigVal != nil
 }
 
 // Returns the ApplicationConfigVal
 func (r *Resources) ApplicationConfig() (config.Application, bool) {
-	return r.ApplicationConfigVal, r.ApplicationConfigVal == nil
+	return r.ApplicationConfigVal, r.ApplicationConfigVal != nil
 }
 
 func (r *Resources) ConsortiumsConfig() (config.Consortiums, bool) {
@@ -182,6 +183,12 @@ type Manager struct {
 
 	// ConfigEnvelopeVal is returned as the value for ConfigEnvelope()
 	ConfigEnvelopeVal *cb.ConfigEnvelope
+
+	// EvaluateUpdatePoliciesVal is returned as the value for EvaluateUpdatePolicies
+	EvaluateUpdatePoliciesVal []*configtxapi.PolicyRequirement
+
+	// EvaluateUpdatePoliciesError is returned as the error value for EvaluateUpdatePolicies
+	EvaluateUpdatePoliciesError error
 }
 
 // ConfigEnvelope returns the ConfigEnvelopeVal
@@ -204,6 +211,11 @@ func (cm *Manager) ProposeConfigUpdate(update *cb.Envelope) (*cb.ConfigEnvelope,
 	return cm.ProposeConfigUpdateVal, cm.ProposeConfigUpdateError
 }
 
+// EvaluateUpdatePolicies returns EvaluateUpdatePoliciesVal and EvaluateUpdatePoliciesError
+func (cm *Manager) EvaluateUpdatePolicies(configUpdateEnv *cb.ConfigUpdateEnvelope) ([]*configtxapi.PolicyRequirement, error) {
+	return cm.EvaluateUpdatePoliciesVal, cm.EvaluateUpdatePoliciesError
+}
+
 // Apply returns ApplyVal
 func (cm *Manager) Apply(configEnv *cb.ConfigEnvelope) error {
 	cm.AppliedConfigUpdateEnvelope = configEnv
@@ -28,6 +28,9 @@ type implicitMetaPolicy struct {
 	conf        *cb.ImplicitMetaPolicy
 	threshold   int
 	subPolicies []Policy
+
+	// subPolicyNames holds the name of the group of each entry in subPolicies
+	subPolicyNames []string
 }
 
 // NewPolicy creates a new policy based on the policy bytes
@@ -44,9 +47,11 @@ func newImplicitMetaPolicy(data []byte) (*implicitMetaPolicy, error) {
 
 func (imp *implicitMetaPolicy) initialize(config *policyConfig) {
 	imp.subPolicies = make([]Policy, len(config.managers))
+	imp.subPolicyNames = make([]string, len(config.managers))
 	i := 0
-	for _, manager := range config.managers {
+	for name, manager := range config.managers {
 		imp.subPolicies[i], _ = manager.GetPolicy(imp.conf.SubPolicy)
+		imp.subPolicyNames[i] = name
 		i++
 	}
 
@@ -68,16 +73,44 @@ func (imp *implicitMetaPolicy) initialize(config *policyConfig) {
 // Evaluate takes a set of SignedData and evaluates whether this set of signatures satisfies the policy
 func (imp *implicitMetaPolicy) Evaluate(signatureSet []*cb.SignedData) error {
 	remaining := imp.threshold
-	for _, policy := range imp.subPolicies {
-		if policy.Evaluate(signatureSet) == nil {
+	if remaining == 0 {
+		return nil
+	}
+
+	trace := &EvaluationTrace{
+		Rule: fmt.Sprintf("%s of %s", imp.conf.Rule, imp.conf.SubPolicy),
+	}
+	for i, policy := range imp.subPolicies {
+		err := policy.Evaluate(signatureSet)
+		if err == nil {
 			remaining--
 			if remaining == 0 {
 				return nil
 			}
 		}
+		trace.Children = append(trace.Children, subPolicyTrace(imp.subPolicyNames[i], imp.conf.SubPolicy, err))
 	}
-	if remaining == 0 {
-		return nil
+	trace.Reason = fmt.Sprintf("%d of %d sub-policies satisfied, %d required", imp.threshold-remaining, len(imp.subPolicies), imp.threshold)
+
+	return &EvaluationError{
+		Message: fmt.Sprintf("Failed to reach implicit threshold of %d sub-policies, required %d remaining", imp.threshold, remaining),
+		Trace:   trace,
+	}
+}
+
+// subPolicyTrace wraps the result of evaluating a sub-policy of an implicit meta policy
+func subPolicyTrace(group, subPolicy string, err error) *EvaluationTrace {
+	trace := &EvaluationTrace{
+		Rule:      group + PathSeparator + subPolicy,
+		Satisfied: err == nil,
+	}
+	if err == nil {
+		return trace
+	}
+	if subTrace, ok := TraceFromError(err); ok {
+		trace.Children = []*EvaluationTrace{subTrace}
+	} else {
+		trace.Reason = err.Error()
 	}
-	return fmt.Errorf("Failed to reach implicit threshold of %d sub-policies, required %d remaining", imp.threshold, remaining)
+	return trace
 }
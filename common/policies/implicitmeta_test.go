@@ -99,3 +99,25 @@ func TestImplicitMetaMajority(t *testing.T) {
 	assert.Error(t, runPolicyTest(cb.ImplicitMetaPolicy_MAJORITY, 10, 0))
 	assert.NoError(t, runPolicyTest(cb.ImplicitMetaPolicy_MAJORITY, 0, 0))
 }
+
+func TestImplicitMetaTrace(t *testing.T) {
+	err := runPolicyTest(cb.ImplicitMetaPolicy_MAJORITY, 3, 1)
+	assert.Error(t, err)
+
+	trace, ok := TraceFromError(err)
+	assert.True(t, ok, "Evaluation error should carry a trace")
+	assert.Equal(t, "MAJORITY of "+TestPolicyName, trace.Rule)
+	assert.False(t, trace.Satisfied)
+	assert.Equal(t, "1 of 3 sub-policies satisfied, 2 required", trace.Reason)
+	assert.Len(t, trace.Children, 3)
+
+	failed := 0
+	for _, child := range trace.Children {
+		if !child.Satisfied {
+			failed++
+			assert.Contains(t, child.Reason, "No such policy type")
+		}
+	}
+	assert.Equal(t, 2, failed)
+	assert.Contains(t, err.Error(), "Failed to reach implicit threshold of 2 sub-policies, required 1 remaining")
+}
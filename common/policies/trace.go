@@ -0,0 +1,146 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policies
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// EvaluationTrace describes a node of the tree built while evaluating a policy.
+// Gates which were not reached because their parent was already satisfied do
+// not appear in the trace.
+type EvaluationTrace struct {
+	// Rule describes the gate or policy evaluated, such as "1 out of 2" or
+	// "signed by Org1MSP.member"
+	Rule string
+
+	// Satisfied reports whether the gate or policy was satisfied
+	Satisfied bool
+
+	// Reason explains a failure which is not accounted for by the children or signatures
+	Reason string
+
+	// Signatures records the outcome of each signature considered by a signature gate
+	Signatures []*SignatureTrace
+
+	// Children holds the traces of the sub-gates or sub-policies
+	Children []*EvaluationTrace
+}
+
+// SignatureTrace records how a single signature fared against a signature gate
+type SignatureTrace struct {
+	// Index is the position of the signature in the evaluated signature set
+	Index int
+
+	// MSPID is the MSP of the signing identity, empty if it could not be deserialized
+	MSPID string
+
+	// Outcome is "matched" for the signature which satisfied the gate, or the
+	// reason the signature was not accepted
+	Outcome string
+}
+
+func (st *SignatureTrace) String() string {
+	mspID := st.MSPID
+	if mspID == "" {
+		mspID = "unknown MSP"
+	}
+	return fmt.Sprintf("signature %d (%s) %s", st.Index, mspID, st.Outcome)
+}
+
+func (et *EvaluationTrace) status() string {
+	if et.Satisfied {
+		return "satisfied"
+	}
+	return "not satisfied"
+}
+
+// String renders the trace on a single line, suitable for inclusion in errors
+func (et *EvaluationTrace) String() string {
+	if et == nil {
+		return ""
+	}
+
+	details := make([]string, 0, len(et.Signatures)+len(et.Children)+1)
+	if et.Reason != "" {
+		details = append(details, et.Reason)
+	}
+	for _, st := range et.Signatures {
+		details = append(details, st.String())
+	}
+	for _, child := range et.Children {
+		details = append(details, child.String())
+	}
+
+	if len(details) == 0 {
+		return fmt.Sprintf("%s %s", et.Rule, et.status())
+	}
+	return fmt.Sprintf("%s %s [%s]", et.Rule, et.status(), strings.Join(details, "; "))
+}
+
+// Tree renders the trace across multiple lines, indenting each level of the evaluation
+func (et *EvaluationTrace) Tree() string {
+	buf := &bytes.Buffer{}
+	et.writeTree(buf, 0)
+	return buf.String()
+}
+
+func (et *EvaluationTrace) writeTree(buf *bytes.Buffer, depth int) {
+	if et == nil {
+		return
+	}
+	indent := strings.Repeat("  ", depth)
+	fmt.Fprintf(buf, "%s%s: %s\n", indent, et.Rule, et.status())
+	if et.Reason != "" {
+		fmt.Fprintf(buf, "%s  %s\n", indent, et.Reason)
+	}
+	for _, st := range et.Signatures {
+		fmt.Fprintf(buf, "%s  %s\n", indent, st)
+	}
+	for _, child := range et.Children {
+		child.writeTree(buf, depth+1)
+	}
+}
+
+// EvaluationError is returned by policies which are not satisfied by a set of
+// signatures, and carries the trace of the evaluation
+type EvaluationError struct {
+	// Message is the summary of the failure
+	Message string
+
+	// Trace is the evaluation tree explaining the failure
+	Trace *EvaluationTrace
+}
+
+func (ee *EvaluationError) Error() string {
+	if ee.Trace == nil {
+		return ee.Message
+	}
+	return fmt.Sprintf("%s: %s", ee.Message, ee.Trace)
+}
+
+// TraceFromError returns the evaluation trace carried by a policy evaluation
+// error and whether there was one
+func TraceFromError(err error) (*EvaluationTrace, bool) {
+	ee, ok := err.(*EvaluationError)
+	if !ok || ee.Trace == nil {
+		return nil, false
+	}
+	return ee.Trace, true
+}
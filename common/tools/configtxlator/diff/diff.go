@@ -0,0 +1,301 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	// Import these to register the proto types
+	_ "github.com/hyperledger/fabric/protos/msp"
+	_ "github.com/hyperledger/fabric/protos/orderer"
+	_ "github.com/hyperledger/fabric/protos/peer"
+)
+
+// The kinds of config elements
+const (
+	KindGroup  = "group"
+	KindValue  = "value"
+	KindPolicy = "policy"
+)
+
+// The actions applied to config elements
+const (
+	ActionAdded    = "added"
+	ActionRemoved  = "removed"
+	ActionModified = "modified"
+)
+
+// Change describes a config element which differs between two configs
+type Change struct {
+	Path    string   `json:"path"`
+	Kind    string   `json:"kind"`
+	Action  string   `json:"action"`
+	Details []string `json:"details,omitempty"`
+}
+
+// String renders the change on a line, followed by a line per detail
+func (c *Change) String() string {
+	s := fmt.Sprintf("%s %s %s", c.Action, c.Kind, c.Path)
+	for _, detail := range c.Details {
+		s += "\n    " + detail
+	}
+	return s
+}
+
+// Compute returns the changes turning the original config into the updated
+// one, ordered by path. The values and policies are compared once decoded,
+// so that the changes name the fields which differ, and the versions of the
+// elements, which are bumped by any update, are ignored.
+func Compute(original, updated *cb.Config) ([]*Change, error) {
+	originalTree, err := decode(original)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding original config: %s", err)
+	}
+	updatedTree, err := decode(updated)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding updated config: %s", err)
+	}
+	return diffGroup("/Channel", object(originalTree["channel_group"]), object(updatedTree["channel_group"])), nil
+}
+
+func decode(config *cb.Config) (map[string]interface{}, error) {
+	buffer := &bytes.Buffer{}
+	if err := protolator.DeepMarshalJSON(buffer, config); err != nil {
+		return nil, err
+	}
+	tree := make(map[string]interface{})
+	if err := json.Unmarshal(buffer.Bytes(), &tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+func diffGroup(path string, original, updated map[string]interface{}) []*Change {
+	var changes []*Change
+
+	if details := diffModPolicy(original, updated); len(details) > 0 {
+		changes = append(changes, &Change{Path: path, Kind: KindGroup, Action: ActionModified, Details: details})
+	}
+
+	originalGroups, updatedGroups := object(original["groups"]), object(updated["groups"])
+	for _, name := range keys(originalGroups, updatedGroups) {
+		groupPath := path + "/" + name
+		originalGroup, inOriginal := originalGroups[name]
+		updatedGroup, inUpdated := updatedGroups[name]
+		switch {
+		case !inOriginal:
+			changes = append(changes, &Change{Path: groupPath, Kind: KindGroup, Action: ActionAdded, Details: describeGroup(object(updatedGroup))})
+		case !inUpdated:
+			changes = append(changes, &Change{Path: groupPath, Kind: KindGroup, Action: ActionRemoved, Details: describeGroup(object(originalGroup))})
+		default:
+			changes = append(changes, diffGroup(groupPath, object(originalGroup), object(updatedGroup))...)
+		}
+	}
+
+	changes = append(changes, diffElements(path, KindValue, "value", object(original["values"]), object(updated["values"]))...)
+	changes = append(changes, diffElements(path, KindPolicy, "policy", object(original["policies"]), object(updated["policies"]))...)
+
+	return changes
+}
+
+// diffElements compares the values or the policies of a group, whose content
+// is held by their field
+func diffElements(path, kind, field string, original, updated map[string]interface{}) []*Change {
+	var changes []*Change
+	for _, name := range keys(original, updated) {
+		elementPath := path + "/" + name
+		originalElement, inOriginal := original[name]
+		updatedElement, inUpdated := updated[name]
+		switch {
+		case !inOriginal:
+			changes = append(changes, &Change{Path: elementPath, Kind: kind, Action: ActionAdded})
+		case !inUpdated:
+			changes = append(changes, &Change{Path: elementPath, Kind: kind, Action: ActionRemoved})
+		default:
+			details := diffModPolicy(object(originalElement), object(updatedElement))
+			details = append(details, diffFields("", object(originalElement)[field], object(updatedElement)[field])...)
+			if len(details) > 0 {
+				changes = append(changes, &Change{Path: elementPath, Kind: kind, Action: ActionModified, Details: details})
+			}
+		}
+	}
+	return changes
+}
+
+func diffModPolicy(original, updated map[string]interface{}) []string {
+	if reflect.DeepEqual(original["mod_policy"], updated["mod_policy"]) {
+		return nil
+	}
+	return []string{describeFieldChange("mod_policy", original["mod_policy"], updated["mod_policy"])}
+}
+
+// describeGroup names the organization defined by a group, if any
+func describeGroup(group map[string]interface{}) []string {
+	msp := object(object(object(object(group["values"])["MSP"])["value"])["config"])
+	if name, ok := msp["name"].(string); ok {
+		return []string{fmt.Sprintf("organization with MSP ID %s", name)}
+	}
+	return nil
+}
+
+// diffFields describes the differences between two decoded fields, recursing
+// into objects and listing the elements added to or removed from lists
+func diffFields(path string, original, updated interface{}) []string {
+	if reflect.DeepEqual(original, updated) {
+		return nil
+	}
+
+	originalObject, originalIsObject := original.(map[string]interface{})
+	updatedObject, updatedIsObject := updated.(map[string]interface{})
+	if (originalIsObject || original == nil) && (updatedIsObject || updated == nil) {
+		var details []string
+		for _, key := range keys(originalObject, updatedObject) {
+			details = append(details, diffFields(join(path, key), originalObject[key], updatedObject[key])...)
+		}
+		return details
+	}
+
+	originalList, originalIsList := original.([]interface{})
+	updatedList, updatedIsList := updated.([]interface{})
+	if (originalIsList || original == nil) && (updatedIsList || updated == nil) {
+		var details []string
+		for _, element := range subtract(updatedList, originalList) {
+			details = append(details, fmt.Sprintf("%s: added %s", path, render(element)))
+		}
+		for _, element := range subtract(originalList, updatedList) {
+			details = append(details, fmt.Sprintf("%s: removed %s", path, render(element)))
+		}
+		if len(details) == 0 {
+			details = append(details, fmt.Sprintf("%s: reordered", path))
+		}
+		return details
+	}
+
+	return []string{describeFieldChange(path, original, updated)}
+}
+
+func describeFieldChange(path string, original, updated interface{}) string {
+	switch {
+	case original == nil:
+		return fmt.Sprintf("%s set to %s", path, render(updated))
+	case updated == nil:
+		return fmt.Sprintf("%s unset, was %s", path, render(original))
+	default:
+		return fmt.Sprintf("%s changed from %s to %s", path, render(original), render(updated))
+	}
+}
+
+// render formats a decoded field, naming the subject of certificates
+func render(field interface{}) string {
+	if s, ok := field.(string); ok {
+		if subject, ok := certificateSubject(s); ok {
+			return fmt.Sprintf("certificate %q", subject)
+		}
+		return s
+	}
+	encoded, err := json.Marshal(field)
+	if err != nil {
+		return fmt.Sprintf("%v", field)
+	}
+	return string(encoded)
+}
+
+// certificateSubject returns the subject of the PEM encoded certificate s
+// holds in base64, as bytes fields are decoded
+func certificateSubject(s string) (string, bool) {
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", false
+	}
+	block, _ := pem.Decode(decoded)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return "", false
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", false
+	}
+	var names []string
+	for _, name := range cert.Subject.Names {
+		names = append(names, fmt.Sprintf("%s=%v", oidName(name.Type.String()), name.Value))
+	}
+	return strings.Join(names, ","), true
+}
+
+var oidNames = map[string]string{
+	"2.5.4.3":  "CN",
+	"2.5.4.6":  "C",
+	"2.5.4.7":  "L",
+	"2.5.4.8":  "ST",
+	"2.5.4.10": "O",
+	"2.5.4.11": "OU",
+}
+
+func oidName(oid string) string {
+	if name, ok := oidNames[oid]; ok {
+		return name
+	}
+	return oid
+}
+
+// subtract returns the elements of a which are not in b
+func subtract(a, b []interface{}) []interface{} {
+	var result []interface{}
+	for _, x := range a {
+		found := false
+		for _, y := range b {
+			if reflect.DeepEqual(x, y) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, x)
+		}
+	}
+	return result
+}
+
+func object(field interface{}) map[string]interface{} {
+	o, _ := field.(map[string]interface{})
+	return o
+}
+
+// keys returns the sorted union of the keys of a and b
+func keys(a, b map[string]interface{}) []string {
+	set := make(map[string]struct{})
+	for key := range a {
+		set[key] = struct{}{}
+	}
+	for key := range b {
+		set[key] = struct{}{}
+	}
+	result := make([]string, 0, len(set))
+	for key := range set {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
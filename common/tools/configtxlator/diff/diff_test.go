@@ -0,0 +1,187 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/configtx"
+	genesisconfig "github.com/hyperledger/fabric/common/configtx/tool/localconfig"
+	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	factory.InitFactories(nil)
+}
+
+func sampleConfig() *cb.Config {
+	conf := genesisconfig.Load(genesisconfig.SampleSingleMSPSoloProfile)
+	gb := provisional.New(conf).GenesisBlockForChannel(provisional.TestChainID)
+	ctx := utils.ExtractEnvelopeOrPanic(gb, 0)
+	return configtx.UnmarshalConfigEnvelopeOrPanic(utils.UnmarshalPayloadOrPanic(ctx.Payload).Data).Config
+}
+
+func TestIdentical(t *testing.T) {
+	changes, err := Compute(sampleConfig(), sampleConfig())
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestIgnoresVersions(t *testing.T) {
+	original := sampleConfig()
+	updated := proto.Clone(original).(*cb.Config)
+	updated.ChannelGroup.Groups["Orderer"].Version = 1
+	updated.ChannelGroup.Groups["Orderer"].Values["BatchSize"].Version = 1
+
+	changes, err := Compute(original, updated)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestModifiedValue(t *testing.T) {
+	original := sampleConfig()
+	updated := proto.Clone(original).(*cb.Config)
+	updated.ChannelGroup.Groups["Orderer"].Values["BatchSize"].Value = utils.MarshalOrPanic(&ab.BatchSize{
+		MaxMessageCount:   20,
+		AbsoluteMaxBytes:  10485760,
+		PreferredMaxBytes: 524288,
+	})
+
+	changes, err := Compute(original, updated)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Change{{
+		Path:    "/Channel/Orderer/BatchSize",
+		Kind:    KindValue,
+		Action:  ActionModified,
+		Details: []string{"max_message_count changed from 10 to 20"},
+	}}, changes)
+}
+
+func TestModifiedPolicy(t *testing.T) {
+	original := sampleConfig()
+	updated := proto.Clone(original).(*cb.Config)
+	readers := updated.ChannelGroup.Groups["Orderer"].Policies["Readers"]
+	readers.ModPolicy = "Writers"
+	readers.Policy.Value = utils.MarshalOrPanic(&cb.ImplicitMetaPolicy{
+		SubPolicy: "Readers",
+		Rule:      cb.ImplicitMetaPolicy_MAJORITY,
+	})
+
+	changes, err := Compute(original, updated)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Change{{
+		Path:   "/Channel/Orderer/Readers",
+		Kind:   KindPolicy,
+		Action: ActionModified,
+		Details: []string{
+			"mod_policy changed from Admins to Writers",
+			"value.rule set to MAJORITY",
+		},
+	}}, changes)
+}
+
+func TestAddedAndRemovedGroups(t *testing.T) {
+	original := sampleConfig()
+	updated := proto.Clone(original).(*cb.Config)
+	orderer := updated.ChannelGroup.Groups["Orderer"]
+	orderer.Groups["OtherOrg"] = orderer.Groups["SampleOrg"]
+	delete(orderer.Groups, "SampleOrg")
+
+	changes, err := Compute(original, updated)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Change{
+		{
+			Path:    "/Channel/Orderer/OtherOrg",
+			Kind:    KindGroup,
+			Action:  ActionAdded,
+			Details: []string{"organization with MSP ID DEFAULT"},
+		},
+		{
+			Path:    "/Channel/Orderer/SampleOrg",
+			Kind:    KindGroup,
+			Action:  ActionRemoved,
+			Details: []string{"organization with MSP ID DEFAULT"},
+		},
+	}, changes)
+}
+
+func TestRemovedValue(t *testing.T) {
+	original := sampleConfig()
+	updated := proto.Clone(original).(*cb.Config)
+	delete(updated.ChannelGroup.Groups["Orderer"].Groups["SampleOrg"].Values, "MSP")
+
+	changes, err := Compute(original, updated)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Change{{
+		Path:   "/Channel/Orderer/SampleOrg/MSP",
+		Kind:   KindValue,
+		Action: ActionRemoved,
+	}}, changes)
+}
+
+func TestModifiedCertificates(t *testing.T) {
+	original := sampleConfig()
+	updated := proto.Clone(original).(*cb.Config)
+	mspValue := updated.ChannelGroup.Groups["Orderer"].Groups["SampleOrg"].Values["MSP"]
+	mspConfig := &mspprotos.MSPConfig{}
+	assert.NoError(t, proto.Unmarshal(mspValue.Value, mspConfig))
+	fabricMSPConfig := &mspprotos.FabricMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(mspConfig.Config, fabricMSPConfig))
+	fabricMSPConfig.RootCerts = append(fabricMSPConfig.RootCerts, fabricMSPConfig.Admins[0])
+	mspConfig.Config = utils.MarshalOrPanic(fabricMSPConfig)
+	mspValue.Value = utils.MarshalOrPanic(mspConfig)
+
+	changes, err := Compute(original, updated)
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 1) {
+		assert.Equal(t, "/Channel/Orderer/SampleOrg/MSP", changes[0].Path)
+		if assert.Len(t, changes[0].Details, 1) {
+			assert.Equal(t, `config.root_certs: added certificate "C=US,ST=North Carolina,L=Raleigh,O=Hyperledger Fabric,OU=COP"`, changes[0].Details[0])
+		}
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	original := map[string]interface{}{
+		"name":  "org",
+		"certs": []interface{}{"a", "b"},
+		"order": []interface{}{"x", "y"},
+		"gone":  "value",
+	}
+	updated := map[string]interface{}{
+		"name":  "other",
+		"certs": []interface{}{"b", "c"},
+		"order": []interface{}{"y", "x"},
+		"new":   map[string]interface{}{"field": float64(1)},
+	}
+	assert.Equal(t, []string{
+		"certs: added c",
+		"certs: removed a",
+		"gone unset, was value",
+		"name changed from org to other",
+		"new.field set to 1",
+		"order: reordered",
+	}, diffFields("", original, updated))
+}
+
+func TestString(t *testing.T) {
+	change := &Change{
+		Path:    "/Channel/Orderer/BatchSize",
+		Kind:    KindValue,
+		Action:  ActionModified,
+		Details: []string{"max_message_count changed from 10 to 20"},
+	}
+	assert.Equal(t, "modified value /Channel/Orderer/BatchSize\n    max_message_count changed from 10 to 20", change.String())
+}
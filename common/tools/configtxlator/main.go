@@ -17,12 +17,27 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"reflect"
 
+	"github.com/hyperledger/fabric/common/tools/configtxlator/diff"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/metadata"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/rest"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	// Import these to register the proto types
+	_ "github.com/hyperledger/fabric/protos/msp"
+	_ "github.com/hyperledger/fabric/protos/orderer"
+	_ "github.com/hyperledger/fabric/protos/peer"
+
+	"github.com/golang/protobuf/proto"
 	"github.com/op/go-logging"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
@@ -37,6 +52,28 @@ var (
 	hostname = start.Flag("hostname", "The hostname or IP on which the REST server will listen").Default("0.0.0.0").String()
 	port     = start.Flag("port", "The port on which the REST server will listen").Default("7059").Int()
 
+	protoEncode       = app.Command("proto_encode", "Converts a JSON document to protobuf")
+	protoEncodeType   = protoEncode.Flag("type", "The type of protobuf structure to encode to, for example 'common.Config'").Required().String()
+	protoEncodeInput  = protoEncode.Flag("input", "A file containing the JSON document").Default(os.Stdin.Name()).File()
+	protoEncodeOutput = protoEncode.Flag("output", "A file to write the output to").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	protoDecode       = app.Command("proto_decode", "Converts a proto message to JSON")
+	protoDecodeType   = protoDecode.Flag("type", "The type of protobuf structure to decode from, for example 'common.Config'").Required().String()
+	protoDecodeInput  = protoDecode.Flag("input", "A file containing the proto message").Default(os.Stdin.Name()).File()
+	protoDecodeOutput = protoDecode.Flag("output", "A file to write the JSON document to").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	computeUpdate          = app.Command("compute_update", "Takes two marshaled common.Config messages and computes the config update which transitions between the two")
+	computeUpdateOriginal  = computeUpdate.Flag("original", "The original config message").Required().File()
+	computeUpdateUpdated   = computeUpdate.Flag("updated", "The updated config message").Required().File()
+	computeUpdateChannelID = computeUpdate.Flag("channel_id", "The name of the channel for this update").Required().String()
+	computeUpdateOutput    = computeUpdate.Flag("output", "A file to write the marshaled common.ConfigUpdate to").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	computeDiff         = app.Command("compute_diff", "Takes two marshaled common.Config messages and describes the changes between the two")
+	computeDiffOriginal = computeDiff.Flag("original", "The original config message").Required().File()
+	computeDiffUpdated  = computeDiff.Flag("updated", "The updated config message").Required().File()
+	computeDiffJSON     = computeDiff.Flag("json", "Write the changes as a JSON document rather than as text").Bool()
+	computeDiffOutput   = computeDiff.Flag("output", "A file to write the changes to").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
 	version = app.Command("version", "Show version information")
 )
 
@@ -47,6 +84,44 @@ func main() {
 	case start.FullCommand():
 		startServer(fmt.Sprintf("%s:%d", *hostname, *port))
 
+	// "proto_encode" command
+	case protoEncode.FullCommand():
+		defer (*protoEncodeInput).Close()
+		defer (*protoEncodeOutput).Close()
+		err := encodeProto(*protoEncodeType, *protoEncodeInput, *protoEncodeOutput)
+		if err != nil {
+			app.Fatalf("Error encoding: %s", err)
+		}
+
+	// "proto_decode" command
+	case protoDecode.FullCommand():
+		defer (*protoDecodeInput).Close()
+		defer (*protoDecodeOutput).Close()
+		err := decodeProto(*protoDecodeType, *protoDecodeInput, *protoDecodeOutput)
+		if err != nil {
+			app.Fatalf("Error decoding: %s", err)
+		}
+
+	// "compute_update" command
+	case computeUpdate.FullCommand():
+		defer (*computeUpdateOriginal).Close()
+		defer (*computeUpdateUpdated).Close()
+		defer (*computeUpdateOutput).Close()
+		err := runComputeUpdate(*computeUpdateOriginal, *computeUpdateUpdated, *computeUpdateOutput, *computeUpdateChannelID)
+		if err != nil {
+			app.Fatalf("Error computing update: %s", err)
+		}
+
+	// "compute_diff" command
+	case computeDiff.FullCommand():
+		defer (*computeDiffOriginal).Close()
+		defer (*computeDiffUpdated).Close()
+		defer (*computeDiffOutput).Close()
+		err := runComputeDiff(*computeDiffOriginal, *computeDiffUpdated, *computeDiffOutput, *computeDiffJSON)
+		if err != nil {
+			app.Fatalf("Error computing diff: %s", err)
+		}
+
 	// "version" command
 	case version.FullCommand():
 		printVersion()
@@ -61,6 +136,144 @@ func startServer(address string) {
 	app.Fatalf("Error starting server:[%s]\n", err)
 }
 
+func newMessage(msgName string) (proto.Message, error) {
+	msgType := proto.MessageType(msgName)
+	if msgType == nil {
+		return nil, fmt.Errorf("message of type %s unknown", msgName)
+	}
+	return reflect.New(msgType.Elem()).Interface().(proto.Message), nil
+}
+
+func encodeProto(msgName string, input io.Reader, output io.Writer) error {
+	msg, err := newMessage(msgName)
+	if err != nil {
+		return err
+	}
+
+	err = protolator.DeepUnmarshalJSON(input, msg)
+	if err != nil {
+		return fmt.Errorf("error decoding input: %s", err)
+	}
+
+	out, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("error marshaling: %s", err)
+	}
+
+	_, err = output.Write(out)
+	if err != nil {
+		return fmt.Errorf("error writing output: %s", err)
+	}
+
+	return nil
+}
+
+func decodeProto(msgName string, input io.Reader, output io.Writer) error {
+	msg, err := newMessage(msgName)
+	if err != nil {
+		return err
+	}
+
+	in, err := ioutil.ReadAll(input)
+	if err != nil {
+		return fmt.Errorf("error reading input: %s", err)
+	}
+
+	err = proto.Unmarshal(in, msg)
+	if err != nil {
+		return fmt.Errorf("error unmarshaling: %s", err)
+	}
+
+	err = protolator.DeepMarshalJSON(output, msg)
+	if err != nil {
+		return fmt.Errorf("error encoding output: %s", err)
+	}
+
+	return nil
+}
+
+func readConfig(input io.Reader) (*cb.Config, error) {
+	in, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, fmt.Errorf("error reading input: %s", err)
+	}
+
+	config := &cb.Config{}
+	err = proto.Unmarshal(in, config)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling config: %s", err)
+	}
+
+	return config, nil
+}
+
+func runComputeUpdate(original, updated io.Reader, output io.Writer, channelID string) error {
+	originalConfig, err := readConfig(original)
+	if err != nil {
+		return fmt.Errorf("original config: %s", err)
+	}
+
+	updatedConfig, err := readConfig(updated)
+	if err != nil {
+		return fmt.Errorf("updated config: %s", err)
+	}
+
+	configUpdate, err := update.Compute(originalConfig, updatedConfig)
+	if err != nil {
+		return err
+	}
+
+	configUpdate.ChannelId = channelID
+
+	out, err := proto.Marshal(configUpdate)
+	if err != nil {
+		return fmt.Errorf("error marshaling config update: %s", err)
+	}
+
+	_, err = output.Write(out)
+	if err != nil {
+		return fmt.Errorf("error writing output: %s", err)
+	}
+
+	return nil
+}
+
+func runComputeDiff(original, updated io.Reader, output io.Writer, asJSON bool) error {
+	originalConfig, err := readConfig(original)
+	if err != nil {
+		return fmt.Errorf("original config: %s", err)
+	}
+
+	updatedConfig, err := readConfig(updated)
+	if err != nil {
+		return fmt.Errorf("updated config: %s", err)
+	}
+
+	changes, err := diff.Compute(originalConfig, updatedConfig)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		if changes == nil {
+			changes = []*diff.Change{}
+		}
+		out, err := json.MarshalIndent(changes, "", "    ")
+		if err != nil {
+			return fmt.Errorf("error marshaling changes: %s", err)
+		}
+		_, err = fmt.Fprintf(output, "%s\n", out)
+		return err
+	}
+
+	for _, change := range changes {
+		if _, err := fmt.Fprintln(output, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func printVersion() {
 	fmt.Println(metadata.GetVersionInfo())
 }
@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/tools/configtxlator/diff"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	original := &cb.Config{Sequence: 3, ChannelGroup: &cb.ConfigGroup{ModPolicy: "foo"}}
+
+	decoded := &bytes.Buffer{}
+	err := decodeProto("common.Config", bytes.NewReader(utils.MarshalOrPanic(original)), decoded)
+	assert.NoError(t, err)
+	assert.Contains(t, decoded.String(), `"mod_policy": "foo"`)
+
+	encoded := &bytes.Buffer{}
+	err = encodeProto("common.Config", decoded, encoded)
+	assert.NoError(t, err)
+
+	result := &cb.Config{}
+	assert.NoError(t, proto.Unmarshal(encoded.Bytes(), result))
+	assert.True(t, proto.Equal(original, result))
+}
+
+func TestProtoUnknownType(t *testing.T) {
+	err := encodeProto("common.Unknown", &bytes.Buffer{}, &bytes.Buffer{})
+	assert.Error(t, err)
+
+	err = decodeProto("common.Unknown", &bytes.Buffer{}, &bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestComputeUpdate(t *testing.T) {
+	original := utils.MarshalOrPanic(&cb.Config{ChannelGroup: &cb.ConfigGroup{ModPolicy: "foo"}})
+	updated := utils.MarshalOrPanic(&cb.Config{ChannelGroup: &cb.ConfigGroup{ModPolicy: "bar"}})
+
+	output := &bytes.Buffer{}
+	err := runComputeUpdate(bytes.NewReader(original), bytes.NewReader(updated), output, "foochannel")
+	assert.NoError(t, err)
+
+	configUpdate := &cb.ConfigUpdate{}
+	assert.NoError(t, proto.Unmarshal(output.Bytes(), configUpdate))
+	assert.Equal(t, "foochannel", configUpdate.ChannelId)
+	assert.Equal(t, "bar", configUpdate.WriteSet.ModPolicy)
+}
+
+func TestComputeUpdateCorruptConfig(t *testing.T) {
+	original := utils.MarshalOrPanic(&cb.Config{ChannelGroup: &cb.ConfigGroup{ModPolicy: "foo"}})
+
+	err := runComputeUpdate(bytes.NewReader(original), bytes.NewReader([]byte("Garbage")), &bytes.Buffer{}, "foochannel")
+	assert.Error(t, err)
+}
+
+func TestComputeDiff(t *testing.T) {
+	original := utils.MarshalOrPanic(&cb.Config{ChannelGroup: &cb.ConfigGroup{ModPolicy: "foo"}})
+	updated := utils.MarshalOrPanic(&cb.Config{ChannelGroup: &cb.ConfigGroup{ModPolicy: "bar"}})
+
+	output := &bytes.Buffer{}
+	err := runComputeDiff(bytes.NewReader(original), bytes.NewReader(updated), output, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "modified group /Channel\n    mod_policy changed from foo to bar\n", output.String())
+
+	output.Reset()
+	err = runComputeDiff(bytes.NewReader(original), bytes.NewReader(updated), output, true)
+	assert.NoError(t, err)
+	var changes []*diff.Change
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &changes))
+	assert.Len(t, changes, 1)
+}
@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"github.com/hyperledger/fabric/common/tools/configtxlator/diff"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/sanitycheck"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -90,6 +91,42 @@ func ComputeUpdateFromConfigs(w http.ResponseWriter, r *http.Request) {
 	w.Write(encoded)
 }
 
+func ComputeDiffFromConfigs(w http.ResponseWriter, r *http.Request) {
+	originalConfig, err := fieldConfigProto("original", r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'original': %s\n", err)
+		return
+	}
+
+	updatedConfig, err := fieldConfigProto("updated", r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'updated': %s\n", err)
+		return
+	}
+
+	changes, err := diff.Compute(originalConfig, updatedConfig)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error computing diff: %s\n", err)
+		return
+	}
+
+	if changes == nil {
+		changes = []*diff.Change{}
+	}
+	resBytes, err := json.Marshal(changes)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Error marshaling result to JSON: %s\n", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resBytes)
+}
+
 func SanityCheckConfig(w http.ResponseWriter, r *http.Request) {
 	buf, err := ioutil.ReadAll(r.Body)
 	if err != nil {
@@ -24,6 +24,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/tools/configtxlator/diff"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/sanitycheck"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -160,6 +161,85 @@ func TestProtolatorCorruptProtos(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
 
+func TestConfigtxlatorComputeDiff(t *testing.T) {
+	originalConfig := utils.MarshalOrPanic(&cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			ModPolicy: "foo",
+		},
+	})
+
+	updatedConfig := utils.MarshalOrPanic(&cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			ModPolicy: "bar",
+		},
+	})
+
+	buffer := &bytes.Buffer{}
+	mpw := multipart.NewWriter(buffer)
+
+	ffw, err := mpw.CreateFormFile("original", "foo")
+	assert.NoError(t, err)
+	_, err = bytes.NewReader(originalConfig).WriteTo(ffw)
+	assert.NoError(t, err)
+
+	ffw, err = mpw.CreateFormFile("updated", "bar")
+	assert.NoError(t, err)
+	_, err = bytes.NewReader(updatedConfig).WriteTo(ffw)
+	assert.NoError(t, err)
+
+	err = mpw.Close()
+	assert.NoError(t, err)
+
+	req, err := http.NewRequest("POST", "/configtxlator/compute/diff-from-configs", buffer)
+	assert.NoError(t, err)
+
+	req.Header.Set("Content-Type", mpw.FormDataContentType())
+	rec := httptest.NewRecorder()
+	r := NewRouter()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var changes []*diff.Change
+	err = json.Unmarshal(rec.Body.Bytes(), &changes)
+	assert.NoError(t, err)
+	assert.Equal(t, []*diff.Change{{
+		Path:    "/Channel",
+		Kind:    diff.KindGroup,
+		Action:  diff.ActionModified,
+		Details: []string{"mod_policy changed from foo to bar"},
+	}}, changes)
+}
+
+func TestConfigtxlatorComputeDiffMissingUpdated(t *testing.T) {
+	originalConfig := utils.MarshalOrPanic(&cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			ModPolicy: "bar",
+		},
+	})
+
+	buffer := &bytes.Buffer{}
+	mpw := multipart.NewWriter(buffer)
+
+	ffw, err := mpw.CreateFormFile("original", "bar")
+	assert.NoError(t, err)
+	_, err = bytes.NewReader(originalConfig).WriteTo(ffw)
+	assert.NoError(t, err)
+
+	err = mpw.Close()
+	assert.NoError(t, err)
+
+	req, err := http.NewRequest("POST", "/configtxlator/compute/diff-from-configs", buffer)
+	assert.NoError(t, err)
+
+	req.Header.Set("Content-Type", mpw.FormDataContentType())
+	rec := httptest.NewRecorder()
+	r := NewRouter()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
 func TestConfigtxlatorSanityCheckConfig(t *testing.T) {
 	req, _ := http.NewRequest("POST", "/configtxlator/config/verify", bytes.NewReader(utils.MarshalOrPanic(&cb.Config{})))
 	rec := httptest.NewRecorder()
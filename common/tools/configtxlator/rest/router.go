@@ -32,6 +32,9 @@ func NewRouter() *mux.Router {
 	router.
 		HandleFunc("/configtxlator/compute/update-from-configs", ComputeUpdateFromConfigs).
 		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/compute/diff-from-configs", ComputeDiffFromConfigs).
+		Methods("POST")
 	router.
 		HandleFunc("/configtxlator/config/verify", SanityCheckConfig).
 		Methods("POST")
@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aclmgmt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/policy"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/viper"
+)
+
+var aclLogger = flogging.MustGetLogger("aclmgmt")
+
+// ACLProvider checks whether a request may access a peer resource
+type ACLProvider interface {
+	// CheckACL checks the ACL of the resource for the channel using idinfo;
+	// idinfo is either a *pb.SignedProposal or a []*common.SignedData from
+	// which the requestor's identity is extracted for testing against the policy.
+	// An empty channelID denotes a request which does not target a channel
+	CheckACL(resName string, channelID string, idinfo interface{}) error
+}
+
+// ChannelPolicyRefGetter returns the policy referenced by the ACLs in the
+// config of the channel for the resource, or the empty string if there is none
+type ChannelPolicyRefGetter func(channelID, resName string) string
+
+type defaultACLProvider struct {
+	policyChecker policy.PolicyChecker
+	channelRefs   ChannelPolicyRefGetter
+	peerRefs      map[string]string
+}
+
+// NewDefaultACLProvider creates an ACLProvider which evaluates policies through
+// the given PolicyChecker. The policy of a resource is looked up, in order, in
+// the ACLs of the channel config (if channelRefs is not nil), in the peer.acls
+// section of the peer configuration and in the built-in defaults. A policy
+// reference starting with the path separator names a channel policy, any other
+// reference names a principal of the local MSP, such as Admins or Members
+func NewDefaultACLProvider(pc policy.PolicyChecker, channelRefs ChannelPolicyRefGetter) ACLProvider {
+	return &defaultACLProvider{
+		policyChecker: pc,
+		channelRefs:   channelRefs,
+		peerRefs:      viper.GetStringMapString("peer.acls"),
+	}
+}
+
+// policyRefForResource returns the policy referenced for the resource on the channel
+func (d *defaultACLProvider) policyRefForResource(resName, channelID string) string {
+	if channelID != "" && d.channelRefs != nil {
+		if policyRef := d.channelRefs(channelID, resName); policyRef != "" {
+			return policyRef
+		}
+	}
+
+	// viper lowercases the keys of the maps it reads
+	for name, policyRef := range d.peerRefs {
+		if strings.EqualFold(name, resName) && policyRef != "" {
+			return policyRef
+		}
+	}
+
+	return defaultACLs[resName]
+}
+
+// CheckACL checks the ACL of the resource for the channel using idinfo
+func (d *defaultACLProvider) CheckACL(resName string, channelID string, idinfo interface{}) error {
+	policyRef := d.policyRefForResource(resName, channelID)
+	if policyRef == "" {
+		return fmt.Errorf("No ACL defined for resource %s", resName)
+	}
+
+	aclLogger.Debugf("Checking ACL for resource %s on channel [%s] with policy %s", resName, channelID, policyRef)
+
+	isChannelPolicy := strings.HasPrefix(policyRef, policies.PathSeparator)
+	if isChannelPolicy && channelID == "" {
+		return fmt.Errorf("Resource %s is protected by channel policy %s but no channel was specified", resName, policyRef)
+	}
+
+	var err error
+	switch id := idinfo.(type) {
+	case *pb.SignedProposal:
+		if isChannelPolicy {
+			err = d.policyChecker.CheckPolicy(channelID, policyRef, id)
+		} else {
+			err = d.policyChecker.CheckPolicyNoChannel(policyRef, id)
+		}
+	case []*common.SignedData:
+		if isChannelPolicy {
+			err = d.policyChecker.CheckPolicyBySignedData(channelID, policyRef, id)
+		} else {
+			err = d.policyChecker.CheckPolicyNoChannelBySignedData(policyRef, id)
+		}
+	default:
+		return fmt.Errorf("Invalid identity information of type %T for resource %s", idinfo, resName)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Access denied for resource %s on channel [%s] with policy %s: [%s]", resName, channelID, policyRef, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aclmgmt
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockPolicyChecker records the last policy it was asked to check
+type mockPolicyChecker struct {
+	channelID  string
+	policyName string
+	local      bool
+	err        error
+}
+
+func (m *mockPolicyChecker) record(channelID, policyName string, local bool) error {
+	m.channelID, m.policyName, m.local = channelID, policyName, local
+	return m.err
+}
+
+func (m *mockPolicyChecker) CheckPolicy(channelID, policyName string, signedProp *pb.SignedProposal) error {
+	return m.record(channelID, policyName, false)
+}
+
+func (m *mockPolicyChecker) CheckPolicyBySignedData(channelID, policyName string, sd []*common.SignedData) error {
+	return m.record(channelID, policyName, false)
+}
+
+func (m *mockPolicyChecker) CheckPolicyNoChannel(policyName string, signedProp *pb.SignedProposal) error {
+	return m.record("", policyName, true)
+}
+
+func (m *mockPolicyChecker) CheckPolicyNoChannelBySignedData(policyName string, sd []*common.SignedData) error {
+	return m.record("", policyName, true)
+}
+
+func TestDefaultACLs(t *testing.T) {
+	pc := &mockPolicyChecker{}
+	p := NewDefaultACLProvider(pc, nil)
+
+	err := p.CheckACL(QsccGetBlockByNumber, "mychannel", &pb.SignedProposal{})
+	assert.NoError(t, err)
+	assert.Equal(t, "mychannel", pc.channelID)
+	assert.Equal(t, policies.ChannelApplicationReaders, pc.policyName)
+	assert.False(t, pc.local)
+
+	err = p.CheckACL(LsccInstall, "", &pb.SignedProposal{})
+	assert.NoError(t, err)
+	assert.Equal(t, mgmt.Admins, pc.policyName)
+	assert.True(t, pc.local)
+
+	err = p.CheckACL(EventRegister, "", []*common.SignedData{{}})
+	assert.NoError(t, err)
+	assert.Equal(t, mgmt.Members, pc.policyName)
+	assert.True(t, pc.local)
+}
+
+func TestChannelACLs(t *testing.T) {
+	pc := &mockPolicyChecker{}
+	channelRefs := func(channelID, resName string) string {
+		if channelID == "mychannel" && resName == LsccGetChaincodes {
+			return policies.ChannelApplicationAdmins
+		}
+		return ""
+	}
+	p := NewDefaultACLProvider(pc, channelRefs)
+
+	err := p.CheckACL(LsccGetChaincodes, "mychannel", &pb.SignedProposal{})
+	assert.NoError(t, err)
+	assert.Equal(t, policies.ChannelApplicationAdmins, pc.policyName)
+	assert.False(t, pc.local)
+
+	// Other channels fall back to the default
+	err = p.CheckACL(LsccGetChaincodes, "otherchannel", &pb.SignedProposal{})
+	assert.NoError(t, err)
+	assert.Equal(t, mgmt.Admins, pc.policyName)
+	assert.True(t, pc.local)
+}
+
+func TestPeerACLs(t *testing.T) {
+	viper.Set("peer.acls", map[string]string{"qscc/getchaininfo": policies.ChannelApplicationAdmins})
+	defer viper.Set("peer.acls", nil)
+
+	pc := &mockPolicyChecker{}
+	p := NewDefaultACLProvider(pc, func(string, string) string { return "" })
+
+	err := p.CheckACL(QsccGetChainInfo, "mychannel", &pb.SignedProposal{})
+	assert.NoError(t, err)
+	assert.Equal(t, policies.ChannelApplicationAdmins, pc.policyName)
+
+	err = p.CheckACL(QsccGetBlockByHash, "mychannel", &pb.SignedProposal{})
+	assert.NoError(t, err)
+	assert.Equal(t, policies.ChannelApplicationReaders, pc.policyName)
+}
+
+func TestCheckACLErrors(t *testing.T) {
+	pc := &mockPolicyChecker{}
+	p := NewDefaultACLProvider(pc, nil)
+
+	err := p.CheckACL("foo/Bar", "mychannel", &pb.SignedProposal{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "No ACL defined for resource foo/Bar")
+
+	err = p.CheckACL(QsccGetChainInfo, "", &pb.SignedProposal{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no channel was specified")
+
+	err = p.CheckACL(QsccGetChainInfo, "mychannel", "foo")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid identity information")
+
+	pc.err = assert.AnError
+	err = p.CheckACL(QsccGetChainInfo, "mychannel", &pb.SignedProposal{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Access denied for resource qscc/GetChainInfo")
+}
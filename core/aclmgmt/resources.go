@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aclmgmt
+
+import (
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/msp/mgmt"
+)
+
+// Names of the peer resources whose access is controlled by ACLs
+const (
+	// Propose is the resource for submitting a proposal to a chaincode
+	Propose = "peer/Propose"
+
+	// Lscc resources
+	LsccInstall                = "lscc/Install"
+	LsccGetInstalledChaincodes = "lscc/GetInstalledChaincodes"
+	LsccGetChaincodes          = "lscc/GetChaincodes"
+	LsccChaincodeExists        = "lscc/ChaincodeExists"
+	LsccGetDeploymentSpec      = "lscc/GetDeploymentSpec"
+	LsccGetChaincodeData       = "lscc/GetChaincodeData"
+
+	// Qscc resources
+	QsccGetChainInfo       = "qscc/GetChainInfo"
+	QsccGetBlockByNumber   = "qscc/GetBlockByNumber"
+	QsccGetBlockByHash     = "qscc/GetBlockByHash"
+	QsccGetTransactionByID = "qscc/GetTransactionByID"
+	QsccGetBlockByTxID     = "qscc/GetBlockByTxID"
+
+	// Cscc resources
+	CsccJoinChain      = "cscc/JoinChain"
+	CsccLeaveChain     = "cscc/LeaveChain"
+	CsccGetConfigBlock = "cscc/GetConfigBlock"
+	CsccGetChannels    = "cscc/GetChannels"
+
+	// EventRegister is the resource for registering with the event hub
+	EventRegister = "event/Register"
+)
+
+// defaultACLs holds the policies used for the resources when neither the
+// channel config nor the peer configuration define an ACL for them. These
+// match the access control the peer enforced before ACLs were configurable.
+var defaultACLs = map[string]string{
+	Propose: policies.ChannelApplicationWriters,
+
+	LsccInstall:                mgmt.Admins,
+	LsccGetInstalledChaincodes: mgmt.Admins,
+	LsccGetChaincodes:          mgmt.Admins,
+	LsccChaincodeExists:        policies.ChannelApplicationReaders,
+	LsccGetDeploymentSpec:      policies.ChannelApplicationReaders,
+	LsccGetChaincodeData:       policies.ChannelApplicationReaders,
+
+	QsccGetChainInfo:       policies.ChannelApplicationReaders,
+	QsccGetBlockByNumber:   policies.ChannelApplicationReaders,
+	QsccGetBlockByHash:     policies.ChannelApplicationReaders,
+	QsccGetTransactionByID: policies.ChannelApplicationReaders,
+	QsccGetBlockByTxID:     policies.ChannelApplicationReaders,
+
+	CsccJoinChain:      mgmt.Admins,
+	CsccLeaveChain:     mgmt.Admins,
+	CsccGetConfigBlock: policies.ChannelApplicationReaders,
+	CsccGetChannels:    mgmt.Members,
+
+	EventRegister: mgmt.Members,
+}
@@ -17,8 +17,14 @@ limitations under the License.
 package core
 
 import (
+	"errors"
+
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/service"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"golang.org/x/net/context"
 )
@@ -71,3 +77,52 @@ func (*ServerAdmin) RevertLogLevels(context.Context, *empty.Empty) (*empty.Empty
 
 	return &empty.Empty{}, err
 }
+
+// GetGossipReputations returns the reputation of the gossip peers that misbehaved
+func (*ServerAdmin) GetGossipReputations(context.Context, *empty.Empty) (*pb.GossipReputations, error) {
+	gossipService := service.GetGossipService()
+	if gossipService == nil {
+		return nil, errors.New("gossip service is not initialized")
+	}
+	return gossipReputations(gossipService.Reputations()), nil
+}
+
+// GetDeliveryFailovers returns the most recent ordering service failovers of the blocks delivery of a channel
+func (*ServerAdmin) GetDeliveryFailovers(ctx context.Context, request *pb.DeliveryFailoversRequest) (*pb.DeliveryFailovers, error) {
+	if request.ChannelId == "" {
+		return nil, errors.New("channel ID must be provided")
+	}
+	gossipService := service.GetGossipService()
+	if gossipService == nil {
+		return nil, errors.New("gossip service is not initialized")
+	}
+	return deliveryFailovers(gossipService.DeliveryFailovers(request.ChannelId)), nil
+}
+
+func gossipReputations(reputations []comm.PeerReputation) *pb.GossipReputations {
+	res := &pb.GossipReputations{}
+	for _, r := range reputations {
+		peer := &pb.GossipPeerReputation{PkiId: r.PKIID, Score: int32(r.Score)}
+		if r.Blacklisted() {
+			peer.BlacklistedUntil = &timestamp.Timestamp{
+				Seconds: r.BlacklistedUntil.Unix(),
+				Nanos:   int32(r.BlacklistedUntil.Nanosecond()),
+			}
+		}
+		res.Peers = append(res.Peers, peer)
+	}
+	return res
+}
+
+func deliveryFailovers(failovers []blocksprovider.Failover) *pb.DeliveryFailovers {
+	res := &pb.DeliveryFailovers{}
+	for _, f := range failovers {
+		res.Failovers = append(res.Failovers, &pb.DeliveryFailover{
+			Time:            &timestamp.Timestamp{Seconds: f.Time.Unix(), Nanos: int32(f.Time.Nanosecond())},
+			Endpoint:        f.Endpoint,
+			LastBlock:       f.LastBlock,
+			AdvertisedBlock: f.AdvertisedBlock,
+		})
+	}
+	return res
+}
@@ -19,10 +19,14 @@ package core
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	"github.com/hyperledger/fabric/core/testutil"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/stretchr/testify/assert"
 )
@@ -68,3 +72,37 @@ func TestLoggingCalls(t *testing.T) {
 	assert.Equal(t, flogging.DefaultLevel(), logResponse.LogLevel, "log level should have been the default")
 	assert.Nil(t, err, "Error should have been nil")
 }
+
+func TestGetGossipReputations(t *testing.T) {
+	_, err := adminServer.GetGossipReputations(context.Background(), &empty.Empty{})
+	assert.Error(t, err, "Gossip service isn't initialized, an error should have been returned")
+
+	reputations := gossipReputations([]comm.PeerReputation{
+		{PKIID: common.PKIidType("p1"), Score: 60},
+		{PKIID: common.PKIidType("p2"), Score: -10, BlacklistedUntil: time.Now().Add(time.Minute)},
+	})
+	assert.Len(t, reputations.Peers, 2)
+	assert.Equal(t, []byte("p1"), reputations.Peers[0].PkiId)
+	assert.Equal(t, int32(60), reputations.Peers[0].Score)
+	assert.Nil(t, reputations.Peers[0].BlacklistedUntil)
+	assert.Equal(t, int32(-10), reputations.Peers[1].Score)
+	assert.NotNil(t, reputations.Peers[1].BlacklistedUntil)
+}
+
+func TestGetDeliveryFailovers(t *testing.T) {
+	_, err := adminServer.GetDeliveryFailovers(context.Background(), &pb.DeliveryFailoversRequest{})
+	assert.Error(t, err, "Channel ID is missing, an error should have been returned")
+	_, err = adminServer.GetDeliveryFailovers(context.Background(), &pb.DeliveryFailoversRequest{ChannelId: "testchainid"})
+	assert.Error(t, err, "Gossip service isn't initialized, an error should have been returned")
+
+	now := time.Now()
+	failovers := deliveryFailovers([]blocksprovider.Failover{
+		{Time: now, Endpoint: "orderer0:7050", LastBlock: 10, AdvertisedBlock: 12},
+	})
+	assert.Len(t, failovers.Failovers, 1)
+	assert.Equal(t, now.Unix(), failovers.Failovers[0].Time.Seconds)
+	assert.Equal(t, "orderer0:7050", failovers.Failovers[0].Endpoint)
+	assert.Equal(t, uint64(10), failovers.Failovers[0].LastBlock)
+	assert.Equal(t, uint64(12), failovers.Failovers[0].AdvertisedBlock)
+	assert.Empty(t, deliveryFailovers(nil).Failovers)
+}
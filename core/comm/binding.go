@@ -0,0 +1,80 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// ExtractCertificateFromContext returns the TLS certificate presented by the
+// remote end of the gRPC stream, or nil if none was presented
+func ExtractCertificateFromContext(ctx context.Context) *x509.Certificate {
+	pr, extracted := peer.FromContext(ctx)
+	if !extracted || pr.AuthInfo == nil {
+		return nil
+	}
+
+	tlsInfo, isTLSConn := pr.AuthInfo.(credentials.TLSInfo)
+	if !isTLSConn {
+		return nil
+	}
+	certs := tlsInfo.State.PeerCertificates
+	if len(certs) == 0 {
+		return nil
+	}
+	return certs[0]
+}
+
+// ExtractCertificateHashFromContext returns the hash of the TLS certificate
+// presented by the remote end of the gRPC stream, or nil if none was presented
+func ExtractCertificateHashFromContext(ctx context.Context) []byte {
+	cert := ExtractCertificateFromContext(ctx)
+	if cert == nil {
+		return nil
+	}
+	return CertificateHash(cert.Raw)
+}
+
+// CertificateHash returns the hash of a DER encoded certificate, as carried in
+// the TlsCertHash field of the headers bound to a TLS certificate
+func CertificateHash(rawCert []byte) []byte {
+	if len(rawCert) == 0 {
+		return nil
+	}
+	hash := sha256.Sum256(rawCert)
+	return hash[:]
+}
+
+// VerifyTLSBinding checks that a message whose header carries the hash of a TLS
+// certificate was received over a gRPC stream on which the client presented that
+// certificate, so that signed messages cannot be replayed by another client.
+// Messages without a hash are rejected only if required is true.
+func VerifyTLSBinding(ctx context.Context, tlsCertHash []byte, required bool) error {
+	if len(tlsCertHash) == 0 {
+		if required {
+			return errors.New("message is not bound to the TLS certificate of the client")
+		}
+		return nil
+	}
+
+	clientCertHash := ExtractCertificateHashFromContext(ctx)
+	if len(clientCertHash) == 0 {
+		return errors.New("message is bound to a TLS certificate, but the client didn't present one")
+	}
+	if !bytes.Equal(tlsCertHash, clientCertHash) {
+		return fmt.Errorf("message is bound to TLS certificate %x, but the client presented %x", tlsCertHash, clientCertHash)
+	}
+	return nil
+}
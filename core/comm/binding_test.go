@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func tlsContext(certs ...*x509.Certificate) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: certs}},
+	})
+}
+
+func TestExtractCertificateFromContext(t *testing.T) {
+	assert.Nil(t, ExtractCertificateFromContext(context.Background()))
+	assert.Nil(t, ExtractCertificateHashFromContext(context.Background()))
+	assert.Nil(t, ExtractCertificateFromContext(peer.NewContext(context.Background(), &peer.Peer{})))
+	assert.Nil(t, ExtractCertificateFromContext(tlsContext()))
+
+	cert := &x509.Certificate{Raw: []byte("client cert")}
+	assert.Equal(t, cert, ExtractCertificateFromContext(tlsContext(cert)))
+	assert.Equal(t, CertificateHash(cert.Raw), ExtractCertificateHashFromContext(tlsContext(cert)))
+	assert.Len(t, CertificateHash(cert.Raw), 32)
+	assert.Nil(t, CertificateHash(nil))
+}
+
+func TestVerifyTLSBinding(t *testing.T) {
+	cert := &x509.Certificate{Raw: []byte("client cert")}
+	ctx := tlsContext(cert)
+	hash := CertificateHash(cert.Raw)
+
+	assert.NoError(t, VerifyTLSBinding(ctx, hash, false))
+	assert.NoError(t, VerifyTLSBinding(ctx, hash, true))
+
+	// Unbound messages are accepted unless the binding is required
+	assert.NoError(t, VerifyTLSBinding(ctx, nil, false))
+	assert.NoError(t, VerifyTLSBinding(context.Background(), nil, false))
+	assert.Error(t, VerifyTLSBinding(ctx, nil, true))
+
+	// A message replayed by another client, or without TLS, is rejected
+	assert.Error(t, VerifyTLSBinding(tlsContext(&x509.Certificate{Raw: []byte("another cert")}), hash, false))
+	assert.Error(t, VerifyTLSBinding(tlsContext(), hash, false))
+	assert.Error(t, VerifyTLSBinding(context.Background(), hash, false))
+}
+
+func TestClientCertificateHash(t *testing.T) {
+	cas := &CASupport{}
+	assert.Nil(t, cas.ClientCertificateHash())
+
+	cas.ClientCertificate = func() tls.Certificate {
+		return tls.Certificate{}
+	}
+	assert.Nil(t, cas.ClientCertificateHash())
+
+	cas.ClientCertificate = func() tls.Certificate {
+		return tls.Certificate{Certificate: [][]byte{[]byte("client cert")}}
+	}
+	assert.Equal(t, CertificateHash([]byte("client cert")), cas.ClientCertificateHash())
+}
@@ -11,6 +11,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"sync"
 	"time"
@@ -34,8 +35,15 @@ type CASupport struct {
 	sync.RWMutex
 	AppRootCAsByChain     map[string][][]byte
 	OrdererRootCAsByChain map[string][][]byte
-	ClientRootCAs         [][]byte
-	ServerRootCAs         [][]byte
+	// OrdererEndpointRootCAsByChain holds, per channel, the root CAs of the orderer
+	// org which defines each ordering service endpoint
+	OrdererEndpointRootCAsByChain map[string]map[string][][]byte
+	ClientRootCAs                 [][]byte
+	ServerRootCAs                 [][]byte
+	// ClientCertificate, if set, returns the TLS certificate presented to ordering
+	// service endpoints. It is looked up on each handshake, so that renewed
+	// certificates are presented without creating new credentials
+	ClientCertificate func() tls.Certificate
 }
 
 // GetCASupport returns the signleton CASupport instance
@@ -43,8 +51,9 @@ func GetCASupport() *CASupport {
 
 	once.Do(func() {
 		caSupport = &CASupport{
-			AppRootCAsByChain:     make(map[string][][]byte),
-			OrdererRootCAsByChain: make(map[string][][]byte),
+			AppRootCAsByChain:             make(map[string][][]byte),
+			OrdererRootCAsByChain:         make(map[string][][]byte),
+			OrdererEndpointRootCAsByChain: make(map[string]map[string][][]byte),
 		}
 	})
 	return caSupport
@@ -81,15 +90,52 @@ func (cas *CASupport) GetDeliverServiceCredentials(channelID string) (credential
 	cas.RLock()
 	defer cas.RUnlock()
 
-	var creds credentials.TransportCredentials
-	var tlsConfig = &tls.Config{}
-	var certPool = x509.NewCertPool()
-
 	rootCACerts, exists := cas.OrdererRootCAsByChain[channelID]
 	if !exists {
 		commLogger.Errorf("Attempted to obtain root CA certs of a non existent channel: %s", channelID)
 		return nil, fmt.Errorf("didn't find any root CA certs for channel %s", channelID)
 	}
+	return deliverServiceCredentials(rootCACerts, cas.ClientCertificate), nil
+}
+
+// GetDeliverServiceCredentialsForEndpoint returns GRPC transport credentials to be used by GRPC
+// clients which communicate with the given ordering service endpoint of a channel, trusting
+// only the root CAs of the orderer org which defines the endpoint.
+// If no orderer org of the channel defines the endpoint, the root CAs of all the
+// orderer orgs of the channel are trusted, as in GetDeliverServiceCredentials.
+func (cas *CASupport) GetDeliverServiceCredentialsForEndpoint(channelID, endpoint string) (credentials.TransportCredentials, error) {
+	cas.RLock()
+	rootCACerts, exists := cas.OrdererEndpointRootCAsByChain[channelID][endpoint]
+	clientCertificate := cas.ClientCertificate
+	cas.RUnlock()
+	if !exists {
+		return cas.GetDeliverServiceCredentials(channelID)
+	}
+	return deliverServiceCredentials(rootCACerts, clientCertificate), nil
+}
+
+// ClientCertificateHash returns the hash of the TLS certificate presented to
+// ordering service endpoints, or nil if none is presented
+func (cas *CASupport) ClientCertificateHash() []byte {
+	cas.RLock()
+	clientCertificate := cas.ClientCertificate
+	cas.RUnlock()
+	if clientCertificate == nil {
+		return nil
+	}
+	return TLSCertificateHash(clientCertificate())
+}
+
+func deliverServiceCredentials(rootCACerts [][]byte, clientCertificate func() tls.Certificate) credentials.TransportCredentials {
+	var tlsConfig = &tls.Config{}
+	var certPool = x509.NewCertPool()
+
+	if clientCertificate != nil {
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert := clientCertificate()
+			return &cert, nil
+		}
+	}
 
 	for _, cert := range rootCACerts {
 		block, _ := pem.Decode(cert)
@@ -105,8 +151,7 @@ func (cas *CASupport) GetDeliverServiceCredentials(channelID string) (credential
 		}
 	}
 	tlsConfig.RootCAs = certPool
-	creds = credentials.NewTLS(tlsConfig)
-	return creds, nil
+	return credentials.NewTLS(tlsConfig)
 }
 
 // GetPeerCredentials returns GRPC transport credentials for use by GRPC
@@ -197,19 +242,58 @@ func NewClientConnectionWithAddress(peerAddress string, block bool, tslEnabled b
 
 // InitTLSForPeer returns TLS credentials for peer
 func InitTLSForPeer() credentials.TransportCredentials {
-	var sn string
+	return InitTLSForPeerWithClientCert(tls.Certificate{})
+}
+
+// InitTLSForPeerWithClientCert returns TLS credentials for peer which present
+// the given certificate to the peer, unless it is empty
+func InitTLSForPeerWithClientCert(cert tls.Certificate) credentials.TransportCredentials {
+	var tlsConfig = &tls.Config{}
 	if viper.GetString("peer.tls.serverhostoverride") != "" {
-		sn = viper.GetString("peer.tls.serverhostoverride")
+		tlsConfig.ServerName = viper.GetString("peer.tls.serverhostoverride")
 	}
-	var creds credentials.TransportCredentials
 	if config.GetPath("peer.tls.rootcert.file") != "" {
-		var err error
-		creds, err = credentials.NewClientTLSFromFile(config.GetPath("peer.tls.rootcert.file"), sn)
+		b, err := ioutil.ReadFile(config.GetPath("peer.tls.rootcert.file"))
 		if err != nil {
 			grpclog.Fatalf("Failed to create TLS credentials %v", err)
 		}
-	} else {
-		creds = credentials.NewClientTLSFromCert(nil, sn)
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(b) {
+			grpclog.Fatalf("Failed to create TLS credentials: failed to append certificates")
+		}
+		tlsConfig.RootCAs = certPool
 	}
-	return creds
+	if len(cert.Certificate) != 0 {
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(tlsConfig)
+}
+
+// GetClientCertificate returns the TLS certificate presented by the clients of
+// the peer, such as the peer CLI, read from peer.tls.clientCert.file and
+// peer.tls.clientKey.file. An empty certificate, to which nothing is bound, is
+// returned when TLS is disabled or no client certificate is set
+func GetClientCertificate() (tls.Certificate, error) {
+	if !TLSEnabled() {
+		return tls.Certificate{}, nil
+	}
+	certFile := config.GetPath("peer.tls.clientCert.file")
+	keyFile := config.GetPath("peer.tls.clientKey.file")
+	if certFile == "" && keyFile == "" {
+		return tls.Certificate{}, nil
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("Error loading the client TLS certificate and key (%s)", err)
+	}
+	return cert, nil
+}
+
+// TLSCertificateHash returns the hash of a TLS certificate, as carried in the
+// headers bound to it, or nil for an empty certificate
+func TLSCertificateHash(cert tls.Certificate) []byte {
+	if len(cert.Certificate) == 0 {
+		return nil
+	}
+	return CertificateHash(cert.Certificate[0])
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
@@ -249,9 +250,52 @@ func TestImpersonation(t *testing.T) {
 
 }
 
+func TestEndpointImpersonation(t *testing.T) {
+	// Scenario: We have 2 orderer organizations: orgA, orgB in channel D,
+	// each defining the endpoint of its own gRPC server (srvA and srvB).
+	// The test would obtain credentials.TransportCredentials by calling
+	// GetDeliverServiceCredentialsForEndpoint, which should trust only the
+	// root CA of the org that defines the endpoint.
+
+	osA := newServer("orgA", 7071)
+	defer osA.Stop()
+	osB := newServer("orgB", 7081)
+	defer osB.Stop()
+	time.Sleep(time.Second)
+
+	cas := GetCASupport()
+	_, err := cas.GetDeliverServiceCredentialsForEndpoint("E", "localhost:7071")
+	assert.Error(t, err)
+
+	cas.OrdererRootCAsByChain["D"] = [][]byte{osA.caCert, osB.caCert}
+	cas.OrdererEndpointRootCAsByChain["D"] = map[string][][]byte{
+		"localhost:7071": {osA.caCert},
+		// srvB impersonates an endpoint of orgA
+		"localhost:7081": {osA.caCert},
+	}
+
+	creds, err := cas.GetDeliverServiceCredentialsForEndpoint("D", "localhost:7071")
+	assert.NoError(t, err)
+	invokeWithCreds(t, creds, osA, true)
+
+	creds, err = cas.GetDeliverServiceCredentialsForEndpoint("D", "localhost:7081")
+	assert.NoError(t, err)
+	invokeWithCreds(t, creds, osB, false)
+
+	// Endpoints no org defines trust the root CAs of all the orderer orgs of the channel
+	delete(cas.OrdererEndpointRootCAsByChain["D"], "localhost:7081")
+	creds, err = cas.GetDeliverServiceCredentialsForEndpoint("D", "localhost:7081")
+	assert.NoError(t, err)
+	invokeWithCreds(t, creds, osB, true)
+}
+
 func testInvoke(t *testing.T, channelID string, s *srv, shouldSucceed bool) {
 	creds, err := GetCASupport().GetDeliverServiceCredentials(channelID)
 	assert.NoError(t, err)
+	invokeWithCreds(t, creds, s, shouldSucceed)
+}
+
+func invokeWithCreds(t *testing.T, creds credentials.TransportCredentials, s *srv, shouldSucceed bool) {
 	endpoint := fmt.Sprintf("localhost:%d", s.port)
 	conn, err := grpc.Dial(endpoint, grpc.WithTimeout(time.Second*3), grpc.WithTransportCredentials(creds), grpc.WithBlock())
 	if shouldSucceed {
@@ -267,3 +311,51 @@ func testInvoke(t *testing.T, channelID string, s *srv, shouldSucceed bool) {
 	assert.NoError(t, err)
 	s.assertServiced(t)
 }
+
+func TestGetClientCertificate(t *testing.T) {
+	defer func(cached, enabled bool) {
+		configurationCached, tlsEnabled = cached, enabled
+	}(configurationCached, tlsEnabled)
+	defer viper.Reset()
+	configurationCached = true
+
+	// No certificate is presented with TLS disabled
+	tlsEnabled = false
+	cert, err := GetClientCertificate()
+	assert.NoError(t, err)
+	assert.Empty(t, cert.Certificate)
+	assert.Nil(t, TLSCertificateHash(cert))
+
+	abs := func(file string) string {
+		path, err := filepath.Abs(filepath.Join("testdata", "certs", file))
+		assert.NoError(t, err)
+		return path
+	}
+	clientCert, err := tls.LoadX509KeyPair(abs("Org1-client1-cert.pem"), abs("Org1-client1-key.pem"))
+	assert.NoError(t, err)
+
+	// The certificate of the peer is never presented in place of a client one
+	tlsEnabled = true
+	viper.Set("peer.tls.cert.file", abs("Org1-server1-cert.pem"))
+	viper.Set("peer.tls.key.file", abs("Org1-server1-key.pem"))
+	cert, err = GetClientCertificate()
+	assert.NoError(t, err)
+	assert.Empty(t, cert.Certificate)
+	assert.Nil(t, TLSCertificateHash(cert))
+
+	viper.Set("peer.tls.clientCert.file", abs("Org1-client1-cert.pem"))
+	viper.Set("peer.tls.clientKey.file", abs("Org1-client1-key.pem"))
+	cert, err = GetClientCertificate()
+	assert.NoError(t, err)
+	assert.Equal(t, clientCert.Certificate, cert.Certificate)
+	assert.Equal(t, CertificateHash(clientCert.Certificate[0]), TLSCertificateHash(cert))
+
+	viper.Set("peer.tls.clientKey.file", abs("Org1-client2-key.pem"))
+	_, err = GetClientCertificate()
+	assert.Error(t, err)
+
+	// A client certificate without its key is a misconfiguration
+	viper.Set("peer.tls.clientKey.file", "")
+	_, err = GetClientCertificate()
+	assert.Error(t, err)
+}
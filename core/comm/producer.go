@@ -18,7 +18,9 @@ package comm
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
@@ -28,6 +30,16 @@ import (
 
 var logger = flogging.MustGetLogger("ConnProducer")
 
+var (
+	// endpointDisablePeriod is the period of time an endpoint is avoided
+	// after it was disabled for the first time
+	endpointDisablePeriod = time.Second * 10
+	// maxEndpointDisablePeriod bounds the period of time an endpoint is avoided,
+	// which doubles every time it is disabled again shortly after it was enabled.
+	// Once an endpoint was not disabled for this period of time, its score is reset
+	maxEndpointDisablePeriod = time.Minute * 10
+)
+
 // ConnectionFactory creates a connection to a certain endpoint
 type ConnectionFactory func(endpoint string) (*grpc.ClientConn, error)
 
@@ -41,11 +53,25 @@ type ConnectionProducer interface {
 	// UpdateEndpoints updates the endpoints of the ConnectionProducer
 	// to be the given endpoints
 	UpdateEndpoints(endpoints []string)
+	// DisableEndpoint makes the ConnectionProducer avoid the given endpoint
+	// for a period of time, as long as other endpoints are available
+	DisableEndpoint(endpoint string)
+}
+
+// endpointScore tracks the times an endpoint was disabled
+type endpointScore struct {
+	// disabledCount is the number of times the endpoint was
+	// disabled, since it was last left enabled for a long time
+	disabledCount int
+	// disabledUntil is the time until which the endpoint is avoided
+	disabledUntil time.Time
 }
 
 type connProducer struct {
 	sync.RWMutex
 	endpoints []string
+	preferred string
+	scores    map[string]*endpointScore
 	connect   ConnectionFactory
 }
 
@@ -55,7 +81,17 @@ func NewConnectionProducer(factory ConnectionFactory, endpoints []string) Connec
 	if len(endpoints) == 0 {
 		return nil
 	}
-	return &connProducer{endpoints: endpoints, connect: factory}
+	return &connProducer{endpoints: endpoints, scores: make(map[string]*endpointScore), connect: factory}
+}
+
+// NewPreferringConnectionProducer creates a new ConnectionProducer like NewConnectionProducer,
+// which tries connecting to the preferred endpoint before the other endpoints,
+// as long as the preferred endpoint is among the endpoints.
+func NewPreferringConnectionProducer(factory ConnectionFactory, endpoints []string, preferred string) ConnectionProducer {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	return &connProducer{endpoints: endpoints, preferred: preferred, scores: make(map[string]*endpointScore), connect: factory}
 }
 
 // NewConnection creates a new connection.
@@ -65,7 +101,7 @@ func (cp *connProducer) NewConnection() (*grpc.ClientConn, string, error) {
 	cp.RLock()
 	defer cp.RUnlock()
 
-	endpoints := shuffle(cp.endpoints)
+	endpoints := cp.orderEndpoints()
 	for _, endpoint := range endpoints {
 		conn, err := cp.connect(endpoint)
 		if err != nil {
@@ -89,6 +125,76 @@ func (cp *connProducer) UpdateEndpoints(endpoints []string) {
 	cp.endpoints = endpoints
 }
 
+// DisableEndpoint makes the ConnectionProducer avoid the given endpoint
+// for a period of time, which grows exponentially if the endpoint is
+// disabled again shortly after it was enabled.
+func (cp *connProducer) DisableEndpoint(endpoint string) {
+	cp.Lock()
+	defer cp.Unlock()
+	score, exists := cp.scores[endpoint]
+	if !exists {
+		score = &endpointScore{}
+		cp.scores[endpoint] = score
+	}
+	if time.Since(score.disabledUntil) > maxEndpointDisablePeriod {
+		score.disabledCount = 0
+	}
+	score.disabledCount++
+	period := math.Min(float64(endpointDisablePeriod)*math.Pow(2, float64(score.disabledCount-1)), float64(maxEndpointDisablePeriod))
+	score.disabledUntil = time.Now().Add(time.Duration(period))
+	logger.Warning("Disabling endpoint", endpoint, "for", time.Duration(period))
+}
+
+// orderEndpoints returns the endpoints in the order connections should be attempted:
+// the endpoints that are not disabled come first, starting with the preferred endpoint,
+// and the endpoints disabled fewer times come before the others.
+// Endpoints ranked the same are shuffled to balance the load among them.
+func (cp *connProducer) orderEndpoints() []string {
+	now := time.Now()
+	endpoints := shuffle(cp.endpoints)
+	ranks := make(map[string]endpointRank, len(endpoints))
+	for _, endpoint := range endpoints {
+		rank := endpointRank{preferred: endpoint == cp.preferred}
+		if score, exists := cp.scores[endpoint]; exists {
+			rank.disabled = now.Before(score.disabledUntil)
+			rank.disabledCount = score.disabledCount
+		}
+		ranks[endpoint] = rank
+	}
+	sort.Stable(&endpointsByRank{endpoints: endpoints, ranks: ranks})
+	return endpoints
+}
+
+type endpointRank struct {
+	disabled      bool
+	preferred     bool
+	disabledCount int
+}
+
+type endpointsByRank struct {
+	endpoints []string
+	ranks     map[string]endpointRank
+}
+
+func (e *endpointsByRank) Len() int {
+	return len(e.endpoints)
+}
+
+func (e *endpointsByRank) Less(i, j int) bool {
+	a, b := e.ranks[e.endpoints[i]], e.ranks[e.endpoints[j]]
+	if a.disabled != b.disabled {
+		return !a.disabled
+	}
+	if a.preferred != b.preferred {
+		return a.preferred
+	}
+	return a.disabledCount < b.disabledCount
+}
+
+func (e *endpointsByRank) Swap(i, j int) {
+	e.endpoints[i], e.endpoints[j] = e.endpoints[j], e.endpoints[i]
+}
+
 func shuffle(a []string) []string {
 	n := len(a)
 	returnedSlice := make([]string, n)
@@ -19,6 +19,7 @@ package comm
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
@@ -109,3 +110,91 @@ func TestUpdateEndpoints(t *testing.T) {
 	conn, _, err = producer.NewConnection()
 	assert.Equal(t, "b", conn2Endpoint[fmt.Sprintf("%p", conn)])
 }
+
+func TestPreferredEndpoint(t *testing.T) {
+	shouldConnFail := map[string]bool{
+		"a": false,
+		"b": false,
+		"c": false,
+	}
+	connFactory := func(endpoint string) (*grpc.ClientConn, error) {
+		if shouldConnFail[endpoint] {
+			return nil, fmt.Errorf("Failed connecting to %s", endpoint)
+		}
+		return &grpc.ClientConn{}, nil
+	}
+	producer := NewPreferringConnectionProducer(connFactory, []string{"a", "b", "c"}, "b")
+	// The preferred endpoint should always be selected while it is reachable
+	for i := 0; i < 100; i++ {
+		_, endpoint, err := producer.NewConnection()
+		assert.NoError(t, err)
+		assert.Equal(t, "b", endpoint)
+	}
+	// Once it isn't reachable, the other endpoints should be selected
+	shouldConnFail["b"] = true
+	_, endpoint, err := producer.NewConnection()
+	assert.NoError(t, err)
+	assert.NotEqual(t, "b", endpoint)
+	// And if it isn't among the endpoints anymore, it should be ignored
+	shouldConnFail["b"] = false
+	producer.UpdateEndpoints([]string{"c"})
+	_, endpoint, err = producer.NewConnection()
+	assert.NoError(t, err)
+	assert.Equal(t, "c", endpoint)
+
+	assert.Nil(t, NewPreferringConnectionProducer(connFactory, []string{}, "a"))
+}
+
+func TestDisableEndpoint(t *testing.T) {
+	defer func(period, maxPeriod time.Duration) {
+		endpointDisablePeriod, maxEndpointDisablePeriod = period, maxPeriod
+	}(endpointDisablePeriod, maxEndpointDisablePeriod)
+	endpointDisablePeriod = time.Millisecond * 100
+	maxEndpointDisablePeriod = time.Millisecond * 300
+
+	connFactory := func(endpoint string) (*grpc.ClientConn, error) {
+		return &grpc.ClientConn{}, nil
+	}
+	producer := NewPreferringConnectionProducer(connFactory, []string{"a", "b", "c"}, "a")
+	producer.DisableEndpoint("a")
+	producer.DisableEndpoint("b")
+	// While disabled, the endpoints shouldn't be selected, even if preferred
+	for i := 0; i < 100; i++ {
+		_, endpoint, err := producer.NewConnection()
+		assert.NoError(t, err)
+		assert.Equal(t, "c", endpoint)
+	}
+	// Yet they should be selected if all endpoints are disabled
+	producer.DisableEndpoint("c")
+	_, endpoint, err := producer.NewConnection()
+	assert.NoError(t, err)
+	assert.Contains(t, []string{"a", "b", "c"}, endpoint)
+
+	// Once enabled again, the preferred endpoint should be selected
+	time.Sleep(time.Millisecond * 150)
+	_, endpoint, err = producer.NewConnection()
+	assert.NoError(t, err)
+	assert.Equal(t, "a", endpoint)
+
+	// Disabling an endpoint again shortly after doubles the period it is disabled
+	producer.DisableEndpoint("a")
+	time.Sleep(time.Millisecond * 150)
+	_, endpoint, err = producer.NewConnection()
+	assert.NoError(t, err)
+	assert.NotEqual(t, "a", endpoint)
+	time.Sleep(time.Millisecond * 100)
+	_, endpoint, err = producer.NewConnection()
+	assert.NoError(t, err)
+	assert.Equal(t, "a", endpoint)
+
+	// Endpoints disabled fewer times are selected first
+	producer.UpdateEndpoints([]string{"b", "c"})
+	producer.DisableEndpoint("c")
+	producer.DisableEndpoint("c")
+	time.Sleep(time.Millisecond * 250)
+	for i := 0; i < 100; i++ {
+		_, endpoint, err := producer.NewConnection()
+		assert.NoError(t, err)
+		assert.Equal(t, "b", endpoint)
+	}
+}
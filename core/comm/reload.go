@@ -0,0 +1,165 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+)
+
+// CertificateReloader keeps a TLS certificate and private key pair in sync with
+// the files they are loaded from, so that renewed certificates are presented in
+// new TLS handshakes without restarting the process and dropping the connections
+// already established
+type CertificateReloader struct {
+	certFile string
+	keyFile  string
+
+	lock      sync.RWMutex
+	cert      tls.Certificate
+	certPEM   []byte
+	keyPEM    []byte
+	listeners []func(tls.Certificate)
+
+	stopOnce sync.Once
+	stopChan chan struct{}
+}
+
+// NewCertificateReloader creates a CertificateReloader which loads the PEM-encoded
+// certificate and private key from the given files
+func NewCertificateReloader(certFile, keyFile string) (*CertificateReloader, error) {
+	r := &CertificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		stopChan: make(chan struct{}),
+	}
+	if _, err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Certificate returns the current certificate and private key pair
+func (r *CertificateReloader) Certificate() tls.Certificate {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.cert
+}
+
+// GetCertificate returns the current certificate, it can be used as the
+// GetCertificate callback of a tls.Config of a server
+func (r *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cert := r.Certificate()
+	return &cert, nil
+}
+
+// GetClientCertificate returns the current certificate, it can be used as the
+// GetClientCertificate callback of a tls.Config of a client
+func (r *CertificateReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	cert := r.Certificate()
+	return &cert, nil
+}
+
+// OnReload registers a function which is invoked with the new certificate
+// each time the certificate is reloaded
+func (r *CertificateReloader) OnReload(f func(tls.Certificate)) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.listeners = append(r.listeners, f)
+}
+
+// Reload loads the certificate and private key files again, and notifies the
+// registered functions if they changed. The current certificate is kept if the
+// files don't hold a valid pair, e.g. while they are being replaced.
+func (r *CertificateReloader) Reload() error {
+	reloaded, err := r.load()
+	if err != nil || !reloaded {
+		return err
+	}
+	commLogger.Infof("Reloaded TLS certificate from %s", r.certFile)
+
+	r.lock.RLock()
+	cert := r.cert
+	listeners := make([]func(tls.Certificate), len(r.listeners))
+	copy(listeners, r.listeners)
+	r.lock.RUnlock()
+
+	for _, f := range listeners {
+		f(cert)
+	}
+	return nil
+}
+
+// load reads the certificate and private key files, and returns
+// whether they differ from the ones currently loaded
+func (r *CertificateReloader) load() (bool, error) {
+	certPEM, err := ioutil.ReadFile(r.certFile)
+	if err != nil {
+		return false, fmt.Errorf("Failed reading TLS certificate file %s: %s", r.certFile, err)
+	}
+	keyPEM, err := ioutil.ReadFile(r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("Failed reading TLS key file %s: %s", r.keyFile, err)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if bytes.Equal(certPEM, r.certPEM) && bytes.Equal(keyPEM, r.keyPEM) {
+		return false, nil
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return false, fmt.Errorf("Failed loading TLS certificate %s and key %s: %s", r.certFile, r.keyFile, err)
+	}
+	r.cert = cert
+	r.certPEM = certPEM
+	r.keyPEM = keyPEM
+	return true, nil
+}
+
+// Watch reloads the certificate and private key files every interval, and each
+// time one of the given signals is received, until Stop is called.
+// A zero interval disables the periodic reload.
+func (r *CertificateReloader) Watch(interval time.Duration, signals ...os.Signal) {
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	sigs := make(chan os.Signal, 1)
+	if len(signals) > 0 {
+		signal.Notify(sigs, signals...)
+		defer signal.Stop(sigs)
+	}
+
+	for {
+		select {
+		case <-tick:
+		case sig := <-sigs:
+			commLogger.Infof("Received %s, reloading TLS certificate", sig)
+		case <-r.stopChan:
+			return
+		}
+		if err := r.Reload(); err != nil {
+			commLogger.Warningf("Failed reloading TLS certificate, keeping the current one: %s", err)
+		}
+	}
+}
+
+// Stop stops watching the certificate and private key files
+func (r *CertificateReloader) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stopChan)
+	})
+}
@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func copyCertPair(t *testing.T, dir, name string) {
+	for _, suffix := range []string{"cert", "key"} {
+		b, err := ioutil.ReadFile(filepath.Join("testdata", "certs", name+"-"+suffix+".pem"))
+		assert.NoError(t, err)
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, suffix+".pem"), b, 0600))
+	}
+}
+
+func TestCertificateReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reload")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	_, err = NewCertificateReloader(certFile, keyFile)
+	assert.Error(t, err, "Should have failed reading missing files")
+
+	copyCertPair(t, dir, "Org1-server1")
+	r, err := NewCertificateReloader(certFile, keyFile)
+	assert.NoError(t, err)
+	org1Cert := r.Certificate()
+	cert, _ := r.GetCertificate(nil)
+	assert.Equal(t, org1Cert, *cert)
+
+	reloaded := make(chan tls.Certificate, 1)
+	r.OnReload(func(cert tls.Certificate) {
+		reloaded <- cert
+	})
+
+	// Reloading unchanged files notifies nobody
+	assert.NoError(t, r.Reload())
+	assert.Len(t, reloaded, 0)
+
+	copyCertPair(t, dir, "Org2-server1")
+	assert.NoError(t, r.Reload())
+	org2Cert := <-reloaded
+	assert.NotEqual(t, org1Cert.Certificate, org2Cert.Certificate)
+	assert.Equal(t, org2Cert, r.Certificate())
+	cert, _ = r.GetClientCertificate(nil)
+	assert.Equal(t, org2Cert, *cert)
+
+	// A key which doesn't match the certificate is rejected, and the current pair is kept
+	b, err := ioutil.ReadFile(filepath.Join("testdata", "certs", "Org1-server1-key.pem"))
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(keyFile, b, 0600))
+	assert.Error(t, r.Reload())
+	assert.Len(t, reloaded, 0)
+	assert.Equal(t, org2Cert, r.Certificate())
+}
+
+func TestCertificateReloaderWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reload")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	copyCertPair(t, dir, "Org1-server1")
+	r, err := NewCertificateReloader(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"))
+	assert.NoError(t, err)
+	reloaded := make(chan tls.Certificate, 1)
+	r.OnReload(func(cert tls.Certificate) {
+		reloaded <- cert
+	})
+	done := make(chan struct{})
+	go func() {
+		r.Watch(time.Millisecond * 50)
+		close(done)
+	}()
+
+	copyCertPair(t, dir, "Org2-server1")
+	select {
+	case cert := <-reloaded:
+		assert.Equal(t, cert, r.Certificate())
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "Certificate wasn't reloaded")
+	}
+
+	r.Stop()
+	r.Stop()
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "Watch didn't return after Stop")
+	}
+}
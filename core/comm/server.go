@@ -51,6 +51,9 @@ type GRPCServer interface {
 	Listener() net.Listener
 	//ServerCertificate returns the tls.Certificate used by the grpc.Server
 	ServerCertificate() tls.Certificate
+	//SetServerCertificate replaces the tls.Certificate used by the grpc.Server
+	//in new TLS handshakes, the connections already established are kept
+	SetServerCertificate(cert tls.Certificate)
 	//TLSEnabled is a flag indicating whether or not TLS is enabled for this
 	//GRPCServer instance
 	TLSEnabled() bool
@@ -74,6 +77,8 @@ type grpcServerImpl struct {
 	server *grpc.Server
 	//Certificate presented by the server for TLS communication
 	serverCertificate tls.Certificate
+	//lock to protect concurrent access to the server certificate
+	certLock sync.RWMutex
 	//Key used by the server for TLS communication
 	serverKeyPEM []byte
 	//List of certificate authorities to optionally pass to the client during
@@ -134,10 +139,10 @@ func NewGRPCServerFromListener(listener net.Listener, secureConfig SecureServerC
 
 			//set up our TLS config
 
-			//base server certificate
-			certificates := []tls.Certificate{grpcServer.serverCertificate}
+			//the server certificate is looked up on each handshake,
+			//so that it can be replaced while the server is running
 			grpcServer.tlsConfig = &tls.Config{
-				Certificates:           certificates,
+				GetCertificate:         grpcServer.getCertificate,
 				SessionTicketsDisabled: true,
 			}
 			grpcServer.tlsConfig.ClientAuth = tls.RequestClientCert
@@ -194,9 +199,25 @@ func (gServer *grpcServerImpl) Server() *grpc.Server {
 
 //ServerCertificate returns the tls.Certificate used by the grpc.Server
 func (gServer *grpcServerImpl) ServerCertificate() tls.Certificate {
+	gServer.certLock.RLock()
+	defer gServer.certLock.RUnlock()
 	return gServer.serverCertificate
 }
 
+//SetServerCertificate replaces the tls.Certificate used by the grpc.Server
+//in new TLS handshakes, the connections already established are kept
+func (gServer *grpcServerImpl) SetServerCertificate(cert tls.Certificate) {
+	gServer.certLock.Lock()
+	defer gServer.certLock.Unlock()
+	gServer.serverCertificate = cert
+}
+
+//internal function returning the server certificate for a TLS handshake
+func (gServer *grpcServerImpl) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cert := gServer.ServerCertificate()
+	return &cert, nil
+}
+
 //TLSEnabled is a flag indicating whether or not TLS is enabled for the
 //GRPCServer instance
 func (gServer *grpcServerImpl) TLSEnabled() bool {
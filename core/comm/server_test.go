@@ -647,6 +647,61 @@ func TestNewSecureGRPCServer(t *testing.T) {
 	}
 }
 
+func TestSetServerCertificate(t *testing.T) {
+
+	t.Parallel()
+	testAddress := "localhost:9059"
+	srv, err := comm.NewGRPCServer(testAddress, comm.SecureServerConfig{
+		UseTLS:            true,
+		ServerCertificate: []byte(selfSignedCertPEM),
+		ServerKey:         []byte(selfSignedKeyPEM),
+	})
+	if err != nil {
+		t.Fatalf("Failed to return new GRPC server: %v", err)
+	}
+	testpb.RegisterTestServiceServer(srv.Server(), &testServiceServer{})
+	go srv.Start()
+	defer srv.Stop()
+	time.Sleep(10 * time.Millisecond)
+
+	servedCert := func() []byte {
+		conn, err := tls.Dial("tcp", testAddress, &tls.Config{InsecureSkipVerify: true})
+		if err != nil {
+			t.Fatalf("Failed to connect to %s: %v", testAddress, err)
+		}
+		defer conn.Close()
+		return conn.ConnectionState().PeerCertificates[0].Raw
+	}
+
+	oldCert, _ := tls.X509KeyPair([]byte(selfSignedCertPEM), []byte(selfSignedKeyPEM))
+	assert.Equal(t, oldCert.Certificate[0], servedCert())
+
+	// open a connection before the certificate is replaced
+	clientConn, err := grpc.Dial(testAddress, grpc.WithBlock(), grpc.WithTimeout(timeout),
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})))
+	if err != nil {
+		t.Fatalf("Failed to dial %s: %v", testAddress, err)
+	}
+	defer clientConn.Close()
+
+	newCert, err := tls.LoadX509KeyPair(filepath.Join("testdata", "certs", "Org1-server1-cert.pem"),
+		filepath.Join("testdata", "certs", "Org1-server1-key.pem"))
+	if err != nil {
+		t.Fatalf("Failed to load test certificate: %v", err)
+	}
+	srv.SetServerCertificate(newCert)
+	assert.Equal(t, newCert, srv.ServerCertificate())
+
+	// new handshakes use the new certificate
+	assert.Equal(t, newCert.Certificate[0], servedCert())
+
+	// the existing connection keeps working
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	_, err = testpb.NewTestServiceClient(clientConn).EmptyCall(ctx, new(testpb.Empty))
+	assert.NoError(t, err)
+}
+
 func TestNewSecureGRPCServerFromListener(t *testing.T) {
 
 	t.Parallel()
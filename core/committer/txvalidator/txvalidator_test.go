@@ -18,10 +18,13 @@ package txvalidator
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	util2 "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
@@ -162,6 +165,123 @@ func TestNewTxValidator_DuplicateTransactions(t *testing.T) {
 	assert.True(t, txsfltr.IsInvalid(0))
 }
 
+func TestDuplicateTransactionsInBlock(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/txvalidatortest")
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+
+	gb, _ := test.MakeGenesisBlock("TestLedger")
+	gbHash := gb.Header.Hash()
+	ledger, _ := ledgermgmt.CreateLedger(gb)
+	defer ledger.Close()
+
+	simulator, _ := ledger.NewTxSimulator()
+	simulator.SetState("ns1", "key1", []byte("value1"))
+	simulator.Done()
+	simRes, _ := simulator.GetTxSimulationResults()
+
+	env, _, err := testutil.ConstructTransaction(t, simRes, true)
+	assert.NoError(t, err)
+	envBytes, err := proto.Marshal(env)
+	assert.NoError(t, err)
+
+	newBlock := func() *common.Block {
+		block := common.NewBlock(1, gbHash)
+		block.Data.Data = [][]byte{envBytes, envBytes}
+		block.Header.DataHash = block.Data.Hash()
+		utils.InitBlockMetadata(block)
+		return block
+	}
+
+	// Without the capability both transactions pass validation, leaving the
+	// duplicate to be caught at commit time
+	tValidator := &txValidator{&mocktxvalidator.Support{LedgerVal: ledger}, &validator.MockVsccValidator{}}
+	block := newBlock()
+	tValidator.Validate(block)
+	txsfltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.True(t, txsfltr.IsValid(0))
+	assert.True(t, txsfltr.IsValid(1))
+
+	tValidator = &txValidator{&mocktxvalidator.Support{
+		LedgerVal:       ledger,
+		CapabilitiesVal: &mockconfig.ApplicationCapabilities{ForbidDuplicateTXIdInBlockVal: true},
+	}, &validator.MockVsccValidator{}}
+	block = newBlock()
+	tValidator.Validate(block)
+	txsfltr = util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.True(t, txsfltr.IsValid(0))
+	assert.True(t, txsfltr.IsSetTo(1, peer.TxValidationCode_DUPLICATE_TXID))
+}
+
+func TestTransactionTimestampWindow(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/txvalidatortest")
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+
+	gb, _ := test.MakeGenesisBlock("TestLedger")
+	gbHash := gb.Header.Hash()
+	ledger, _ := ledgermgmt.CreateLedger(gb)
+	defer ledger.Close()
+
+	simulator, _ := ledger.NewTxSimulator()
+	simulator.SetState("ns1", "key1", []byte("value1"))
+	simulator.Done()
+	simRes, _ := simulator.GetTxSimulationResults()
+
+	env, _, err := testutil.ConstructTransaction(t, simRes, true)
+	assert.NoError(t, err)
+	envBytes, err := proto.Marshal(env)
+	assert.NoError(t, err)
+
+	newBlock := func(blockTime *time.Time) *common.Block {
+		block := common.NewBlock(1, gbHash)
+		block.Data.Data = [][]byte{envBytes}
+		block.Header.DataHash = block.Data.Hash()
+		utils.InitBlockMetadata(block)
+		if blockTime != nil {
+			utils.SetBlockTimestamp(block, &timestamp.Timestamp{Seconds: blockTime.Unix()})
+		}
+		return block
+	}
+
+	tValidator := &txValidator{&mocktxvalidator.Support{
+		LedgerVal:        ledger,
+		ChannelConfigVal: &mockconfig.Channel{CapabilitiesVal: &mockconfig.ChannelCapabilities{}, TransactionTimestampWindowVal: time.Hour},
+	}, &validator.MockVsccValidator{}}
+
+	now := time.Now()
+	later := now.Add(2 * time.Hour)
+	earlier := now.Add(-2 * time.Hour)
+	for _, tc := range []struct {
+		name      string
+		blockTime *time.Time
+		valid     bool
+	}{
+		{"WithinWindow", &now, true},
+		{"Expired", &later, false},
+		{"AheadOfBlock", &earlier, false},
+		{"NoBlockTimestamp", nil, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			block := newBlock(tc.blockTime)
+			tValidator.Validate(block)
+			txsfltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+			if tc.valid {
+				assert.True(t, txsfltr.IsValid(0))
+			} else {
+				assert.True(t, txsfltr.IsSetTo(0, peer.TxValidationCode_TIMESTAMP_OUT_OF_WINDOW))
+			}
+		})
+	}
+
+	// Without a window transactions are not checked
+	tValidator = &txValidator{&mocktxvalidator.Support{LedgerVal: ledger}, &validator.MockVsccValidator{}}
+	block := newBlock(&later)
+	tValidator.Validate(block)
+	txsfltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.True(t, txsfltr.IsValid(0))
+}
+
 func createCCUpgradeEnvelope(chainID, chaincodeName, chaincodeVersion string, signer msp.SigningIdentity) (*common.Envelope, error) {
 	creator, err := signer.Serialize()
 	if err != nil {
@@ -235,6 +355,84 @@ func TestGetTxCCInstance(t *testing.T) {
 	assert.EqualValues(t, expectUpgradeCCIns, upgradeCCIns)
 }
 
+func createCCSetPolicyEnvelope(chainID, chaincodeName string, signer msp.SigningIdentity) (*common.Envelope, error) {
+	creator, err := signer.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	prop, _, err := utils.CreateSetPolicyProposal(chainID, chaincodeName, creator, []byte("policy"), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	proposalResponse := &peer.ProposalResponse{
+		Response: &peer.Response{
+			Status: 200, // endorsed successfully
+		},
+		Endorsement: &peer.Endorsement{},
+	}
+
+	return utils.CreateSignedTx(prop, signer, proposalResponse)
+}
+
+func TestGetTxCCInstanceSetPolicy(t *testing.T) {
+	// setup the MSP manager so that we can sign/verify
+	err := msptesttools.LoadMSPSetupForTesting()
+	if err != nil {
+		t.Fatalf("Could not initialize msp, err: %s", err)
+	}
+	signer, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+	if err != nil {
+		t.Fatalf("Could not initialize signer, err: %s", err)
+	}
+
+	chainID := util2.GetTestChainID()
+
+	env, err := createCCSetPolicyEnvelope(chainID, "mycc", signer)
+	assert.NoError(t, err)
+
+	payload, err := utils.GetPayload(env)
+	assert.NoError(t, err)
+
+	tValidator := &txValidator{}
+	invokeCCIns, setPolicyCCIns, err := tValidator.getTxCCInstance(payload)
+	assert.NoError(t, err)
+	assert.EqualValues(t, &sysccprovider.ChaincodeInstance{ChainID: chainID, ChaincodeName: "lscc"}, invokeCCIns)
+	assert.EqualValues(t, &sysccprovider.ChaincodeInstance{ChainID: chainID, ChaincodeName: "mycc"}, setPolicyCCIns)
+}
+
+func TestInvalidTXsForSetPolicyCC(t *testing.T) {
+	txsChaincodeNames := map[int]*sysccprovider.ChaincodeInstance{
+		0: &sysccprovider.ChaincodeInstance{ChainID: "chain1", ChaincodeName: "cc0", ChaincodeVersion: "v0"}, // invoke cc0/chain1:v0, should be invalided by cc0/chain1 setpolicy tx
+		1: &sysccprovider.ChaincodeInstance{ChainID: "chain1", ChaincodeName: "lscc", ChaincodeVersion: ""},  // upgrade cc0/chain1 to v1, should be invalided by latter cc0/chain1 setpolicy tx
+		2: &sysccprovider.ChaincodeInstance{ChainID: "chain1", ChaincodeName: "lscc", ChaincodeVersion: ""},  // setpolicy of cc0/chain1
+		3: &sysccprovider.ChaincodeInstance{ChainID: "chain1", ChaincodeName: "cc1", ChaincodeVersion: "v0"}, // invoke cc1/chain1:v0, should not be affected by setpolicy of cc0
+		4: &sysccprovider.ChaincodeInstance{ChainID: "chain1", ChaincodeName: "cc0", ChaincodeVersion: "v0"}, // invoke cc0/chain1:v0, should be invalided by cc0/chain1 setpolicy tx
+	}
+	upgradedChaincodes := map[int]*sysccprovider.ChaincodeInstance{
+		1: &sysccprovider.ChaincodeInstance{ChainID: "chain1", ChaincodeName: "cc0", ChaincodeVersion: "v1"},
+		2: &sysccprovider.ChaincodeInstance{ChainID: "chain1", ChaincodeName: "cc0", ChaincodeVersion: ""},
+	}
+
+	txsfltr := ledgerUtil.NewTxValidationFlags(5)
+	for i := 0; i < 5; i++ {
+		txsfltr.SetFlag(i, peer.TxValidationCode_VALID)
+	}
+
+	expectTxsFltr := ledgerUtil.NewTxValidationFlags(5)
+	expectTxsFltr.SetFlag(0, peer.TxValidationCode_CHAINCODE_VERSION_CONFLICT)
+	expectTxsFltr.SetFlag(1, peer.TxValidationCode_CHAINCODE_VERSION_CONFLICT)
+	expectTxsFltr.SetFlag(2, peer.TxValidationCode_VALID)
+	expectTxsFltr.SetFlag(3, peer.TxValidationCode_VALID)
+	expectTxsFltr.SetFlag(4, peer.TxValidationCode_CHAINCODE_VERSION_CONFLICT)
+
+	tValidator := &txValidator{}
+	finalfltr := tValidator.invalidTXsForUpgradeCC(txsChaincodeNames, upgradedChaincodes, txsfltr)
+
+	assert.EqualValues(t, expectTxsFltr, finalfltr)
+}
+
 func TestInvalidTXsForUpgradeCC(t *testing.T) {
 	txsChaincodeNames := map[int]*sysccprovider.ChaincodeInstance{
 		0: &sysccprovider.ChaincodeInstance{"chain0", "cc0", "v0"}, // invoke cc0/chain0:v0, should not be affected by upgrade tx in other chain
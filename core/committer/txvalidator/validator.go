@@ -22,6 +22,8 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/flogging"
 	coreUtil "github.com/hyperledger/fabric/common/util"
@@ -40,6 +42,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/events/producer"
 )
 
 var txvalidator_log, _ = os.Create("/root/txvalidator.log")
@@ -58,6 +61,12 @@ type Support interface {
 	// GetMSPIDs returns the IDs for the application MSPs
 	// that have been defined in the channel
 	GetMSPIDs(cid string) []string
+
+	// ChannelConfig returns the channel level config of the channel
+	ChannelConfig() config.Channel
+
+	// Capabilities defines the capabilities for the application portion of this channel
+	Capabilities() config.ApplicationCapabilities
 }
 
 //Validator interface which defines API to validate block transactions
@@ -126,6 +135,14 @@ func (v *txValidator) Validate(block *common.Block) error {
 	txsChaincodeNames := make(map[int]*sysccprovider.ChaincodeInstance)
 	// upgradedChaincodes records all the chaincodes that are upgrded in a block
 	txsUpgradedChaincodes := make(map[int]*sysccprovider.ChaincodeInstance)
+	// txIDs records the IDs of the transactions seen so far in the block
+	txIDs := make(map[string]struct{})
+	forbidDuplicateTXIdInBlock := v.support.Capabilities().ForbidDuplicateTXIdInBlock()
+	timestampWindow := v.support.ChannelConfig().TransactionTimestampWindow()
+	blockTimestamp, err := utils.GetBlockTimestamp(block)
+	if err != nil {
+		logger.Warningf("Block [%d] has malformed timestamp metadata, not checking transaction timestamps: %s", block.Header.Number, err)
+	}
 	for tIdx, d := range block.Data.Data {
 		if d != nil {
 			if env, err := utils.GetEnvelopeFromBlock(d); err != nil {
@@ -168,8 +185,16 @@ func (v *txValidator) Validate(block *common.Block) error {
 				}
 
 				if common.HeaderType(chdr.Type) == common.HeaderType_ENDORSER_TRANSACTION {
-					// Check duplicate transactions
 					txID := chdr.TxId
+
+					// Check that the transaction was ordered shortly after being created
+					if !timestampWithinWindow(chdr.Timestamp, blockTimestamp, timestampWindow) {
+						logger.Errorf("Transaction %s has timestamp outside of the %s window around the block timestamp, skipping", txID, timestampWindow)
+						txsfltr.SetFlag(tIdx, peer.TxValidationCode_TIMESTAMP_OUT_OF_WINDOW)
+						continue
+					}
+
+					// Check duplicate transactions
 					sTime := time.Now()
 					if _, err := v.support.Ledger().GetTransactionByID(txID); err == nil {
 						txvalidator_log.WriteString(fmt.Sprintf("%s GetTransactionById failed %d %+v\n", time.Now(), time.Now().Sub(sTime).Nanoseconds(), err))
@@ -177,6 +202,14 @@ func (v *txValidator) Validate(block *common.Block) error {
 						txsfltr.SetFlag(tIdx, peer.TxValidationCode_DUPLICATE_TXID)
 						continue
 					}
+					if forbidDuplicateTXIdInBlock {
+						if _, seen := txIDs[txID]; seen {
+							logger.Error("Duplicate transaction found in block, ", txID, ", skipping")
+							txsfltr.SetFlag(tIdx, peer.TxValidationCode_DUPLICATE_TXID)
+							continue
+						}
+						txIDs[txID] = struct{}{}
+					}
 					txvalidator_log.WriteString(fmt.Sprintf("%s GetTransactionById done %d\n", time.Now(), time.Now().Sub(sTime).Nanoseconds()))
 
 					// Validate tx with vscc and policy
@@ -188,6 +221,7 @@ func (v *txValidator) Validate(block *common.Block) error {
 						txID := txID
 						logger.Errorf("VSCCValidateTx for transaction txId = %s returned error %s", txID, err)
 						txsfltr.SetFlag(tIdx, cde)
+						sendRejectionEvent(channel, txID, cde, payload, err)
 						continue
 					}
 
@@ -199,7 +233,11 @@ func (v *txValidator) Validate(block *common.Block) error {
 					}
 					txsChaincodeNames[tIdx] = invokeCC
 					if upgradeCC != nil {
-						logger.Infof("Find chaincode upgrade transaction for chaincode %s on chain %s with new version %s", upgradeCC.ChaincodeName, upgradeCC.ChainID, upgradeCC.ChaincodeVersion)
+						if upgradeCC.ChaincodeVersion == "" {
+							logger.Infof("Find endorsement policy update transaction for chaincode %s on chain %s", upgradeCC.ChaincodeName, upgradeCC.ChainID)
+						} else {
+							logger.Infof("Find chaincode upgrade transaction for chaincode %s on chain %s with new version %s", upgradeCC.ChaincodeName, upgradeCC.ChainID, upgradeCC.ChaincodeVersion)
+						}
 						txsUpgradedChaincodes[tIdx] = upgradeCC
 					}
 				} else if common.HeaderType(chdr.Type) == common.HeaderType_CONFIG {
@@ -215,6 +253,15 @@ func (v *txValidator) Validate(block *common.Block) error {
 						logger.Critical(err)
 						return err
 					}
+
+					// A peer which does not support the capabilities required by the new
+					// config must stop processing the channel rather than risk validating
+					// the following blocks differently from the peers which do
+					if err := v.capabilitiesSupported(); err != nil {
+						err := fmt.Errorf("Channel %s requires capabilities which this peer does not support, upgrade the peer to continue processing it: %s", channel, err)
+						logger.Critical(err)
+						return err
+					}
 					logger.Debugf("config transaction received for chain %s", channel)
 				} else {
 					logger.Warningf("Unknown transaction type [%s] in block number [%d] transaction index [%d]",
@@ -247,12 +294,50 @@ func (v *txValidator) Validate(block *common.Block) error {
 	return nil
 }
 
+// sendRejectionEvent notifies event consumers of a transaction which failed
+// validation, along with the reason (e.g. the trace of a failed policy evaluation)
+func sendRejectionEvent(chainID, txID string, code peer.TxValidationCode, payload *common.Payload, err error) {
+	tx, terr := utils.GetTransaction(payload.Data)
+	if terr != nil {
+		logger.Debugf("Could not extract transaction %s for rejection event: %s", txID, terr)
+	}
+	if serr := producer.Send(producer.CreateRejectionEvent(tx, chainID, txID, code, err.Error())); serr != nil {
+		logger.Warningf("Failed to send rejection event for transaction %s: %s", txID, serr)
+	}
+}
+
+// capabilitiesSupported checks that this peer supports the capabilities
+// required by the channel and application config of the channel
+func (v *txValidator) capabilitiesSupported() error {
+	if err := v.support.ChannelConfig().Capabilities().Supported(); err != nil {
+		return err
+	}
+	return v.support.Capabilities().Supported()
+}
+
+// timestampWithinWindow checks that the timestamp of a transaction is within
+// window of the timestamp of its block. Transactions are not checked when the
+// channel sets no window, or when the block has no timestamp, as is the case
+// of the blocks written by orderers predating block timestamps.
+func timestampWithinWindow(txTimestamp, blockTimestamp *timestamp.Timestamp, window time.Duration) bool {
+	if window == 0 || blockTimestamp == nil {
+		return true
+	}
+	if txTimestamp == nil {
+		return false
+	}
+	txTime := time.Unix(txTimestamp.Seconds, int64(txTimestamp.Nanos))
+	blockTime := time.Unix(blockTimestamp.Seconds, int64(blockTimestamp.Nanos))
+	return !txTime.Before(blockTime.Add(-window)) && !txTime.After(blockTime.Add(window))
+}
+
 // generateCCKey generates a unique identifier for chaincode in specific chain
 func (v *txValidator) generateCCKey(ccName, chainID string) string {
 	return fmt.Sprintf("%s/%s", ccName, chainID)
 }
 
-// invalidTXsForUpgradeCC invalid all txs that should be invalided because of chaincode upgrade txs
+// invalidTXsForUpgradeCC invalid all txs that should be invalided because of chaincode upgrade txs;
+// txs changing the endorsement policy of a chaincode are treated as upgrades to the same version
 func (v *txValidator) invalidTXsForUpgradeCC(txsChaincodeNames map[int]*sysccprovider.ChaincodeInstance, txsUpgradedChaincodes map[int]*sysccprovider.ChaincodeInstance, txsfltr ledgerUtil.TxValidationFlags) ledgerUtil.TxValidationFlags {
 	if len(txsUpgradedChaincodes) == 0 {
 		return txsfltr
@@ -348,12 +433,21 @@ func (v *txValidator) getTxCCInstance(payload *common.Payload) (invokeCCIns, upg
 	}
 
 	if invokeCC.Name == "lscc" {
-		if string(cis.ChaincodeSpec.Input.Args[0]) == "upgrade" {
+		switch string(cis.ChaincodeSpec.Input.Args[0]) {
+		case "upgrade":
 			upgradeIns, err := v.getUpgradeTxInstance(chainID, cis.ChaincodeSpec.Input.Args[2])
 			if err != nil {
 				return invokeIns, nil, nil
 			}
 			return invokeIns, upgradeIns, nil
+		case "setpolicy":
+			// a change of the endorsement policy conflicts with the other
+			// transactions of the block exactly like an upgrade does: they
+			// have been validated against the policy found on the ledger
+			if len(cis.ChaincodeSpec.Input.Args) < 3 {
+				return invokeIns, nil, nil
+			}
+			return invokeIns, v.getSetPolicyTxInstance(chainID, cis.ChaincodeSpec.Input.Args[2]), nil
 		}
 	}
 
@@ -373,6 +467,15 @@ func (v *txValidator) getUpgradeTxInstance(chainID string, cdsBytes []byte) (*sy
 	}, nil
 }
 
+// getSetPolicyTxInstance returns the instance of the chaincode whose endorsement
+// policy is changed; the version is left empty since it is not modified
+func (v *txValidator) getSetPolicyTxInstance(chainID string, ccName []byte) *sysccprovider.ChaincodeInstance {
+	return &sysccprovider.ChaincodeInstance{
+		ChainID:       chainID,
+		ChaincodeName: string(ccName),
+	}
+}
+
 // GetInfoForValidate gets the ChaincodeInstance(with latest version) of tx, vscc and policy from lscc
 func (v *vsccValidatorImpl) GetInfoForValidate(txid, chID, ccID string) (*sysccprovider.ChaincodeInstance, *sysccprovider.ChaincodeInstance, []byte, error) {
 	cc := &sysccprovider.ChaincodeInstance{ChainID: chID}
@@ -22,8 +22,10 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/config"
 	ctxt "github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/common/mocks/scc"
 	"github.com/hyperledger/fabric/common/util"
 	ccp "github.com/hyperledger/fabric/core/common/ccprovider"
@@ -148,6 +150,14 @@ func (m *mockSupport) GetMSPIDs(cid string) []string {
 	return []string{"DEFAULT"}
 }
 
+func (m *mockSupport) ChannelConfig() config.Channel {
+	return &mockconfig.Channel{CapabilitiesVal: &mockconfig.ChannelCapabilities{}}
+}
+
+func (m *mockSupport) Capabilities() config.ApplicationCapabilities {
+	return &mockconfig.ApplicationCapabilities{}
+}
+
 func assertInvalid(block *common.Block, t *testing.T, code peer.TxValidationCode) {
 	txsFilter := lutils.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
 	assert.True(t, txsFilter.IsInvalid(0))
@@ -18,6 +18,7 @@ package blocksprovider
 
 import (
 	"math"
+	"sync"
 	"time"
 
 	"sync/atomic"
@@ -25,6 +26,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	gossipcommon "github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/state"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -62,6 +64,25 @@ type BlocksProvider interface {
 
 	// Stop shutdowns blocks provider and stops delivering new blocks
 	Stop()
+
+	// Failovers returns the most recent decisions of switching
+	// from an ordering service endpoint to another
+	Failovers() []Failover
+}
+
+// Failover records the decision of switching from an ordering
+// service endpoint that stopped delivering blocks to another endpoint
+type Failover struct {
+	// Time is the time the decision was made
+	Time time.Time
+	// Endpoint is the endpoint switched from
+	Endpoint string
+	// LastBlock is the sequence number of the last block
+	// received from the ordering service
+	LastBlock uint64
+	// AdvertisedBlock is the highest sequence number of the
+	// blocks peers of the channel advertised having
+	AdvertisedBlock uint64
 }
 
 // BlocksDeliverer defines interface which actually helps
@@ -83,8 +104,13 @@ type streamClient interface {
 	// Close closes the stream and its underlying connection
 	Close()
 
-	// Disconnect disconnects from the remote node
-	Disconnect()
+	// Disconnect disconnects from the remote node,
+	// and if disableEndpoint is true, avoids its endpoint
+	// when connecting again
+	Disconnect(disableEndpoint bool)
+
+	// GetEndpoint returns the endpoint of the remote node
+	GetEndpoint() string
 }
 
 // blocksProviderImpl the actual implementation for BlocksProvider interface
@@ -99,13 +125,38 @@ type blocksProviderImpl struct {
 
 	done int32
 
+	stopChan chan struct{}
+
 	wrongStatusThreshold int
+
+	progressLock sync.Mutex
+	// lastProgress is the last time a block was received, or
+	// the ordering service endpoint was switched
+	lastProgress time.Time
+	// lastBlock is the highest sequence number among the blocks received,
+	// or advertised by peers of the channel when progress was last made
+	lastBlock uint64
+	failovers []Failover
 }
 
-const wrongStatusThreshold = 10
+const (
+	wrongStatusThreshold = 10
+
+	maxFailovers = 100
+)
 
 var MaxRetryDelay = time.Second * 10
 
+var (
+	// StallTimeout is the period of time without receiving blocks after which,
+	// if peers of the channel advertise blocks that weren't received,
+	// the ordering service endpoint is considered stalled and is switched
+	StallTimeout = time.Minute
+	// StallCheckInterval is the interval at which the progress
+	// of blocks delivery is checked
+	StallCheckInterval = time.Second * 10
+)
+
 var logger *logging.Logger // package-level logger
 
 func init() {
@@ -119,6 +170,7 @@ func NewBlocksProvider(chainID string, client streamClient, gossip GossipService
 		client:               client,
 		gossip:               gossip,
 		mcs:                  mcs,
+		stopChan:             make(chan struct{}),
 		wrongStatusThreshold: wrongStatusThreshold,
 	}
 }
@@ -129,6 +181,11 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 	errorStatusCounter := 0
 	statusCounter := 0
 	defer b.client.Close()
+	b.progressLock.Lock()
+	b.lastProgress = time.Now()
+	b.lastBlock = b.advertisedBlock()
+	b.progressLock.Unlock()
+	go b.monitorProgress()
 	for !b.isDone() {
 		msg, err := b.client.Recv()
 		if err != nil {
@@ -158,12 +215,13 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 			if currDelay < maxDelay {
 				statusCounter++
 			}
-			b.client.Disconnect()
+			b.client.Disconnect(false)
 			continue
 		case *orderer.DeliverResponse_Block:
 			errorStatusCounter = 0
 			statusCounter = 0
 			seqNum := t.Block.Header.Number
+			b.markProgress(seqNum)
 
 			marshaledBlock, err := proto.Marshal(t.Block)
 			if err != nil {
@@ -183,7 +241,12 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 
 			logger.Debugf("[%s] Adding payload locally, buffer seqNum = [%d], peers number [%d]", b.chainID, seqNum, numberOfPeers)
 			// Add payload to local state payloads buffer
-			b.gossip.AddPayload(b.chainID, payload)
+			if err := b.gossip.AddPayload(b.chainID, payload); err != nil {
+				// The block was already received, from another leader of the channel
+				// that already disseminated it, hence there is no need to gossip it again
+				logger.Debugf("[%s] Not gossiping block [%d], due to %s", b.chainID, seqNum, err)
+				continue
+			}
 
 			// Gossip messages with other nodes
 			logger.Debugf("[%s] Gossiping block [%d], peers number [%d]", b.chainID, seqNum, numberOfPeers)
@@ -197,10 +260,88 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 
 // Stop stops blocks delivery provider
 func (b *blocksProviderImpl) Stop() {
-	atomic.StoreInt32(&b.done, 1)
+	if atomic.CompareAndSwapInt32(&b.done, 0, 1) && b.stopChan != nil {
+		close(b.stopChan)
+	}
 	b.client.Close()
 }
 
+// Failovers returns the most recent decisions of switching
+// from an ordering service endpoint to another
+func (b *blocksProviderImpl) Failovers() []Failover {
+	b.progressLock.Lock()
+	defer b.progressLock.Unlock()
+	return append([]Failover(nil), b.failovers...)
+}
+
+// markProgress records that a block with the given sequence number was received
+func (b *blocksProviderImpl) markProgress(seqNum uint64) {
+	b.progressLock.Lock()
+	defer b.progressLock.Unlock()
+	b.lastProgress = time.Now()
+	if seqNum > b.lastBlock {
+		b.lastBlock = seqNum
+	}
+}
+
+// monitorProgress periodically checks whether the ordering service stopped
+// delivering blocks while peers of the channel still receive blocks,
+// from other ordering service nodes, and if so, switches to another endpoint
+func (b *blocksProviderImpl) monitorProgress() {
+	for !b.isDone() {
+		select {
+		case <-time.After(StallCheckInterval):
+			b.checkProgress()
+		case <-b.stopChan:
+			return
+		}
+	}
+}
+
+func (b *blocksProviderImpl) checkProgress() {
+	advertisedBlock := b.advertisedBlock()
+
+	b.progressLock.Lock()
+	if time.Since(b.lastProgress) < StallTimeout || advertisedBlock <= b.lastBlock {
+		b.progressLock.Unlock()
+		return
+	}
+	failover := Failover{
+		Time:            time.Now(),
+		Endpoint:        b.client.GetEndpoint(),
+		LastBlock:       b.lastBlock,
+		AdvertisedBlock: advertisedBlock,
+	}
+	b.failovers = append(b.failovers, failover)
+	if len(b.failovers) > maxFailovers {
+		b.failovers = b.failovers[1:]
+	}
+	// Give the next endpoint time to deliver the blocks
+	b.lastProgress = time.Now()
+	b.lastBlock = advertisedBlock
+	b.progressLock.Unlock()
+
+	logger.Warningf("[%s] No block received from %s for %v, while peers of the channel have block [%d] and the last block received is [%d], switching to another endpoint",
+		b.chainID, failover.Endpoint, StallTimeout, failover.AdvertisedBlock, failover.LastBlock)
+	b.client.Disconnect(true)
+}
+
+// advertisedBlock returns the highest sequence number of
+// the blocks peers of the channel advertise having
+func (b *blocksProviderImpl) advertisedBlock() uint64 {
+	var max uint64
+	for _, peer := range b.gossip.PeersOfChannel(gossipcommon.ChainID(b.chainID)) {
+		nodeMetastate, err := state.FromBytes(peer.Metadata)
+		if err != nil {
+			continue
+		}
+		if height := nodeMetastate.Height(); height > max {
+			max = height
+		}
+	}
+	return max
+}
+
 // Check whenever provider is stopped
 func (b *blocksProviderImpl) isDone() bool {
 	return atomic.LoadInt32(&b.done) == 1
@@ -25,7 +25,10 @@ import (
 	"github.com/hyperledger/fabric/core/deliverservice/mocks"
 	"github.com/hyperledger/fabric/gossip/api"
 	common2 "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/state"
 	"github.com/hyperledger/fabric/protos/common"
+	gossip_proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -386,3 +389,115 @@ func TestBlockVerificationFailure(t *testing.T) {
 	mcs.On("VerifyBlock", mock.Anything).Return(errors.New("Invalid signature"))
 	makeTestCase(uint64(0), mcs, false, rcvr)(t)
 }
+
+// duplicatesGossipAdapter rejects the payloads of blocks
+// with an even sequence number, as if they were already received
+type duplicatesGossipAdapter struct {
+	*mocks.MockGossipServiceAdapter
+}
+
+func (ga *duplicatesGossipAdapter) AddPayload(chainID string, payload *gossip_proto.Payload) error {
+	ga.MockGossipServiceAdapter.AddPayload(chainID, payload)
+	if payload.SeqNum%2 == 0 {
+		return errors.New("already received")
+	}
+	return nil
+}
+
+func TestBlocksProviderSkipsDuplicateBlocks(t *testing.T) {
+	// Scenario: another leader of the channel already delivered some of the blocks received.
+	// Expected outcome: only the blocks that weren't received yet are gossiped.
+	gossipServiceAdapter := &duplicatesGossipAdapter{&mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64)}}
+	deliverer := &mocks.MockBlocksDeliverer{Pos: 0}
+	deliverer.MockRecv = mocks.MockRecv
+	mcs := &mockMCS{}
+	mcs.On("VerifyBlock", mock.Anything).Return(nil)
+	provider := NewBlocksProvider("***TEST_CHAINID***", deliverer, gossipServiceAdapter, mcs)
+	go provider.DeliverBlocks()
+
+	for _, expected := range []uint64{1, 3, 5} {
+		select {
+		case seqNum := <-gossipServiceAdapter.GossipBlockDisseminations:
+			assert.Equal(t, expected, seqNum)
+		case <-time.After(time.Second):
+			assert.Fail(t, "Didn't gossip a block within a timely manner")
+		}
+	}
+	provider.Stop()
+	// Unblock the provider in case it is gossiping another block
+	select {
+	case <-gossipServiceAdapter.GossipBlockDisseminations:
+	case <-time.After(time.Second):
+	}
+}
+
+// advertisingGossipAdapter has a peer of the channel
+// advertise having the block with a given sequence number
+type advertisingGossipAdapter struct {
+	*mocks.MockGossipServiceAdapter
+	advertisedBlock uint64
+}
+
+func (ga *advertisingGossipAdapter) PeersOfChannel(common2.ChainID) []discovery.NetworkMember {
+	metadata, _ := state.NewNodeMetastate(atomic.LoadUint64(&ga.advertisedBlock)).Bytes()
+	return []discovery.NetworkMember{{Endpoint: "p1", Metadata: metadata}}
+}
+
+func TestBlocksProviderFailover(t *testing.T) {
+	// Scenario: the ordering service delivers a block and then stops delivering blocks,
+	// while a peer of the channel advertises it received newer blocks.
+	// Expected outcome: the endpoint of the ordering service is disabled and switched,
+	// once, until the peer advertises receiving newer blocks again.
+	defer func(stallTimeout, stallCheckInterval time.Duration) {
+		StallTimeout, StallCheckInterval = stallTimeout, stallCheckInterval
+	}(StallTimeout, StallCheckInterval)
+	StallTimeout = time.Millisecond * 300
+	StallCheckInterval = time.Millisecond * 50
+
+	gossipServiceAdapter := &advertisingGossipAdapter{MockGossipServiceAdapter: &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64, 1)}}
+	bd := &mocks.MockBlocksDeliverer{DisconnectCalled: make(chan struct{}, 10)}
+	stopped := make(chan struct{})
+	bd.MockRecv = func(mock *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
+		if atomic.LoadInt32(&mock.RecvCnt) == 1 {
+			return mocks.MockRecv(mock)
+		}
+		<-stopped
+		return nil, errors.New("Stopping")
+	}
+	mcs := &mockMCS{}
+	mcs.On("VerifyBlock", mock.Anything).Return(nil)
+	provider := NewBlocksProvider("***TEST_CHAINID***", bd, gossipServiceAdapter, mcs)
+	go provider.DeliverBlocks()
+	defer close(stopped)
+	defer provider.Stop()
+
+	select {
+	case seqNum := <-gossipServiceAdapter.GossipBlockDisseminations:
+		assert.Equal(t, uint64(0), seqNum)
+	case <-time.After(time.Second):
+		assert.Fail(t, "Didn't gossip a block within a timely manner")
+	}
+
+	// No failover as long as no newer block is advertised
+	time.Sleep(StallTimeout * 2)
+	assert.Empty(t, provider.Failovers())
+
+	atomic.StoreUint64(&gossipServiceAdapter.advertisedBlock, 5)
+	waitUntilOrFail(t, func() bool {
+		return atomic.LoadInt32(&bd.DisabledEndpointsCnt) == 1
+	})
+	failovers := provider.Failovers()
+	assert.Len(t, failovers, 1)
+	assert.Equal(t, "localhost:5611", failovers[0].Endpoint)
+	assert.Equal(t, uint64(0), failovers[0].LastBlock)
+	assert.Equal(t, uint64(5), failovers[0].AdvertisedBlock)
+
+	// The next endpoint isn't switched unless newer blocks are advertised again
+	time.Sleep(StallTimeout * 2)
+	assert.Len(t, provider.Failovers(), 1)
+	atomic.StoreUint64(&gossipServiceAdapter.advertisedBlock, 6)
+	waitUntilOrFail(t, func() bool {
+		return atomic.LoadInt32(&bd.DisabledEndpointsCnt) == 2
+	})
+	assert.Len(t, provider.Failovers(), 2)
+}
@@ -68,7 +68,11 @@ func (bc *broadcastClient) Recv() (*orderer.DeliverResponse, error) {
 		if bc.shouldStop() {
 			return nil, errors.New("closing")
 		}
-		return bc.BlocksDeliverer.Recv()
+		deliverer, err := bc.deliverer()
+		if err != nil {
+			return nil, err
+		}
+		return deliverer.Recv()
 	})
 	if err != nil {
 		return nil, err
@@ -82,11 +86,26 @@ func (bc *broadcastClient) Send(msg *common.Envelope) error {
 		if bc.shouldStop() {
 			return nil, errors.New("closing")
 		}
-		return nil, bc.BlocksDeliverer.Send(msg)
+		deliverer, err := bc.deliverer()
+		if err != nil {
+			return nil, err
+		}
+		return nil, deliverer.Send(msg)
 	})
 	return err
 }
 
+// deliverer returns the stream of the current connection. Since the client
+// may be disconnected concurrently, returns an error if it isn't connected
+func (bc *broadcastClient) deliverer() (blocksprovider.BlocksDeliverer, error) {
+	bc.Lock()
+	defer bc.Unlock()
+	if bc.BlocksDeliverer == nil {
+		return nil, errors.New("disconnected")
+	}
+	return bc.BlocksDeliverer, nil
+}
+
 func (bc *broadcastClient) try(action func() (interface{}, error)) (interface{}, error) {
 	attempt := 0
 	start := time.Now()
@@ -120,7 +139,7 @@ func (bc *broadcastClient) doAction(action func() (interface{}, error)) (interfa
 	}
 	resp, err := action()
 	if err != nil {
-		bc.Disconnect()
+		bc.Disconnect(false)
 		return nil, err
 	}
 	return resp, nil
@@ -146,19 +165,19 @@ func (bc *broadcastClient) connect() error {
 		conn.Close()
 		return err
 	}
-	err = bc.afterConnect(conn, abc, cf)
+	err = bc.afterConnect(conn, endpoint, abc, cf)
 	if err == nil {
 		return nil
 	}
 	// If we reached here, lets make sure connection is closed
 	// and nullified before we return
-	bc.Disconnect()
+	bc.Disconnect(false)
 	return err
 }
 
-func (bc *broadcastClient) afterConnect(conn *grpc.ClientConn, abc orderer.AtomicBroadcast_DeliverClient, cf context.CancelFunc) error {
+func (bc *broadcastClient) afterConnect(conn *grpc.ClientConn, endpoint string, abc orderer.AtomicBroadcast_DeliverClient, cf context.CancelFunc) error {
 	bc.Lock()
-	bc.conn = &connection{ClientConn: conn, cancel: cf}
+	bc.conn = &connection{ClientConn: conn, endpoint: endpoint, cancel: cf}
 	bc.BlocksDeliverer = abc
 	if bc.shouldStop() {
 		bc.Unlock()
@@ -207,22 +226,39 @@ func (bc *broadcastClient) Close() {
 	bc.conn.Close()
 }
 
-// Disconnect makes the client close the existing connection
-func (bc *broadcastClient) Disconnect() {
+// Disconnect makes the client close the existing connection,
+// and if disableEndpoint is true, also makes the client avoid
+// the endpoint of the connection when it reconnects
+func (bc *broadcastClient) Disconnect(disableEndpoint bool) {
 	bc.Lock()
 	defer bc.Unlock()
 	if bc.conn == nil {
 		return
 	}
+	if disableEndpoint {
+		bc.prod.DisableEndpoint(bc.conn.endpoint)
+	}
 	bc.conn.Close()
 	bc.conn = nil
 	bc.BlocksDeliverer = nil
 }
 
+// GetEndpoint returns the endpoint the client is connected to,
+// or an empty string if it isn't connected
+func (bc *broadcastClient) GetEndpoint() string {
+	bc.Lock()
+	defer bc.Unlock()
+	if bc.conn == nil {
+		return ""
+	}
+	return bc.conn.endpoint
+}
+
 type connection struct {
 	sync.Once
 	*grpc.ClientConn
-	cancel context.CancelFunc
+	endpoint string
+	cancel   context.CancelFunc
 }
 
 func (c *connection) Close() error {
@@ -153,6 +153,11 @@ func (cp *connProducer) UpdateEndpoints(endpoints []string) {
 	panic("Not implemented")
 }
 
+// DisableEndpoint makes the ConnectionProducer avoid the given endpoint
+func (cp *connProducer) DisableEndpoint(endpoint string) {
+	panic("Not implemented")
+}
+
 func TestOrderingServiceConnFailure(t *testing.T) {
 	testOrderingServiceConnFailure(t, blockDelivererConsumerWithRecv)
 	testOrderingServiceConnFailure(t, blockDelivererConsumerWithSend)
@@ -644,7 +649,7 @@ func TestDisconnect(t *testing.T) {
 		stopChan <- struct{}{}
 	}()
 	waitForConnectionToSomeOSN()
-	cl.Disconnect()
+	cl.Disconnect(false)
 
 	i := 0
 	for (os1.ConnCount() == 0 || os2.ConnCount() == 0) && i < 100 {
@@ -653,7 +658,7 @@ func TestDisconnect(t *testing.T) {
 		if i == 100 {
 			assert.Fail(t, "Didn't switch to other instance after many attempts")
 		}
-		cl.Disconnect()
+		cl.Disconnect(false)
 		time.Sleep(time.Millisecond * 500)
 	}
 	cl.Close()
@@ -664,6 +669,66 @@ func TestDisconnect(t *testing.T) {
 	}
 }
 
+func TestDisconnectDisablingEndpoint(t *testing.T) {
+	// Scenario: spawn 2 ordering service instances
+	// and a client.
+	// Have the client try to Recv() from one of them,
+	// and disconnect the client while disabling the endpoint it is connected to.
+	// The client is expected to connect to the other instance right away.
+
+	defer ensureNoGoroutineLeak(t)()
+	os1 := mocks.NewOrderer(5617, t)
+	os1.SetNextExpectedSeek(5)
+	os2 := mocks.NewOrderer(5618, t)
+	os2.SetNextExpectedSeek(5)
+
+	defer os1.Shutdown()
+	defer os2.Shutdown()
+
+	waitForEndpoint := func(cl *broadcastClient) string {
+		for i := 0; i < 100; i++ {
+			if endpoint := cl.GetEndpoint(); endpoint != "" {
+				return endpoint
+			}
+			time.Sleep(time.Millisecond * 100)
+		}
+		assert.Fail(t, "Didn't connect to any instance")
+		return ""
+	}
+
+	connFact := func(endpoint string) (*grpc.ClientConn, error) {
+		return grpc.Dial(endpoint, grpc.WithInsecure(), grpc.WithBlock())
+	}
+	prod := comm.NewConnectionProducer(connFact, []string{"localhost:5617", "localhost:5618"})
+	clFact := func(cc *grpc.ClientConn) orderer.AtomicBroadcastClient {
+		return orderer.NewAtomicBroadcastClient(cc)
+	}
+	onConnect := func(bd blocksprovider.BlocksDeliverer) error {
+		return nil
+	}
+	retryPol := func(attemptNum int, elapsedTime time.Duration) (time.Duration, bool) {
+		return time.Millisecond * 10, attemptNum < 100
+	}
+
+	cl := NewBroadcastClient(prod, clFact, onConnect, retryPol)
+	stopChan := make(chan struct{})
+	go func() {
+		cl.Recv()
+		stopChan <- struct{}{}
+	}()
+	firstEndpoint := waitForEndpoint(cl)
+	cl.Disconnect(true)
+	secondEndpoint := waitForEndpoint(cl)
+	assert.NotEqual(t, firstEndpoint, secondEndpoint, "Should have connected to the other instance")
+
+	cl.Close()
+	select {
+	case <-stopChan:
+	case <-time.After(time.Second * 20):
+		assert.Fail(t, "Didn't stop within a timely manner")
+	}
+}
+
 func newTestSeekInfo() *orderer.SeekInfo {
 	return &orderer.SeekInfo{Start: &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: 5}}},
 		Stop:     &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: math.MaxUint64}}},
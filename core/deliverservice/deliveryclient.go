@@ -41,10 +41,25 @@ type DeliverService interface {
 	// to channel peers.
 	StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo) error
 
+	// StartDeliverForChannelFrom dynamically starts delivery of new blocks from ordering service
+	// to channel peers, connecting preferably to the endpoint of the ordering service at the
+	// given index, modulo the number of endpoints.
+	StartDeliverForChannelFrom(chainID string, ledgerInfo blocksprovider.LedgerInfo, endpointIndex int) error
+
 	// StopDeliverForChannel dynamically stops delivery of new blocks from ordering service
 	// to channel peers.
 	StopDeliverForChannel(chainID string) error
 
+	// UpdateEndpoints sets the endpoints of the ordering service blocks of the given channel
+	// are delivered from. Channels without endpoints of their own use the endpoints
+	// the delivery service was created with.
+	UpdateEndpoints(chainID string, endpoints []string)
+
+	// Failovers returns the most recent decisions of switching from an ordering
+	// service endpoint to another taken by the delivery of the given channel,
+	// or nil if blocks aren't delivered for the channel.
+	Failovers(chainID string) []blocksprovider.Failover
+
 	// Stop terminates delivery service and closes the connection
 	Stop()
 }
@@ -55,6 +70,8 @@ type DeliverService interface {
 type deliverServiceImpl struct {
 	conf           *Config
 	blockProviders map[string]blocksprovider.BlocksProvider
+	connProducers  map[string]comm.ConnectionProducer
+	endpoints      map[string][]string
 	lock           sync.RWMutex
 	stopping       bool
 }
@@ -74,7 +91,8 @@ type Config struct {
 	// Gossip enables to enumerate peers in the channel, send a message to peers,
 	// and add a block to the gossip state transfer layer
 	Gossip blocksprovider.GossipServiceAdapter
-	// Endpoints specifies the endpoints of the ordering service
+	// Endpoints specifies the endpoints of the ordering service,
+	// used for channels without endpoints of their own
 	Endpoints []string
 }
 
@@ -86,6 +104,8 @@ func NewDeliverService(conf *Config) (DeliverService, error) {
 	ds := &deliverServiceImpl{
 		conf:           conf,
 		blockProviders: make(map[string]blocksprovider.BlocksProvider),
+		connProducers:  make(map[string]comm.ConnectionProducer),
+		endpoints:      make(map[string][]string),
 	}
 	if err := ds.validateConfiguration(); err != nil {
 		return nil, err
@@ -118,6 +138,20 @@ func (d *deliverServiceImpl) validateConfiguration() error {
 // that spawns in go routine to read new blocks starting from the position provided by ledger
 // info instance.
 func (d *deliverServiceImpl) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo) error {
+	return d.startDeliverForChannel(chainID, ledgerInfo, -1)
+}
+
+// StartDeliverForChannelFrom starts blocks delivery for channel like StartDeliverForChannel,
+// connecting preferably to the endpoint at the given index, so that several peers delivering
+// blocks for the same channel connect to different endpoints.
+func (d *deliverServiceImpl) StartDeliverForChannelFrom(chainID string, ledgerInfo blocksprovider.LedgerInfo, endpointIndex int) error {
+	if endpointIndex < 0 {
+		return fmt.Errorf("Invalid endpoint index %d", endpointIndex)
+	}
+	return d.startDeliverForChannel(chainID, ledgerInfo, endpointIndex)
+}
+
+func (d *deliverServiceImpl) startDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo, endpointIndex int) error {
 	d.lock.Lock()
 	defer d.lock.Unlock()
 	if d.stopping {
@@ -130,7 +164,14 @@ func (d *deliverServiceImpl) StartDeliverForChannel(chainID string, ledgerInfo b
 		logger.Errorf(errMsg)
 		return errors.New(errMsg)
 	} else {
-		client := d.newClient(chainID, ledgerInfo)
+		endpoints := d.endpointsOf(chainID)
+		preferredEndpoint := ""
+		if endpointIndex >= 0 {
+			preferredEndpoint = endpoints[endpointIndex%len(endpoints)]
+		}
+		connProd := comm.NewPreferringConnectionProducer(d.conf.ConnFactory(chainID), endpoints, preferredEndpoint)
+		d.connProducers[chainID] = connProd
+		client := d.newClient(chainID, ledgerInfo, connProd)
 		logger.Debug("This peer will pass blocks from orderer service to other peers for channel", chainID)
 		d.blockProviders[chainID] = blocksprovider.NewBlocksProvider(chainID, client, d.conf.Gossip, d.conf.CryptoSvc)
 		go d.blockProviders[chainID].DeliverBlocks()
@@ -150,6 +191,7 @@ func (d *deliverServiceImpl) StopDeliverForChannel(chainID string) error {
 	if client, exist := d.blockProviders[chainID]; exist {
 		client.Stop()
 		delete(d.blockProviders, chainID)
+		delete(d.connProducers, chainID)
 		logger.Debug("This peer will stop pass blocks from orderer service to other peers")
 	} else {
 		errMsg := fmt.Sprintf("Delivery service - no block provider for %s found, can't stop delivery", chainID)
@@ -159,6 +201,41 @@ func (d *deliverServiceImpl) StopDeliverForChannel(chainID string) error {
 	return nil
 }
 
+// UpdateEndpoints sets the endpoints of the ordering service blocks of the given channel
+// are delivered from, and hands them over to the running delivery of the channel, if any
+func (d *deliverServiceImpl) UpdateEndpoints(chainID string, endpoints []string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if len(endpoints) == 0 {
+		delete(d.endpoints, chainID)
+	} else {
+		d.endpoints[chainID] = endpoints
+	}
+	if connProd, exists := d.connProducers[chainID]; exists {
+		logger.Debugf("Updating the ordering service endpoints of channel %s to %v", chainID, d.endpointsOf(chainID))
+		connProd.UpdateEndpoints(d.endpointsOf(chainID))
+	}
+}
+
+// Failovers returns the most recent decisions of switching from an ordering
+// service endpoint to another taken by the blocks provider of the given channel
+func (d *deliverServiceImpl) Failovers(chainID string) []blocksprovider.Failover {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	if client, exist := d.blockProviders[chainID]; exist {
+		return client.Failovers()
+	}
+	return nil
+}
+
+// endpointsOf returns the endpoints of the ordering service for the given channel
+func (d *deliverServiceImpl) endpointsOf(chainID string) []string {
+	if endpoints, exists := d.endpoints[chainID]; exists {
+		return endpoints
+	}
+	return d.conf.Endpoints
+}
+
 // Stop all service and release resources
 func (d *deliverServiceImpl) Stop() {
 	d.lock.Lock()
@@ -171,7 +248,7 @@ func (d *deliverServiceImpl) Stop() {
 	}
 }
 
-func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocksprovider.LedgerInfo) *broadcastClient {
+func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocksprovider.LedgerInfo, connProd comm.ConnectionProducer) *broadcastClient {
 	requester := &blocksRequester{
 		chainID: chainID,
 	}
@@ -186,7 +263,6 @@ func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocks
 		attempt := float64(attemptNum)
 		return time.Duration(math.Min(math.Pow(2, attempt)*sleepIncrement, reConnectBackoffThreshold)), true
 	}
-	connProd := comm.NewConnectionProducer(d.conf.ConnFactory(chainID), d.conf.Endpoints)
 	bClient := NewBroadcastClient(connProd, d.conf.ABCFactory, broadcastSetup, backoffPolicy)
 	requester.client = bClient
 	return bClient
@@ -202,7 +278,7 @@ func DefaultConnectionFactory(channelID string) func(endpoint string) (*grpc.Cli
 		dialOpts = append(dialOpts, comm.ClientKeepaliveOptions()...)
 
 		if comm.TLSEnabled() {
-			creds, err := comm.GetCASupport().GetDeliverServiceCredentials(channelID)
+			creds, err := comm.GetCASupport().GetDeliverServiceCredentialsForEndpoint(channelID, endpoint)
 			if err != nil {
 				return nil, fmt.Errorf("Failed obtaining credentials for channel %s: %v", channelID, err)
 			}
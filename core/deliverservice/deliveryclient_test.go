@@ -26,6 +26,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	"github.com/hyperledger/fabric/core/deliverservice/mocks"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -134,6 +135,107 @@ func TestNewDeliverService(t *testing.T) {
 	assert.Error(t, service.StopDeliverForChannel("TEST_CHAINID"), "Delivery service is stopping")
 }
 
+func TestDeliverServicePreferredEndpoint(t *testing.T) {
+	defer ensureNoGoroutineLeak(t)()
+	// Scenario: start delivery for 2 channels, each preferring a different endpoint.
+	// Each channel is expected to connect to its preferred endpoint.
+	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64, 2)}
+	blocksDeliverer := &mocks.MockBlocksDeliverer{}
+	blocksDeliverer.MockRecv = mocks.MockRecv
+	abcf := func(*grpc.ClientConn) orderer.AtomicBroadcastClient {
+		return &mocks.MockAtomicBroadcastClient{BD: blocksDeliverer}
+	}
+
+	var endpointsLock sync.Mutex
+	connectedEndpoints := make(map[string]string)
+	connFactory := func(channelID string) func(string) (*grpc.ClientConn, error) {
+		return func(endpoint string) (*grpc.ClientConn, error) {
+			endpointsLock.Lock()
+			connectedEndpoints[channelID] = endpoint
+			endpointsLock.Unlock()
+			lock.Lock()
+			defer lock.Unlock()
+			return newConnection(), nil
+		}
+	}
+	service, err := NewDeliverService(&Config{
+		Endpoints:   []string{"a", "b", "c"},
+		Gossip:      gossipServiceAdapter,
+		CryptoSvc:   &mockMCS{},
+		ABCFactory:  abcf,
+		ConnFactory: connFactory,
+	})
+	assert.NoError(t, err)
+	assert.Error(t, service.StartDeliverForChannelFrom("TEST_CHAINID", &mocks.MockLedgerInfo{Height: 0}, -1))
+	assert.NoError(t, service.StartDeliverForChannelFrom("TEST_CHAINID", &mocks.MockLedgerInfo{Height: 0}, 1))
+	// The index wraps around the endpoints
+	assert.NoError(t, service.StartDeliverForChannelFrom("TEST_CHAINID2", &mocks.MockLedgerInfo{Height: 0}, 5))
+
+	time.Sleep(time.Second)
+	endpointsLock.Lock()
+	assert.Equal(t, "b", connectedEndpoints["TEST_CHAINID"])
+	assert.Equal(t, "c", connectedEndpoints["TEST_CHAINID2"])
+	endpointsLock.Unlock()
+	service.Stop()
+	// Unblock the blocks providers waiting for their blocks to be gossiped
+	for i := 0; i < 2; i++ {
+		<-gossipServiceAdapter.GossipBlockDisseminations
+	}
+	time.Sleep(time.Duration(500) * time.Millisecond)
+}
+
+func TestDeliverServiceChannelEndpoints(t *testing.T) {
+	defer ensureNoGoroutineLeak(t)()
+	// Scenario: start delivery for 2 channels, one of them with endpoints of its own.
+	// The channel with endpoints of its own is expected to connect to one of them,
+	// the other channel to the endpoints the delivery service was created with.
+	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64, 2)}
+	blocksDeliverer := &mocks.MockBlocksDeliverer{}
+	blocksDeliverer.MockRecv = mocks.MockRecv
+	abcf := func(*grpc.ClientConn) orderer.AtomicBroadcastClient {
+		return &mocks.MockAtomicBroadcastClient{BD: blocksDeliverer}
+	}
+
+	var endpointsLock sync.Mutex
+	connectedEndpoints := make(map[string]string)
+	connFactory := func(channelID string) func(string) (*grpc.ClientConn, error) {
+		return func(endpoint string) (*grpc.ClientConn, error) {
+			endpointsLock.Lock()
+			connectedEndpoints[channelID] = endpoint
+			endpointsLock.Unlock()
+			lock.Lock()
+			defer lock.Unlock()
+			return newConnection(), nil
+		}
+	}
+	service, err := NewDeliverService(&Config{
+		Endpoints:   []string{"a"},
+		Gossip:      gossipServiceAdapter,
+		CryptoSvc:   &mockMCS{},
+		ABCFactory:  abcf,
+		ConnFactory: connFactory,
+	})
+	assert.NoError(t, err)
+	service.UpdateEndpoints("TEST_CHAINID", []string{"b", "c"})
+	service.UpdateEndpoints("TEST_CHAINID2", []string{"d"})
+	// Channels without endpoints fall back to the endpoints of the delivery service
+	service.UpdateEndpoints("TEST_CHAINID2", nil)
+	assert.NoError(t, service.StartDeliverForChannelFrom("TEST_CHAINID", &mocks.MockLedgerInfo{Height: 0}, 1))
+	assert.NoError(t, service.StartDeliverForChannel("TEST_CHAINID2", &mocks.MockLedgerInfo{Height: 0}))
+
+	time.Sleep(time.Second)
+	endpointsLock.Lock()
+	assert.Equal(t, "c", connectedEndpoints["TEST_CHAINID"])
+	assert.Equal(t, "a", connectedEndpoints["TEST_CHAINID2"])
+	endpointsLock.Unlock()
+	service.Stop()
+	// Unblock the blocks providers waiting for their blocks to be gossiped
+	for i := 0; i < 2; i++ {
+		<-gossipServiceAdapter.GossipBlockDisseminations
+	}
+	time.Sleep(time.Duration(500) * time.Millisecond)
+}
+
 func TestDeliverServiceRestart(t *testing.T) {
 	defer ensureNoGoroutineLeak(t)()
 	// Scenario: bring up ordering service instance, then shut it down, and then resurrect it.
@@ -445,13 +547,37 @@ func TestDeliverServiceBadConfig(t *testing.T) {
 	assert.Nil(t, service)
 }
 
+type mockBlocksProvider struct {
+	failovers []blocksprovider.Failover
+}
+
+func (*mockBlocksProvider) DeliverBlocks() {}
+
+func (*mockBlocksProvider) Stop() {}
+
+func (mock *mockBlocksProvider) Failovers() []blocksprovider.Failover {
+	return mock.failovers
+}
+
+func TestDeliverServiceFailovers(t *testing.T) {
+	failovers := []blocksprovider.Failover{{Time: time.Now(), Endpoint: "localhost:5611", LastBlock: 10, AdvertisedBlock: 12}}
+	service := &deliverServiceImpl{
+		blockProviders: map[string]blocksprovider.BlocksProvider{
+			"TEST_CHAINID": &mockBlocksProvider{failovers: failovers},
+		},
+	}
+	assert.Equal(t, failovers, service.Failovers("TEST_CHAINID"))
+	assert.Nil(t, service.Failovers("OTHER_CHAINID"), "Blocks aren't delivered for the channel, there should be no failovers")
+}
+
 func TestRetryPolicyOverflow(t *testing.T) {
 	connFactory := func(channelID string) func(endpoint string) (*grpc.ClientConn, error) {
 		return func(_ string) (*grpc.ClientConn, error) {
 			return nil, errors.New("")
 		}
 	}
-	client := (&deliverServiceImpl{conf: &Config{ConnFactory: connFactory}}).newClient("TEST", &mocks.MockLedgerInfo{Height: uint64(100)})
+	connProd := comm.NewConnectionProducer(connFactory("TEST"), []string{"a"})
+	client := (&deliverServiceImpl{conf: &Config{ConnFactory: connFactory}}).newClient("TEST", &mocks.MockLedgerInfo{Height: uint64(100)}, connProd)
 	assert.NotNil(t, client.shouldRetry)
 	for i := 0; i < 100; i++ {
 		retryTime, _ := client.shouldRetry(i, time.Second)
@@ -73,8 +73,9 @@ type MockBlocksDeliverer struct {
 	CloseCalled      chan struct{}
 	Pos              uint64
 	grpc.ClientStream
-	RecvCnt  int32
-	MockRecv func(mock *MockBlocksDeliverer) (*orderer.DeliverResponse, error)
+	RecvCnt              int32
+	DisabledEndpointsCnt int32
+	MockRecv             func(mock *MockBlocksDeliverer) (*orderer.DeliverResponse, error)
 }
 
 // Recv gets responses from the ordering service, currently mocked to return
@@ -123,10 +124,18 @@ func (mock *MockBlocksDeliverer) Send(env *common.Envelope) error {
 	return nil
 }
 
-func (mock *MockBlocksDeliverer) Disconnect() {
+func (mock *MockBlocksDeliverer) Disconnect(disableEndpoint bool) {
+	if disableEndpoint {
+		atomic.AddInt32(&mock.DisabledEndpointsCnt, 1)
+	}
 	mock.DisconnectCalled <- struct{}{}
 }
 
+// GetEndpoint returns the endpoint the mock is connected to
+func (mock *MockBlocksDeliverer) GetEndpoint() string {
+	return "localhost:5611"
+}
+
 func (mock *MockBlocksDeliverer) Close() {
 	if mock.CloseCalled == nil {
 		return
@@ -17,10 +17,11 @@ import (
 
 	"errors"
 
-	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/validation"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -56,25 +57,28 @@ var endorserLogger = flogging.MustGetLogger("endorser")
 
 // Endorser provides the Endorser service ProcessProposal
 type Endorser struct {
-	policyChecker policy.PolicyChecker
+	aclProvider aclmgmt.ACLProvider
 }
 
 // NewEndorserServer creates and returns a new Endorser server instance.
 func NewEndorserServer() pb.EndorserServer {
 	e := new(Endorser)
-	e.policyChecker = policy.NewPolicyChecker(
-		peer.NewChannelPolicyManagerGetter(),
-		mgmt.GetLocalMSP(),
-		mgmt.NewLocalMSPPrincipalGetter(),
+	e.aclProvider = aclmgmt.NewDefaultACLProvider(
+		policy.NewPolicyChecker(
+			peer.NewChannelPolicyManagerGetter(),
+			mgmt.GetLocalMSP(),
+			mgmt.NewLocalMSPPrincipalGetter(),
+		),
+		peer.GetPolicyRefForAPI,
 	)
 
 	return e
 }
 
 // checkACL checks that the supplied proposal complies
-// with the writers policy of the chain
+// with the ACL of the chain for submitting proposals
 func (e *Endorser) checkACL(signedProp *pb.SignedProposal, chdr *common.ChannelHeader, shdr *common.SignatureHeader, hdrext *pb.ChaincodeHeaderExtension) error {
-	return e.policyChecker.CheckPolicy(chdr.ChannelId, policies.ChannelApplicationWriters, signedProp)
+	return e.aclProvider.CheckACL(aclmgmt.Propose, chdr.ChannelId, signedProp)
 }
 
 //TODO - check for escc and vscc
@@ -409,6 +413,14 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
 	}
 	endorserLogger.Debugf("processing txid: %s", txid)
+
+	// a proposal bound to a TLS certificate must be sent by the client presenting it,
+	// which channels requiring the binding make mandatory
+	if err = comm.VerifyTLSBinding(ctx, chdr.TlsCertHash, peer.TLSBindingRequired(chainID)); err != nil {
+		endorserLogger.Warningf("Rejecting proposal %s: %s", txid, err)
+		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+	}
+
 	if chainID != "" {
 		// here we handle uniqueness check and ACLs for proposals targeting a chain
 		lgr := peer.GetLedger(chainID)
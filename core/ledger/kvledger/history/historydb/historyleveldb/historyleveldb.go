@@ -53,6 +53,11 @@ func (provider *HistoryDBProvider) GetDBHandle(dbName string) (historydb.History
 	return newHistoryDB(provider.dbProvider.GetDBHandle(dbName), dbName), nil
 }
 
+// Remove deletes all the keys of a named database
+func (provider *HistoryDBProvider) Remove(dbName string) error {
+	return provider.dbProvider.GetDBHandle(dbName).DeleteAll(true)
+}
+
 // Close closes the underlying db
 func (provider *HistoryDBProvider) Close() {
 	provider.dbProvider.Close()
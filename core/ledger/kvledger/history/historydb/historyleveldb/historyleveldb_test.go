@@ -96,6 +96,28 @@ func TestSavepoint(t *testing.T) {
 	testutil.AssertEquals(t, blockNum, uint64(3))
 }
 
+func TestRemove(t *testing.T) {
+	env := NewTestHistoryEnv(t)
+	defer env.cleanup()
+
+	otherHistoryDB, err := env.testHistoryDBProvider.GetDBHandle("OtherHistoryDB")
+	testutil.AssertNoError(t, err, "")
+
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	testutil.AssertNoError(t, env.testHistoryDB.Commit(gb), "")
+	testutil.AssertNoError(t, otherHistoryDB.Commit(gb), "")
+
+	testutil.AssertNoError(t, env.testHistoryDBProvider.Remove("TestHistoryDB"), "")
+
+	// the savepoint of the removed db is gone, the one of the other db is kept
+	savepoint, err := env.testHistoryDB.GetLastSavepoint()
+	testutil.AssertNoError(t, err, "Error upon historyDatabase.GetLastSavepoint()")
+	testutil.AssertNil(t, savepoint)
+	savepoint, err = otherHistoryDB.GetLastSavepoint()
+	testutil.AssertNoError(t, err, "Error upon historyDatabase.GetLastSavepoint()")
+	testutil.AssertEquals(t, savepoint.BlockNum, uint64(0))
+}
+
 func TestHistory(t *testing.T) {
 
 	env := NewTestHistoryEnv(t)
@@ -38,6 +38,10 @@ import (
 var kvledger_log, _ = os.Create("/root/kvledger.log")
 var logger = flogging.MustGetLogger("kvledger")
 
+// recommitProgressInterval is the number of blocks between two progress
+// reports while recovering the databases
+const recommitProgressInterval = 1000
+
 // KVLedger provides an implementation of `ledger.PeerLedger`.
 // This implementation provides a key-value based data model
 type kvLedger struct {
@@ -87,6 +91,12 @@ func (l *kvLedger) recoverDBs() error {
 		if err != nil {
 			return err
 		}
+		if recoverFlag && firstBlockNum > lastAvailableBlockNum+1 {
+			// the db holds blocks the block storage has lost, which can't be
+			// undone by recommitting blocks
+			return fmt.Errorf("Database is ahead of block storage, at block [%d] while the last block is [%d]. Run \"peer node rebuild-dbs\" to regenerate it",
+				firstBlockNum-1, lastAvailableBlockNum)
+		}
 		if recoverFlag {
 			recoverers = append(recoverers, &recoverer{firstBlockNum, recoverable})
 		}
@@ -120,6 +130,7 @@ func (l *kvLedger) recoverDBs() error {
 func (l *kvLedger) recommitLostBlocks(firstBlockNum uint64, lastBlockNum uint64, recoverables ...recoverable) error {
 	var err error
 	var block *common.Block
+	logger.Infof("Recommitting blocks [%d] to [%d] of ledger [%s]", firstBlockNum, lastBlockNum, l.ledgerID)
 	for blockNumber := firstBlockNum; blockNumber <= lastBlockNum; blockNumber++ {
 		if block, err = l.GetBlockByNumber(blockNumber); err != nil {
 			return err
@@ -129,6 +140,9 @@ func (l *kvLedger) recommitLostBlocks(firstBlockNum uint64, lastBlockNum uint64,
 				return err
 			}
 		}
+		if (blockNumber+1)%recommitProgressInterval == 0 {
+			logger.Infof("Recommitted block [%d] of [%d] of ledger [%s]", blockNumber, lastBlockNum, l.ledgerID)
+		}
 	}
 	return nil
 }
@@ -46,6 +46,7 @@ var (
 	ErrLedgerNotOpened = errors.New("Ledger is not opened yet")
 
 	underConstructionLedgerKey = []byte("underConstructionLedgerKey")
+	underDeletionLedgerKey     = []byte("underDeletionLedgerKey")
 	ledgerKeyPrefix            = []byte("l")
 )
 
@@ -100,6 +101,7 @@ func NewProvider() (ledger.PeerLedgerProvider, error) {
 
 	logger.Info("ledger provider Initialized")
 	provider := &Provider{idStore, blockStoreProvider, vdbProvider, historydbProvider}
+	provider.recoverUnderDeletionLedger()
 	provider.recoverUnderConstructionLedger()
 	return provider, nil
 }
@@ -191,6 +193,47 @@ func (provider *Provider) List() ([]string, error) {
 	return provider.idStore.getAllLedgerIds()
 }
 
+// Remove implements the corresponding method from interface ledger.PeerLedgerProvider
+// This function removes the ledger id from the created ledgers list and sets a under deletion flag (atomically)
+// before removing the blockstore, statedb, and historydb of the ledger, and finally unsets the flag. If a crash
+// happens in between, the 'recoverUnderDeletionLedger' function is invoked before declaring the provider to be usable
+func (provider *Provider) Remove(ledgerID string) error {
+	exists, err := provider.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNonExistingLedgerID
+	}
+	if err = provider.idStore.deleteLedgerID(ledgerID); err != nil {
+		return err
+	}
+	if err = provider.removeLedgerData(ledgerID); err != nil {
+		return err
+	}
+	return provider.idStore.unsetUnderDeletionFlag()
+}
+
+// removeLedgerData removes the blockstore, statedb, and historydb of a ledger
+func (provider *Provider) removeLedgerData(ledgerID string) error {
+	if err := provider.blockStoreProvider.Remove(ledgerID); err != nil {
+		return fmt.Errorf("Error while removing the block store of ledger [%s]: %s", ledgerID, err)
+	}
+	return provider.removeDBs(ledgerID)
+}
+
+// removeDBs removes the statedb and historydb of a ledger, which get rebuilt
+// from the blockstore when the ledger is opened again
+func (provider *Provider) removeDBs(ledgerID string) error {
+	if err := provider.vdbProvider.Remove(ledgerID); err != nil {
+		return fmt.Errorf("Error while removing the state database of ledger [%s]: %s", ledgerID, err)
+	}
+	if err := provider.historydbProvider.Remove(ledgerID); err != nil {
+		return fmt.Errorf("Error while removing the history database of ledger [%s]: %s", ledgerID, err)
+	}
+	return nil
+}
+
 // Close implements the corresponding method from interface ledger.PeerLedgerProvider
 func (provider *Provider) Close() {
 	provider.idStore.close()
@@ -236,6 +279,22 @@ func (provider *Provider) recoverUnderConstructionLedger() {
 	return
 }
 
+// recoverUnderDeletionLedger checks whether the under deletion flag is set - this would be the case if a crash
+// had happened during the removal of a ledger. Recovery removes what is left of the data of the ledger and
+// clears the under deletion flag
+func (provider *Provider) recoverUnderDeletionLedger() {
+	logger.Debugf("Recovering under deletion ledger")
+	ledgerID, err := provider.idStore.getUnderDeletionFlag()
+	panicOnErr(err, "Error while checking whether the under deletion flag is set")
+	if ledgerID == "" {
+		logger.Debugf("No under deletion ledger found. Quitting recovery")
+		return
+	}
+	logger.Infof("ledger [%s] found as under deletion, completing its removal", ledgerID)
+	panicOnErr(provider.removeLedgerData(ledgerID), "Error while removing ledger [%s]", ledgerID)
+	panicOnErr(provider.idStore.unsetUnderDeletionFlag(), "Error while unsetting under deletion flag")
+}
+
 // runCleanup cleans up blockstorage, statedb, and historydb for what
 // may have got created during in-complete ledger creation
 func (provider *Provider) runCleanup(ledgerID string) error {
@@ -303,6 +362,25 @@ func (s *idStore) createLedgerID(ledgerID string, gb *common.Block) error {
 	return s.db.WriteBatch(batch, true)
 }
 
+func (s *idStore) deleteLedgerID(ledgerID string) error {
+	batch := &leveldb.Batch{}
+	batch.Delete(s.encodeLedgerKey(ledgerID))
+	batch.Put(underDeletionLedgerKey, []byte(ledgerID))
+	return s.db.WriteBatch(batch, true)
+}
+
+func (s *idStore) unsetUnderDeletionFlag() error {
+	return s.db.Delete(underDeletionLedgerKey, true)
+}
+
+func (s *idStore) getUnderDeletionFlag() (string, error) {
+	val, err := s.db.Get(underDeletionLedgerKey)
+	if err != nil {
+		return "", err
+	}
+	return string(val), nil
+}
+
 func (s *idStore) ledgerIDExists(ledgerID string) (bool, error) {
 	key := s.encodeLedgerKey(ledgerID)
 	val := []byte{}
@@ -318,11 +396,10 @@ func (s *idStore) getAllLedgerIds() ([]string, error) {
 	itr := s.db.GetIterator(nil, nil)
 	itr.First()
 	for itr.Valid() {
-		if bytes.Equal(itr.Key(), underConstructionLedgerKey) {
-			continue
+		// skip the under construction and under deletion flags
+		if bytes.HasPrefix(itr.Key(), ledgerKeyPrefix) {
+			ids = append(ids, s.decodeLedgerID(itr.Key()))
 		}
-		id := string(s.decodeLedgerID(itr.Key()))
-		ids = append(ids, id)
 		itr.Next()
 	}
 	return ids, nil
@@ -122,6 +122,83 @@ func TestRecovery(t *testing.T) {
 
 }
 
+func TestLedgerRemove(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+	defer provider.Close()
+
+	for i := 0; i < 2; i++ {
+		bg, gb := testutil.NewBlockGenerator(t, constructTestLedgerID(i), false)
+		l, err := provider.Create(gb)
+		testutil.AssertNoError(t, err, "")
+		s, _ := l.NewTxSimulator()
+		s.SetState("ns", "testKey", []byte(fmt.Sprintf("testValue_%d", i)))
+		s.Done()
+		res, _ := s.GetTxSimulationResults()
+		testutil.AssertNoError(t, l.Commit(bg.NextBlock([][]byte{res})), "")
+		l.Close()
+	}
+
+	testutil.AssertNoError(t, provider.Remove(constructTestLedgerID(0)), "")
+	testutil.AssertEquals(t, provider.Remove(constructTestLedgerID(0)), ErrNonExistingLedgerID)
+	ledgerIds, _ := provider.List()
+	testutil.AssertEquals(t, ledgerIds, []string{constructTestLedgerID(1)})
+	_, err := provider.Open(constructTestLedgerID(0))
+	testutil.AssertEquals(t, err, ErrNonExistingLedgerID)
+
+	// the ledger can be created again from scratch
+	bg, gb := testutil.NewBlockGenerator(t, constructTestLedgerID(0), false)
+	l, err := provider.Create(gb)
+	testutil.AssertNoError(t, err, "")
+	bcInfo, _ := l.GetBlockchainInfo()
+	testutil.AssertEquals(t, bcInfo.Height, uint64(1))
+	q, _ := l.NewQueryExecutor()
+	val, err := q.GetState("ns", "testKey")
+	q.Done()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertNil(t, val)
+	testutil.AssertNoError(t, l.Commit(bg.NextBlock([][]byte{})), "")
+	l.Close()
+
+	// the other ledger is untouched
+	l, err = provider.Open(constructTestLedgerID(1))
+	testutil.AssertNoError(t, err, "")
+	defer l.Close()
+	q, _ = l.NewQueryExecutor()
+	val, err = q.GetState("ns", "testKey")
+	q.Done()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, val, []byte("testValue_1"))
+}
+
+func TestRemoveRecovery(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+	genesisBlock, _ := configtxtest.MakeGenesisBlock(constructTestLedgerID(1))
+	ledger, err := provider.Create(genesisBlock)
+	testutil.AssertNoError(t, err, "")
+	ledger.Close()
+
+	// assume a crash happens after the ledger was marked as under deletion, before its data was removed
+	testutil.AssertNoError(t, provider.(*Provider).idStore.deleteLedgerID(constructTestLedgerID(1)), "")
+	provider.Close()
+
+	// construct a new provider to invoke recovery
+	provider, err = NewProvider()
+	testutil.AssertNoError(t, err, "Provider failed to recover an underDeletionLedger")
+	defer provider.Close()
+	flag, err := provider.(*Provider).idStore.getUnderDeletionFlag()
+	testutil.AssertNoError(t, err, "Failed to read the under deletion flag")
+	testutil.AssertEquals(t, flag, "")
+	exists, err := provider.(*Provider).blockStoreProvider.Exists(constructTestLedgerID(1))
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, exists, false)
+	ledgerIds, _ := provider.List()
+	testutil.AssertEquals(t, len(ledgerIds), 0)
+}
+
 func TestMultipleLedgerBasicRW(t *testing.T) {
 	env := newTestEnv(t)
 	defer env.cleanup()
@@ -0,0 +1,152 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+)
+
+// RebuildDBs drops the state, history and block index databases of every
+// ledger and regenerates them from the block files. The ledgers must not be in
+// use, i.e. the peer must be stopped
+func RebuildDBs() error {
+	provider, err := openOffline()
+	if err != nil {
+		return err
+	}
+	defer provider.Close()
+	ledgerIDs, err := provider.List()
+	if err != nil {
+		return err
+	}
+	for i, ledgerID := range ledgerIDs {
+		logger.Infof("Rebuilding the databases of ledger [%s] (%d of %d)", ledgerID, i+1, len(ledgerIDs))
+		if err := provider.blockStoreProvider.DropIndex(ledgerID); err != nil {
+			return fmt.Errorf("Error while dropping the block index of ledger [%s]: %s", ledgerID, err)
+		}
+		if err := provider.removeDBs(ledgerID); err != nil {
+			return err
+		}
+		if err := provider.recoverDBs(ledgerID); err != nil {
+			return err
+		}
+	}
+	logger.Infof("Rebuilt the databases of %d ledgers", len(ledgerIDs))
+	return nil
+}
+
+// RollbackKVLedger truncates a ledger after block blockNum and regenerates its
+// state and history databases. The ledgers must not be in use, i.e. the peer
+// must be stopped
+func RollbackKVLedger(ledgerID string, blockNum uint64) error {
+	provider, err := openOffline()
+	if err != nil {
+		return err
+	}
+	defer provider.Close()
+	exists, err := provider.Exists(ledgerID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNonExistingLedgerID
+	}
+	logger.Infof("Rolling back ledger [%s] to block [%d]", ledgerID, blockNum)
+	if err := provider.rollback(ledgerID, blockNum); err != nil {
+		return err
+	}
+	logger.Infof("Rolled back ledger [%s] to block [%d]", ledgerID, blockNum)
+	return nil
+}
+
+// ResetAllKVLedgers truncates every ledger to its genesis block and
+// regenerates the state and history databases. The ledgers must not be in
+// use, i.e. the peer must be stopped
+func ResetAllKVLedgers() error {
+	provider, err := openOffline()
+	if err != nil {
+		return err
+	}
+	defer provider.Close()
+	ledgerIDs, err := provider.List()
+	if err != nil {
+		return err
+	}
+	for i, ledgerID := range ledgerIDs {
+		logger.Infof("Resetting ledger [%s] to its genesis block (%d of %d)", ledgerID, i+1, len(ledgerIDs))
+		if err := provider.rollback(ledgerID, 0); err != nil {
+			return err
+		}
+	}
+	logger.Infof("Reset %d ledgers to their genesis block", len(ledgerIDs))
+	return nil
+}
+
+// rollback truncates the block store of a ledger after block blockNum and
+// regenerates its state and history databases. The databases are removed
+// before the block store gets truncated, so that they are rebuilt from what
+// the block store holds should a crash happen in between
+func (provider *Provider) rollback(ledgerID string, blockNum uint64) error {
+	blockStore, err := provider.blockStoreProvider.OpenBlockStore(ledgerID)
+	if err != nil {
+		return err
+	}
+	bcInfo, err := blockStore.GetBlockchainInfo()
+	blockStore.Shutdown()
+	if err != nil {
+		return err
+	}
+	if blockNum >= bcInfo.Height {
+		return fmt.Errorf("Cannot roll back ledger [%s] to block [%d], the height of the ledger is [%d]", ledgerID, blockNum, bcInfo.Height)
+	}
+
+	if err := provider.removeDBs(ledgerID); err != nil {
+		return err
+	}
+	if err := provider.blockStoreProvider.Rollback(ledgerID, blockNum); err != nil {
+		return fmt.Errorf("Error while rolling back the block store of ledger [%s]: %s", ledgerID, err)
+	}
+	return provider.recoverDBs(ledgerID)
+}
+
+// recoverDBs opens a ledger, which rebuilds the block index, state and history
+// databases removed, and closes it
+func (provider *Provider) recoverDBs(ledgerID string) error {
+	l, err := provider.openInternal(ledgerID)
+	if err != nil {
+		return err
+	}
+	l.Close()
+	return nil
+}
+
+// openOffline opens the ledger provider for the maintenance of the ledgers,
+// after checking that no peer has them opened
+func openOffline() (*Provider, error) {
+	path := ledgerconfig.GetLedgerProviderPath()
+	exists, _, err := util.FileExists(path)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		// a running peer holds the lock of the ledger provider database
+		s, err := storage.OpenFile(path, true)
+		if err != nil {
+			return nil, fmt.Errorf("Ledgers at [%s] are in use, the peer must be stopped: %s", ledgerconfig.GetRootPath(), err)
+		}
+		s.Close()
+	}
+	provider, err := NewProvider()
+	if err != nil {
+		return nil, err
+	}
+	return provider.(*Provider), nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/spf13/viper"
+)
+
+// populateTestLedger creates a ledger holding a genesis block followed by
+// numBlocks blocks, block i setting key1 to "value<i>"
+func populateTestLedger(t *testing.T, provider ledger.PeerLedgerProvider, ledgerID string, numBlocks int) []*common.Block {
+	bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+	l, err := provider.Create(gb)
+	testutil.AssertNoError(t, err, "")
+	defer l.Close()
+	blocks := []*common.Block{gb}
+	for i := 1; i <= numBlocks; i++ {
+		simulator, _ := l.NewTxSimulator()
+		simulator.SetState("ns1", "key1", []byte(fmt.Sprintf("value%d", i)))
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		block := bg.NextBlock([][]byte{simRes})
+		testutil.AssertNoError(t, l.Commit(block), "")
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// checkTestLedger checks that a ledger populated by populateTestLedger holds
+// the given blocks only, along with the matching state and history
+func checkTestLedger(t *testing.T, provider ledger.PeerLedgerProvider, ledgerID string, blocks []*common.Block) {
+	l, err := provider.Open(ledgerID)
+	testutil.AssertNoError(t, err, "")
+	defer l.Close()
+
+	bcInfo, _ := l.GetBlockchainInfo()
+	testutil.AssertEquals(t, bcInfo.Height, uint64(len(blocks)))
+	for _, block := range blocks {
+		b, err := l.GetBlockByHash(block.Header.Hash())
+		testutil.AssertNoError(t, err, "")
+		testutil.AssertEquals(t, b, block)
+	}
+
+	qe, _ := l.NewQueryExecutor()
+	defer qe.Done()
+	value, _ := qe.GetState("ns1", "key1")
+	if len(blocks) == 1 {
+		testutil.AssertNil(t, value)
+	} else {
+		testutil.AssertEquals(t, value, []byte(fmt.Sprintf("value%d", len(blocks)-1)))
+	}
+
+	hqe, err := l.NewHistoryQueryExecutor()
+	testutil.AssertNoError(t, err, "")
+	itr, err := hqe.GetHistoryForKey("ns1", "key1")
+	testutil.AssertNoError(t, err, "")
+	defer itr.Close()
+	for i := 1; i < len(blocks); i++ {
+		result, err := itr.Next()
+		testutil.AssertNoError(t, err, "")
+		testutil.AssertEquals(t, result.(*queryresult.KeyModification).Value, []byte(fmt.Sprintf("value%d", i)))
+	}
+	result, err := itr.Next()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertNil(t, result)
+}
+
+func TestRebuildDBs(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+
+	provider, _ := NewProvider()
+	blocks1 := populateTestLedger(t, provider, "ledger1", 5)
+	blocks2 := populateTestLedger(t, provider, "ledger2", 3)
+
+	// The ledgers can't be maintained while in use
+	testutil.AssertError(t, RebuildDBs(), "Expected an error while the ledgers are in use")
+	provider.Close()
+
+	testutil.AssertNoError(t, RebuildDBs(), "")
+
+	provider, _ = NewProvider()
+	defer provider.Close()
+	checkTestLedger(t, provider, "ledger1", blocks1)
+	checkTestLedger(t, provider, "ledger2", blocks2)
+}
+
+func TestRollbackKVLedger(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+
+	provider, _ := NewProvider()
+	blocks := populateTestLedger(t, provider, "ledger1", 5)
+	provider.Close()
+
+	testutil.AssertEquals(t, RollbackKVLedger("nonexisting", 1), ErrNonExistingLedgerID)
+	testutil.AssertError(t, RollbackKVLedger("ledger1", 6), "Expected an error rolling back beyond the last block")
+	testutil.AssertNoError(t, RollbackKVLedger("ledger1", 2), "")
+
+	provider, _ = NewProvider()
+	defer provider.Close()
+	checkTestLedger(t, provider, "ledger1", blocks[:3])
+
+	// The blocks rolled back can be committed again
+	l, err := provider.Open("ledger1")
+	testutil.AssertNoError(t, err, "")
+	for _, block := range blocks[3:] {
+		testutil.AssertNoError(t, l.Commit(block), "")
+	}
+	l.Close()
+	checkTestLedger(t, provider, "ledger1", blocks)
+}
+
+func TestResetAllKVLedgers(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+
+	provider, _ := NewProvider()
+	blocks1 := populateTestLedger(t, provider, "ledger1", 5)
+	blocks2 := populateTestLedger(t, provider, "ledger2", 0)
+	provider.Close()
+
+	testutil.AssertNoError(t, ResetAllKVLedgers(), "")
+
+	provider, _ = NewProvider()
+	defer provider.Close()
+	checkTestLedger(t, provider, "ledger1", blocks1[:1])
+	checkTestLedger(t, provider, "ledger2", blocks2)
+}
+
+func TestDBsAheadOfBlockStore(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	provider, _ := NewProvider()
+	populateTestLedger(t, provider, "ledger1", 5)
+	// Truncate the block store without removing the databases
+	p := provider.(*Provider)
+	testutil.AssertNoError(t, p.blockStoreProvider.Rollback("ledger1", 2), "")
+	defer provider.Close()
+
+	defer func() {
+		testutil.AssertNotNil(t, recover())
+	}()
+	provider.Open("ledger1")
+}
@@ -132,6 +132,36 @@ func TestMultiDBBasicRW(t *testing.T, dbProvider statedb.VersionedDBProvider) {
 	testutil.AssertEquals(t, sp, savePoint2)
 }
 
+// TestRemove tests removing a db, while the other dbs are kept
+func TestRemove(t *testing.T, dbProvider statedb.VersionedDBProvider) {
+	db1, err := dbProvider.GetDBHandle("testremove")
+	testutil.AssertNoError(t, err, "")
+	db2, err := dbProvider.GetDBHandle("testremove2")
+	testutil.AssertNoError(t, err, "")
+
+	vv := statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}
+	for _, db := range []statedb.VersionedDB{db1, db2} {
+		batch := statedb.NewUpdateBatch()
+		batch.Put("ns1", "key1", vv.Value, vv.Version)
+		testutil.AssertNoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 1)), "")
+	}
+
+	testutil.AssertNoError(t, dbProvider.Remove("testremove"), "")
+
+	db1, err = dbProvider.GetDBHandle("testremove")
+	testutil.AssertNoError(t, err, "")
+	val, err := db1.GetState("ns1", "key1")
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertNil(t, val)
+	sp, err := db1.GetLatestSavePoint()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertNil(t, sp)
+
+	val, err = db2.GetState("ns1", "key1")
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, val, &vv)
+}
+
 // TestDeletes tests deteles
 func TestDeletes(t *testing.T, dbProvider statedb.VersionedDBProvider) {
 	db, err := dbProvider.GetDBHandle("testdeletes")
@@ -82,6 +82,21 @@ func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.Version
 	return vdb, nil
 }
 
+// Remove drops the CouchDB database backing a named database
+func (provider *VersionedDBProvider) Remove(dbName string) error {
+	vdb, err := provider.GetDBHandle(dbName)
+	if err != nil {
+		return err
+	}
+	if _, err = vdb.(*VersionedDB).db.DropDatabase(); err != nil {
+		return err
+	}
+	provider.mux.Lock()
+	defer provider.mux.Unlock()
+	delete(provider.databases, dbName)
+	return nil
+}
+
 // Close closes the underlying db instance
 func (provider *VersionedDBProvider) Close() {
 	// No close needed on Couch
@@ -90,6 +90,17 @@ func TestDeletes(t *testing.T) {
 	}
 }
 
+func TestRemove(t *testing.T) {
+	if ledgerconfig.IsCouchDBEnabled() == true {
+		env := NewTestVDBEnv(t)
+		env.Cleanup("testremove")
+		env.Cleanup("testremove2")
+		defer env.Cleanup("testremove")
+		defer env.Cleanup("testremove2")
+		commontests.TestRemove(t, env.DBProvider)
+	}
+}
+
 func TestIterator(t *testing.T) {
 	if ledgerconfig.IsCouchDBEnabled() == true {
 
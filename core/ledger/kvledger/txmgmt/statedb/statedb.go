@@ -27,6 +27,8 @@ import (
 type VersionedDBProvider interface {
 	// GetDBHandle returns a handle to a VersionedDB
 	GetDBHandle(id string) (VersionedDB, error)
+	// Remove deletes the VersionedDB with the given id. Any handle to this VersionedDB must not be used afterwards
+	Remove(id string) error
 	// Close closes all the VersionedDB instances and releases any resources held by VersionedDBProvider
 	Close()
 }
@@ -52,6 +52,11 @@ func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.Version
 	return newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName), nil
 }
 
+// Remove deletes all the keys of a named database
+func (provider *VersionedDBProvider) Remove(dbName string) error {
+	return provider.dbProvider.GetDBHandle(dbName).DeleteAll(true)
+}
+
 // Close closes the underlying db
 func (provider *VersionedDBProvider) Close() {
 	provider.dbProvider.Close()
@@ -50,6 +50,12 @@ func TestDeletes(t *testing.T) {
 	commontests.TestDeletes(t, env.DBProvider)
 }
 
+func TestRemove(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestRemove(t, env.DBProvider)
+}
+
 func TestIterator(t *testing.T) {
 	env := NewTestVDBEnv(t)
 	defer env.Cleanup()
@@ -34,6 +34,9 @@ type PeerLedgerProvider interface {
 	Exists(ledgerID string) (bool, error)
 	// List lists the ids of the existing ledgers
 	List() ([]string, error)
+	// Remove removes the ledger with the given id along with all its data.
+	// The ledger must have been closed beforehand
+	Remove(ledgerID string) error
 	// Close closes the PeerLedgerProvider
 	Close()
 }
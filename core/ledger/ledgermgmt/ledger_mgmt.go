@@ -121,6 +121,24 @@ func GetLedgerIDs() ([]string, error) {
 	return ledgerProvider.List()
 }
 
+// RemoveLedger closes the ledger with the given id, if opened, and removes it along with all its data
+func RemoveLedger(id string) error {
+	logger.Infof("Removing ledger with id = %s", id)
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return ErrLedgerMgmtNotInitialized
+	}
+	if l, ok := openedLedgers[id]; ok {
+		l.(*closableLedger).closeWithoutLock()
+	}
+	if err := ledgerProvider.Remove(id); err != nil {
+		return err
+	}
+	logger.Infof("Removed ledger with id = %s", id)
+	return nil
+}
+
 // Close closes all the opened ledgers and any resources held for ledger management
 func Close() {
 	logger.Infof("Closing ledger mgmt")
@@ -25,6 +25,7 @@ import (
 	"github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/spf13/viper"
 )
 
@@ -81,6 +82,18 @@ func TestLedgerMgmt(t *testing.T) {
 	l, err = OpenLedger(ledgerID)
 	testutil.AssertEquals(t, err, ErrLedgerAlreadyOpened)
 
+	// remove an opened ledger, and a closed one
+	testutil.AssertNoError(t, RemoveLedger(ledgerID), "")
+	ledgers[3].Close()
+	testutil.AssertNoError(t, RemoveLedger(constructTestLedgerID(3)), "")
+	ids, _ = GetLedgerIDs()
+	testutil.AssertEquals(t, len(ids), numLedgers-2)
+	_, err = OpenLedger(ledgerID)
+	testutil.AssertEquals(t, err, kvledger.ErrNonExistingLedgerID)
+	gb, _ = test.MakeGenesisBlock(ledgerID)
+	l, err = CreateLedger(gb)
+	testutil.AssertNoError(t, err, "")
+
 	// close all opened ledgers and ledger mgmt
 	Close()
 
@@ -17,6 +17,8 @@ limitations under the License.
 package support
 
 import (
+	"github.com/hyperledger/fabric/common/config"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -25,9 +27,11 @@ import (
 )
 
 type Support struct {
-	LedgerVal     ledger.PeerLedger
-	MSPManagerVal msp.MSPManager
-	ApplyVal      error
+	LedgerVal        ledger.PeerLedger
+	MSPManagerVal    msp.MSPManager
+	ApplyVal         error
+	ChannelConfigVal *mockconfig.Channel
+	CapabilitiesVal  *mockconfig.ApplicationCapabilities
 }
 
 // Ledger returns LedgerVal
@@ -52,3 +56,21 @@ func (ms *Support) PolicyManager() policies.Manager {
 func (cs *Support) GetMSPIDs(cid string) []string {
 	return []string{"DEFAULT"}
 }
+
+// ChannelConfig returns ChannelConfigVal if set, otherwise a channel config
+// whose capabilities are all supported
+func (ms *Support) ChannelConfig() config.Channel {
+	if ms.ChannelConfigVal == nil {
+		return &mockconfig.Channel{CapabilitiesVal: &mockconfig.ChannelCapabilities{}}
+	}
+	return ms.ChannelConfigVal
+}
+
+// Capabilities returns CapabilitiesVal if set, otherwise capabilities which
+// are all supported and enable no new behavior
+func (ms *Support) Capabilities() config.ApplicationCapabilities {
+	if ms.CapabilitiesVal == nil {
+		return &mockconfig.ApplicationCapabilities{}
+	}
+	return ms.CapabilitiesVal
+}
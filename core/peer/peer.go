@@ -10,8 +10,10 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"sync"
 
+	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/common/configtx"
 	configtxapi "github.com/hyperledger/fabric/common/configtx/api"
@@ -57,6 +59,38 @@ func (cs *chainSupport) GetMSPIDs(cid string) []string {
 	return GetMSPIDs(cid)
 }
 
+// Capabilities returns the application capabilities of the current channel config
+func (cs *chainSupport) Capabilities() config.ApplicationCapabilities {
+	ac, ok := cs.ApplicationConfig()
+	if !ok || ac == nil {
+		return capabilities.NewApplicationProvider(nil)
+	}
+	return ac.Capabilities()
+}
+
+// channelCapabilities returns the channel capabilities of the current channel config
+func (cs *chainSupport) channelCapabilities() config.ChannelCapabilities {
+	cc := cs.ChannelConfig()
+	if cc == nil {
+		return capabilities.NewChannelProvider(nil)
+	}
+	return cc.Capabilities()
+}
+
+// capabilitiesSupported checks that this peer supports the capabilities
+// required by the channel and application config of the channel
+func capabilitiesSupported(res configtxapi.Resources) error {
+	if err := res.ChannelConfig().Capabilities().Supported(); err != nil {
+		return err
+	}
+
+	ac, ok := res.ApplicationConfig()
+	if !ok || ac == nil {
+		return nil
+	}
+	return ac.Capabilities().Supported()
+}
+
 // chain is a local struct to manage objects in a chain
 type chain struct {
 	cs        *chainSupport
@@ -182,6 +216,7 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block) error {
 			Manager:     cm,
 			Application: ac,
 		})
+		service.GetGossipService().UpdateEndpoints(cm.ChainID(), ordererEndpoints(cm))
 		service.GetGossipService().SuspectPeers(func(identity api.PeerIdentityType) bool {
 			// TODO: this is a place-holder that would somehow make the MSP layer suspect
 			// that a given certificate is revoked, or its intermediate CA is revoked.
@@ -204,6 +239,10 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block) error {
 		return err
 	}
 
+	if err := capabilitiesSupported(configtxManager); err != nil {
+		return fmt.Errorf("Channel %s requires capabilities which this peer does not support: %s", cid, err)
+	}
+
 	// TODO remove once all references to mspmgmt are gone from peer code
 	mspmgmt.XXXSetMSPManager(cid, configtxManager.MSPManager())
 
@@ -225,7 +264,7 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block) error {
 		return SetCurrConfigBlock(block, chainID)
 	})
 
-	ordererAddresses := configtxManager.ChannelConfig().OrdererAddresses()
+	ordererAddresses := ordererEndpoints(configtxManager)
 	if len(ordererAddresses) == 0 {
 		return errors.New("No ordering service endpoint provided in configuration block")
 	}
@@ -241,6 +280,28 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block) error {
 	return nil
 }
 
+// ordererEndpoints returns the endpoints the orderer orgs of the channel define,
+// or the channel wide orderer addresses if no orderer org defines any
+func ordererEndpoints(cm configtxapi.Manager) []string {
+	var endpoints []string
+	if oc, ok := cm.OrdererConfig(); ok {
+		orgs := oc.Organizations()
+		names := make([]string, 0, len(orgs))
+		for name := range orgs {
+			names = append(names, name)
+		}
+		// Keep the same order of endpoints on all peers
+		sort.Strings(names)
+		for _, name := range names {
+			endpoints = append(endpoints, orgs[name].Endpoints()...)
+		}
+	}
+	if len(endpoints) == 0 {
+		return cm.ChannelConfig().OrdererAddresses()
+	}
+	return endpoints
+}
+
 // CreateChainFromBlock creates a new chain from config block
 func CreateChainFromBlock(cb *common.Block) error {
 	cid, err := utils.GetChainIDFromBlock(cb)
@@ -256,6 +317,31 @@ func CreateChainFromBlock(cb *common.Block) error {
 	return createChain(cid, l, cb)
 }
 
+// LeaveChain stops the gossip and blocks delivery of the chain, drops the chain
+// from the chains of the peer and removes its ledger along with all its data
+func LeaveChain(cid string) error {
+	chains.RLock()
+	_, ok := chains.list[cid]
+	chains.RUnlock()
+	if !ok {
+		return fmt.Errorf("Peer is not part of channel %s", cid)
+	}
+
+	service.GetGossipService().LeaveChannel(cid)
+
+	chains.Lock()
+	delete(chains.list, cid)
+	chains.Unlock()
+
+	mspmgmt.XXXRemoveMSPManager(cid)
+	removeTrustedRootsForChain(cid)
+
+	if err := ledgermgmt.RemoveLedger(cid); err != nil {
+		return fmt.Errorf("Cannot remove the ledger of channel %s, due to %s", cid, err)
+	}
+	return nil
+}
+
 // MockCreateChain used for creating a ledger for a chain for tests
 // without havin to join
 func MockCreateChain(cid string) error {
@@ -322,6 +408,48 @@ func GetPolicyManager(cid string) policies.Manager {
 	return nil
 }
 
+// TLSBindingRequired returns whether the messages of the chain with chain ID must be
+// bound to the TLS certificate of the client. Note that this call returns false if
+// chain cid has not been created.
+func TLSBindingRequired(cid string) bool {
+	chains.RLock()
+	defer chains.RUnlock()
+	if c, ok := chains.list[cid]; ok {
+		return c.cs.channelCapabilities().TLSBinding()
+	}
+	return false
+}
+
+// TLSBindingRequiredByAnyChain returns whether the messages of any of the chains
+// of the peer must be bound to the TLS certificate of the client
+func TLSBindingRequiredByAnyChain() bool {
+	chains.RLock()
+	defer chains.RUnlock()
+	for _, c := range chains.list {
+		if c.cs.channelCapabilities().TLSBinding() {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPolicyRefForAPI returns the policy referenced by the ACLs of the chain with
+// chain ID for the given resource. Note that this call returns the empty string
+// if chain cid has not been created or if its config does not define an ACL
+// for the resource.
+func GetPolicyRefForAPI(cid, resName string) string {
+	chains.RLock()
+	defer chains.RUnlock()
+	if c, ok := chains.list[cid]; ok {
+		ac, ok := c.cs.ApplicationConfig()
+		if !ok || ac == nil {
+			return ""
+		}
+		return ac.PolicyRefForAPI(resName)
+	}
+	return ""
+}
+
 // GetCurrConfigBlock returns the cached config block of the specified chain.
 // Note that this call returns nil if chain cid has not been created.
 func GetCurrConfigBlock(cid string) *common.Block {
@@ -343,36 +471,55 @@ func updateTrustedRoots(cm configtxapi.Manager) {
 	secureConfig, err = GetSecureConfig()
 	if err == nil && secureConfig.UseTLS {
 		buildTrustedRootsForChain(cm)
+		setClientRootCAs(secureConfig, cm.ChainID())
+	}
+}
 
-		// now iterate over all roots for all app and orderer chains
-		trustedRoots := [][]byte{}
-		rootCASupport.RLock()
-		defer rootCASupport.RUnlock()
-		for _, roots := range rootCASupport.AppRootCAsByChain {
-			trustedRoots = append(trustedRoots, roots...)
-		}
-		// also need to append statically configured root certs
-		if len(secureConfig.ClientRootCAs) > 0 {
-			trustedRoots = append(trustedRoots, secureConfig.ClientRootCAs...)
-		}
-		if len(secureConfig.ServerRootCAs) > 0 {
-			trustedRoots = append(trustedRoots, secureConfig.ServerRootCAs...)
-		}
+// setClientRootCAs sets the client roots of the peerServer to the roots of all
+// app chains, along with the statically configured root certs
+func setClientRootCAs(secureConfig comm.SecureServerConfig, cid string) {
+	// now iterate over all roots for all app and orderer chains
+	trustedRoots := [][]byte{}
+	rootCASupport.RLock()
+	defer rootCASupport.RUnlock()
+	for _, roots := range rootCASupport.AppRootCAsByChain {
+		trustedRoots = append(trustedRoots, roots...)
+	}
+	// also need to append statically configured root certs
+	if len(secureConfig.ClientRootCAs) > 0 {
+		trustedRoots = append(trustedRoots, secureConfig.ClientRootCAs...)
+	}
+	if len(secureConfig.ServerRootCAs) > 0 {
+		trustedRoots = append(trustedRoots, secureConfig.ServerRootCAs...)
+	}
 
-		server := GetPeerServer()
-		// now update the client roots for the peerServer
-		if server != nil {
-			err := server.SetClientRootCAs(trustedRoots)
-			if err != nil {
-				msg := "Failed to update trusted roots for peer from latest config " +
-					"block.  This peer may not be able to communicate " +
-					"with members of channel %s (%s)"
-				peerLogger.Warningf(msg, cm.ChainID(), err)
-			}
+	server := GetPeerServer()
+	// now update the client roots for the peerServer
+	if server != nil {
+		err := server.SetClientRootCAs(trustedRoots)
+		if err != nil {
+			msg := "Failed to update trusted roots for peer from latest config " +
+				"block.  This peer may not be able to communicate " +
+				"with members of channel %s (%s)"
+			peerLogger.Warningf(msg, cid, err)
 		}
 	}
 }
 
+// removeTrustedRootsForChain drops the root CAs of a chain the peer left
+func removeTrustedRootsForChain(cid string) {
+	rootCASupport.Lock()
+	delete(rootCASupport.AppRootCAsByChain, cid)
+	delete(rootCASupport.OrdererRootCAsByChain, cid)
+	delete(rootCASupport.OrdererEndpointRootCAsByChain, cid)
+	rootCASupport.Unlock()
+
+	secureConfig, err := GetSecureConfig()
+	if err == nil && secureConfig.UseTLS {
+		setClientRootCAs(secureConfig, cid)
+	}
+}
+
 // populates the appRootCAs and orderRootCAs maps by getting the
 // root and intermediate certs for all msps associated with the MSPManager
 func buildTrustedRootsForChain(cm configtxapi.Manager) {
@@ -381,6 +528,7 @@ func buildTrustedRootsForChain(cm configtxapi.Manager) {
 
 	appRootCAs := [][]byte{}
 	ordererRootCAs := [][]byte{}
+	rootCAsByMSP := make(map[string][][]byte)
 	appOrgMSPs := make(map[string]struct{})
 	ac, ok := cm.ApplicationConfig()
 	if ok {
@@ -409,6 +557,7 @@ func buildTrustedRootsForChain(cm configtxapi.Manager) {
 						peerLogger.Debugf("adding orderer root CAs for MSP [%s]", k)
 						ordererRootCAs = append(ordererRootCAs, root)
 					}
+					rootCAsByMSP[k] = append(rootCAsByMSP[k], root)
 				}
 				for _, intermediate := range v.GetTLSIntermediateCerts() {
 					// check to see of this is an app org MSP
@@ -419,11 +568,24 @@ func buildTrustedRootsForChain(cm configtxapi.Manager) {
 						peerLogger.Debugf("adding orderer root CAs for MSP [%s]", k)
 						ordererRootCAs = append(ordererRootCAs, intermediate)
 					}
+					rootCAsByMSP[k] = append(rootCAsByMSP[k], intermediate)
 				}
 			}
 		}
 		rootCASupport.AppRootCAsByChain[cid] = appRootCAs
 		rootCASupport.OrdererRootCAsByChain[cid] = ordererRootCAs
+
+		// each endpoint an orderer org defines is trusted only with the root CAs of the org
+		endpointRootCAs := make(map[string][][]byte)
+		if oc, ok := cm.OrdererConfig(); ok {
+			for _, ordererOrg := range oc.Organizations() {
+				for _, endpoint := range ordererOrg.Endpoints() {
+					peerLogger.Debugf("adding root CAs of MSP [%s] for orderer endpoint [%s]", ordererOrg.MSPID(), endpoint)
+					endpointRootCAs[endpoint] = append(endpointRootCAs[endpoint], rootCAsByMSP[ordererOrg.MSPID()]...)
+				}
+			}
+		}
+		rootCASupport.OrdererEndpointRootCAsByChain[cid] = endpointRootCAs
 	}
 }
 
@@ -493,9 +655,15 @@ func GetLocalIP() string {
 }
 
 // NewPeerClientConnectionWithAddress Returns a new grpc.ClientConn to the configured local PEER.
+// With TLS enabled, the client TLS certificate is presented to the peer, which
+// binds the headers carrying its hash to the connection
 func NewPeerClientConnectionWithAddress(peerAddress string) (*grpc.ClientConn, error) {
 	if comm.TLSEnabled() {
-		return comm.NewClientConnectionWithAddress(peerAddress, true, true, comm.InitTLSForPeer())
+		cert, err := comm.GetClientCertificate()
+		if err != nil {
+			return nil, err
+		}
+		return comm.NewClientConnectionWithAddress(peerAddress, true, true, comm.InitTLSForPeerWithClientCert(cert))
 	}
 	return comm.NewClientConnectionWithAddress(peerAddress, true, false, nil)
 }
@@ -23,14 +23,18 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/config"
 	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/localmsp"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	mockconfigtx "github.com/hyperledger/fabric/common/mocks/configtx"
 	mscc "github.com/hyperledger/fabric/common/mocks/scc"
 	"github.com/hyperledger/fabric/core/comm"
 	ccp "github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/mocks/ccprovider"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/service"
@@ -52,12 +56,29 @@ func (ds *mockDeliveryClient) StartDeliverForChannel(chainID string, ledgerInfo
 	return nil
 }
 
+// StartDeliverForChannelFrom dynamically starts delivery of new blocks from ordering service
+// to channel peers, connecting preferably to the endpoint at the given index.
+func (ds *mockDeliveryClient) StartDeliverForChannelFrom(chainID string, ledgerInfo blocksprovider.LedgerInfo, endpointIndex int) error {
+	return nil
+}
+
 // StopDeliverForChannel dynamically stops delivery of new blocks from ordering service
 // to channel peers.
 func (ds *mockDeliveryClient) StopDeliverForChannel(chainID string) error {
 	return nil
 }
 
+// UpdateEndpoints sets the endpoints of the ordering service blocks of the given channel
+// are delivered from.
+func (ds *mockDeliveryClient) UpdateEndpoints(chainID string, endpoints []string) {
+}
+
+// Failovers returns the most recent ordering service failovers of the blocks
+// delivery of the given channel.
+func (ds *mockDeliveryClient) Failovers(chainID string) []blocksprovider.Failover {
+	return nil
+}
+
 // Stop terminates delivery service and closes the connection
 func (*mockDeliveryClient) Stop() {
 
@@ -228,6 +249,85 @@ func TestCreateChainFromBlock(t *testing.T) {
 	if len(channels) != 1 {
 		t.Fatalf("incorrect number of channels")
 	}
+
+	// Leave the chain, its ledger is removed
+	assert.NoError(t, LeaveChain(testChainID))
+	assert.Nil(t, GetLedger(testChainID))
+	assert.Len(t, GetChannelsInfo(), 0)
+	assert.NotContains(t, mgmt.GetDeserializers(), testChainID)
+	ledgerIDs, err := ledgermgmt.GetLedgerIDs()
+	assert.NoError(t, err)
+	assert.NotContains(t, ledgerIDs, testChainID)
+	assert.Error(t, LeaveChain(testChainID), "Leaving a chain twice should fail")
+
+	// The chain can be joined again
+	block, err = configtxtest.MakeGenesisBlock(testChainID)
+	assert.NoError(t, err)
+	assert.NoError(t, CreateChainFromBlock(block))
+	assert.NotNil(t, GetLedger(testChainID))
+}
+
+type ordererOrgMock struct {
+	name      string
+	endpoints []string
+}
+
+func (o *ordererOrgMock) Name() string {
+	return o.name
+}
+
+func (o *ordererOrgMock) MSPID() string {
+	return o.name + "MSP"
+}
+
+func (o *ordererOrgMock) Endpoints() []string {
+	return o.endpoints
+}
+
+func TestOrdererEndpoints(t *testing.T) {
+	cm := &mockconfigtx.Manager{}
+	cm.ChannelConfigVal = &mockconfig.Channel{OrdererAddressesVal: []string{"orderer:7050"}}
+	assert.Equal(t, []string{"orderer:7050"}, ordererEndpoints(cm))
+
+	// Orgs without endpoints fall back to the channel wide orderer addresses
+	ordererConfig := &mockconfig.Orderer{OrganizationsVal: map[string]config.OrdererOrg{
+		"org1": &ordererOrgMock{name: "org1"},
+	}}
+	cm.OrdererConfigVal = ordererConfig
+	assert.Equal(t, []string{"orderer:7050"}, ordererEndpoints(cm))
+
+	ordererConfig.OrganizationsVal = map[string]config.OrdererOrg{
+		"org2": &ordererOrgMock{name: "org2", endpoints: []string{"orderer0.org2:7050"}},
+		"org1": &ordererOrgMock{name: "org1", endpoints: []string{"orderer0.org1:7050", "orderer1.org1:7050"}},
+	}
+	assert.Equal(t, []string{"orderer0.org1:7050", "orderer1.org1:7050", "orderer0.org2:7050"}, ordererEndpoints(cm))
+}
+
+func TestTLSBindingRequired(t *testing.T) {
+	unbound := &mockconfigtx.Manager{}
+	bound := &mockconfigtx.Manager{}
+	bound.ChannelConfigVal = &mockconfig.Channel{CapabilitiesVal: &mockconfig.ChannelCapabilities{TLSBindingVal: true}}
+
+	chains.Lock()
+	chains.list["unboundchain"] = &chain{cs: &chainSupport{Manager: unbound}}
+	chains.Unlock()
+	defer func() {
+		chains.Lock()
+		delete(chains.list, "unboundchain")
+		delete(chains.list, "boundchain")
+		chains.Unlock()
+	}()
+
+	assert.False(t, TLSBindingRequired("unboundchain"))
+	assert.False(t, TLSBindingRequired("missingchain"))
+	assert.False(t, TLSBindingRequiredByAnyChain())
+
+	chains.Lock()
+	chains.list["boundchain"] = &chain{cs: &chainSupport{Manager: bound}}
+	chains.Unlock()
+	assert.True(t, TLSBindingRequired("boundchain"))
+	assert.False(t, TLSBindingRequired("unboundchain"))
+	assert.True(t, TLSBindingRequiredByAnyChain())
 }
 
 func TestNewPeerClientConnection(t *testing.T) {
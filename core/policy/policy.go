@@ -45,6 +45,10 @@ type PolicyChecker interface {
 	// CheckPolicyNoChannel checks that the passed signed proposal is valid with the respect to
 	// passed policy on the local MSP.
 	CheckPolicyNoChannel(policyName string, signedProp *pb.SignedProposal) error
+
+	// CheckPolicyNoChannelBySignedData checks that the passed signed data is valid with the respect to
+	// passed policy on the local MSP.
+	CheckPolicyNoChannelBySignedData(policyName string, sd []*common.SignedData) error
 }
 
 type policyChecker struct {
@@ -152,6 +156,50 @@ func (p *policyChecker) CheckPolicyNoChannel(policyName string, signedProp *pb.S
 	return id.Verify(signedProp.ProposalBytes, signedProp.Signature)
 }
 
+// CheckPolicyNoChannelBySignedData checks that the passed signed data is valid with the respect to
+// passed policy on the local MSP. Each of the signed data must come from an identity
+// satisfying the local MSP principal.
+func (p *policyChecker) CheckPolicyNoChannelBySignedData(policyName string, sd []*common.SignedData) error {
+	if policyName == "" {
+		return errors.New("Invalid policy name during channelless check policy on signed data. Name must be different from nil.")
+	}
+
+	if len(sd) == 0 {
+		return fmt.Errorf("Invalid signed data during channelless check policy with policy [%s]", policyName)
+	}
+
+	// Load MSPPrincipal for policy
+	principal, err := p.principalGetter.Get(policyName)
+	if err != nil {
+		return fmt.Errorf("Failed getting local MSP principal during channelless check policy with policy [%s]: [%s]", policyName, err)
+	}
+
+	for _, d := range sd {
+		if d == nil {
+			return fmt.Errorf("Invalid signed data during channelless check policy with policy [%s]", policyName)
+		}
+
+		// Deserialize the signer with the local MSP
+		id, err := p.localMSP.DeserializeIdentity(d.Identity)
+		if err != nil {
+			return fmt.Errorf("Failed deserializing signer during channelless check policy with policy [%s]: [%s]", policyName, err)
+		}
+
+		// Verify that the signer satisfies the principal
+		err = id.SatisfiesPrincipal(principal)
+		if err != nil {
+			return fmt.Errorf("Failed verifying that signer satisfies local MSP principal during channelless check policy with policy [%s]: [%s]", policyName, err)
+		}
+
+		// Verify the signature
+		if err = id.Verify(d.Data, d.Signature); err != nil {
+			return fmt.Errorf("Failed verifying signature during channelless check policy with policy [%s]: [%s]", policyName, err)
+		}
+	}
+
+	return nil
+}
+
 // CheckPolicyBySignedData checks that the passed signed data is valid with the respect to
 // passed policy on the passed channel.
 func (p *policyChecker) CheckPolicyBySignedData(channelID, policyName string, sd []*common.SignedData) error {
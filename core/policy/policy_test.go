@@ -176,6 +176,35 @@ func TestPolicyChecker(t *testing.T) {
 	assert.Contains(t, err.Error(), "Failed deserializing proposal creator during channelless check policy with policy [Members]: [Invalid Identity]")
 }
 
+func TestCheckPolicyNoChannelBySignedData(t *testing.T) {
+	identityDeserializer := &mocks.MockIdentityDeserializer{Identity: []byte("Alice"), Msg: []byte("msg1")}
+	pc := NewPolicyChecker(
+		&mocks.MockChannelPolicyManagerGetter{},
+		identityDeserializer,
+		&mocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	)
+
+	err := pc.CheckPolicyNoChannelBySignedData("", []*common.SignedData{&common.SignedData{}})
+	assert.Error(t, err)
+
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Members, nil)
+	assert.Error(t, err)
+
+	// Alice is a member of the local MSP, policy check must succeed
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Members, []*common.SignedData{&common.SignedData{Data: []byte("msg1"), Identity: []byte("Alice"), Signature: []byte("msg1")}})
+	assert.NoError(t, err)
+
+	// Alice's signature is not valid, policy check must fail
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Members, []*common.SignedData{&common.SignedData{Data: []byte("msg1"), Identity: []byte("Alice"), Signature: []byte("msg2")}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid Signature")
+
+	// Bob is not a member of the local MSP, policy check must fail
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Members, []*common.SignedData{&common.SignedData{Data: []byte("msg1"), Identity: []byte("Bob"), Signature: []byte("msg1")}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed deserializing signer during channelless check policy with policy [Members]: [Invalid Identity]")
+}
+
 type MockPolicyCheckerFactory struct {
 	mock.Mock
 }
@@ -18,7 +18,7 @@ limitations under the License.
 // configuration transactions as the network is being reconfigured. The
 // configuration transactions arrive from the ordering service to the committer
 // who calls this chaincode. The chaincode also provides peer configuration
-// services such as joining a chain or getting configuration data.
+// services such as joining or leaving a chain or getting configuration data.
 package cscc
 
 import (
@@ -28,22 +28,26 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/common/flogging"
-	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/policy"
 	"github.com/hyperledger/fabric/events/producer"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
+	"golang.org/x/net/context"
 )
 
 // PeerConfiger implements the configuration handler for the peer. For every
 // configuration transaction coming in from the ordering service, the
 // committer calls this system chaincode to process the transaction.
 type PeerConfiger struct {
-	policyChecker policy.PolicyChecker
+	aclProvider aclmgmt.ACLProvider
 }
 
 var cnflogger = flogging.MustGetLogger("cscc")
@@ -51,6 +55,7 @@ var cnflogger = flogging.MustGetLogger("cscc")
 // These are function names from Invoke first parameter
 const (
 	JoinChain      string = "JoinChain"
+	LeaveChain     string = "LeaveChain"
 	GetConfigBlock string = "GetConfigBlock"
 	GetChannels    string = "GetChannels"
 )
@@ -61,11 +66,14 @@ const (
 func (e *PeerConfiger) Init(stub shim.ChaincodeStubInterface) pb.Response {
 	cnflogger.Info("Init CSCC")
 
-	// Init policy checker for access control
-	e.policyChecker = policy.NewPolicyChecker(
-		peer.NewChannelPolicyManagerGetter(),
-		mgmt.GetLocalMSP(),
-		mgmt.NewLocalMSPPrincipalGetter(),
+	// Init ACL provider for access control
+	e.aclProvider = aclmgmt.NewDefaultACLProvider(
+		policy.NewPolicyChecker(
+			peer.NewChannelPolicyManagerGetter(),
+			mgmt.GetLocalMSP(),
+			mgmt.NewLocalMSPPrincipalGetter(),
+		),
+		peer.GetPolicyRefForAPI,
 	)
 
 	return shim.Success(nil)
@@ -73,11 +81,12 @@ func (e *PeerConfiger) Init(stub shim.ChaincodeStubInterface) pb.Response {
 
 // Invoke is called for the following:
 // # to process joining a chain (called by app as a transaction proposal)
+// # to process leaving a chain (called by app as a transaction proposal)
 // # to get the current configuration block (called by app)
 // # to update the configuration block (called by commmitter)
 // Peer calls this function with 2 arguments:
-// # args[0] is the function name, which must be JoinChain, GetConfigBlock or
-// UpdateConfigBlock
+// # args[0] is the function name, which must be JoinChain, LeaveChain,
+// GetConfigBlock or UpdateConfigBlock
 // # args[1] is a configuration Block if args[0] is JoinChain or
 // UpdateConfigBlock; otherwise it is the chain id
 // TODO: Improve the scc interface to avoid marshal/unmarshal args
@@ -125,22 +134,35 @@ func (e *PeerConfiger) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 				"of configuration block, because of %s", cid, err))
 		}
 
-		// 2. check local MSP Admins policy
-		if err = e.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
+		// 2. check the ACL for joining channels
+		if err = e.aclProvider.CheckACL(aclmgmt.CsccJoinChain, "", sp); err != nil {
 			return shim.Error(fmt.Sprintf("\"JoinChain\" request failed authorization check "+
 				"for channel [%s]: [%s]", cid, err))
 		}
 
 		return joinChain(cid, block)
+	case LeaveChain:
+		cid := string(args[1])
+		if cid == "" {
+			return shim.Error("Cannot leave the channel, empty channel id provided")
+		}
+
+		// 2. check the ACL for leaving channels
+		if err = e.aclProvider.CheckACL(aclmgmt.CsccLeaveChain, "", sp); err != nil {
+			return shim.Error(fmt.Sprintf("\"LeaveChain\" request failed authorization check "+
+				"for channel [%s]: [%s]", cid, err))
+		}
+
+		return leaveChain(cid)
 	case GetConfigBlock:
-		// 2. check the channel reader policy
-		if err = e.policyChecker.CheckPolicy(string(args[1]), policies.ChannelApplicationReaders, sp); err != nil {
+		// 2. check the ACL of the channel for getting its config block
+		if err = e.aclProvider.CheckACL(aclmgmt.CsccGetConfigBlock, string(args[1]), sp); err != nil {
 			return shim.Error(fmt.Sprintf("\"GetConfigBlock\" request failed authorization check for channel [%s]: [%s]", args[1], err))
 		}
 		return getConfigBlock(args[1])
 	case GetChannels:
-		// 2. check local MSP Members policy
-		if err = e.policyChecker.CheckPolicyNoChannel(mgmt.Members, sp); err != nil {
+		// 2. check the ACL for listing channels
+		if err = e.aclProvider.CheckACL(aclmgmt.CsccGetChannels, "", sp); err != nil {
 			return shim.Error(fmt.Sprintf("\"GetChannels\" request failed authorization check: [%s]", err))
 		}
 
@@ -201,6 +223,96 @@ func joinChain(chainID string, block *common.Block) pb.Response {
 	return shim.Success(nil)
 }
 
+// leaveChain makes the peer leave the specified chain and removes its ledger.
+// The chaincode containers which run only for this chain are stopped as well
+func leaveChain(chainID string) pb.Response {
+	l := peer.GetLedger(chainID)
+	if l == nil {
+		return shim.Error(fmt.Sprintf("Unknown chain ID, %s", chainID))
+	}
+
+	chaincodes, err := instantiatedChaincodes(l)
+	if err != nil {
+		cnflogger.Warningf("Failed to list the chaincodes instantiated on chain %s, their containers won't be stopped: %s", chainID, err)
+	}
+	for _, cid := range peer.GetChannelsInfo() {
+		if cid.ChannelId == chainID {
+			continue
+		}
+		others, err := instantiatedChaincodes(peer.GetLedger(cid.ChannelId))
+		if err != nil {
+			cnflogger.Warningf("Failed to list the chaincodes instantiated on chain %s, the containers of chain %s won't be stopped: %s", cid.ChannelId, chainID, err)
+			chaincodes = nil
+			break
+		}
+		for canName := range others {
+			delete(chaincodes, canName)
+		}
+	}
+
+	if err := peer.LeaveChain(chainID); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	for _, cd := range chaincodes {
+		stopChaincode(chainID, cd)
+	}
+
+	return shim.Success(nil)
+}
+
+// instantiatedChaincodes returns the chaincodes instantiated on a ledger, by
+// canonical name
+func instantiatedChaincodes(l ledger.PeerLedger) (map[string]*ccprovider.ChaincodeData, error) {
+	if l == nil {
+		return nil, errors.New("nil ledger instance")
+	}
+	qe, err := l.NewQueryExecutor()
+	if err != nil {
+		return nil, err
+	}
+	defer qe.Done()
+
+	itr, err := qe.GetStateRangeScanIterator("lscc", "", "")
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	chaincodes := make(map[string]*ccprovider.ChaincodeData)
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			return chaincodes, nil
+		}
+		cd := &ccprovider.ChaincodeData{}
+		if err := proto.Unmarshal(res.(*queryresult.KV).Value, cd); err != nil {
+			return nil, err
+		}
+		chaincodes[cd.Name+":"+cd.Version] = cd
+	}
+}
+
+// stopChaincode stops the container of a chaincode, if running
+func stopChaincode(chainID string, cd *ccprovider.ChaincodeData) {
+	ccpack, err := ccprovider.GetChaincodeFromFS(cd.Name, cd.Version)
+	if err != nil {
+		// a chaincode which isn't installed can't be running
+		cnflogger.Debugf("Chaincode %s:%s isn't installed: %s", cd.Name, cd.Version, err)
+		return
+	}
+	ccprov := ccprovider.GetChaincodeProvider()
+	cccid := ccprov.GetCCContext(chainID, cd.Name, cd.Version, "", false, nil, nil)
+	if err := ccprov.Stop(context.Background(), cccid, ccpack.GetDepSpec()); err != nil {
+		cnflogger.Warningf("Failed to stop chaincode %s:%s: %s", cd.Name, cd.Version, err)
+		return
+	}
+	cnflogger.Infof("Stopped chaincode %s:%s, which was instantiated on chain %s only", cd.Name, cd.Version, chainID)
+}
+
 // Return the current configuration block for the specified chainID. If the
 // peer doesn't belong to the chain, return error
 func getConfigBlock(chainID []byte) pb.Response {
@@ -29,6 +29,7 @@ import (
 	"github.com/hyperledger/fabric/common/mocks/scc"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/deliverservice"
@@ -59,12 +60,29 @@ func (ds *mockDeliveryClient) StartDeliverForChannel(chainID string, ledgerInfo
 	return nil
 }
 
+// StartDeliverForChannelFrom dynamically starts delivery of new blocks from ordering service
+// to channel peers, connecting preferably to the endpoint at the given index.
+func (ds *mockDeliveryClient) StartDeliverForChannelFrom(chainID string, ledgerInfo blocksprovider.LedgerInfo, endpointIndex int) error {
+	return nil
+}
+
 // StopDeliverForChannel dynamically stops delivery of new blocks from ordering service
 // to channel peers.
 func (ds *mockDeliveryClient) StopDeliverForChannel(chainID string) error {
 	return nil
 }
 
+// UpdateEndpoints sets the endpoints of the ordering service blocks of the given channel
+// are delivered from.
+func (ds *mockDeliveryClient) UpdateEndpoints(chainID string, endpoints []string) {
+}
+
+// Failovers returns the most recent ordering service failovers of the blocks
+// delivery of the given channel.
+func (ds *mockDeliveryClient) Failovers(chainID string) []blocksprovider.Failover {
+	return nil
+}
+
 // Stop terminates delivery service and closes the connection
 func (*mockDeliveryClient) Stop() {
 
@@ -191,10 +209,13 @@ func TestConfigerInvokeJoinChainCorrectParams(t *testing.T) {
 
 	identityDeserializer := &policymocks.MockIdentityDeserializer{[]byte("Alice"), []byte("msg1")}
 
-	e.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	e.aclProvider = aclmgmt.NewDefaultACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 
 	identity, _ := mgmt.GetLocalSigningIdentityOrPanic().Serialize()
@@ -274,6 +295,32 @@ func TestConfigerInvokeJoinChainCorrectParams(t *testing.T) {
 	if len(cqr.GetChannels()) != 1 {
 		t.FailNow()
 	}
+
+	// Leaving a channel requires authorization
+	args = [][]byte{[]byte(LeaveChain), []byte(chainID)}
+	sProp.Signature = nil
+	res = stub.MockInvokeWithSignedProposal("3", args, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.True(t, strings.HasPrefix(res.Message, "\"LeaveChain\" request failed authorization check for channel"))
+	sProp.Signature = sProp.ProposalBytes
+
+	// Leave the channel
+	res = stub.MockInvokeWithSignedProposal("4", args, sProp)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	res = stub.MockInvokeWithSignedProposal("5", [][]byte{[]byte(GetChannels)}, sProp)
+	assert.Equal(t, int32(shim.OK), res.Status)
+	cqr = &pb.ChannelQueryResponse{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, cqr))
+	assert.Len(t, cqr.GetChannels(), 0)
+
+	// The peer isn't part of the channel anymore
+	res = stub.MockInvokeWithSignedProposal("6", args, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+
+	// Missing channel ID
+	res = stub.MockInvokeWithSignedProposal("7", [][]byte{[]byte(LeaveChain), nil}, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
 }
 
 func TestPeerConfiger_SubmittingOrdererGenesis(t *testing.T) {
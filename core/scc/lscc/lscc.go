@@ -23,14 +23,12 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/flogging"
-	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/peer"
-	"github.com/hyperledger/fabric/core/policy"
 	"github.com/hyperledger/fabric/core/policyprovider"
-	"github.com/hyperledger/fabric/msp/mgmt"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -41,6 +39,7 @@ import (
 //on this peer. It manages chaincodes via Invoke proposals.
 //     "Args":["deploy",<ChaincodeDeploymentSpec>]
 //     "Args":["upgrade",<ChaincodeDeploymentSpec>]
+//     "Args":["setpolicy",<chainname>,<chaincodename>,<policy>]
 //     "Args":["stop",<ChaincodeInvocationSpec>]
 //     "Args":["start",<ChaincodeInvocationSpec>]
 
@@ -61,6 +60,9 @@ const (
 	//UPGRADE upgrade chaincode
 	UPGRADE = "upgrade"
 
+	//SETPOLICY update the endorsement policy of an instantiated chaincode
+	SETPOLICY = "setpolicy"
+
 	//GETCCINFO get chaincode
 	GETCCINFO = "getid"
 
@@ -89,9 +91,9 @@ type LifeCycleSysCC struct {
 	// import cycles
 	sccprovider sysccprovider.SystemChaincodeProvider
 
-	// policyChecker is the interface used to perform
+	// aclProvider is the interface used to perform
 	// access control
-	policyChecker policy.PolicyChecker
+	aclProvider aclmgmt.ACLProvider
 }
 
 //----------------errors---------------
@@ -236,6 +238,13 @@ func (f InstantiationPolicyMissing) Error() string {
 	return "instantiation policy missing"
 }
 
+//EmptyPolicyErr endorsement policy not provided when updating the policy of a CC
+type EmptyPolicyErr string
+
+func (f EmptyPolicyErr) Error() string {
+	return fmt.Sprintf("endorsement policy not provided for chaincode with name '%s'", string(f))
+}
+
 //-------------- helper functions ------------------
 //create the chaincode on the given chain
 func (lscc *LifeCycleSysCC) createChaincode(stub shim.ChaincodeStubInterface, cd *ccprovider.ChaincodeData) error {
@@ -681,6 +690,76 @@ func (lscc *LifeCycleSysCC) executeUpgrade(stub shim.ChaincodeStubInterface, cha
 	return cd, nil
 }
 
+// executeSetPolicy implements the "setpolicy" Invoke transaction. Only the
+// endorsement policy and optionally the names of escc and vscc of the
+// instantiated chaincode are replaced; name, version and instantiation
+// policy are retained.
+func (lscc *LifeCycleSysCC) executeSetPolicy(stub shim.ChaincodeStubInterface, chainName string, chaincodeName string, policy []byte, escc []byte, vscc []byte) (*ccprovider.ChaincodeData, error) {
+	if err := lscc.isValidChaincodeName(chaincodeName); err != nil {
+		return nil, err
+	}
+
+	if len(policy) == 0 {
+		return nil, EmptyPolicyErr(chaincodeName)
+	}
+
+	// the chaincode has to be instantiated on the channel; as for upgrade,
+	// the package on the FS is not needed
+	cdbytes, _ := lscc.getCCInstance(stub, chaincodeName)
+	if cdbytes == nil {
+		return nil, NotFoundErr(chaincodeName)
+	}
+
+	cd, err := lscc.getChaincodeData(chaincodeName, cdbytes)
+	if err != nil {
+		return nil, err
+	}
+
+	//do not change the policy if instantiation policy is violated
+	if cd.InstantiationPolicy == nil {
+		return nil, InstantiationPolicyMissing("")
+	}
+	err = lscc.checkInstantiationPolicy(stub, chainName, cd.InstantiationPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	cd.Policy = policy
+	if len(escc) > 0 {
+		cd.Escc = string(escc)
+	}
+	if len(vscc) > 0 {
+		cd.Vscc = string(vscc)
+	}
+
+	err = lscc.putChaincodeData(stub, cd)
+	if err != nil {
+		return nil, err
+	}
+
+	return cd, nil
+}
+
+// getChannelID returns the ID of the channel targeted by the signed proposal
+func getChannelID(sp *pb.SignedProposal) (string, error) {
+	prop, err := utils.GetProposal(sp.ProposalBytes)
+	if err != nil {
+		return "", err
+	}
+
+	hdr, err := utils.GetHeader(prop.Header)
+	if err != nil {
+		return "", err
+	}
+
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return "", err
+	}
+
+	return chdr.ChannelId, nil
+}
+
 //-------------- the chaincode stub interface implementation ----------
 
 //Init only initializes the system chaincode provider
@@ -688,13 +767,14 @@ func (lscc *LifeCycleSysCC) Init(stub shim.ChaincodeStubInterface) pb.Response {
 	lscc.sccprovider = sysccprovider.GetSystemChaincodeProvider()
 
 	// Init policy checker for access control
-	lscc.policyChecker = policyprovider.GetPolicyChecker()
+	lscc.aclProvider = aclmgmt.NewDefaultACLProvider(policyprovider.GetPolicyChecker(), peer.GetPolicyRefForAPI)
 
 	return shim.Success(nil)
 }
 
-// Invoke implements lifecycle functions "deploy", "start", "stop", "upgrade".
+// Invoke implements lifecycle functions "deploy", "start", "stop", "upgrade", "setpolicy".
 // Deploy's arguments -  {[]byte("deploy"), []byte(<chainname>), <unmarshalled pb.ChaincodeDeploymentSpec>}
+// SetPolicy's arguments -  {[]byte("setpolicy"), []byte(<chainname>), []byte(<chaincodename>), <marshalled policy>}
 //
 // Invoke also implements some query-like functions
 // Get chaincode arguments -  {[]byte("getid"), []byte(<chainname>), []byte(<chaincodename>)}
@@ -719,8 +799,8 @@ func (lscc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 			return shim.Error(InvalidArgsLenErr(len(args)).Error())
 		}
 
-		// 2. check local MSP Admins policy
-		if err = lscc.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
+		// 2. check the ACL for installing chaincodes
+		if err = lscc.aclProvider.CheckACL(aclmgmt.LsccInstall, "", sp); err != nil {
 			return shim.Error(fmt.Sprintf("Authorization for INSTALL has been denied (error-%s)", err))
 		}
 
@@ -840,6 +920,46 @@ func (lscc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 			return shim.Error(err.Error())
 		}
 		return shim.Success(cdbytes)
+	case SETPOLICY:
+		if len(args) < 4 || len(args) > 6 {
+			return shim.Error(InvalidArgsLenErr(len(args)).Error())
+		}
+
+		chainname := string(args[1])
+		if !lscc.isValidChainName(chainname) {
+			return shim.Error(InvalidChainNameErr(chainname).Error())
+		}
+
+		// access control is enforced by the instantiation policy
+		// of the chaincode in executeSetPolicy
+
+		ccname := string(args[2])
+
+		// args[3] is a marshalled SignaturePolicyEnvelope representing the new endorsement policy
+		// optional arguments here (they can each be nil and may or may not be present)
+		// args[4] is the name of escc; if not present the current one is retained
+		// args[5] is the name of vscc; if not present the current one is retained
+		policy := args[3]
+
+		var escc []byte
+		if len(args) > 4 {
+			escc = args[4]
+		}
+
+		var vscc []byte
+		if len(args) > 5 {
+			vscc = args[5]
+		}
+
+		cd, err := lscc.executeSetPolicy(stub, chainname, ccname, policy, escc, vscc)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		cdbytes, err := proto.Marshal(cd)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(cdbytes)
 	case GETCCINFO, GETDEPSPEC, GETCCDATA:
 		if len(args) != 3 {
 			return shim.Error(InvalidArgsLenErr(len(args)).Error())
@@ -848,10 +968,19 @@ func (lscc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 		chain := string(args[1])
 		ccname := string(args[2])
 
-		// 2. check local Channel Readers policy
+		// 2. check the ACL of the channel for the function
 		// Notice that this information are already available on the ledger
-		// therefore we enforce here that the caller is reader of the channel.
-		if err = lscc.policyChecker.CheckPolicy(chain, policies.ChannelApplicationReaders, sp); err != nil {
+		// therefore by default we enforce here that the caller is reader of the channel.
+		var resName string
+		switch function {
+		case GETCCINFO:
+			resName = aclmgmt.LsccChaincodeExists
+		case GETDEPSPEC:
+			resName = aclmgmt.LsccGetDeploymentSpec
+		default:
+			resName = aclmgmt.LsccGetChaincodeData
+		}
+		if err = lscc.aclProvider.CheckACL(resName, chain, sp); err != nil {
 			return shim.Error(fmt.Sprintf("Authorization for %s on channel %s has been denied with error %s", function, args[1], err))
 		}
 
@@ -882,9 +1011,13 @@ func (lscc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 			return shim.Error(InvalidArgsLenErr(len(args)).Error())
 		}
 
-		// 2. check local MSP Admins policy
-		if err = lscc.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
-			return shim.Error(fmt.Sprintf("Authorization for GETCHAINCODES on channel %s has been denied with error %s", args[0], err))
+		// 2. check the ACL of the channel for listing chaincodes
+		chain, err := getChannelID(sp)
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Authorization for GETCHAINCODES has been denied with error %s", err))
+		}
+		if err = lscc.aclProvider.CheckACL(aclmgmt.LsccGetChaincodes, chain, sp); err != nil {
+			return shim.Error(fmt.Sprintf("Authorization for GETCHAINCODES on channel %s has been denied with error %s", chain, err))
 		}
 
 		return lscc.getChaincodes(stub)
@@ -893,8 +1026,8 @@ func (lscc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 			return shim.Error(InvalidArgsLenErr(len(args)).Error())
 		}
 
-		// 2. check local MSP Admins policy
-		if err = lscc.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
+		// 2. check the ACL for listing installed chaincodes
+		if err = lscc.aclProvider.CheckACL(aclmgmt.LsccGetInstalledChaincodes, "", sp); err != nil {
 			return shim.Error(fmt.Sprintf("Authorization for GETINSTALLEDCHAINCODES on channel %s has been denied with error %s", args[0], err))
 		}
 
@@ -30,6 +30,7 @@ import (
 	"github.com/hyperledger/fabric/common/mocks/scc"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/ccpackage"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
@@ -105,10 +106,13 @@ func testInstall(t *testing.T, ccname string, version string, path string, expec
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewDefaultACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 
 	cds, err := constructDeploymentSpec(ccname, path, version, [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}, false)
@@ -259,10 +263,13 @@ func testDeploy(t *testing.T, ccname string, version string, path string, forceB
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewDefaultACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
 	identityDeserializer.Msg = sProp.ProposalBytes
@@ -388,10 +395,13 @@ func TestMultipleDeploy(t *testing.T) {
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewDefaultACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
 	identityDeserializer.Msg = sProp.ProposalBytes
@@ -474,10 +484,13 @@ func TestRetryFailedDeploy(t *testing.T) {
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewDefaultACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
 	identityDeserializer.Msg = sProp.ProposalBytes
@@ -538,10 +551,13 @@ func TestTamperChaincode(t *testing.T) {
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewDefaultACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
 	identityDeserializer.Msg = sProp.ProposalBytes
@@ -635,10 +651,13 @@ func TestIPolDeployDefaultFail(t *testing.T) {
 			chainid: &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewDefaultACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
 	identityDeserializer.Msg = sProp.ProposalBytes
@@ -681,10 +700,13 @@ func testIPolDeploy(t *testing.T, iPol string, successExpected bool) {
 			chainid: &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewDefaultACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
 	identityDeserializer.Msg = sProp.ProposalBytes
@@ -835,10 +857,13 @@ func testIPolUpgrade(t *testing.T, iPol string, successExpected bool) {
 			chainid: &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewDefaultACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
 	identityDeserializer.Msg = sProp.ProposalBytes
@@ -908,6 +933,112 @@ func testIPolUpgrade(t *testing.T, iPol string, successExpected bool) {
 	}
 }
 
+//TestSetPolicy tests changing the endorsement policy of an instantiated chaincode
+func TestSetPolicy(t *testing.T) {
+	newPolicy := utils.MarshalOrPanic(cauthdsl.SignedByMspMember("DEFAULT"))
+
+	testSetPolicy(t, "example02", "example02", newPolicy, nil, nil, "")
+	testSetPolicy(t, "example02", "example02", newPolicy, []byte("escc"), []byte("notext"), "")
+	testSetPolicy(t, "example02", "example03", newPolicy, nil, nil, NotFoundErr("example03").Error())
+	testSetPolicy(t, "example02", "example02", nil, nil, nil, EmptyPolicyErr("example02").Error())
+	testSetPolicy(t, "example02", "example*02", newPolicy, nil, nil, InvalidChaincodeNameErr("example*02").Error())
+	testSetPolicy(t, "example02", "example02", newPolicy, []byte("bogus"), nil, "bogus is not a valid endorsement system chaincode")
+}
+
+func testSetPolicy(t *testing.T, ccname string, setccname string, newPolicy []byte, escc []byte, vscc []byte, expectedErrorMsg string) {
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lscc", scc)
+
+	if res := stub.MockInit("1", nil); res.Status != shim.OK {
+		t.Fatalf("Init failed %s", string(res.Message))
+	}
+
+	path := "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02"
+	cds, err := constructDeploymentSpec(ccname, path, "0", [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}, true)
+	assert.NoError(t, err)
+	defer os.Remove(lscctestpath + "/" + ccname + ".0")
+	b, err := proto.Marshal(cds)
+	assert.NoError(t, err)
+
+	sProp, _ := putils.MockSignedEndorserProposal2OrPanic(chainid, &pb.ChaincodeSpec{}, id)
+	args := [][]byte{[]byte(DEPLOY), []byte("test"), b}
+	res := stub.MockInvokeWithSignedProposal("1", args, sProp)
+	if res.Status != shim.OK {
+		t.Fatalf("Deploy chaincode error: %s", res.Message)
+	}
+	deployed := &ccprovider.ChaincodeData{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, deployed))
+
+	args = [][]byte{[]byte(SETPOLICY), []byte("test"), []byte(setccname), newPolicy, escc, vscc}
+	res = stub.MockInvokeWithSignedProposal("1", args, sProp)
+	if expectedErrorMsg != "" {
+		assert.NotEqual(t, int32(shim.OK), res.Status)
+		assert.Equal(t, expectedErrorMsg, res.Message)
+		return
+	}
+	if res.Status != shim.OK {
+		t.Fatalf("SetPolicy error: %s", res.Message)
+	}
+
+	cd := &ccprovider.ChaincodeData{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, cd))
+	assert.Equal(t, newPolicy, cd.Policy)
+	assert.Equal(t, deployed.Version, cd.Version)
+	assert.Equal(t, deployed.InstantiationPolicy, cd.InstantiationPolicy)
+	if escc == nil {
+		assert.Equal(t, deployed.Escc, cd.Escc)
+	} else {
+		assert.Equal(t, string(escc), cd.Escc)
+	}
+	if vscc == nil {
+		assert.Equal(t, deployed.Vscc, cd.Vscc)
+	} else {
+		assert.Equal(t, string(vscc), cd.Vscc)
+	}
+
+	// the ledger entry must have been updated
+	assert.Equal(t, res.Payload, stub.State[ccname])
+}
+
+//TestIPolSetPolicy tests that setpolicy is governed by the instantiation policy
+func TestIPolSetPolicy(t *testing.T) {
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lscc", scc)
+	if res := stub.MockInit("1", nil); res.Status != shim.OK {
+		t.Fatalf("Init failed %s", string(res.Message))
+	}
+
+	// deploy a package whose instantiation policy can't be satisfied by the caller
+	cds, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", "0", [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}, false)
+	assert.NoError(t, err)
+	cdsbytes, err := proto.Marshal(cds)
+	assert.NoError(t, err)
+	ip := cauthdsl.SignedByMspAdmin(mspid)
+	objToWrite, err := ccpackage.OwnerCreateSignedCCDepSpec(cds, ip, nil)
+	assert.NoError(t, err)
+	bytesToWrite, err := proto.Marshal(objToWrite)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(lscctestpath+"/example02.0", bytesToWrite, 0700)
+	assert.NoError(t, err)
+	defer os.Remove(lscctestpath + "/example02.0")
+
+	sProp, _ := putils.MockSignedEndorserProposal2OrPanic(chainid, &pb.ChaincodeSpec{}, id)
+	args := [][]byte{[]byte(DEPLOY), []byte(chainid), cdsbytes}
+	if res := stub.MockInvokeWithSignedProposal("1", args, sProp); res.Status != shim.OK {
+		t.Fatalf("Deploy failed %s", res.Message)
+	}
+
+	// a proposal from an identity not satisfying the instantiation policy must be rejected
+	bogusProp, _ := utils.MockSignedEndorserProposalOrPanic(chainid, &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
+	args = [][]byte{[]byte(SETPOLICY), []byte(chainid), []byte("example02"), utils.MarshalOrPanic(cauthdsl.SignedByMspMember(mspid))}
+	res := stub.MockInvokeWithSignedProposal("1", args, bogusProp)
+	assert.NotEqual(t, int32(shim.OK), res.Status)
+
+	// the admin can
+	res = stub.MockInvokeWithSignedProposal("1", args, sProp)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+}
+
 //TestGetAPIsWithoutInstall get functions should return the right responses when chaicode is on
 //ledger but not on FS
 func TestGetAPIsWithoutInstall(t *testing.T) {
@@ -925,10 +1056,13 @@ func TestGetAPIsWithoutInstall(t *testing.T) {
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewDefaultACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
 	identityDeserializer.Msg = sProp.ProposalBytes
@@ -1026,10 +1160,13 @@ func TestGetInstalledChaincodesAccessRights(t *testing.T) {
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewDefaultACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 
 	// Should pass
@@ -1070,10 +1207,13 @@ func TestGetChaincodesAccessRights(t *testing.T) {
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewDefaultACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 
 	// Should pass
@@ -1114,10 +1254,13 @@ func TestGetCCAccessRights(t *testing.T) {
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewDefaultACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 
 	cds, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", "0", [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}, true)
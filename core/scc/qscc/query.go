@@ -22,7 +22,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/flogging"
 
-	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/peer"
@@ -38,7 +38,7 @@ import (
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
 type LedgerQuerier struct {
-	policyChecker policy.PolicyChecker
+	aclProvider aclmgmt.ACLProvider
 }
 
 var qscclogger = flogging.MustGetLogger("qscc")
@@ -58,11 +58,14 @@ const (
 func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 	qscclogger.Info("Init QSCC")
 
-	// Init policy checker for access control
-	e.policyChecker = policy.NewPolicyChecker(
-		peer.NewChannelPolicyManagerGetter(),
-		mgmt.GetLocalMSP(),
-		mgmt.NewLocalMSPPrincipalGetter(),
+	// Init ACL provider for access control
+	e.aclProvider = aclmgmt.NewDefaultACLProvider(
+		policy.NewPolicyChecker(
+			peer.NewChannelPolicyManagerGetter(),
+			mgmt.GetLocalMSP(),
+			mgmt.NewLocalMSPPrincipalGetter(),
+		),
+		peer.GetPolicyRefForAPI,
 	)
 
 	return shim.Success(nil)
@@ -84,6 +87,13 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	fname := string(args[0])
 	cid := string(args[1])
 
+	// Unknown functions have no ACL, so they are rejected before the ACL check
+	switch fname {
+	case GetChainInfo, GetBlockByNumber, GetBlockByHash, GetTransactionByID, GetBlockByTxID:
+	default:
+		return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
+	}
+
 	if fname != GetChainInfo && len(args) < 3 {
 		return shim.Error(fmt.Sprintf("missing 3rd argument for %s", fname))
 	}
@@ -102,8 +112,8 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return shim.Error(fmt.Sprintf("Failed getting signed proposal from stub, %s: %s", cid, err))
 	}
 
-	// 2. check the channel reader policy
-	if err = e.policyChecker.CheckPolicy(cid, policies.ChannelApplicationReaders, sp); err != nil {
+	// 2. check the ACL of the channel for the function
+	if err = e.aclProvider.CheckACL("qscc/"+fname, cid, sp); err != nil {
 		return shim.Error(fmt.Sprintf("Authorization request failed %s: %s", cid, err))
 	}
 
@@ -118,9 +128,9 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	default:
+		return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
 	}
-
-	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
 }
 
 func getTransactionByID(vledger ledger.PeerLedger, tid []byte) pb.Response {
@@ -23,6 +23,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/policy"
@@ -164,10 +165,13 @@ func TestFailingAccessControl(t *testing.T) {
 			chainid: &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: &policymocks.MockIdentityDeserializer{Identity: []byte("Alice"), Msg: []byte("msg1")}}},
 		},
 	}
-	e.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		&policymocks.MockIdentityDeserializer{Identity: []byte("Alice"), Msg: []byte("msg1")},
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	e.aclProvider = aclmgmt.NewDefaultACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			&policymocks.MockIdentityDeserializer{Identity: []byte("Alice"), Msg: []byte("msg1")},
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 	stub := shim.NewMockStub("LedgerQuerier", e)
 
@@ -196,6 +200,7 @@ func TestQueryNonexistentFunction(t *testing.T) {
 	args := [][]byte{[]byte("GetBlocks"), []byte(chainid), []byte("arg1")}
 	res := stub.MockInvoke("1", args)
 	assert.Equal(t, int32(shim.ERROR), res.Status, "GetBlocks should have failed because the function does not exist")
+	assert.Equal(t, "Requested function GetBlocks not found.", res.Message)
 }
 
 // TestQueryGeneratedBlock tests various queries for a newly generated block
@@ -17,6 +17,7 @@ limitations under the License.
 package vscc
 
 import (
+	"bytes"
 	"fmt"
 
 	"errors"
@@ -257,31 +258,10 @@ func (vscc *ValidatorOneValidSignature) ValidateLSCCInvocation(stub shim.Chainco
 			return fmt.Errorf("VSCC error: invocation of lscc(%s) does not have appropriate arguments", lsccFunc)
 		}
 
-		// get the rwset
-		pRespPayload, err := utils.GetProposalResponsePayload(cap.Action.ProposalResponsePayload)
+		// get the rwset and extract the one for lscc
+		txRWSet, lsccrwset, err := vscc.getLSCCRWSet(cap)
 		if err != nil {
-			return fmt.Errorf("GetProposalResponsePayload error %s", err)
-		}
-		if pRespPayload.Extension == nil {
-			return fmt.Errorf("nil pRespPayload.Extension")
-		}
-		respPayload, err := utils.GetChaincodeAction(pRespPayload.Extension)
-		if err != nil {
-			return fmt.Errorf("GetChaincodeAction error %s", err)
-		}
-		txRWSet := &rwsetutil.TxRwSet{}
-		if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
-			return fmt.Errorf("txRWSet.FromProtoBytes error %s", err)
-		}
-
-		// extract the rwset for lscc
-		var lsccrwset *kvrwset.KVRWSet
-		for _, ns := range txRWSet.NsRwSets {
-			logger.Debugf("Namespace %s", ns.NameSpace)
-			if ns.NameSpace == "lscc" {
-				lsccrwset = ns.KvRwSet
-				break
-			}
+			return err
 		}
 
 		// retrieve from the ledger the entry for the chaincode at hand
@@ -388,12 +368,140 @@ func (vscc *ValidatorOneValidSignature) ValidateLSCCInvocation(stub shim.Chainco
 		}
 
 		// all is good!
+		return nil
+	case lscc.SETPOLICY:
+		logger.Debugf("VSCC info: validating invocation of lscc function %s on arguments %#v", lsccFunc, lsccArgs)
+
+		if len(lsccArgs) < 3 || len(lsccArgs) > 5 {
+			return fmt.Errorf("Wrong number of arguments for invocation lscc(%s): expected between 3 and 5, received %d", lsccFunc, len(lsccArgs))
+		}
+
+		ccname := string(lsccArgs[1])
+		if ccname == "" || len(lsccArgs[2]) == 0 || cap.Action == nil || cap.Action.ProposalResponsePayload == nil {
+			return fmt.Errorf("VSCC error: invocation of lscc(%s) does not have appropriate arguments", lsccFunc)
+		}
+
+		txRWSet, lsccrwset, err := vscc.getLSCCRWSet(cap)
+		if err != nil {
+			return err
+		}
+
+		// retrieve from the ledger the entry for the chaincode at hand
+		cdLedger, ccExistsOnLedger, err := vscc.getInstantiatedCC(chid, ccname)
+		if err != nil {
+			return err
+		}
+
+		/******************************************/
+		/* security check 0 - validation of rwset */
+		/******************************************/
+		// there has to be one
+		if lsccrwset == nil {
+			return errors.New("No read write set for lscc was found")
+		}
+		// there can only be a single one
+		if len(lsccrwset.Writes) != 1 {
+			return errors.New("LSCC can only issue a single putState upon setpolicy")
+		}
+		// the key name must be the chaincode id
+		if lsccrwset.Writes[0].Key != ccname {
+			return fmt.Errorf("Expected key %s, found %s", ccname, lsccrwset.Writes[0].Key)
+		}
+		// the value must be a ChaincodeData struct
+		cdRWSet := &ccprovider.ChaincodeData{}
+		err = proto.Unmarshal(lsccrwset.Writes[0].Value, cdRWSet)
+		if err != nil {
+			return fmt.Errorf("Unmarhsalling of ChaincodeData failed, error %s", err)
+		}
+		// it must only write to LSCC's namespace
+		for _, ns := range txRWSet.NsRwSets {
+			if ns.NameSpace != "lscc" && len(ns.KvRwSet.Writes) > 0 {
+				return fmt.Errorf("LSCC invocation is attempting to write to namespace %s", ns.NameSpace)
+			}
+		}
+
+		/**************************************************************/
+		/* security check 1 - cc in the LCCC table of instantiated cc */
+		/**************************************************************/
+		if !ccExistsOnLedger {
+			return fmt.Errorf("Setting the policy of non-existent chaincode %s", ccname)
+		}
+
+		/*****************************************************/
+		/* security check 2 - check the instantiation policy */
+		/*****************************************************/
+		pol := cdLedger.InstantiationPolicy
+		if pol == nil {
+			return fmt.Errorf("No installation policy was specified")
+		}
+		err = vscc.checkInstantiationPolicy(chid, env, pol, payl)
+		if err != nil {
+			return err
+		}
+
+		/****************************************************************/
+		/* security check 3 - only the policy, escc and vscc may change */
+		/****************************************************************/
+		if cdRWSet.Name != cdLedger.Name || cdRWSet.Version != cdLedger.Version ||
+			!bytes.Equal(cdRWSet.Id, cdLedger.Id) ||
+			!bytes.Equal(cdRWSet.InstantiationPolicy, cdLedger.InstantiationPolicy) {
+			return fmt.Errorf("Setting the policy of chaincode %s must not modify its name, version, id or instantiation policy", ccname)
+		}
+		if !bytes.Equal(cdRWSet.Policy, lsccArgs[2]) {
+			return fmt.Errorf("Policy written for chaincode %s does not match the requested one", ccname)
+		}
+		expectedEscc := cdLedger.Escc
+		if len(lsccArgs) > 3 && len(lsccArgs[3]) > 0 {
+			expectedEscc = string(lsccArgs[3])
+		}
+		if cdRWSet.Escc != expectedEscc {
+			return fmt.Errorf("Expected escc %s, found %s", expectedEscc, cdRWSet.Escc)
+		}
+		expectedVscc := cdLedger.Vscc
+		if len(lsccArgs) > 4 && len(lsccArgs[4]) > 0 {
+			expectedVscc = string(lsccArgs[4])
+		}
+		if cdRWSet.Vscc != expectedVscc {
+			return fmt.Errorf("Expected vscc %s, found %s", expectedVscc, cdRWSet.Vscc)
+		}
+
 		return nil
 	default:
 		return fmt.Errorf("VSCC error: committing an invocation of function %s of lscc is invalid", lsccFunc)
 	}
 }
 
+// getLSCCRWSet returns the read-write set of the action and the one for lscc's namespace, if any
+func (vscc *ValidatorOneValidSignature) getLSCCRWSet(cap *pb.ChaincodeActionPayload) (*rwsetutil.TxRwSet, *kvrwset.KVRWSet, error) {
+	pRespPayload, err := utils.GetProposalResponsePayload(cap.Action.ProposalResponsePayload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("GetProposalResponsePayload error %s", err)
+	}
+	if pRespPayload.Extension == nil {
+		return nil, nil, fmt.Errorf("nil pRespPayload.Extension")
+	}
+	respPayload, err := utils.GetChaincodeAction(pRespPayload.Extension)
+	if err != nil {
+		return nil, nil, fmt.Errorf("GetChaincodeAction error %s", err)
+	}
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+		return nil, nil, fmt.Errorf("txRWSet.FromProtoBytes error %s", err)
+	}
+
+	// extract the rwset for lscc
+	var lsccrwset *kvrwset.KVRWSet
+	for _, ns := range txRWSet.NsRwSets {
+		logger.Debugf("Namespace %s", ns.NameSpace)
+		if ns.NameSpace == "lscc" {
+			lsccrwset = ns.KvRwSet
+			break
+		}
+	}
+
+	return txRWSet, lsccrwset, nil
+}
+
 func (vscc *ValidatorOneValidSignature) getInstantiatedCC(chid, ccid string) (cd *ccprovider.ChaincodeData, exists bool, err error) {
 	qe, err := vscc.sccprovider.GetQueryExecutorForLedger(chid)
 	if err != nil {
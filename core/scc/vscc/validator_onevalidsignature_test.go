@@ -1401,6 +1401,155 @@ func TestValidateUpgradeWithPoliciesFail(t *testing.T) {
 	}
 }
 
+func createLSCCSetPolicyTx(ccname string, lsccArgs [][]byte, cd *ccprovider.ChaincodeData, extraNs string) (*common.Envelope, error) {
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToWriteSet("lscc", ccname, utils.MarshalOrPanic(cd))
+	if extraNs != "" {
+		rwsetBuilder.AddToWriteSet(extraNs, "key", []byte("value"))
+	}
+	res, err := rwsetBuilder.GetTxReadWriteSet().ToProtoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	cis := &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			ChaincodeId: &peer.ChaincodeID{Name: "lscc"},
+			Input: &peer.ChaincodeInput{
+				Args: append([][]byte{[]byte(lscc.SETPOLICY), []byte(chainId), []byte(ccname)}, lsccArgs...),
+			},
+			Type: peer.ChaincodeSpec_GOLANG,
+		},
+	}
+
+	prop, _, err := utils.CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, util.GetTestChainID(), cis, sid)
+	if err != nil {
+		return nil, err
+	}
+
+	ccid := &peer.ChaincodeID{Name: "lscc", Version: util.GetSysCCVersion()}
+
+	presp, err := utils.CreateProposalResponse(prop.Header, prop.Payload, &peer.Response{Status: 200}, res, nil, ccid, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.CreateSignedTx(prop, id, presp)
+}
+
+func TestValidateSetPolicy(t *testing.T) {
+	v := new(ValidatorOneValidSignature)
+	stub := shim.NewMockStub("validatoronevalidsignature", v)
+
+	lccc := new(lscc.LifeCycleSysCC)
+	stublccc := shim.NewMockStub("lscc", lccc)
+
+	State := make(map[string]map[string][]byte)
+	State["lscc"] = stublccc.State
+	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{Qe: lm.NewMockQueryExecutor(State)})
+	stub.MockPeerChaincode("lscc", stublccc)
+
+	r1 := stub.MockInit("1", [][]byte{})
+	assert.Equal(t, int32(shim.OK), r1.Status, r1.Message)
+	r := stublccc.MockInit("1", [][]byte{})
+	assert.Equal(t, int32(shim.OK), r.Status, r.Message)
+
+	ccname := "mycc"
+	ccver := "1"
+	path := "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02"
+	ppath := lccctestpath + "/" + ccname + "." + ccver
+	os.Remove(ppath)
+
+	cds, err := constructDeploymentSpec(ccname, path, ccver, [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}, true)
+	assert.NoError(t, err)
+	defer os.Remove(ppath)
+	b, err := proto.Marshal(cds)
+	assert.NoError(t, err)
+
+	sProp2, _ := utils.MockSignedEndorserProposal2OrPanic(chainId, &peer.ChaincodeSpec{}, id)
+	args := [][]byte{[]byte("deploy"), []byte(ccname), b}
+	res := stublccc.MockInvokeWithSignedProposal("1", args, sProp2)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	policy, err := getSignedByMSPMemberPolicy(mspid)
+	assert.NoError(t, err)
+	newPolicy, err := getSignedByMSPAdminPolicy(mspid)
+	assert.NoError(t, err)
+
+	ledgerCD := func() *ccprovider.ChaincodeData {
+		cd := &ccprovider.ChaincodeData{}
+		assert.NoError(t, proto.Unmarshal(stublccc.State[ccname], cd))
+		return cd
+	}
+
+	validate := func(txName string, lsccArgs [][]byte, cd *ccprovider.ChaincodeData, extraNs string) peer.Response {
+		tx, err := createLSCCSetPolicyTx(txName, lsccArgs, cd, extraNs)
+		assert.NoError(t, err)
+		envBytes, err := utils.GetBytesEnvelope(tx)
+		assert.NoError(t, err)
+		return stub.MockInvoke("1", [][]byte{[]byte("dv"), envBytes, policy})
+	}
+
+	// good path: only the policy changes
+	cd := ledgerCD()
+	cd.Policy = newPolicy
+	res = validate(ccname, [][]byte{newPolicy}, cd, "")
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	// good path: escc and vscc explicitly changed
+	cd = ledgerCD()
+	cd.Policy = newPolicy
+	cd.Escc = "myescc"
+	cd.Vscc = "myvscc"
+	res = validate(ccname, [][]byte{newPolicy, []byte("myescc"), []byte("myvscc")}, cd, "")
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	// bad path: escc changed without being requested
+	cd = ledgerCD()
+	cd.Policy = newPolicy
+	cd.Escc = "myescc"
+	res = validate(ccname, [][]byte{newPolicy}, cd, "")
+	assert.NotEqual(t, int32(shim.OK), res.Status)
+
+	// bad path: policy written is not the one requested
+	cd = ledgerCD()
+	cd.Policy = policy
+	res = validate(ccname, [][]byte{newPolicy}, cd, "")
+	assert.NotEqual(t, int32(shim.OK), res.Status)
+
+	// bad path: version modified
+	cd = ledgerCD()
+	cd.Policy = newPolicy
+	cd.Version = "2"
+	res = validate(ccname, [][]byte{newPolicy}, cd, "")
+	assert.NotEqual(t, int32(shim.OK), res.Status)
+
+	// bad path: instantiation policy modified
+	cd = ledgerCD()
+	cd.Policy = newPolicy
+	cd.InstantiationPolicy = utils.MarshalOrPanic(cauthdsl.AcceptAllPolicy)
+	res = validate(ccname, [][]byte{newPolicy}, cd, "")
+	assert.NotEqual(t, int32(shim.OK), res.Status)
+
+	// bad path: writes to the namespace of the chaincode
+	cd = ledgerCD()
+	cd.Policy = newPolicy
+	res = validate(ccname, [][]byte{newPolicy}, cd, ccname)
+	assert.NotEqual(t, int32(shim.OK), res.Status)
+
+	// bad path: chaincode doesn't exist
+	cd = ledgerCD()
+	cd.Name = "othercc"
+	cd.Policy = newPolicy
+	res = validate("othercc", [][]byte{newPolicy}, cd, "")
+	assert.NotEqual(t, int32(shim.OK), res.Status)
+
+	// bad path: no policy
+	cd = ledgerCD()
+	res = validate(ccname, [][]byte{nil}, cd, "")
+	assert.NotEqual(t, int32(shim.OK), res.Status)
+}
+
 var id msp.SigningIdentity
 var sid []byte
 var mspid string
@@ -1428,6 +1577,10 @@ func (c *mockPolicyChecker) CheckPolicyNoChannel(policyName string, signedProp *
 	return nil
 }
 
+func (c *mockPolicyChecker) CheckPolicyNoChannelBySignedData(policyName string, sd []*common.SignedData) error {
+	return nil
+}
+
 var lccctestpath = "/tmp/lscc-validation-test"
 
 func TestMain(m *testing.M) {
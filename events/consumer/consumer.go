@@ -17,6 +17,7 @@ limitations under the License.
 package consumer
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -42,6 +43,7 @@ type EventsClient struct {
 	regTimeout  time.Duration
 	stream      ehpb.Events_ChatClient
 	adapter     EventAdapter
+	tlsCertHash []byte // the hash of the client TLS certificate the events are bound to
 }
 
 //NewEventsClient Returns a new grpc.ClientConn to the configured local PEER.
@@ -54,13 +56,14 @@ func NewEventsClient(peerAddress string, regTimeout time.Duration, adapter Event
 		regTimeout = 60 * time.Second
 		err = fmt.Errorf("regTimeout > 60, setting to 60 sec")
 	}
-	return &EventsClient{sync.RWMutex{}, peerAddress, regTimeout, nil, adapter}, err
+	return &EventsClient{sync.RWMutex{}, peerAddress, regTimeout, nil, adapter, nil}, err
 }
 
-//newEventsClientConnectionWithAddress Returns a new grpc.ClientConn to the configured local PEER.
-func newEventsClientConnectionWithAddress(peerAddress string) (*grpc.ClientConn, error) {
+//newEventsClientConnectionWithAddress Returns a new grpc.ClientConn to the configured local PEER,
+//presenting the client TLS certificate when TLS is enabled
+func newEventsClientConnectionWithAddress(peerAddress string, cert tls.Certificate) (*grpc.ClientConn, error) {
 	if comm.TLSEnabled() {
-		return comm.NewClientConnectionWithAddress(peerAddress, true, true, comm.InitTLSForPeer())
+		return comm.NewClientConnectionWithAddress(peerAddress, true, true, comm.InitTLSForPeerWithClientCert(cert))
 	}
 	return comm.NewClientConnectionWithAddress(peerAddress, true, false, nil)
 }
@@ -86,6 +89,7 @@ func (ec *EventsClient) send(emsg *ehpb.Event) error {
 		return fmt.Errorf("fail to serialize the default signing identity, err %s", err)
 	}
 	emsg.Creator = signerCert
+	emsg.TlsCertHash = ec.tlsCertHash
 
 	signedEvt, err := utils.GetSignedEvent(emsg, signer)
 	if err != nil {
@@ -201,7 +205,13 @@ func (ec *EventsClient) processEvents() error {
 
 //Start establishes connection with Event hub and registers interested events with it
 func (ec *EventsClient) Start() error {
-	conn, err := newEventsClientConnectionWithAddress(ec.peerAddress)
+	cert, err := comm.GetClientCertificate()
+	if err != nil {
+		return fmt.Errorf("could not load the client TLS certificate: %s", err)
+	}
+	ec.tlsCertHash = comm.TLSCertificateHash(cert)
+
+	conn, err := newEventsClientConnectionWithAddress(ec.peerAddress, cert)
 	if err != nil {
 		return fmt.Errorf("could not create client conn to %s:%s", ec.peerAddress, err)
 	}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package consumer
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"os"
@@ -137,7 +138,7 @@ func TestNewEventsClientConnectionWithAddress(t *testing.T) {
 	for _, test := range cases {
 		t.Run(test.name, func(t *testing.T) {
 			t.Logf("Running test: %s", test.name)
-			_, err := newEventsClientConnectionWithAddress(test.address)
+			_, err := newEventsClientConnectionWithAddress(test.address, tls.Certificate{})
 			if test.expected {
 				assert.NoError(t, err)
 			} else {
@@ -117,7 +117,13 @@ func CreateChaincodeEvent(te *pb.ChaincodeEvent) *pb.Event {
 	return &pb.Event{Event: &pb.Event_ChaincodeEvent{ChaincodeEvent: te}}
 }
 
-//CreateRejectionEvent creates an Event from TxResults
-func CreateRejectionEvent(tx *pb.Transaction, errorMsg string) *pb.Event {
-	return &pb.Event{Event: &pb.Event_Rejection{Rejection: &pb.Rejection{Tx: tx, ErrorMsg: errorMsg}}}
+//CreateRejectionEvent creates an Event for a transaction which failed validation
+func CreateRejectionEvent(tx *pb.Transaction, chainID, txID string, code pb.TxValidationCode, errorMsg string) *pb.Event {
+	return &pb.Event{Event: &pb.Event_Rejection{Rejection: &pb.Rejection{
+		Tx:             tx,
+		ErrorMsg:       errorMsg,
+		TxId:           txID,
+		ChannelId:      chainID,
+		ValidationCode: code,
+	}}}
 }
@@ -22,18 +22,30 @@ import (
 
 	"github.com/golang/protobuf/proto"
 
+	"github.com/hyperledger/fabric/core/aclmgmt"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/policy"
 	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
 type handler struct {
-	ChatStream       pb.Events_ChatServer
-	interestedEvents map[string]*pb.Interest
+	ChatStream         pb.Events_ChatServer
+	interestedEvents   map[string]*pb.Interest
+	tlsBindingRequired func() bool
+	aclProvider        aclmgmt.ACLProvider
 }
 
 func newEventHandler(stream pb.Events_ChatServer) (*handler, error) {
 	d := &handler{
 		ChatStream: stream,
+		// The event hub ACL by default requires the creator to satisfy
+		// the local MSP's [member] principal
+		aclProvider: aclmgmt.NewDefaultACLProvider(
+			policy.NewPolicyChecker(nil, mgmt.GetLocalMSP(), mgmt.NewLocalMSPPrincipalGetter()),
+			nil,
+		),
 	}
 	d.interestedEvents = make(map[string]*pb.Interest)
 	return d, nil
@@ -99,11 +111,15 @@ func (d *handler) deregisterAll() {
 
 // HandleMessage handles the Openchain messages for the Peer.
 func (d *handler) HandleMessage(msg *pb.SignedEvent) error {
-	evt, err := validateEventMessage(msg)
+	evt, err := d.validateEventMessage(msg)
 	if err != nil {
 		return fmt.Errorf("event message must be properly signed by an identity from the same organization as the peer: [%s]", err)
 	}
 
+	if err := d.verifyTLSBinding(evt); err != nil {
+		return fmt.Errorf("event message must be sent by the client it is bound to: [%s]", err)
+	}
+
 	switch evt.Event.(type) {
 	case *pb.Event_Register:
 		eventsObj := evt.GetRegister()
@@ -136,10 +152,21 @@ func (d *handler) SendMessage(msg *pb.Event) error {
 	return nil
 }
 
+// verifyTLSBinding checks that an event message carrying the hash of a TLS certificate,
+// or any message if the binding is required, is sent by the client presenting it
+func (d *handler) verifyTLSBinding(evt *pb.Event) error {
+	required := d.tlsBindingRequired != nil && d.tlsBindingRequired()
+	if len(evt.TlsCertHash) == 0 && !required {
+		return nil
+	}
+	return comm.VerifyTLSBinding(d.ChatStream.Context(), evt.TlsCertHash, required)
+}
+
 // Validates event messages by validating the Creator and verifying
 // the signature. Returns the unmarshaled Event object
-// Validation of the creator identity's validity is done by checking with local MSP to ensure the
-// submitter is a member in the same organization as the peer
+// Validation of the creator identity's validity is done by checking the event hub ACL, which
+// by default ensures with the local MSP that the submitter is a member in the same organization
+// as the peer
 //
 // TODO: ideally this should also check each channel's "Readers" policy to ensure the identity satisfies
 // each channel's access control policy. This step is necessary because the registered listener is going
@@ -147,7 +174,7 @@ func (d *handler) SendMessage(msg *pb.Event) error {
 // However, this is not being done for v1.0 due to complexity concerns and the need to complex a stable,
 // minimally viable release. Eventually events will be made channel-specific, at which point this method
 // should be revisited
-func validateEventMessage(signedEvt *pb.SignedEvent) (*pb.Event, error) {
+func (d *handler) validateEventMessage(signedEvt *pb.SignedEvent) (*pb.Event, error) {
 	logger.Debugf("ValidateEventMessage starts for signed event %p", signedEvt)
 
 	// messages from the client for registering and unregistering must be signed
@@ -158,30 +185,14 @@ func validateEventMessage(signedEvt *pb.SignedEvent) (*pb.Event, error) {
 		return nil, fmt.Errorf("error unmarshaling the event bytes in the SignedEvent: %s", err)
 	}
 
-	localMSP := mgmt.GetLocalMSP()
-	principalGetter := mgmt.NewLocalMSPPrincipalGetter()
-
-	// Load MSPPrincipal for policy
-	principal, err := principalGetter.Get(mgmt.Members)
-	if err != nil {
-		return nil, fmt.Errorf("failed getting local MSP principal [member]: [%s]", err)
-	}
-
-	id, err := localMSP.DeserializeIdentity(evt.Creator)
-	if err != nil {
-		return nil, fmt.Errorf("failed deserializing event creator: [%s]", err)
-	}
-
-	// Verify that event's creator satisfies the principal
-	err = id.SatisfiesPrincipal(principal)
-	if err != nil {
-		return nil, fmt.Errorf("failed verifying the creator satisfies local MSP's [member] principal: [%s]", err)
-	}
-
-	// Verify the signature
-	err = id.Verify(signedEvt.EventBytes, signedEvt.Signature)
-	if err != nil {
-		return nil, fmt.Errorf("failed verifying the event signature: %s", err)
+	// Check the event hub ACL and verify the signature
+	sd := []*common.SignedData{{
+		Data:      signedEvt.EventBytes,
+		Identity:  evt.Creator,
+		Signature: signedEvt.Signature,
+	}}
+	if err = d.aclProvider.CheckACL(aclmgmt.EventRegister, "", sd); err != nil {
+		return nil, fmt.Errorf("failed checking event creator: [%s]", err)
 	}
 
 	return evt, nil
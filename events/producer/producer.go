@@ -29,6 +29,9 @@ var logger = flogging.MustGetLogger("eventhub_producer")
 
 // EventsServer implementation of the Peer service
 type EventsServer struct {
+	// tlsBindingRequired returns whether registrations must be
+	// bound to the TLS certificate of the client
+	tlsBindingRequired func() bool
 }
 
 //singleton - if we want to create multiple servers, we need to subsume events.gEventConsumers into EventsServer
@@ -45,12 +48,21 @@ func NewEventsServer(bufferSize uint, timeout time.Duration) *EventsServer {
 	return globalEventsServer
 }
 
+// SetTLSBindingRequired sets the function deciding whether registrations must be
+// bound to the TLS certificate of the client. As registered clients receive the
+// blocks of all the channels, it should require the binding as soon as one
+// channel does.
+func (p *EventsServer) SetTLSBindingRequired(tlsBindingRequired func() bool) {
+	p.tlsBindingRequired = tlsBindingRequired
+}
+
 // Chat implementation of the Chat bidi streaming RPC function
 func (p *EventsServer) Chat(stream pb.Events_ChatServer) error {
 	handler, err := newEventHandler(stream)
 	if err != nil {
 		return fmt.Errorf("error creating handler during handleChat initiation: %s", err)
 	}
+	handler.tlsBindingRequired = p.tlsBindingRequired
 	defer handler.Stop()
 	for {
 		in, err := stream.Recv()
@@ -17,6 +17,8 @@ limitations under the License.
 package producer
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
@@ -33,10 +35,12 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/grpc/metadata"
+	grpcpeer "google.golang.org/grpc/peer"
 
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	mmsp "github.com/hyperledger/fabric/common/mocks/msp"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/config"
 	coreutil "github.com/hyperledger/fabric/core/testutil"
 	"github.com/hyperledger/fabric/events/consumer"
@@ -145,8 +149,14 @@ func TestSignedEvent(t *testing.T) {
 		return
 	}
 
+	d, err := newEventHandler(nil)
+	if err != nil {
+		t.Fatalf("newEventHandler failed, err %s", err)
+		return
+	}
+
 	// validate it. Expected to succeed
-	_, err = validateEventMessage(sEvt)
+	_, err = d.validateEventMessage(sEvt)
 	if err != nil {
 		t.Fatalf("validateEventMessage failed, err %s", err)
 		return
@@ -156,7 +166,7 @@ func TestSignedEvent(t *testing.T) {
 	corrupt(sEvt.Signature)
 
 	// validate it, it should fail
-	_, err = validateEventMessage(sEvt)
+	_, err = d.validateEventMessage(sEvt)
 	if err == nil {
 		t.Fatalf("validateEventMessage should have failed")
 		return
@@ -177,7 +187,7 @@ func TestSignedEvent(t *testing.T) {
 	}
 
 	// validate it, it should fail
-	_, err = validateEventMessage(sEvt)
+	_, err = d.validateEventMessage(sEvt)
 	if err == nil {
 		t.Fatalf("validateEventMessage should have failed")
 		return
@@ -352,7 +362,8 @@ func TestNewEventsServer(t *testing.T) {
 }
 
 type mockstream struct {
-	c chan *streamEvent
+	c   chan *streamEvent
+	ctx context.Context
 }
 
 type streamEvent struct {
@@ -384,8 +395,11 @@ func (*mockstream) SetTrailer(metadata.MD) {
 	panic("not implemented")
 }
 
-func (*mockstream) Context() context.Context {
-	panic("not implemented")
+func (ms *mockstream) Context() context.Context {
+	if ms.ctx == nil {
+		panic("not implemented")
+	}
+	return ms.ctx
 }
 
 func (*mockstream) SendMsg(m interface{}) error {
@@ -396,6 +410,24 @@ func (*mockstream) RecvMsg(m interface{}) error {
 	panic("not implemented")
 }
 
+func TestEventTLSBinding(t *testing.T) {
+	cert := &x509.Certificate{Raw: []byte("client cert")}
+	stream := &mockstream{ctx: grpcpeer.NewContext(context.Background(), &grpcpeer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}},
+	})}
+	handler, err := newEventHandler(stream)
+	assert.NoError(t, err)
+
+	assert.NoError(t, handler.verifyTLSBinding(&peer.Event{}))
+	assert.NoError(t, handler.verifyTLSBinding(&peer.Event{TlsCertHash: comm.CertificateHash(cert.Raw)}))
+	assert.Error(t, handler.verifyTLSBinding(&peer.Event{TlsCertHash: comm.CertificateHash([]byte("another cert"))}))
+
+	// Once a channel requires the binding, every registration must be bound
+	handler.tlsBindingRequired = func() bool { return true }
+	assert.Error(t, handler.verifyTLSBinding(&peer.Event{}))
+	assert.NoError(t, handler.verifyTLSBinding(&peer.Event{TlsCertHash: comm.CertificateHash(cert.Raw)}))
+}
+
 func TestChat(t *testing.T) {
 	recvChan := make(chan *streamEvent)
 	stream := &mockstream{c: recvChan}
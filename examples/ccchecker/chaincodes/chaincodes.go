@@ -17,6 +17,7 @@ limitations under the License.
 package chaincodes
 
 import (
+	"crypto/tls"
 	"fmt"
 	"sync"
 	"time"
@@ -158,7 +159,7 @@ func (cc *CCClient) doInvokes(ctxt context.Context, chainID string,
 		}
 
 		var pResp *pb.ProposalResponse
-		if pResp, err = chaincode.ChaincodeInvokeOrQuery(spec, chainID, true, signer, ec, bc); err != nil {
+		if pResp, err = chaincode.ChaincodeInvokeOrQuery(spec, chainID, true, signer, tls.Certificate{}, ec, bc); err != nil {
 			cc.invokeErr = err
 			break
 		}
@@ -237,7 +238,7 @@ func (cc *CCClient) validateIter(ctxt context.Context, iter int, chainID string,
 
 		var pResp *pb.ProposalResponse
 		var err error
-		if pResp, err = chaincode.ChaincodeInvokeOrQuery(spec, chainID, false, signer, ec, bc); err != nil {
+		if pResp, err = chaincode.ChaincodeInvokeOrQuery(spec, chainID, false, signer, tls.Certificate{}, ec, bc); err != nil {
 			cc.queryErrs[iter] = err
 			break
 		}
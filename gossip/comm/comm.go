@@ -17,6 +17,7 @@ limitations under the License.
 package comm
 
 import (
+	"crypto/tls"
 	"fmt"
 
 	"github.com/hyperledger/fabric/gossip/api"
@@ -52,6 +53,17 @@ type Comm interface {
 	// CloseConn closes a connection to a certain endpoint
 	CloseConn(peer *RemotePeer)
 
+	// Penalize lowers the reputation of a remote peer for an offense it committed.
+	// A peer whose reputation falls to a threshold is disconnected and blacklisted for a time
+	Penalize(pkiID common.PKIidType, offense Offense)
+
+	// Reputations returns the reputation of the remote peers that misbehaved
+	Reputations() []PeerReputation
+
+	// UpdateTLSCertificate replaces the TLS certificate the connections
+	// with remote peers are bound to, after it was renewed
+	UpdateTLSCertificate(cert *tls.Certificate)
+
 	// Stop stops the module
 	Stop()
 }
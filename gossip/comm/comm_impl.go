@@ -93,6 +93,8 @@ func NewCommInstanceWithServer(port int, idMapper identity.Mapper, peerIdentity
 		stopping:       int32(0),
 		exitChan:       make(chan struct{}, 1),
 		subscriptions:  make([]chan proto.ReceivedMessage, 0),
+		reputation:     newReputationStore(),
+		rateLimiter:    newRateLimiter(),
 	}
 	commInst.connStore = newConnStore(commInst, commInst.logger)
 
@@ -152,6 +154,8 @@ type commImpl struct {
 	subscriptions  []chan proto.ReceivedMessage
 	port           int
 	stopping       int32
+	reputation     *reputationStore
+	rateLimiter    *rateLimiter
 }
 
 func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidType) (*connection, error) {
@@ -202,6 +206,9 @@ func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidT
 
 			h := func(m *proto.SignedGossipMessage) {
 				c.logger.Debug("Got message:", m)
+				if !c.admit(pkiID, m) {
+					return
+				}
 				c.msgPublisher.DeMultiplex(&ReceivedMessageImpl{
 					conn:                conn,
 					lock:                conn,
@@ -210,6 +217,9 @@ func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidT
 				})
 			}
 			conn.handler = h
+			conn.onOffense = func(offense Offense) {
+				c.Penalize(pkiID, offense)
+			}
 			return conn, nil
 		}
 		c.logger.Warning("Authentication failed:", err)
@@ -357,6 +367,56 @@ func (c *commImpl) CloseConn(peer *RemotePeer) {
 	c.connStore.closeConn(peer)
 }
 
+func (c *commImpl) Penalize(pkiID common.PKIidType, offense Offense) {
+	if !c.reputation.penalize(pkiID, offense) {
+		c.logger.Debug("Penalized", pkiID, "for", offense)
+		return
+	}
+	c.logger.Warning("Blacklisting", pkiID, "whose reputation dropped too low, last offense:", offense)
+	c.rateLimiter.forget(pkiID)
+	go c.disconnect(pkiID)
+}
+
+func (c *commImpl) Reputations() []PeerReputation {
+	return c.reputation.reputations()
+}
+
+func (c *commImpl) UpdateTLSCertificate(cert *tls.Certificate) {
+	if cert == nil || len(cert.Certificate) == 0 {
+		c.logger.Warning("Ignoring a TLS certificate update with an empty certificate chain")
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.selfCertHash = certHashFromRawCert(cert.Certificate[0])
+}
+
+// certHash returns the hash of the TLS certificate of the peer,
+// bound to the connections it establishes
+func (c *commImpl) certHash() []byte {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.selfCertHash
+}
+
+// admit returns whether a message received from the given peer should be processed,
+// and penalizes the peer once per rateLimitWindow in which it sends messages
+// of the same type too often
+func (c *commImpl) admit(pkiID common.PKIidType, m *proto.SignedGossipMessage) bool {
+	if c.reputation.isBlacklisted(pkiID) {
+		return false
+	}
+	allowed, offense := c.rateLimiter.allow(pkiID, m)
+	if !allowed {
+		c.logger.Debug(pkiID, "exceeded the rate limit, dropping", m)
+		if offense {
+			c.Penalize(pkiID, RateLimitExceeded)
+		}
+		return false
+	}
+	return true
+}
+
 func (c *commImpl) emptySubscriptions() {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -410,7 +470,8 @@ func (c *commImpl) authenticateRemotePeer(stream stream) (*proto.ConnectionInfo,
 	var err error
 	var cMsg *proto.SignedGossipMessage
 	var signer proto.Signer
-	useTLS := c.selfCertHash != nil
+	selfCertHash := c.certHash()
+	useTLS := selfCertHash != nil
 
 	// If TLS is enabled, sign the connection message in order to bind
 	// the TLS session to the peer's identity
@@ -431,7 +492,7 @@ func (c *commImpl) authenticateRemotePeer(stream stream) (*proto.ConnectionInfo,
 		return nil, errors.New("No TLS certificate")
 	}
 
-	cMsg, err = c.createConnectionMsg(c.PKIID, c.selfCertHash, c.peerIdentity, signer)
+	cMsg, err = c.createConnectionMsg(c.PKIID, selfCertHash, c.peerIdentity, signer)
 	if err != nil {
 		return nil, err
 	}
@@ -454,6 +515,11 @@ func (c *commImpl) authenticateRemotePeer(stream stream) (*proto.ConnectionInfo,
 		return nil, errors.New("No PKI-ID")
 	}
 
+	if c.reputation.isBlacklisted(receivedMsg.PkiId) {
+		c.logger.Warning(remoteAddress, "is blacklisted, refusing to connect")
+		return nil, errors.New("Blacklisted")
+	}
+
 	c.logger.Debug("Received", receivedMsg, "from", remoteAddress)
 	err = c.idMapper.Put(receivedMsg.PkiId, receivedMsg.Identity)
 	if err != nil {
@@ -514,6 +580,9 @@ func (c *commImpl) GossipStream(stream proto.Gossip_GossipStreamServer) error {
 	}
 
 	h := func(m *proto.SignedGossipMessage) {
+		if !c.admit(connInfo.ID, m) {
+			return
+		}
 		c.msgPublisher.DeMultiplex(&ReceivedMessageImpl{
 			conn:                conn,
 			lock:                conn,
@@ -523,6 +592,9 @@ func (c *commImpl) GossipStream(stream proto.Gossip_GossipStreamServer) error {
 	}
 
 	conn.handler = h
+	conn.onOffense = func(offense Offense) {
+		c.Penalize(connInfo.ID, offense)
+	}
 
 	defer func() {
 		c.logger.Debug("Client", extractRemoteAddress(stream), " disconnected")
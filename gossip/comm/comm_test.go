@@ -386,6 +386,21 @@ func TestProdConstructor(t *testing.T) {
 	waitForMessages(t, out, 2, "Didn't receive 2 messages")
 }
 
+func TestUpdateTLSCertificate(t *testing.T) {
+	t.Parallel()
+	comm1, _ := newCommInstance(6811, naiveSec)
+	defer comm1.Stop()
+	oldHash := comm1.(*commImpl).certHash()
+
+	comm1.UpdateTLSCertificate(nil)
+	assert.Equal(t, oldHash, comm1.(*commImpl).certHash())
+
+	cert := GenerateCertificatesOrPanic()
+	comm1.UpdateTLSCertificate(&cert)
+	assert.Equal(t, certHashFromRawCert(cert.Certificate[0]), comm1.(*commImpl).certHash())
+	assert.NotEqual(t, oldHash, comm1.(*commImpl).certHash())
+}
+
 func TestGetConnectionInfo(t *testing.T) {
 	t.Parallel()
 	comm1, _ := newCommInstance(6000, naiveSec)
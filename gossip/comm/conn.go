@@ -213,6 +213,7 @@ type connection struct {
 	logger       *logging.Logger                 // logger
 	pkiID        common.PKIidType                // pkiID of the remote endpoint
 	handler      handler                         // function to invoke upon a message reception
+	onOffense    func(Offense)                   // function to invoke upon a misbehavior of the remote endpoint
 	conn         *grpc.ClientConn                // gRPC connection to remote endpoint
 	cl           proto.GossipClient              // gRPC stub of remote endpoint
 	clientStream proto.Gossip_GossipStreamClient // client-side stream to remote endpoint
@@ -345,8 +346,11 @@ func (conn *connection) readFromStream(errChan chan error, msgChan chan *proto.S
 		}
 		msg, err := envelope.ToGossipMessage()
 		if err != nil {
-			errChan <- err
-			conn.logger.Warning(conn.pkiID, "Got error, aborting:", err)
+			conn.logger.Warning(conn.pkiID, "Sent a malformed message:", err)
+			if conn.onOffense != nil {
+				conn.onOffense(MalformedMessage)
+			}
+			continue
 		}
 		msgChan <- msg
 	}
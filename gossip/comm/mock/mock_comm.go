@@ -17,6 +17,8 @@ limitations under the License.
 package mock
 
 import (
+	"crypto/tls"
+
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
 	"github.com/hyperledger/fabric/gossip/common"
@@ -184,6 +186,21 @@ func (mock *commMock) CloseConn(peer *comm.RemotePeer) {
 	// NOOP
 }
 
+// Penalize lowers the reputation of a remote peer for an offense it committed
+func (mock *commMock) Penalize(pkiID common.PKIidType, offense comm.Offense) {
+	// NOOP
+}
+
+// Reputations returns the reputation of the remote peers that misbehaved
+func (mock *commMock) Reputations() []comm.PeerReputation {
+	return nil
+}
+
+// UpdateTLSCertificate replaces the TLS certificate the connections with remote peers are bound to
+func (mock *commMock) UpdateTLSCertificate(cert *tls.Certificate) {
+	// NOOP
+}
+
 // Stop stops the module
 func (mock *commMock) Stop() {
 	logger.Debug("Stopping communication module, closing all accepting channels.")
@@ -0,0 +1,261 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/util"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+)
+
+const (
+	maxReputation                 = 100
+	defReputationThreshold        = 0
+	defBlacklistDuration          = time.Minute * time.Duration(10)
+	defReputationRecoveryInterval = time.Second * time.Duration(10)
+	defRateLimit                  = 100
+	defRateLimitBurst             = 1000
+	// rateLimitWindow is the period over which messages in excess of a rate
+	// limit count as a single offense, so that a burst of them costs a peer
+	// as much reputation as a single one
+	rateLimitWindow = time.Second
+)
+
+// Offense is a misbehavior of a remote peer, which lowers its reputation
+type Offense int
+
+const (
+	// InvalidSignature is a message with a signature that doesn't verify
+	InvalidSignature Offense = iota
+	// MalformedMessage is a message that cannot be parsed, or has illegal content
+	MalformedMessage
+	// OversizedDigest is a pull digest or request with too many items
+	OversizedDigest
+	// RateLimitExceeded is a message sent beyond the allowed rate of its type,
+	// counted once per rateLimitWindow
+	RateLimitExceeded
+)
+
+var offenseNames = map[Offense]string{
+	InvalidSignature:  "invalid signature",
+	MalformedMessage:  "malformed message",
+	OversizedDigest:   "oversized digest",
+	RateLimitExceeded: "rate limit exceeded",
+}
+
+// penalties maps each offense to the reputation it costs
+var penalties = map[Offense]int{
+	InvalidSignature:  20,
+	MalformedMessage:  20,
+	OversizedDigest:   10,
+	RateLimitExceeded: 1,
+}
+
+// String returns a description of the offense
+func (o Offense) String() string {
+	if name, exists := offenseNames[o]; exists {
+		return name
+	}
+	return fmt.Sprintf("offense %d", int(o))
+}
+
+// PeerReputation is the reputation of a remote peer that misbehaved
+type PeerReputation struct {
+	PKIID            common.PKIidType
+	Score            int
+	BlacklistedUntil time.Time
+}
+
+// Blacklisted returns whether the peer is blacklisted
+func (r PeerReputation) Blacklisted() bool {
+	return time.Now().Before(r.BlacklistedUntil)
+}
+
+type peerScore struct {
+	score            int
+	lastUpdate       time.Time
+	blacklistedUntil time.Time
+}
+
+// reputationStore tracks the reputation of remote peers.
+// Every peer starts with the maximum reputation, which drops with each offense
+// and recovers by one point every recovery interval. A peer whose reputation
+// falls to the threshold is blacklisted, and starts over once the blacklisting ends.
+type reputationStore struct {
+	sync.Mutex
+	scores            map[string]*peerScore
+	threshold         int
+	blacklistDuration time.Duration
+	recoveryInterval  time.Duration
+}
+
+func newReputationStore() *reputationStore {
+	return &reputationStore{
+		scores:            make(map[string]*peerScore),
+		threshold:         util.GetIntOrDefault("peer.gossip.reputation.threshold", defReputationThreshold),
+		blacklistDuration: util.GetDurationOrDefault("peer.gossip.reputation.blacklistDuration", defBlacklistDuration),
+		recoveryInterval:  util.GetDurationOrDefault("peer.gossip.reputation.recoveryInterval", defReputationRecoveryInterval),
+	}
+}
+
+// penalize lowers the reputation of the given peer for the given offense,
+// and returns whether the peer got blacklisted as a result
+func (rs *reputationStore) penalize(pkiID common.PKIidType, offense Offense) bool {
+	rs.Lock()
+	defer rs.Unlock()
+
+	now := time.Now()
+	s := rs.scoreOf(pkiID, now)
+	if now.Before(s.blacklistedUntil) {
+		return false
+	}
+	s.score -= penalties[offense]
+	if s.score > rs.threshold {
+		return false
+	}
+	s.blacklistedUntil = now.Add(rs.blacklistDuration)
+	return true
+}
+
+// isBlacklisted returns whether the given peer is blacklisted
+func (rs *reputationStore) isBlacklisted(pkiID common.PKIidType) bool {
+	rs.Lock()
+	defer rs.Unlock()
+
+	s, exists := rs.scores[string(pkiID)]
+	return exists && time.Now().Before(s.blacklistedUntil)
+}
+
+// reputations returns the reputation of the peers that misbehaved,
+// and forgets the ones that fully recovered
+func (rs *reputationStore) reputations() []PeerReputation {
+	rs.Lock()
+	defer rs.Unlock()
+
+	now := time.Now()
+	var res []PeerReputation
+	for pkiID := range rs.scores {
+		s := rs.scoreOf(common.PKIidType(pkiID), now)
+		if s.score >= maxReputation && !now.Before(s.blacklistedUntil) {
+			delete(rs.scores, pkiID)
+			continue
+		}
+		res = append(res, PeerReputation{
+			PKIID:            common.PKIidType(pkiID),
+			Score:            s.score,
+			BlacklistedUntil: s.blacklistedUntil,
+		})
+	}
+	return res
+}
+
+// scoreOf returns the score of the given peer, updated to the given time.
+// Must be called with the lock held.
+func (rs *reputationStore) scoreOf(pkiID common.PKIidType, now time.Time) *peerScore {
+	s, exists := rs.scores[string(pkiID)]
+	if !exists {
+		s = &peerScore{score: maxReputation, lastUpdate: now}
+		rs.scores[string(pkiID)] = s
+		return s
+	}
+
+	if !s.blacklistedUntil.IsZero() && !now.Before(s.blacklistedUntil) {
+		// The blacklisting ended, the peer is given a fresh start
+		s.score = maxReputation
+		s.blacklistedUntil = time.Time{}
+		s.lastUpdate = now
+		return s
+	}
+
+	if rs.recoveryInterval > 0 {
+		recovered := int(now.Sub(s.lastUpdate) / rs.recoveryInterval)
+		s.lastUpdate = s.lastUpdate.Add(time.Duration(recovered) * rs.recoveryInterval)
+		s.score += recovered
+	}
+	if s.score >= maxReputation {
+		s.score = maxReputation
+		s.lastUpdate = now
+	}
+	return s
+}
+
+// tokenBucket allows a number of events per second, with bursts up to its capacity
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+	// time at which the window of the last excess counted as an offense started
+	lastOffense time.Time
+}
+
+// rateLimiter limits the rate of messages of each type a remote peer may send
+type rateLimiter struct {
+	sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[string]map[reflect.Type]*tokenBucket
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		rate:    float64(util.GetIntOrDefault("peer.gossip.rateLimit.messagesPerSecond", defRateLimit)),
+		burst:   float64(util.GetIntOrDefault("peer.gossip.rateLimit.burst", defRateLimitBurst)),
+		buckets: make(map[string]map[reflect.Type]*tokenBucket),
+	}
+}
+
+// allow returns whether the given peer may send the given message,
+// considering the messages of the same type it recently sent, and if not,
+// whether it is the first excess of its type in the current rateLimitWindow
+func (rl *rateLimiter) allow(pkiID common.PKIidType, msg *proto.SignedGossipMessage) (allowed bool, offense bool) {
+	if rl.rate <= 0 {
+		return true, false
+	}
+
+	rl.Lock()
+	defer rl.Unlock()
+
+	now := time.Now()
+	msgType := reflect.TypeOf(msg.Content)
+	peerBuckets, exists := rl.buckets[string(pkiID)]
+	if !exists {
+		peerBuckets = make(map[reflect.Type]*tokenBucket)
+		rl.buckets[string(pkiID)] = peerBuckets
+	}
+	b, exists := peerBuckets[msgType]
+	if !exists {
+		b = &tokenBucket{tokens: rl.burst, lastRefill: now}
+		peerBuckets[msgType] = b
+	}
+
+	b.tokens += now.Sub(b.lastRefill).Seconds() * rl.rate
+	if b.tokens > rl.burst {
+		b.tokens = rl.burst
+	}
+	b.lastRefill = now
+
+	if b.tokens < 1 {
+		if now.Sub(b.lastOffense) < rateLimitWindow {
+			return false, false
+		}
+		b.lastOffense = now
+		return false, true
+	}
+	b.tokens--
+	return true, false
+}
+
+// forget discards the rate of messages sent by the given peer
+func (rl *rateLimiter) forget(pkiID common.PKIidType) {
+	rl.Lock()
+	defer rl.Unlock()
+	delete(rl.buckets, string(pkiID))
+}
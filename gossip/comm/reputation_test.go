@@ -0,0 +1,152 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/common"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReputationPenalize(t *testing.T) {
+	t.Parallel()
+	rs := newReputationStore()
+	rs.recoveryInterval = time.Hour
+	pkiID := common.PKIidType("p1")
+
+	assert.Empty(t, rs.reputations())
+	assert.False(t, rs.isBlacklisted(pkiID))
+
+	for i := 0; i < maxReputation/penalties[InvalidSignature]-1; i++ {
+		assert.False(t, rs.penalize(pkiID, InvalidSignature))
+	}
+	reputations := rs.reputations()
+	assert.Len(t, reputations, 1)
+	assert.Equal(t, pkiID, reputations[0].PKIID)
+	assert.Equal(t, penalties[InvalidSignature], reputations[0].Score)
+	assert.False(t, reputations[0].Blacklisted())
+	assert.False(t, rs.isBlacklisted(pkiID))
+
+	// The last penalty drops the reputation to the threshold
+	assert.True(t, rs.penalize(pkiID, InvalidSignature))
+	assert.True(t, rs.isBlacklisted(pkiID))
+	assert.True(t, rs.reputations()[0].Blacklisted())
+	// A blacklisted peer isn't blacklisted again
+	assert.False(t, rs.penalize(pkiID, InvalidSignature))
+	assert.False(t, rs.isBlacklisted(common.PKIidType("p2")))
+}
+
+func TestReputationBlacklistExpiration(t *testing.T) {
+	t.Parallel()
+	rs := newReputationStore()
+	rs.blacklistDuration = time.Millisecond * 100
+	rs.recoveryInterval = time.Hour
+	pkiID := common.PKIidType("p1")
+
+	for !rs.penalize(pkiID, MalformedMessage) {
+	}
+	assert.True(t, rs.isBlacklisted(pkiID))
+	time.Sleep(time.Millisecond * 200)
+	assert.False(t, rs.isBlacklisted(pkiID))
+	// The peer is given a fresh start, and is eventually forgotten
+	assert.Empty(t, rs.reputations())
+}
+
+func TestReputationRecovery(t *testing.T) {
+	t.Parallel()
+	rs := newReputationStore()
+	rs.recoveryInterval = time.Millisecond * 10
+	pkiID := common.PKIidType("p1")
+
+	rs.penalize(pkiID, RateLimitExceeded)
+	assert.Len(t, rs.reputations(), 1)
+	time.Sleep(time.Millisecond * 50)
+	assert.Empty(t, rs.reputations())
+}
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+	rl := newRateLimiter()
+	rl.rate = 10
+	rl.burst = 5
+	p1 := common.PKIidType("p1")
+	p2 := common.PKIidType("p2")
+	aliveMsg := &proto.SignedGossipMessage{GossipMessage: &proto.GossipMessage{
+		Content: &proto.GossipMessage_AliveMsg{AliveMsg: &proto.AliveMessage{}},
+	}}
+	dataMsg := &proto.SignedGossipMessage{GossipMessage: &proto.GossipMessage{
+		Content: &proto.GossipMessage_DataMsg{DataMsg: &proto.DataMessage{}},
+	}}
+	allowed := func(pkiID common.PKIidType, msg *proto.SignedGossipMessage) bool {
+		allowed, _ := rl.allow(pkiID, msg)
+		return allowed
+	}
+
+	for i := 0; i < 5; i++ {
+		assert.True(t, allowed(p1, aliveMsg))
+	}
+	// Only the first excess in a window is an offense
+	allowed1, offense := rl.allow(p1, aliveMsg)
+	assert.False(t, allowed1)
+	assert.True(t, offense)
+	allowed1, offense = rl.allow(p1, aliveMsg)
+	assert.False(t, allowed1)
+	assert.False(t, offense)
+	// Other message types and other peers have their own limits
+	assert.True(t, allowed(p1, dataMsg))
+	assert.True(t, allowed(p2, aliveMsg))
+
+	// Tokens are refilled over time
+	time.Sleep(time.Millisecond * 200)
+	assert.True(t, allowed(p1, aliveMsg))
+
+	rl.forget(p1)
+	for i := 0; i < 5; i++ {
+		assert.True(t, allowed(p1, aliveMsg))
+	}
+
+	rl.rate = 0
+	assert.True(t, allowed(p1, aliveMsg), "Rate limiting should be disabled")
+}
+
+func TestBlacklistedPeerDisconnected(t *testing.T) {
+	t.Parallel()
+	comm1, _ := newCommInstance(6711, naiveSec)
+	comm2, _ := newCommInstance(6712, naiveSec)
+	defer comm1.Stop()
+	defer comm2.Stop()
+	time.Sleep(time.Second)
+
+	_, err := comm1.Handshake(remotePeer(6712))
+	assert.NoError(t, err)
+
+	pkiID := remotePeer(6712).PKIID
+	comm1.Penalize(pkiID, OversizedDigest)
+	reputations := comm1.Reputations()
+	assert.Len(t, reputations, 1)
+	assert.Equal(t, maxReputation-penalties[OversizedDigest], reputations[0].Score)
+
+	for i := 0; i < maxReputation/penalties[OversizedDigest]; i++ {
+		comm1.Penalize(pkiID, OversizedDigest)
+	}
+	assert.True(t, comm1.Reputations()[0].Blacklisted())
+
+	_, err = comm1.Handshake(remotePeer(6712))
+	assert.Error(t, err)
+
+	// Messages from the blacklisted peer are not received
+	inc := comm1.Accept(acceptAll)
+	comm2.Send(createGossipMsg(), remotePeer(6711))
+	select {
+	case <-inc:
+		assert.Fail(t, "Received a message from a blacklisted peer")
+	case <-time.After(time.Second):
+	}
+}
@@ -137,4 +137,13 @@ type Discovery interface {
 	// the peer, and to assert its PKI-ID, whether its in the peer's org or not,
 	// and whether the action was successful or not
 	Connect(member NetworkMember, id identifier)
+
+	// Snapshot returns the alive members in the view, each with its last
+	// alive message and the time it was last seen alive
+	Snapshot() []*PersistedMember
+
+	// Restore makes this instance learn about members remembered from a previous run.
+	// The members are suspected alive: they are probed right away, yet considered
+	// alive only once a fresh alive message is received from them
+	Restore(members []*PersistedMember)
 }
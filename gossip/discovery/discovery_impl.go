@@ -928,6 +928,86 @@ func (d *gossipDiscoveryImpl) GetMembership() []NetworkMember {
 
 }
 
+func (d *gossipDiscoveryImpl) Snapshot() []*PersistedMember {
+	if d.toDie() {
+		return nil
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	var members []*PersistedMember
+	for _, m := range d.aliveMembership.ToSlice() {
+		lastTS, isAlive := d.aliveLastTS[string(m.GetAliveMsg().Membership.PkiId)]
+		if !isAlive {
+			continue
+		}
+		members = append(members, &PersistedMember{
+			AliveMsg: m.Envelope,
+			LastSeen: lastTS.lastSeen,
+		})
+	}
+	return members
+}
+
+func (d *gossipDiscoveryImpl) Restore(members []*PersistedMember) {
+	var suspected []*proto.SignedGossipMessage
+	for _, member := range members {
+		if member.AliveMsg == nil {
+			continue
+		}
+		am, err := member.AliveMsg.ToGossipMessage()
+		if err != nil {
+			d.logger.Warning("Persisted member has an invalid alive message:", err)
+			continue
+		}
+		if !am.IsAliveMsg() || am.GetAliveMsg().Membership == nil || am.GetAliveMsg().Timestamp == nil {
+			d.logger.Warning("Expected alive message, got", am, "instead")
+			continue
+		}
+		pkiID := am.GetAliveMsg().Membership.PkiId
+		if equalPKIid(pkiID, d.self.PKIid) {
+			continue
+		}
+		if !d.crypt.ValidateAliveMsg(am) {
+			d.logger.Debugf("Persisted alive message of %s isn't authentic", am.GetAliveMsg().Membership)
+			continue
+		}
+
+		d.lock.RLock()
+		_, known := d.id2Member[string(pkiID)]
+		d.lock.RUnlock()
+		if known || !d.msgStore.Add(am) {
+			// A fresher alive message was received meanwhile
+			continue
+		}
+		suspected = append(suspected, am)
+	}
+
+	if len(suspected) == 0 {
+		return
+	}
+	d.logger.Info("Restoring", len(suspected), "members persisted in a previous run as suspected alive")
+
+	// Suspected members are learned as dead, so that they are considered alive
+	// only once an alive message newer than the persisted one is received,
+	// and are forgotten once their alive message expires
+	d.learnNewMembers([]*proto.SignedGossipMessage{}, suspected)
+
+	for _, am := range suspected {
+		d.lock.RLock()
+		member := *d.id2Member[string(am.GetAliveMsg().Membership.PkiId)]
+		d.lock.RUnlock()
+		go func(member NetworkMember) {
+			if d.comm.Ping(&member) {
+				d.logger.Debug(member, "is responding, sending membership request")
+				d.sendMembershipRequest(&member, true)
+			} else {
+				d.logger.Debug(member, "isn't responding, will retry later")
+			}
+		}(member)
+	}
+}
+
 func tsToTime(ts uint64) time.Time {
 	return time.Unix(int64(0), int64(ts))
 }
@@ -1245,3 +1245,72 @@ func portOfEndpoint(endpoint string) int {
 	port, _ := strconv.ParseInt(strings.Split(endpoint, ":")[1], 10, 64)
 	return int(port)
 }
+
+func TestRestore(t *testing.T) {
+	// Scenario: p1 knows p2 and p3, and persists its membership. p3 stops,
+	// and a new instance restores the membership persisted by p1, without any
+	// bootstrap peer. p2 should be found alive once probed, and p3 never,
+	// until it is eventually forgotten.
+	t.Parallel()
+	p1 := createDiscoveryInstance(13611, "d1", []string{})
+	p2 := createDiscoveryInstance(13612, "d2", []string{bootPeer(13611)})
+	p3 := createDiscoveryInstance(13613, "d3", []string{bootPeer(13611)})
+	assertMembership(t, []*gossipInstance{p1, p2, p3}, 2)
+
+	snapshot := p1.Snapshot()
+	assert.Len(t, snapshot, 2)
+	for _, member := range snapshot {
+		am, err := member.AliveMsg.ToGossipMessage()
+		assert.NoError(t, err)
+		assert.True(t, am.IsAliveMsg())
+		assert.True(t, time.Since(member.LastSeen) < timeout)
+	}
+
+	waitUntilOrFailBlocking(t, p1.Stop)
+	waitUntilOrFailBlocking(t, p3.Stop)
+
+	restored := createDiscoveryInstance(13615, "d5", []string{})
+	defer restored.Stop()
+	defer p2.Stop()
+
+	restored.Restore(snapshot)
+	// The restored members are known, yet not considered alive
+	assert.NotNil(t, restored.Lookup(p3.discoveryImpl().self.PKIid))
+	assert.NotContains(t, portsOfMembers(restored.GetMembership()), 13613)
+
+	// Restoring members already known has no effect
+	restored.Restore(snapshot)
+
+	// p2 is found alive without any bootstrap peer
+	waitUntilOrFail(t, func() bool {
+		return len(restored.GetMembership()) == 1
+	})
+	assert.Equal(t, []int{13612}, portsOfMembers(restored.GetMembership()))
+
+	// p3 is eventually forgotten
+	waitUntilTimeoutOrFail(t, func() bool {
+		return restored.Lookup(p3.discoveryImpl().self.PKIid) == nil
+	}, getAliveExpirationTimeout()*msgExpirationFactor*2)
+	assert.Equal(t, []int{13612}, portsOfMembers(restored.GetMembership()))
+}
+
+func TestRestoreInvalidMembers(t *testing.T) {
+	t.Parallel()
+	inst := createDiscoveryInstance(13614, "d1", []string{})
+	defer inst.Stop()
+
+	notAlive, _ := (&proto.GossipMessage{
+		Content: &proto.GossipMessage_Empty{Empty: &proto.Empty{}},
+	}).NoopSign()
+	self, _ := inst.discoveryImpl().createAliveMessage(true)
+	inst.Restore([]*PersistedMember{
+		{},
+		{AliveMsg: &proto.Envelope{Payload: []byte{1, 2, 3}}},
+		{AliveMsg: notAlive.Envelope},
+		{AliveMsg: self.Envelope},
+	})
+
+	inst.discoveryImpl().lock.RLock()
+	defer inst.discoveryImpl().lock.RUnlock()
+	assert.Empty(t, inst.discoveryImpl().id2Member)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	proto "github.com/hyperledger/fabric/protos/gossip"
+)
+
+// PersistedMember is a member of the network, as remembered across restarts
+type PersistedMember struct {
+	// AliveMsg is the last alive message received from the member
+	AliveMsg *proto.Envelope
+	// Identity is the identity of the member
+	Identity []byte
+	// StateInfo holds the last StateInfo messages of the member,
+	// one for each channel it was found in
+	StateInfo []*proto.Envelope
+	// LastSeen is the last time the member was known to be alive
+	LastSeen time.Time
+}
+
+// Persistence stores the members of the network,
+// so that they are remembered across restarts
+type Persistence interface {
+	// Save replaces the stored members with the given ones
+	Save(members []*PersistedMember) error
+
+	// Load returns the stored members
+	Load() ([]*PersistedMember, error)
+}
+
+// NewFilePersistence returns a Persistence that stores the members
+// in the file at the given path
+func NewFilePersistence(path string) Persistence {
+	return &filePersistence{path: path}
+}
+
+type filePersistence struct {
+	path string
+}
+
+// Save replaces the stored members with the given ones. The file is replaced
+// atomically, hence a crash while saving leaves the previous members stored.
+func (p *filePersistence) Save(members []*PersistedMember) error {
+	data, err := json.Marshal(members)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p.path), 0755); err != nil {
+		return err
+	}
+
+	tmpPath := p.path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, p.path)
+}
+
+// Load returns the stored members, or none if no member was ever saved
+func (p *filePersistence) Load() ([]*PersistedMember, error) {
+	data, err := ioutil.ReadFile(p.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var members []*PersistedMember
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilePersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "persistence")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	p := NewFilePersistence(filepath.Join(dir, "gossip", "membership.json"))
+
+	// Nothing was saved yet
+	members, err := p.Load()
+	assert.NoError(t, err)
+	assert.Empty(t, members)
+
+	lastSeen := time.Unix(1500000000, 0)
+	saved := []*PersistedMember{
+		{
+			AliveMsg:  &proto.Envelope{Payload: []byte{1}, Signature: []byte{2}},
+			Identity:  []byte("identity"),
+			StateInfo: []*proto.Envelope{{Payload: []byte{3}}},
+			LastSeen:  lastSeen,
+		},
+	}
+	assert.NoError(t, p.Save(saved))
+	members, err = p.Load()
+	assert.NoError(t, err)
+	assert.Len(t, members, 1)
+	assert.Equal(t, saved[0].AliveMsg.Payload, members[0].AliveMsg.Payload)
+	assert.Equal(t, saved[0].AliveMsg.Signature, members[0].AliveMsg.Signature)
+	assert.Equal(t, saved[0].Identity, members[0].Identity)
+	assert.Equal(t, saved[0].StateInfo[0].Payload, members[0].StateInfo[0].Payload)
+	assert.True(t, lastSeen.Equal(members[0].LastSeen))
+
+	// Saving replaces the members saved previously
+	assert.NoError(t, p.Save(nil))
+	members, err = p.Load()
+	assert.NoError(t, err)
+	assert.Empty(t, members)
+
+	// A corrupt file fails loading
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "gossip", "membership.json"), []byte("{"), 0600))
+	_, err = p.Load()
+	assert.Error(t, err)
+}
@@ -60,8 +60,8 @@ import (
 // 			LeaderElection()
 //		If you are the leader:
 //			Broadcast leadership declaration
-//			If a leadership declaration was received from
-// 			a peer with a lower ID,
+//			If leadership declarations were received from
+// 			leaderCount peers with a lower ID,
 //			become a follower
 //		Else, you're a follower:
 //			If haven't received a leadership declaration within
@@ -71,12 +71,16 @@ import (
 // LeaderElection():
 // 	Gossip leadership proposal message
 //	Collect messages from other peers sent within a time period
-//	If received a leadership declaration:
+//	If received leadership declarations from leaderCount peers:
 //		return
-//	Iterate over all proposal messages collected.
-// 	If a proposal message from a peer with an ID lower
-// 	than yourself was received, return.
+//	Iterate over all proposal and declaration messages collected.
+// 	If messages from leaderCount peers with an ID lower
+// 	than yourself were received, return.
 //	Else, declare yourself a leader
+//
+// leaderCount is the number of leaders the peers elect, 1 by default.
+// Since the peers with the lowest IDs are elected, the leaders are
+// chosen deterministically once the membership view is stable.
 
 // LeaderElectionAdapter is used by the leader election module
 // to send and receive messages and to get membership information
@@ -134,11 +138,13 @@ func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback
 	le := &leaderElectionSvcImpl{
 		id:            peerID(id),
 		proposals:     util.NewSet(),
+		leaders:       make(map[string]time.Time),
 		adapter:       adapter,
 		stopChan:      make(chan struct{}, 1),
 		interruptChan: make(chan struct{}, 1),
 		logger:        util.GetLogger(util.LoggingElectionModule, ""),
 		callback:      noopCallback,
+		leaderCount:   GetLeaderCount(),
 	}
 
 	if callback != nil {
@@ -153,6 +159,9 @@ func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback
 type leaderElectionSvcImpl struct {
 	id        peerID
 	proposals *util.Set
+	// leaders maps the IDs of the peers that declared
+	// themselves leaders to the time of their last declaration
+	leaders map[string]time.Time
 	sync.Mutex
 	stopChan      chan struct{}
 	interruptChan chan struct{}
@@ -164,6 +173,7 @@ type leaderElectionSvcImpl struct {
 	adapter       LeaderElectionAdapter
 	logger        *logging.Logger
 	callback      leadershipCallback
+	leaderCount   int // the number of leaders elected, read once at creation
 }
 
 func (le *leaderElectionSvcImpl) start() {
@@ -205,11 +215,14 @@ func (le *leaderElectionSvcImpl) handleMessage(msg Msg) {
 	if msg.IsProposal() {
 		le.proposals.Add(string(msg.SenderID()))
 	} else if msg.IsDeclaration() {
-		atomic.StoreInt32(&le.leaderExists, int32(1))
-		if le.sleeping && len(le.interruptChan) == 0 {
-			le.interruptChan <- struct{}{}
+		le.leaders[string(msg.SenderID())] = time.Now()
+		if len(le.aliveLeaders()) >= le.leaderCount {
+			atomic.StoreInt32(&le.leaderExists, int32(1))
+			if le.sleeping && len(le.interruptChan) == 0 {
+				le.interruptChan <- struct{}{}
+			}
 		}
-		if bytes.Compare(msg.SenderID(), le.id) < 0 && le.IsLeader() {
+		if le.IsLeader() && le.betterCandidates(le.aliveLeaders()) >= le.leaderCount {
 			le.stopBeingLeader()
 		}
 	} else {
@@ -271,16 +284,19 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 		le.logger.Debug(le.id, ": Some peer is already a leader")
 		return
 	}
-	// Leader doesn't exist, let's see if there is a better candidate than us
-	// for being a leader
+	// Not enough leaders exist, let's see if there are enough better
+	// candidates than us for being leaders
+	le.Lock()
+	candidates := le.aliveLeaders()
+	le.Unlock()
 	for _, o := range le.proposals.ToArray() {
-		id := o.(string)
-		if bytes.Compare(peerID(id), le.id) < 0 {
-			return
-		}
+		candidates = append(candidates, peerID(o.(string)))
 	}
-	// If we got here, there is no one that proposed being a leader
-	// that's a better candidate than us.
+	if le.betterCandidates(candidates) >= le.leaderCount {
+		return
+	}
+	// If we got here, less peers than the leaders needed proposed
+	// being a leader while being better candidates than us.
 	le.beLeader()
 	atomic.StoreInt32(&le.leaderExists, int32(1))
 }
@@ -329,6 +345,33 @@ func (le *leaderElectionSvcImpl) waitForMembershipStabilization(timeLimit time.D
 	}
 }
 
+// aliveLeaders returns the IDs of the peers that declared
+// themselves leaders within the leader alive threshold.
+// Should be called with the lock held.
+func (le *leaderElectionSvcImpl) aliveLeaders() []peerID {
+	var leaders []peerID
+	for id, lastDeclaration := range le.leaders {
+		if time.Since(lastDeclaration) > getLeaderAliveThreshold() {
+			delete(le.leaders, id)
+			continue
+		}
+		leaders = append(leaders, peerID(id))
+	}
+	return leaders
+}
+
+// betterCandidates returns the number of distinct peers
+// among the given ones that have an ID lower than ours
+func (le *leaderElectionSvcImpl) betterCandidates(ids []peerID) int {
+	better := make(map[string]struct{})
+	for _, id := range ids {
+		if bytes.Compare(id, le.id) < 0 {
+			better[string(id)] = struct{}{}
+		}
+	}
+	return len(better)
+}
+
 // drainInterruptChannel clears the interruptChannel
 // if needed
 func (le *leaderElectionSvcImpl) drainInterruptChannel() {
@@ -406,6 +449,22 @@ func SetLeaderElectionDuration(t time.Duration) {
 	viper.Set("peer.gossip.election.leaderElectionDuration", t)
 }
 
+// SetLeaderCount configures the number of leaders elected,
+// which connect concurrently to the ordering service
+func SetLeaderCount(n int) {
+	viper.Set("peer.gossip.election.leaderCount", n)
+}
+
+// GetLeaderCount returns the number of leaders elected, which is at least 1
+func GetLeaderCount() int {
+	leaderCount := util.GetIntOrDefault("peer.gossip.election.leaderCount", 1)
+	if leaderCount < 1 {
+		util.GetLogger(util.LoggingElectionModule, "").Warningf("Invalid peer.gossip.election.leaderCount %d, at least one leader must be elected, using 1 instead", leaderCount)
+		return 1
+	}
+	return leaderCount
+}
+
 func getStartupGracePeriod() time.Duration {
 	return util.GetDurationOrDefault("peer.gossip.election.startupGracePeriod", time.Second*15)
 }
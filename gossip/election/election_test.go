@@ -363,6 +363,36 @@ func TestPartition(t *testing.T) {
 
 }
 
+func TestMultipleLeaders(t *testing.T) {
+	// Scenario: peers are configured to elect 2 leaders, and are spawned at the same time.
+	// After a while, one of the leaders stops.
+	// Expected outcome: the 2 peers with the lowest IDs are the leaders,
+	// and the peer with the next lowest ID takes over the stopped leader.
+	// Not parallel since the leader count is a global setting.
+	SetLeaderCount(2)
+	defer SetLeaderCount(1)
+
+	peers := createPeers(0, 5, 4, 3, 2, 1, 0)
+	defer func() {
+		for _, p := range peers[:len(peers)-1] {
+			p.Stop()
+		}
+	}()
+	leaders := waitForMultipleLeadersElection(t, peers, 2)
+	assert.Len(t, leaders, 2, "Exactly 2 leaders should have been elected")
+	assert.True(t, peers[5].IsLeader(), "p0 isn't a leader. Leaders are: %v", leaders)
+	assert.True(t, peers[4].IsLeader(), "p1 isn't a leader. Leaders are: %v", leaders)
+	waitForBoolFunc(t, peers[5].isLeaderFromCallback, true, "Leadership callback result is wrong for %s", peers[5].id)
+	waitForBoolFunc(t, peers[4].isLeaderFromCallback, true, "Leadership callback result is wrong for %s", peers[4].id)
+
+	peers[5].Stop()
+	time.Sleep(getLeadershipDeclarationInterval() + getLeaderAliveThreshold()*3)
+	leaders = waitForMultipleLeadersElection(t, peers[:5], 2)
+	assert.Len(t, leaders, 2, "Exactly 2 leaders should have been elected")
+	assert.True(t, peers[4].IsLeader(), "p1 isn't a leader. Leaders are: %v", leaders)
+	assert.True(t, peers[3].IsLeader(), "p2 isn't a leader. Leaders are: %v", leaders)
+}
+
 func TestConfigFromFile(t *testing.T) {
 	preStartupGracePeriod := getStartupGracePeriod()
 	preMembershipSampleInterval := getMembershipSampleInterval()
@@ -384,6 +414,11 @@ func TestConfigFromFile(t *testing.T) {
 	assert.Equal(t, time.Second*10, getLeaderAliveThreshold())
 	assert.Equal(t, time.Second*5, getLeaderElectionDuration())
 	assert.Equal(t, getLeaderAliveThreshold()/2, getLeadershipDeclarationInterval())
+	assert.Equal(t, 1, GetLeaderCount())
+
+	// Verify that at least one leader is elected
+	SetLeaderCount(-2)
+	assert.Equal(t, 1, GetLeaderCount())
 
 	//Verify reading the values from config file
 	viper.Reset()
@@ -399,6 +434,7 @@ func TestConfigFromFile(t *testing.T) {
 	assert.Equal(t, time.Second*10, getLeaderAliveThreshold())
 	assert.Equal(t, time.Second*5, getLeaderElectionDuration())
 	assert.Equal(t, getLeaderAliveThreshold()/2, getLeadershipDeclarationInterval())
+	assert.Equal(t, 1, GetLeaderCount())
 }
 
 func waitForBoolFunc(t *testing.T, f func() bool, expectedValue bool, msgAndArgs ...interface{}) {
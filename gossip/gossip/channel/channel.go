@@ -76,6 +76,9 @@ type GossipChannel interface {
 	// AddToMsgStore adds a given GossipMessage to the message store
 	AddToMsgStore(msg *proto.SignedGossipMessage)
 
+	// StateInfoMessages returns the StateInfo messages of the members of the channel
+	StateInfoMessages() []*proto.SignedGossipMessage
+
 	// ConfigureChannel (re)configures the list of organizations
 	// that are eligible to be in the channel
 	ConfigureChannel(joinMsg api.JoinChannelMessage)
@@ -356,6 +359,11 @@ func (gc *gossipChannel) AddToMsgStore(msg *proto.SignedGossipMessage) {
 	}
 }
 
+// StateInfoMessages returns the StateInfo messages of the members of the channel
+func (gc *gossipChannel) StateInfoMessages() []*proto.SignedGossipMessage {
+	return gc.stateInfoMsgStore.ToSlice()
+}
+
 // ConfigureChannel (re)configures the list of organizations
 // that are eligible to be in the channel
 func (gc *gossipChannel) ConfigureChannel(joinMsg api.JoinChannelMessage) {
@@ -119,6 +119,18 @@ func (cs *channelState) joinChannel(joinMsg api.JoinChannelMessage, chainID comm
 	}
 }
 
+func (cs *channelState) leaveChannel(chainID common.ChainID) {
+	if cs.isStopping() {
+		return
+	}
+	cs.Lock()
+	defer cs.Unlock()
+	if gc, exists := cs.channels[string(chainID)]; exists {
+		gc.Stop()
+		delete(cs.channels, string(chainID))
+	}
+}
+
 type gossipAdapterImpl struct {
 	*gossipServiceImpl
 	discovery.Discovery
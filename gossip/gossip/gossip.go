@@ -61,10 +61,21 @@ type Gossip interface {
 	// JoinChan makes the Gossip instance join a channel
 	JoinChan(joinMsg api.JoinChannelMessage, chainID common.ChainID)
 
+	// LeaveChan makes the Gossip instance leave a channel,
+	// it stops disseminating and pulling messages of the channel
+	LeaveChan(chainID common.ChainID)
+
 	// SuspectPeers makes the gossip instance validate identities of suspected peers, and close
 	// any connections to peers with identities that are found invalid
 	SuspectPeers(s api.PeerSuspector)
 
+	// Reputations returns the reputation of the remote peers that misbehaved
+	Reputations() []comm.PeerReputation
+
+	// UpdateTLSCertificate replaces the TLS certificate the connections
+	// with remote peers are bound to, after it was renewed
+	UpdateTLSCertificate(cert *tls.Certificate)
+
 	// Stop stops the gossip component
 	Stop()
 }
@@ -94,4 +105,10 @@ type Config struct {
 
 	InternalEndpoint string // Endpoint we publish to peers in our organization
 	ExternalEndpoint string // Peer publishes this endpoint instead of SelfEndpoint to foreign organizations
+
+	MembershipPersistencePath     string        // File the membership is persisted to across restarts, not persisted if empty
+	MembershipPersistenceInterval time.Duration // Determines frequency of persisting the membership
+	MembershipExpiration          time.Duration // Time after which a persisted member that wasn't seen alive is forgotten
+
+	MaxDigestSize int // Maximum number of items in a pull digest or request other than identity pulls, unlimited if 0
 }
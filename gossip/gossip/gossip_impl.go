@@ -76,6 +76,10 @@ type gossipServiceImpl struct {
 	disSecAdap        *discoverySecurityAdapter
 	mcs               api.MessageCryptoService
 	stateInfoMsgStore msgstore.MessageStore
+
+	persistence            discovery.Persistence
+	persistedStateInfo     []*proto.SignedGossipMessage
+	persistedStateInfoLock sync.Mutex
 }
 
 // NewGossipService creates a gossip instance attached to a gRPC server
@@ -133,6 +137,12 @@ func NewGossipService(conf *Config, s *grpc.Server, secAdvisor api.SecurityAdvis
 		g.logger.Warning("External endpoint is empty, peer will not be accessible outside of its organization")
 	}
 
+	if conf.MembershipPersistencePath != "" {
+		g.persistence = discovery.NewFilePersistence(conf.MembershipPersistencePath)
+		g.restoreMembership()
+		go g.periodicalPersistMembership()
+	}
+
 	go g.start()
 	go g.periodicalIdentityValidationAndExpiration()
 	go g.connect2BootstrapPeers()
@@ -194,6 +204,9 @@ func (g *gossipServiceImpl) toDie() bool {
 func (g *gossipServiceImpl) JoinChan(joinMsg api.JoinChannelMessage, chainID common.ChainID) {
 	// joinMsg is supposed to have been already verified
 	g.chanState.joinChannel(joinMsg, chainID)
+	if g.persistence != nil {
+		g.restoreStateInfo(chainID)
+	}
 
 	for _, org := range joinMsg.Members() {
 		g.learnAnchorPeers(org, joinMsg.AnchorPeersOf(org))
@@ -365,6 +378,12 @@ func (g *gossipServiceImpl) handleMessage(m proto.ReceivedMessage) {
 		return
 	}
 
+	if g.isDigestOversized(msg) {
+		g.logger.Warning(m.GetConnectionInfo().ID, "sent an oversized pull message, discarding it")
+		g.comm.Penalize(m.GetConnectionInfo().ID, comm.OversizedDigest)
+		return
+	}
+
 	if msg.IsChannelRestricted() {
 		if gc := g.chanState.lookupChannelForMsg(m); gc == nil {
 			// If we're not in the channel, we should still forward to peers of our org
@@ -381,6 +400,7 @@ func (g *gossipServiceImpl) handleMessage(m proto.ReceivedMessage) {
 			if m.GetGossipMessage().IsLeadershipMsg() {
 				if err := g.validateLeadershipMessage(m.GetGossipMessage()); err != nil {
 					g.logger.Warning("Failed validating LeaderElection message:", err)
+					g.penalizeInvalidSignature(m.GetConnectionInfo().ID, m.GetGossipMessage().GetLeadershipMsg().PkiId)
 					return
 				}
 			}
@@ -396,14 +416,17 @@ func (g *gossipServiceImpl) handleMessage(m proto.ReceivedMessage) {
 			sMsg, err := m.GetGossipMessage().GetMemReq().SelfInformation.ToGossipMessage()
 			if err != nil {
 				g.logger.Warning("Got membership request with invalid selfInfo:", err)
+				g.comm.Penalize(m.GetConnectionInfo().ID, comm.MalformedMessage)
 				return
 			}
 			if !sMsg.IsAliveMsg() {
 				g.logger.Warning("Got membership request with selfInfo that isn't an AliveMessage")
+				g.comm.Penalize(m.GetConnectionInfo().ID, comm.MalformedMessage)
 				return
 			}
 			if !bytes.Equal(sMsg.GetAliveMsg().Membership.PkiId, m.GetConnectionInfo().ID) {
 				g.logger.Warning("Got membership request with selfInfo that doesn't match the handshake")
+				g.comm.Penalize(m.GetConnectionInfo().ID, comm.MalformedMessage)
 				return
 			}
 		}
@@ -424,15 +447,22 @@ func (g *gossipServiceImpl) forwardDiscoveryMsg(msg proto.ReceivedMessage) {
 }
 
 // validateMsg checks the signature of the message if exists,
-// and also checks that the tag matches the message type
+// and also checks that the tag matches the message type.
+// The sender of a malformed message, or of an invalid message
+// it created, is penalized.
 func (g *gossipServiceImpl) validateMsg(msg proto.ReceivedMessage) bool {
+	sender := msg.GetConnectionInfo().ID
 	if err := msg.GetGossipMessage().IsTagLegal(); err != nil {
 		g.logger.Warning("Tag of", msg.GetGossipMessage(), "isn't legal:", err)
+		g.comm.Penalize(sender, comm.MalformedMessage)
 		return false
 	}
 
 	if msg.GetGossipMessage().IsAliveMsg() {
 		if !g.disSecAdap.ValidateAliveMsg(msg.GetGossipMessage()) {
+			if membership := msg.GetGossipMessage().GetAliveMsg().Membership; membership != nil {
+				g.penalizeInvalidSignature(sender, membership.PkiId)
+			}
 			return false
 		}
 	}
@@ -440,12 +470,47 @@ func (g *gossipServiceImpl) validateMsg(msg proto.ReceivedMessage) bool {
 	if msg.GetGossipMessage().IsStateInfoMsg() {
 		if err := g.validateStateInfoMsg(msg.GetGossipMessage()); err != nil {
 			g.logger.Warning("StateInfo message", msg, "is found invalid:", err)
+			g.penalizeInvalidSignature(sender, msg.GetGossipMessage().GetStateInfo().PkiId)
 			return false
 		}
 	}
 	return true
 }
 
+// penalizeInvalidSignature penalizes the sender of a message that failed verification,
+// if it created the message itself and its identity is known. Messages relayed by other
+// peers are only dropped, as an honest relay may forward a message which was valid when
+// it received it, e.g. before the certificate of the creator expired.
+func (g *gossipServiceImpl) penalizeInvalidSignature(sender common.PKIidType, creator common.PKIidType) {
+	if !bytes.Equal(sender, creator) {
+		return
+	}
+	if _, err := g.idMapper.Get(creator); err != nil {
+		return
+	}
+	g.comm.Penalize(sender, comm.InvalidSignature)
+}
+
+// isDigestOversized returns whether the given message is a pull digest,
+// request or response that carries more items than allowed.
+// Identity pulls are exempt, as they carry an item for every peer in the
+// membership, however large it grows.
+func (g *gossipServiceImpl) isDigestOversized(msg *proto.SignedGossipMessage) bool {
+	if g.conf.MaxDigestSize <= 0 || !msg.IsPullMsg() || msg.GetPullMsgType() == proto.PullMsgType_IDENTITY_MSG {
+		return false
+	}
+	var size int
+	switch {
+	case msg.IsDigestMsg():
+		size = len(msg.GetDataDig().Digests)
+	case msg.IsDataReq():
+		size = len(msg.GetDataReq().Digests)
+	case msg.IsDataUpdate():
+		size = len(msg.GetDataUpdate().Data)
+	}
+	return size > g.conf.MaxDigestSize
+}
+
 func (g *gossipServiceImpl) sendGossipBatch(a []interface{}) {
 	msgs2Gossip := make([]*proto.SignedGossipMessage, len(a))
 	for i, e := range a {
@@ -676,11 +741,25 @@ func (g *gossipServiceImpl) PeersOfChannel(channel common.ChainID) []discovery.N
 	return gc.GetPeers()
 }
 
+// Reputations returns the reputation of the remote peers that misbehaved
+func (g *gossipServiceImpl) Reputations() []comm.PeerReputation {
+	return g.comm.Reputations()
+}
+
+// UpdateTLSCertificate replaces the TLS certificate the connections
+// with remote peers are bound to, after it was renewed
+func (g *gossipServiceImpl) UpdateTLSCertificate(cert *tls.Certificate) {
+	g.comm.UpdateTLSCertificate(cert)
+}
+
 // Stop stops the gossip component
 func (g *gossipServiceImpl) Stop() {
 	if g.toDie() {
 		return
 	}
+	if g.persistence != nil {
+		g.persistMembership()
+	}
 	atomic.StoreInt32(&g.stopFlag, int32(1))
 	g.logger.Info("Stopping gossip")
 	comWG := sync.WaitGroup{}
@@ -705,6 +784,11 @@ func (g *gossipServiceImpl) UpdateMetadata(md []byte) {
 	g.disc.UpdateMetadata(md)
 }
 
+// LeaveChan makes the Gossip instance leave a channel
+func (g *gossipServiceImpl) LeaveChan(chainID common.ChainID) {
+	g.chanState.leaveChannel(chainID)
+}
+
 // UpdateChannelMetadata updates the self metadata the peer
 // publishes to other peers about its channel-related state
 func (g *gossipServiceImpl) UpdateChannelMetadata(md []byte, chainID common.ChainID) {
@@ -1042,6 +1042,59 @@ func TestIdentityExpiration(t *testing.T) {
 	stopPeers(peers)
 }
 
+func TestDigestSizeLimit(t *testing.T) {
+	t.Parallel()
+	g := &gossipServiceImpl{conf: &Config{MaxDigestSize: 2}}
+	pullMsg := func(digests ...string) *proto.SignedGossipMessage {
+		return &proto.SignedGossipMessage{GossipMessage: &proto.GossipMessage{
+			Content: &proto.GossipMessage_DataDig{
+				DataDig: &proto.DataDigest{MsgType: proto.PullMsgType_BLOCK_MSG, Digests: digests},
+			},
+		}}
+	}
+	assert.False(t, g.isDigestOversized(pullMsg("1", "2")))
+	assert.True(t, g.isDigestOversized(pullMsg("1", "2", "3")))
+	assert.False(t, g.isDigestOversized(&proto.SignedGossipMessage{GossipMessage: createDataMsg(1, []byte{}, common.ChainID("A"))}))
+
+	identityPullMsg := pullMsg("1", "2", "3")
+	identityPullMsg.GetDataDig().MsgType = proto.PullMsgType_IDENTITY_MSG
+	assert.False(t, g.isDigestOversized(identityPullMsg), "Identity pulls are sized by the membership")
+
+	g.conf.MaxDigestSize = 0
+	assert.False(t, g.isDigestOversized(pullMsg("1", "2", "3")))
+}
+
+type penaltyRecorder struct {
+	comm.Comm
+	penalized []common.PKIidType
+}
+
+func (pr *penaltyRecorder) Penalize(pkiID common.PKIidType, offense comm.Offense) {
+	pr.penalized = append(pr.penalized, pkiID)
+}
+
+func TestPenalizeInvalidSignature(t *testing.T) {
+	t.Parallel()
+	creator := common.PKIidType("creator")
+	relay := common.PKIidType("relay")
+	cs := &naiveCryptoService{}
+	idMapper := identity.NewIdentityMapper(cs, api.PeerIdentityType("self"))
+	assert.NoError(t, idMapper.Put(creator, api.PeerIdentityType("creator")))
+	assert.NoError(t, idMapper.Put(relay, api.PeerIdentityType("relay")))
+	pr := &penaltyRecorder{}
+	g := &gossipServiceImpl{comm: pr, idMapper: idMapper}
+
+	// An honest relay may forward a message which became invalid since it received it
+	g.penalizeInvalidSignature(relay, creator)
+	assert.Empty(t, pr.penalized, "The relay of an invalid message should not be penalized")
+
+	g.penalizeInvalidSignature(common.PKIidType("unknown"), common.PKIidType("unknown"))
+	assert.Empty(t, pr.penalized, "A peer whose identity is unknown should not be penalized")
+
+	g.penalizeInvalidSignature(creator, creator)
+	assert.Equal(t, []common.PKIidType{creator}, pr.penalized, "The creator of an invalid message should be penalized")
+}
+
 func TestEndedGoroutines(t *testing.T) {
 	t.Parallel()
 	testWG.Wait()
@@ -0,0 +1,129 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/gossip/channel"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+)
+
+// restoreMembership loads the members persisted in a previous run, and makes
+// the discovery layer probe those which were seen alive recently enough
+func (g *gossipServiceImpl) restoreMembership() {
+	members, err := g.persistence.Load()
+	if err != nil {
+		g.logger.Warning("Failed loading persisted membership:", err)
+		return
+	}
+
+	g.persistedStateInfoLock.Lock()
+	defer g.persistedStateInfoLock.Unlock()
+
+	var restored []*discovery.PersistedMember
+	for _, member := range members {
+		if time.Since(member.LastSeen) > g.conf.MembershipExpiration {
+			continue
+		}
+		// The identity must be known to verify the alive and StateInfo messages
+		identity := api.PeerIdentityType(member.Identity)
+		if err := g.idMapper.Put(g.idMapper.GetPKIidOfCert(identity), identity); err != nil {
+			g.logger.Warning("Failed restoring the identity of a persisted member:", err)
+			continue
+		}
+		for _, env := range member.StateInfo {
+			msg, err := env.ToGossipMessage()
+			if err != nil || !msg.IsStateInfoMsg() {
+				g.logger.Warning("Persisted member has an invalid StateInfo message:", err)
+				continue
+			}
+			g.persistedStateInfo = append(g.persistedStateInfo, msg)
+		}
+		restored = append(restored, member)
+	}
+
+	g.disc.Restore(restored)
+}
+
+// restoreStateInfo adds the persisted StateInfo messages of the given channel
+// to the channel, to know which members are in the channel as soon as they are
+// found alive, without waiting for them to publish their StateInfo messages again
+func (g *gossipServiceImpl) restoreStateInfo(chainID common.ChainID) {
+	gc := g.chanState.getGossipChannelByChainID(chainID)
+	if gc == nil {
+		return
+	}
+
+	g.persistedStateInfoLock.Lock()
+	defer g.persistedStateInfoLock.Unlock()
+
+	var remaining []*proto.SignedGossipMessage
+	for _, msg := range g.persistedStateInfo {
+		stateInfo := msg.GetStateInfo()
+		if !bytes.Equal(stateInfo.Channel_MAC, channel.GenerateMAC(stateInfo.PkiId, chainID)) {
+			remaining = append(remaining, msg)
+			continue
+		}
+		if err := g.validateStateInfoMsg(msg); err != nil {
+			g.logger.Warning("Persisted StateInfo message of", stateInfo.PkiId, "isn't valid:", err)
+			continue
+		}
+		gc.AddToMsgStore(msg)
+	}
+	g.persistedStateInfo = remaining
+}
+
+func (g *gossipServiceImpl) periodicalPersistMembership() {
+	for {
+		select {
+		case s := <-g.toDieChan:
+			g.toDieChan <- s
+			return
+		case <-time.After(g.conf.MembershipPersistenceInterval):
+			g.persistMembership()
+		}
+	}
+}
+
+// persistMembership stores the alive members, along with their identities
+// and the StateInfo messages they published in the channels they are in
+func (g *gossipServiceImpl) persistMembership() {
+	stateInfoMsgs := make(map[string][]*proto.Envelope)
+	g.chanState.RLock()
+	for _, gc := range g.chanState.channels {
+		for _, msg := range gc.StateInfoMessages() {
+			pkiID := string(msg.GetStateInfo().PkiId)
+			stateInfoMsgs[pkiID] = append(stateInfoMsgs[pkiID], msg.Envelope)
+		}
+	}
+	g.chanState.RUnlock()
+
+	var members []*discovery.PersistedMember
+	for _, member := range g.disc.Snapshot() {
+		am, err := member.AliveMsg.ToGossipMessage()
+		if err != nil {
+			continue
+		}
+		pkiID := am.GetAliveMsg().Membership.PkiId
+		identity, err := g.idMapper.Get(pkiID)
+		if err != nil {
+			continue
+		}
+		member.Identity = identity
+		member.StateInfo = stateInfoMsgs[string(pkiID)]
+		members = append(members, member)
+	}
+
+	if err := g.persistence.Save(members); err != nil {
+		g.logger.Warning("Failed persisting membership:", err)
+	}
+}
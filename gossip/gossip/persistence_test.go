@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func newGossipInstanceWithPersistence(portPrefix int, id int, path string, expiration time.Duration, boot ...int) Gossip {
+	port := id + portPrefix
+	conf := &Config{
+		BindPort:                      port,
+		BootstrapPeers:                bootPeers(portPrefix, boot...),
+		ID:                            fmt.Sprintf("p%d", id),
+		MaxBlockCountToStore:          100,
+		MaxPropagationBurstLatency:    time.Duration(500) * time.Millisecond,
+		MaxPropagationBurstSize:       20,
+		PropagateIterations:           1,
+		PropagatePeerNum:              3,
+		PullInterval:                  time.Duration(2) * time.Second,
+		PullPeerNum:                   5,
+		InternalEndpoint:              fmt.Sprintf("localhost:%d", port),
+		ExternalEndpoint:              fmt.Sprintf("1.2.3.4:%d", port),
+		PublishCertPeriod:             time.Duration(4) * time.Second,
+		PublishStateInfoInterval:      time.Duration(1) * time.Second,
+		RequestStateInfoInterval:      time.Duration(1) * time.Second,
+		MembershipPersistencePath:     path,
+		MembershipPersistenceInterval: time.Duration(500) * time.Millisecond,
+		MembershipExpiration:          expiration,
+	}
+	mcs := &naiveCryptoService{}
+	selfId := api.PeerIdentityType(conf.InternalEndpoint)
+	idMapper := identity.NewIdentityMapper(mcs, selfId)
+	return NewGossipServiceWithServer(conf, &orgCryptoService{}, mcs, idMapper, selfId, nil)
+}
+
+func TestMembershipPersistence(t *testing.T) {
+	t.Parallel()
+	// Scenario: p1 and p2 bootstrap from p0, and all join channel A.
+	// p0 restarts without any bootstrap peer, and should find p1 and p2,
+	// and that they are in channel A, from the membership it persisted.
+	// Members persisted which weren't seen alive for too long shouldn't be restored.
+	portPrefix := 14610
+	dir, err := ioutil.TempDir("", "membership")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "membership.json")
+
+	p0 := newGossipInstanceWithPersistence(portPrefix, 0, path, time.Hour)
+	p1 := newGossipInstance(portPrefix, 1, 100, 0)
+	p2 := newGossipInstance(portPrefix, 2, 100, 0)
+	defer p1.Stop()
+	for _, p := range []Gossip{p0, p1, p2} {
+		p.JoinChan(&joinChanMsg{}, common.ChainID("A"))
+		p.UpdateChannelMetadata([]byte("p"), common.ChainID("A"))
+	}
+	waitUntilOrFail(t, func() bool {
+		return len(p0.PeersOfChannel(common.ChainID("A"))) == 2
+	})
+
+	waitUntilOrFailBlocking(t, p0.Stop)
+	members, err := discovery.NewFilePersistence(path).Load()
+	assert.NoError(t, err)
+	assert.Len(t, members, 2)
+	for _, member := range members {
+		assert.NotEmpty(t, member.Identity)
+		assert.Len(t, member.StateInfo, 1)
+	}
+
+	p0 = newGossipInstanceWithPersistence(portPrefix, 0, path, time.Hour)
+	p0.JoinChan(&joinChanMsg{}, common.ChainID("A"))
+	waitUntilOrFail(t, func() bool {
+		return len(p0.PeersOfChannel(common.ChainID("A"))) == 2
+	})
+	waitUntilOrFailBlocking(t, p0.Stop)
+	waitUntilOrFailBlocking(t, p2.Stop)
+
+	// p2 wasn't seen alive for longer than the expiration
+	members, err = discovery.NewFilePersistence(path).Load()
+	assert.NoError(t, err)
+	p2PKIID := common.PKIidType("localhost:14612")
+	for _, member := range members {
+		am, _ := member.AliveMsg.ToGossipMessage()
+		if !bytes.Equal(p2PKIID, am.GetAliveMsg().Membership.PkiId) {
+			continue
+		}
+		member.LastSeen = time.Now().Add(-2 * time.Hour)
+	}
+	assert.NoError(t, discovery.NewFilePersistence(path).Save(members))
+
+	p0 = newGossipInstanceWithPersistence(portPrefix, 0, path, time.Hour)
+	defer p0.Stop()
+	disc := p0.(*gossipServiceImpl).disc
+	assert.NotNil(t, disc.Lookup(common.PKIidType("localhost:14611")))
+	assert.Nil(t, disc.Lookup(p2PKIID))
+}
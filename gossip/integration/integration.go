@@ -20,6 +20,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
+	"path/filepath"
 	"strconv"
 	"time"
 
@@ -73,9 +74,28 @@ func newConfig(selfEndpoint string, externalEndpoint string, bootPeers ...string
 		PublishStateInfoInterval:   util.GetDurationOrDefault("peer.gossip.publishStateInfoInterval", 4*time.Second),
 		SkipBlockVerification:      viper.GetBool("peer.gossip.skipBlockVerification"),
 		TLSServerCert:              cert,
+
+		MembershipPersistencePath:     membershipPersistencePath(),
+		MembershipPersistenceInterval: util.GetDurationOrDefault("peer.gossip.membershipPersistence.interval", 10*time.Second),
+		MembershipExpiration:          util.GetDurationOrDefault("peer.gossip.membershipPersistence.expiration", time.Hour),
+
+		MaxDigestSize: util.GetIntOrDefault("peer.gossip.maxDigestSize", 1000),
 	}, nil
 }
 
+// membershipPersistencePath returns the file the gossip membership is persisted to,
+// or an empty path if it shouldn't be persisted
+func membershipPersistencePath() string {
+	if !viper.GetBool("peer.gossip.membershipPersistence.enabled") {
+		return ""
+	}
+	fileSystemPath := config.GetPath("peer.fileSystemPath")
+	if fileSystemPath == "" {
+		return ""
+	}
+	return filepath.Join(fileSystemPath, "gossip", "membership.json")
+}
+
 // NewGossipComponent creates a gossip component that attaches itself to the given gRPC server
 func NewGossipComponent(peerIdentity []byte, endpoint string, s *grpc.Server,
 	secAdv api.SecurityAdvisor, cryptSvc api.MessageCryptoService, idMapper identity.Mapper,
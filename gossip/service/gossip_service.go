@@ -17,6 +17,7 @@ limitations under the License.
 package service
 
 import (
+	"bytes"
 	"sync"
 
 	"github.com/hyperledger/fabric/core/committer"
@@ -51,6 +52,13 @@ type GossipService interface {
 	NewConfigEventer() ConfigProcessor
 	// InitializeChannel allocates the state provider and should be invoked once per channel per execution
 	InitializeChannel(chainID string, committer committer.Committer, endpoints []string)
+	// LeaveChannel stops the state provider, the leader election and the blocks delivery
+	// of the channel, and leaves it in gossip
+	LeaveChannel(chainID string)
+	// UpdateEndpoints sets the ordering service endpoints blocks of the given channel are delivered from
+	UpdateEndpoints(chainID string, endpoints []string)
+	// DeliveryFailovers returns the most recent ordering service failovers of the blocks delivery of the given channel
+	DeliveryFailovers(chainID string) []blocksprovider.Failover
 	// GetBlock returns block for given chain
 	GetBlock(chainID string, index uint64) *common.Block
 	// AddPayload appends message payload to for given chain
@@ -88,6 +96,7 @@ type gossipServiceImpl struct {
 	mcs             api.MessageCryptoService
 	peerIdentity    []byte
 	secAdv          api.SecurityAdvisor
+	leaderCount     int
 }
 
 // This is an implementation of api.JoinChannelMessage.
@@ -155,13 +164,18 @@ func InitGossipServiceCustomDeliveryFactory(peerIdentity []byte, endpoint string
 			idMapper:        idMapper,
 			peerIdentity:    peerIdentity,
 			secAdv:          secAdv,
+			leaderCount:     election.GetLeaderCount(),
 		}
 	})
 	return err
 }
 
-// GetGossipService returns an instance of gossip service
+// GetGossipService returns an instance of gossip service,
+// or nil if it wasn't initialized
 func GetGossipService() GossipService {
+	if gossipServiceInstance == nil {
+		return nil
+	}
 	return gossipServiceInstance
 }
 
@@ -188,6 +202,8 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, committer committe
 	// Delivery service might be nil only if it was not able to get connected
 	// to the ordering service
 	if g.deliveryService != nil {
+		g.deliveryService.UpdateEndpoints(chainID, endpoints)
+
 		// Parameters:
 		//              - peer.gossip.useLeaderElection
 		//              - peer.gossip.orgLeader
@@ -215,6 +231,51 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, committer committe
 	}
 }
 
+// LeaveChannel stops the state provider, the leader election and the blocks delivery
+// of the channel, and leaves it in gossip
+func (g *gossipServiceImpl) LeaveChannel(chainID string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	logger.Info("Leaving channel", chainID)
+	delivering := viper.GetBool("peer.gossip.orgLeader")
+	if electionService, exists := g.leaderElection[chainID]; exists {
+		electionService.Stop()
+		delivering = electionService.IsLeader()
+		delete(g.leaderElection, chainID)
+	}
+	if g.deliveryService != nil && delivering {
+		if err := g.deliveryService.StopDeliverForChannel(chainID); err != nil {
+			logger.Warning("Failed stopping blocks delivery for channel", chainID, "due to", err)
+		}
+	}
+	if ch, exists := g.chains[chainID]; exists {
+		ch.Stop()
+		delete(g.chains, chainID)
+	}
+	g.LeaveChan(gossipCommon.ChainID(chainID))
+}
+
+// UpdateEndpoints sets the ordering service endpoints blocks of the given channel are delivered from
+func (g *gossipServiceImpl) UpdateEndpoints(chainID string, endpoints []string) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	if g.deliveryService == nil {
+		logger.Warning("Delivery client is down, can't update the ordering service endpoints of chain", chainID)
+		return
+	}
+	g.deliveryService.UpdateEndpoints(chainID, endpoints)
+}
+
+// DeliveryFailovers returns the most recent ordering service failovers of the blocks delivery of the given channel
+func (g *gossipServiceImpl) DeliveryFailovers(chainID string) []blocksprovider.Failover {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	if g.deliveryService == nil {
+		return nil
+	}
+	return g.deliveryService.Failovers(chainID)
+}
+
 // configUpdated constructs a joinChannelMessage and sends it to the gossipSvc
 func (g *gossipServiceImpl) configUpdated(config Config) {
 	myOrg := string(g.secAdv.OrgByPeerIdentity(api.PeerIdentityType(g.peerIdentity)))
@@ -293,7 +354,15 @@ func (g *gossipServiceImpl) onStatusChangeFactory(chainID string, committer bloc
 	return func(isLeader bool) {
 		if isLeader {
 			logger.Info("Elected as a leader, starting delivery service for channel", chainID)
-			if err := g.deliveryService.StartDeliverForChannel(chainID, committer); err != nil {
+			var err error
+			if g.leaderCount > 1 {
+				// Several leaders deliver blocks for the channel,
+				// have each one connect to a different orderer endpoint
+				err = g.deliveryService.StartDeliverForChannelFrom(chainID, committer, g.leaderIndex(chainID))
+			} else {
+				err = g.deliveryService.StartDeliverForChannel(chainID, committer)
+			}
+			if err != nil {
 				logger.Error("Delivery service is not able to start blocks delivery for chain, due to", err)
 			}
 		} else {
@@ -307,6 +376,26 @@ func (g *gossipServiceImpl) onStatusChangeFactory(chainID string, committer bloc
 	}
 }
 
+// leaderIndex returns the number of peers of our organization in the channel with a lower
+// PKI-ID than ours. Since the peers with the lowest PKI-IDs are elected as leaders,
+// each leader of the channel gets a different index once the membership view is stable.
+func (g *gossipServiceImpl) leaderIndex(chainID string) int {
+	selfPKIid := g.idMapper.GetPKIidOfCert(g.peerIdentity)
+	myOrg := g.secAdv.OrgByPeerIdentity(api.PeerIdentityType(g.peerIdentity))
+	index := 0
+	for _, member := range g.PeersOfChannel(gossipCommon.ChainID(chainID)) {
+		if bytes.Compare(member.PKIid, selfPKIid) >= 0 {
+			continue
+		}
+		identity, err := g.idMapper.Get(member.PKIid)
+		if err != nil || !bytes.Equal(g.secAdv.OrgByPeerIdentity(identity), myOrg) {
+			continue
+		}
+		index++
+	}
+	return index
+}
+
 func orgListFromConfig(config Config) []string {
 	var orgList []string
 	for _, appOrg := range config.Organizations() {
@@ -149,6 +149,65 @@ func TestLeaderElectionWithDeliverClient(t *testing.T) {
 	stopPeers(gossips)
 }
 
+func TestMultipleLeadersWithDeliverClient(t *testing.T) {
+
+	//Test check if several leaders are elected when configured so
+	//5 peers started, added to channel and at the end we check that
+	//the 2 peers with the lowest PKI-IDs started delivery, each from a different endpoint
+
+	viper.Set("peer.gossip.useLeaderElection", true)
+	viper.Set("peer.gossip.orgLeader", false)
+	election.SetLeaderCount(2)
+	defer election.SetLeaderCount(1)
+
+	n := 5
+	gossips := startPeers(t, n, 20000)
+
+	channelName := "chanA"
+	peerIndexes := make([]int, n)
+	for i := 0; i < n; i++ {
+		peerIndexes[i] = i
+	}
+	addPeersToChannel(t, n, 20000, channelName, gossips, peerIndexes)
+
+	waitForFullMembership(t, gossips, n, time.Second*20, time.Second*2)
+
+	services := make([]*electionService, n)
+
+	for i := 0; i < n; i++ {
+		deliverServiceFactory := &mockDeliverServiceFactory{
+			service: &mockDeliverService{
+				running:         make(map[string]bool),
+				endpointIndexes: make(map[string]int),
+			},
+		}
+		gossips[i].(*gossipServiceImpl).deliveryFactory = deliverServiceFactory
+		gossips[i].(*gossipServiceImpl).secAdv = &orgCryptoService{}
+		deliverServiceFactory.service.running[channelName] = false
+
+		gossips[i].InitializeChannel(channelName, &mockLedgerInfo{1}, []string{"localhost:5005", "localhost:5006"})
+		service, exist := gossips[i].(*gossipServiceImpl).leaderElection[channelName]
+		assert.True(t, exist, "Leader election service should be created for peer %d and channel %s", i, channelName)
+		services[i] = &electionService{nil, false, 0}
+		services[i].LeaderElectionService = service
+	}
+
+	assert.True(t, waitForMultipleLeadersElection(t, services, 2, time.Second*30, time.Second*2), "Two leaders should be selected")
+
+	for i := 0; i < n; i++ {
+		ds := gossips[i].(*gossipServiceImpl).deliveryService.(*mockDeliverService)
+		// The peers with the lowest PKI-IDs are p0 and p1
+		if i < 2 {
+			assert.True(t, ds.running[channelName], "Delivery client should start for peer %d", i)
+			assert.Equal(t, i, ds.endpointIndexes[channelName], "Peer %d should deliver from a different endpoint", i)
+		} else {
+			assert.False(t, ds.running[channelName], "Delivery client should not start for peer %d", i)
+		}
+	}
+
+	stopPeers(gossips)
+}
+
 func TestWithStaticDeliverClientLeader(t *testing.T) {
 
 	//Tests check if static leader flag works ok.
@@ -175,7 +234,8 @@ func TestWithStaticDeliverClientLeader(t *testing.T) {
 
 	deliverServiceFactory := &mockDeliverServiceFactory{
 		service: &mockDeliverService{
-			running: make(map[string]bool),
+			running:   make(map[string]bool),
+			endpoints: make(map[string][]string),
 		},
 	}
 
@@ -189,6 +249,7 @@ func TestWithStaticDeliverClientLeader(t *testing.T) {
 		assert.NotNil(t, gossips[i].(*gossipServiceImpl).deliveryService, "Delivery service not initiated in peer %d", i)
 		assert.True(t, gossips[i].(*gossipServiceImpl).deliveryService.(*mockDeliverService).running[channelName], "Block deliverer not started for peer %d", i)
 	}
+	assert.Equal(t, []string{"localhost:5005"}, deliverServiceFactory.service.endpoints[channelName])
 
 	channelName = "chanB"
 	for i := 0; i < n; i++ {
@@ -241,6 +302,67 @@ func TestWithStaticDeliverClientNotLeader(t *testing.T) {
 	stopPeers(gossips)
 }
 
+func TestLeaveChannel(t *testing.T) {
+	viper.Set("peer.gossip.useLeaderElection", false)
+	viper.Set("peer.gossip.orgLeader", true)
+
+	gossips := startPeers(t, 1, 20000)
+	g := gossips[0].(*gossipServiceImpl)
+
+	addPeersToChannel(t, 1, 20000, "chanA", gossips, []int{0})
+	addPeersToChannel(t, 1, 20000, "chanB", gossips, []int{0})
+
+	deliverServiceFactory := &mockDeliverServiceFactory{
+		service: &mockDeliverService{
+			running: make(map[string]bool),
+		},
+	}
+	g.deliveryFactory = deliverServiceFactory
+	g.InitializeChannel("chanA", &mockLedgerInfo{1}, []string{"localhost:5005"})
+	g.InitializeChannel("chanB", &mockLedgerInfo{1}, []string{"localhost:5005"})
+	assert.True(t, deliverServiceFactory.service.running["chanA"])
+	assert.True(t, deliverServiceFactory.service.running["chanB"])
+
+	g.LeaveChannel("chanA")
+	assert.False(t, deliverServiceFactory.service.running["chanA"], "Block deliverer of the channel left should be stopped")
+	assert.True(t, deliverServiceFactory.service.running["chanB"])
+	_, exists := g.chains["chanA"]
+	assert.False(t, exists, "State provider of the channel left should be removed")
+	_, exists = g.chains["chanB"]
+	assert.True(t, exists)
+
+	// Leaving a channel again, or a channel never joined, does nothing
+	g.LeaveChannel("chanA")
+	g.LeaveChannel("chanC")
+
+	stopPeers(gossips)
+}
+
+func TestDeliveryFailovers(t *testing.T) {
+	viper.Set("peer.gossip.useLeaderElection", false)
+	viper.Set("peer.gossip.orgLeader", true)
+
+	gossips := startPeers(t, 1, 20000)
+	g := gossips[0].(*gossipServiceImpl)
+
+	addPeersToChannel(t, 1, 20000, "chanA", gossips, []int{0})
+
+	assert.Nil(t, g.DeliveryFailovers("chanA"), "Delivery service isn't initiated, there should be no failovers")
+
+	failovers := []blocksprovider.Failover{{Time: time.Now(), Endpoint: "localhost:5005", LastBlock: 1, AdvertisedBlock: 3}}
+	g.deliveryFactory = &mockDeliverServiceFactory{
+		service: &mockDeliverService{
+			running:   make(map[string]bool),
+			failovers: map[string][]blocksprovider.Failover{"chanA": failovers},
+		},
+	}
+	g.InitializeChannel("chanA", &mockLedgerInfo{1}, []string{"localhost:5005"})
+	assert.Equal(t, failovers, g.DeliveryFailovers("chanA"))
+	assert.Nil(t, g.DeliveryFailovers("chanB"))
+
+	stopPeers(gossips)
+}
+
 func TestWithStaticDeliverClientBothStaticAndLeaderElection(t *testing.T) {
 	viper.Set("peer.gossip.useLeaderElection", true)
 	viper.Set("peer.gossip.orgLeader", true)
@@ -283,7 +405,10 @@ func (mf *mockDeliverServiceFactory) Service(g GossipService, endpoints []string
 }
 
 type mockDeliverService struct {
-	running map[string]bool
+	running         map[string]bool
+	endpointIndexes map[string]int
+	endpoints       map[string][]string
+	failovers       map[string][]blocksprovider.Failover
 }
 
 func (ds *mockDeliverService) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo) error {
@@ -291,11 +416,27 @@ func (ds *mockDeliverService) StartDeliverForChannel(chainID string, ledgerInfo
 	return nil
 }
 
+func (ds *mockDeliverService) StartDeliverForChannelFrom(chainID string, ledgerInfo blocksprovider.LedgerInfo, endpointIndex int) error {
+	ds.running[chainID] = true
+	ds.endpointIndexes[chainID] = endpointIndex
+	return nil
+}
+
 func (ds *mockDeliverService) StopDeliverForChannel(chainID string) error {
 	ds.running[chainID] = false
 	return nil
 }
 
+func (ds *mockDeliverService) UpdateEndpoints(chainID string, endpoints []string) {
+	if ds.endpoints != nil {
+		ds.endpoints[chainID] = endpoints
+	}
+}
+
+func (ds *mockDeliverService) Failovers(chainID string) []blocksprovider.Failover {
+	return ds.failovers[chainID]
+}
+
 func (ds *mockDeliverService) Stop() {
 }
 
@@ -622,6 +763,7 @@ func newGossipInstance(portPrefix int, id int, maxMsgCount int, boot ...int) Gos
 		deliveryFactory: &deliveryFactoryImpl{},
 		idMapper:        idMapper,
 		peerIdentity:    api.PeerIdentityType(conf.InternalEndpoint),
+		leaderCount:     election.GetLeaderCount(),
 	}
 
 	return gossipService
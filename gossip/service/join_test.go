@@ -17,6 +17,7 @@ limitations under the License.
 package service
 
 import (
+	"crypto/tls"
 	"sync"
 	"testing"
 	"time"
@@ -52,6 +53,14 @@ func (*gossipMock) SuspectPeers(s api.PeerSuspector) {
 	panic("implement me")
 }
 
+func (*gossipMock) Reputations() []comm.PeerReputation {
+	panic("implement me")
+}
+
+func (*gossipMock) UpdateTLSCertificate(cert *tls.Certificate) {
+	panic("implement me")
+}
+
 func (*gossipMock) Send(msg *proto.GossipMessage, peers ...*comm.RemotePeer) {
 	panic("implement me")
 }
@@ -84,6 +93,10 @@ func (g *gossipMock) JoinChan(joinMsg api.JoinChannelMessage, chainID common.Cha
 	g.Called(joinMsg, chainID)
 }
 
+func (*gossipMock) LeaveChan(chainID common.ChainID) {
+	panic("implement me")
+}
+
 func (*gossipMock) Stop() {
 	panic("implement me")
 }
@@ -17,6 +17,8 @@ limitations under the License.
 package mocks
 
 import (
+	"crypto/tls"
+
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
 	"github.com/hyperledger/fabric/gossip/common"
@@ -33,6 +35,14 @@ func (*GossipMock) SuspectPeers(s api.PeerSuspector) {
 	panic("implement me")
 }
 
+func (*GossipMock) Reputations() []comm.PeerReputation {
+	panic("implement me")
+}
+
+func (*GossipMock) UpdateTLSCertificate(cert *tls.Certificate) {
+	panic("implement me")
+}
+
 func (*GossipMock) Send(msg *proto.GossipMessage, peers ...*comm.RemotePeer) {
 	panic("implement me")
 }
@@ -68,5 +78,8 @@ func (g *GossipMock) Accept(acceptor common.MessageAcceptor, passThrough bool) (
 func (g *GossipMock) JoinChan(joinMsg api.JoinChannelMessage, chainID common.ChainID) {
 }
 
+func (g *GossipMock) LeaveChan(chainID common.ChainID) {
+}
+
 func (*GossipMock) Stop() {
 }
@@ -18,7 +18,6 @@ package state
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"os"
 	"sync"
@@ -56,11 +55,53 @@ const (
 	defAntiEntropyInterval             = 10 * time.Second
 	defAntiEntropyStateResponseTimeout = 3 * time.Second
 	defAntiEntropyBatchSize            = 10
+	defAntiEntropyParallelism          = 4
 
 	defChannelBufferSize     = 100
 	defAntiEntropyMaxRetries = 3
 )
 
+// stateConfig holds the parameters of the anti-entropy procedure
+type stateConfig struct {
+	// The interval at which the ledger height is compared to the peers' ones
+	antiEntropyInterval time.Duration
+
+	// The time to wait for the response to a state request
+	responseTimeout time.Duration
+
+	// The number of blocks requested, or served, per state request
+	batchSize uint64
+
+	// The maximal number of state requests in flight
+	parallelism int
+
+	// The number of times a batch is requested again before giving up until
+	// the next anti-entropy round
+	maxRetries int
+}
+
+func readStateConfig() *stateConfig {
+	return &stateConfig{
+		antiEntropyInterval: util.GetDurationOrDefault("peer.gossip.state.checkInterval", defAntiEntropyInterval),
+		responseTimeout:     util.GetDurationOrDefault("peer.gossip.state.responseTimeout", defAntiEntropyStateResponseTimeout),
+		batchSize:           uint64(positiveIntOrDefault("peer.gossip.state.batchSize", defAntiEntropyBatchSize)),
+		parallelism:         positiveIntOrDefault("peer.gossip.state.parallelism", defAntiEntropyParallelism),
+		maxRetries:          positiveIntOrDefault("peer.gossip.state.maxRetries", defAntiEntropyMaxRetries),
+	}
+}
+
+// positiveIntOrDefault returns the value of the given key, or defVal if the
+// key is unset or its value is not positive, as the transfer of missing
+// blocks would otherwise never complete
+func positiveIntOrDefault(key string, defVal int) int {
+	val := util.GetIntOrDefault(key, defVal)
+	if val < 1 {
+		logger.Warningf("Invalid value %d of %s, it must be positive, using %d instead", val, key, defVal)
+		return defVal
+	}
+	return val
+}
+
 // GossipAdapter defines gossip/communication required interface for state provider
 type GossipAdapter interface {
 	// Send sends a message to remote peers
@@ -115,6 +156,13 @@ type GossipStateProviderImpl struct {
 	once sync.Once
 
 	stateTransferActive int32
+
+	config *stateConfig
+
+	// Penalties of the peers which failed to serve state requests, by PKI-ID
+	penalties map[string]int
+
+	penaltiesLock sync.Mutex
 }
 
 var logger *logging.Logger // package-level logger
@@ -205,6 +253,10 @@ func NewGossipStateProvider(chainID string, g GossipAdapter, committer committer
 		stateTransferActive: 0,
 
 		once: sync.Once{},
+
+		config: readStateConfig(),
+
+		penalties: make(map[string]int),
 	}
 
 	nodeMetastate := NewNodeMetastate(height - 1)
@@ -308,29 +360,31 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 	}
 	request := msg.GetGossipMessage().GetStateRequest()
 
-	batchSize := request.EndSeqNum - request.StartSeqNum
-	if batchSize > defAntiEntropyBatchSize {
-		logger.Errorf("Requesting blocks batchSize size (%d) greater than configured allowed"+
-			" (%d) batching for anti-entropy. Ignoring request...", batchSize, defAntiEntropyBatchSize)
-		return
-	}
-
 	if request.StartSeqNum > request.EndSeqNum {
 		logger.Errorf("Invalid sequence interval [%d...%d], ignoring request...", request.StartSeqNum, request.EndSeqNum)
 		return
 	}
 
+	endSeqNum := request.EndSeqNum
+	if batchSize := request.EndSeqNum - request.StartSeqNum + 1; batchSize > s.config.batchSize {
+		// The requester fetches the rest of the range with further requests
+		logger.Debugf("Requesting blocks batchSize size (%d) greater than configured allowed"+
+			" (%d) batching for anti-entropy, serving only [%d...%d]", batchSize, s.config.batchSize,
+			request.StartSeqNum, request.StartSeqNum+s.config.batchSize-1)
+		endSeqNum = request.StartSeqNum + s.config.batchSize - 1
+	}
+
 	currentHeight, err := s.committer.LedgerHeight()
 	if err != nil {
 		logger.Errorf("Cannot access to current ledger height, due to %s", err)
 		return
 	}
-	if currentHeight < request.EndSeqNum {
+	if currentHeight < endSeqNum {
 		logger.Warningf("Received state request to transfer blocks with sequence numbers higher  [%d...%d] "+
-			"than available in ledger (%d)", request.StartSeqNum, request.StartSeqNum, currentHeight)
+			"than available in ledger (%d)", request.StartSeqNum, endSeqNum, currentHeight)
 	}
 
-	endSeqNum := min(currentHeight, request.EndSeqNum)
+	endSeqNum = min(currentHeight, endSeqNum)
 
 	response := &proto.RemoteStateResponse{Payloads: make([]*proto.Payload, 0)}
 	for seqNum := request.StartSeqNum; seqNum <= endSeqNum; seqNum++ {
@@ -362,31 +416,6 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 	})
 }
 
-func (s *GossipStateProviderImpl) handleStateResponse(msg proto.ReceivedMessage) (uint64, error) {
-	max := uint64(0)
-	// Send signal that response for given nonce has been received
-	response := msg.GetGossipMessage().GetStateResponse()
-	// Extract payloads, verify and push into buffer
-	if len(response.GetPayloads()) == 0 {
-		return uint64(0), errors.New("Received state tranfer response without payload")
-	}
-	for _, payload := range response.GetPayloads() {
-		logger.Debugf("Received payload with sequence number %d.", payload.SeqNum)
-		if err := s.mcs.VerifyBlock(common2.ChainID(s.chainID), payload.SeqNum, payload.Data); err != nil {
-			logger.Warningf("Error verifying block with sequence number %d, due to %s", payload.SeqNum, err)
-			return uint64(0), err
-		}
-		if max < payload.SeqNum {
-			max = payload.SeqNum
-		}
-		err := s.payloads.Push(payload)
-		if err != nil {
-			logger.Warningf("Payload with sequence number %d was received earlier", payload.SeqNum)
-		}
-	}
-	return max, nil
-}
-
 // Stop function send halting signal to all go routines
 func (s *GossipStateProviderImpl) Stop() {
 	// Make sure stop won't be executed twice
@@ -416,7 +445,9 @@ func (s *GossipStateProviderImpl) queueNewMessage(msg *proto.GossipMessage) {
 		// Add new payload to ordered set
 
 		logger.Debugf("Received new payload with sequence number = [%d]", dataMsg.Payload.SeqNum)
-		s.payloads.Push(dataMsg.GetPayload())
+		if err := s.payloads.Push(dataMsg.GetPayload()); err != nil {
+			logger.Debug("Ignoring duplicate payload:", err)
+		}
 	} else {
 		logger.Debug("Gossip message received is not of data message type, usually this should not happen.")
 	}
@@ -479,7 +510,7 @@ func (s *GossipStateProviderImpl) antiEntropy() {
 		case <-s.stopCh:
 			s.stopCh <- struct{}{}
 			return
-		case <-time.After(defAntiEntropyInterval):
+		case <-time.After(s.config.antiEntropyInterval):
 			current, err := s.committer.LedgerHeight()
 			if err != nil {
 				// Unable to read from ledger continue to the next round
@@ -515,64 +546,6 @@ func (s *GossipStateProviderImpl) maxAvailableLedgerHeight() uint64 {
 	return max
 }
 
-// GetBlocksInRange capable to acquire blocks with sequence
-// numbers in the range [start...end].
-func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64) {
-	atomic.StoreInt32(&s.stateTransferActive, 1)
-	defer atomic.StoreInt32(&s.stateTransferActive, 0)
-
-	for prev := start; prev <= end; {
-		next := min(end, prev+defAntiEntropyBatchSize)
-
-		gossipMsg := s.stateRequestMessage(prev, next)
-
-		responseReceived := false
-		tryCounts := 0
-
-		for !responseReceived {
-			if tryCounts > defAntiEntropyMaxRetries {
-				logger.Warningf("Wasn't  able to get blocks in range [%d...%d], after %d retries",
-					prev, next, tryCounts)
-				return
-			}
-			// Select peers to ask for blocks
-			peer, err := s.selectPeerToRequestFrom(next)
-			if err != nil {
-				logger.Warningf("Cannot send state request for blocks in range [%d...%d], due to",
-					prev, next, err)
-				return
-			}
-
-			logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d], "+
-				"for chainID %s", peer.Endpoint, prev, next, s.chainID)
-
-			s.gossip.Send(gossipMsg, peer)
-			tryCounts++
-
-			// Wait until timeout or response arrival
-			select {
-			case msg := <-s.stateResponseCh:
-				if msg.GetGossipMessage().Nonce != gossipMsg.Nonce {
-					continue
-				}
-				// Got corresponding response for state request, can continue
-				index, err := s.handleStateResponse(msg)
-				if err != nil {
-					logger.Warningf("Wasn't able to process state response for "+
-						"blocks [%d...%d], due to %s", prev, next, err)
-					continue
-				}
-				prev = index + 1
-				responseReceived = true
-			case <-time.After(defAntiEntropyStateResponseTimeout):
-			case <-s.stopCh:
-				s.stopCh <- struct{}{}
-				return
-			}
-		}
-	}
-}
-
 // Generate state request message for given blocks in range [beginSeq...endSeq]
 func (s *GossipStateProviderImpl) stateRequestMessage(beginSeq uint64, endSeq uint64) *proto.GossipMessage {
 	return &proto.GossipMessage{
@@ -588,47 +561,6 @@ func (s *GossipStateProviderImpl) stateRequestMessage(beginSeq uint64, endSeq ui
 	}
 }
 
-// Select peer which has required blocks to ask missing blocks from
-func (s *GossipStateProviderImpl) selectPeerToRequestFrom(height uint64) (*comm.RemotePeer, error) {
-	// Filter peers which posses required range of missing blocks
-	peers := s.filterPeers(s.hasRequiredHeight(height))
-
-	n := len(peers)
-	if n == 0 {
-		return nil, errors.New("there are no peers to ask for missing blocks from")
-	}
-
-	// Select peers to ask for blocks
-	return peers[util.RandomInt(n)], nil
-}
-
-// filterPeers return list of peers which aligns the predicate provided
-func (s *GossipStateProviderImpl) filterPeers(predicate func(peer discovery.NetworkMember) bool) []*comm.RemotePeer {
-	var peers []*comm.RemotePeer
-
-	for _, member := range s.gossip.PeersOfChannel(common2.ChainID(s.chainID)) {
-		if predicate(member) {
-			peers = append(peers, &comm.RemotePeer{Endpoint: member.PreferredEndpoint(), PKIID: member.PKIid})
-		}
-	}
-
-	return peers
-}
-
-// hasRequiredHeight returns predicate which is capable to filter peers with ledger height above than indicated
-// by provided input parameter
-func (s *GossipStateProviderImpl) hasRequiredHeight(height uint64) func(peer discovery.NetworkMember) bool {
-	return func(peer discovery.NetworkMember) bool {
-		if nodeMetadata, err := FromBytes(peer.Metadata); err != nil {
-			logger.Errorf("Unable to de-serialize node meta state, error = %s", err)
-		} else if nodeMetadata.LedgerHeight >= height {
-			return true
-		}
-
-		return false
-	}
-}
-
 // GetBlock return ledger block given its sequence number as a parameter
 func (s *GossipStateProviderImpl) GetBlock(index uint64) *common.Block {
 	// Try to read missing block from the ledger, should return no nil with
@@ -640,7 +572,8 @@ func (s *GossipStateProviderImpl) GetBlock(index uint64) *common.Block {
 	return nil
 }
 
-// AddPayload add new payload into state
+// AddPayload add new payload into state. Since several leaders may deliver
+// the same blocks, returns an error if the payload was already received
 func (s *GossipStateProviderImpl) AddPayload(payload *proto.Payload) error {
 
 	logger.Debug("Adding new payload into the buffer, seqNum = ", payload.SeqNum)
@@ -260,6 +260,31 @@ func newPeerNode(config *gossip.Config, committer committer.Committer, acceptor
 	return newPeerNodeWithGossip(config, committer, acceptor, nil)
 }
 
+func TestReadStateConfig(t *testing.T) {
+	defer func() {
+		viper.Set("peer.gossip.state.batchSize", nil)
+		viper.Set("peer.gossip.state.parallelism", nil)
+		viper.Set("peer.gossip.state.maxRetries", nil)
+	}()
+
+	viper.Set("peer.gossip.state.batchSize", 20)
+	viper.Set("peer.gossip.state.parallelism", 2)
+	viper.Set("peer.gossip.state.maxRetries", 5)
+	config := readStateConfig()
+	assert.Equal(t, uint64(20), config.batchSize)
+	assert.Equal(t, 2, config.parallelism)
+	assert.Equal(t, 5, config.maxRetries)
+
+	// Values which would prevent the transfers from completing are replaced by the defaults
+	viper.Set("peer.gossip.state.batchSize", -1)
+	viper.Set("peer.gossip.state.parallelism", -4)
+	viper.Set("peer.gossip.state.maxRetries", -2)
+	config = readStateConfig()
+	assert.Equal(t, uint64(defAntiEntropyBatchSize), config.batchSize)
+	assert.Equal(t, defAntiEntropyParallelism, config.parallelism)
+	assert.Equal(t, defAntiEntropyMaxRetries, config.maxRetries)
+}
+
 func TestNilDirectMsg(t *testing.T) {
 	mc := &mockCommitter{}
 	mc.On("LedgerHeight", mock.Anything).Return(uint64(1), nil)
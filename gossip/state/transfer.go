@@ -0,0 +1,357 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/comm"
+	common2 "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/util"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+)
+
+// The interval at which the window of in-flight requests is re-examined
+// while it is full of blocks waiting to be committed
+const windowPollInterval = 100 * time.Millisecond
+
+// blockRange is a range [start...end] of blocks to request from a remote peer
+type blockRange struct {
+	start uint64
+	end   uint64
+
+	// The number of times the range was requested without success
+	attempts int
+}
+
+// inFlightRequest is a state request sent to a remote peer, awaiting its response
+type inFlightRequest struct {
+	*blockRange
+	peer     *comm.RemotePeer
+	deadline time.Time
+}
+
+// transfer tracks the ranges of blocks of a state transfer which remain to
+// be received, and the requests in flight for them
+type transfer struct {
+	// The next block not yet split into a range, and the last block to transfer
+	next uint64
+	end  uint64
+
+	batchSize uint64
+
+	// Ranges to request again, ordered by their start
+	retries []*blockRange
+
+	// Requests in flight, by nonce
+	inFlight map[uint64]*inFlightRequest
+}
+
+func newTransfer(start, end, batchSize uint64) *transfer {
+	return &transfer{
+		next:      start,
+		end:       end,
+		batchSize: batchSize,
+		inFlight:  make(map[uint64]*inFlightRequest),
+	}
+}
+
+// done returns whether all the blocks of the transfer were received
+func (t *transfer) done() bool {
+	return t.next > t.end && len(t.retries) == 0 && len(t.inFlight) == 0
+}
+
+// peek returns the lowest range which remains to be requested, skipping the
+// blocks below from which are already received
+func (t *transfer) peek(from uint64) *blockRange {
+	for len(t.retries) > 0 {
+		r := t.retries[0]
+		if r.end >= from {
+			if r.start < from {
+				r.start = from
+			}
+			return r
+		}
+		t.retries = t.retries[1:]
+	}
+
+	if t.next < from {
+		t.next = from
+	}
+	if t.next > t.end {
+		return nil
+	}
+	return &blockRange{start: t.next, end: min(t.end, t.next+t.batchSize-1)}
+}
+
+// take removes the range returned by peek from the ranges to request
+func (t *transfer) take(r *blockRange) {
+	if len(t.retries) > 0 && t.retries[0] == r {
+		t.retries = t.retries[1:]
+		return
+	}
+	t.next = r.end + 1
+}
+
+// retry schedules the range to be requested again
+func (t *transfer) retry(r *blockRange) {
+	i := sort.Search(len(t.retries), func(i int) bool {
+		return t.retries[i].start > r.start
+	})
+	t.retries = append(t.retries, nil)
+	copy(t.retries[i+1:], t.retries[i:])
+	t.retries[i] = r
+}
+
+// earliestDeadline returns the earliest deadline of the requests in flight
+func (t *transfer) earliestDeadline() (time.Time, bool) {
+	var earliest time.Time
+	for _, req := range t.inFlight {
+		if earliest.IsZero() || req.deadline.Before(earliest) {
+			earliest = req.deadline
+		}
+	}
+	return earliest, !earliest.IsZero()
+}
+
+// requestBlocksInRange acquires the blocks with sequence numbers in the range
+// [start...end], split in batches requested in parallel from the peers which
+// advertise a ledger height covering them. The number of requests in flight
+// is bounded, as is the number of blocks received ahead of the next block to
+// commit.
+func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64) {
+	atomic.StoreInt32(&s.stateTransferActive, 1)
+	defer atomic.StoreInt32(&s.stateTransferActive, 0)
+
+	t := newTransfer(start, end, s.config.batchSize)
+	window := s.config.batchSize * uint64(s.config.parallelism)
+
+	for !t.done() {
+		windowFull := false
+		for len(t.inFlight) < s.config.parallelism {
+			r := t.peek(s.payloads.Next())
+			if r == nil {
+				break
+			}
+			if r.start >= s.payloads.Next()+window {
+				// Wait for the blocks received to be committed
+				windowFull = true
+				break
+			}
+			if r.attempts > s.config.maxRetries {
+				logger.Warningf("Wasn't able to get blocks in range [%d...%d], after %d retries",
+					r.start, r.end, r.attempts)
+				return
+			}
+
+			peer, err := s.selectPeerToRequestFrom(r.end, t.inFlight)
+			if err != nil {
+				if len(t.inFlight) == 0 {
+					logger.Warningf("Cannot send state request for blocks in range [%d...%d], due to %s",
+						r.start, r.end, err)
+					return
+				}
+				// Try again once the requests in flight are answered
+				break
+			}
+			t.take(r)
+
+			gossipMsg := s.stateRequestMessage(r.start, r.end)
+			t.inFlight[gossipMsg.Nonce] = &inFlightRequest{
+				blockRange: r,
+				peer:       peer,
+				deadline:   time.Now().Add(s.config.responseTimeout),
+			}
+
+			logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d], "+
+				"for chainID %s", peer.Endpoint, r.start, r.end, s.chainID)
+			s.gossip.Send(gossipMsg, peer)
+		}
+
+		if t.done() {
+			return
+		}
+
+		wait := windowPollInterval
+		if deadline, ok := t.earliestDeadline(); ok && (!windowFull || deadline.Sub(time.Now()) < wait) {
+			wait = deadline.Sub(time.Now())
+		}
+
+		select {
+		case msg := <-s.stateResponseCh:
+			req, ok := t.inFlight[msg.GetGossipMessage().Nonce]
+			if !ok {
+				// A late response to a request which timed out, or to an earlier transfer
+				continue
+			}
+			delete(t.inFlight, msg.GetGossipMessage().Nonce)
+
+			received, err := s.handleStateResponse(msg, req.start, req.end)
+			if err != nil {
+				logger.Warningf("Wasn't able to process state response from %s for "+
+					"blocks [%d...%d], due to %s", req.peer.Endpoint, req.start, req.end, err)
+				s.penalize(req.peer)
+				req.attempts++
+				t.retry(req.blockRange)
+				continue
+			}
+			s.reward(req.peer)
+			if received < req.end {
+				// The peer sent only part of the range, request the rest
+				t.retry(&blockRange{start: received + 1, end: req.end})
+			}
+		case <-time.After(wait):
+			now := time.Now()
+			for nonce, req := range t.inFlight {
+				if req.deadline.After(now) {
+					continue
+				}
+				logger.Debugf("State request to %s for blocks [%d...%d] timed out", req.peer.Endpoint, req.start, req.end)
+				delete(t.inFlight, nonce)
+				s.penalize(req.peer)
+				req.attempts++
+				t.retry(req.blockRange)
+			}
+		case <-s.stopCh:
+			s.stopCh <- struct{}{}
+			return
+		}
+	}
+}
+
+// handleStateResponse verifies the blocks of a response to a request for the
+// blocks in range [start...end], and pushes them into the payloads buffer.
+// It returns the highest sequence number up to which the blocks of the range
+// were received.
+func (s *GossipStateProviderImpl) handleStateResponse(msg proto.ReceivedMessage, start uint64, end uint64) (uint64, error) {
+	response := msg.GetGossipMessage().GetStateResponse()
+	// Extract payloads, verify and push into buffer
+	if len(response.GetPayloads()) == 0 {
+		return uint64(0), errors.New("Received state tranfer response without payload")
+	}
+
+	received := make(map[uint64]struct{})
+	for _, payload := range response.GetPayloads() {
+		logger.Debugf("Received payload with sequence number %d.", payload.SeqNum)
+		if payload.SeqNum < start || payload.SeqNum > end {
+			return uint64(0), fmt.Errorf("block with sequence number %d is out of the range requested", payload.SeqNum)
+		}
+		if err := s.mcs.VerifyBlock(common2.ChainID(s.chainID), payload.SeqNum, payload.Data); err != nil {
+			logger.Warningf("Error verifying block with sequence number %d, due to %s", payload.SeqNum, err)
+			return uint64(0), err
+		}
+		received[payload.SeqNum] = struct{}{}
+	}
+
+	if _, ok := received[start]; !ok {
+		return uint64(0), fmt.Errorf("missing block with sequence number %d", start)
+	}
+
+	for _, payload := range response.GetPayloads() {
+		if err := s.payloads.Push(payload); err != nil {
+			logger.Debugf("Payload with sequence number %d was received earlier", payload.SeqNum)
+		}
+	}
+
+	max := start
+	for max < end {
+		if _, ok := received[max+1]; !ok {
+			break
+		}
+		max++
+	}
+	return max, nil
+}
+
+// selectPeerToRequestFrom selects a peer which has the blocks up to height to
+// ask missing blocks from, preferring the peers which failed the least to
+// serve previous requests, and then the peers with the fewest requests in flight
+func (s *GossipStateProviderImpl) selectPeerToRequestFrom(height uint64, inFlight map[uint64]*inFlightRequest) (*comm.RemotePeer, error) {
+	// Filter peers which posses required range of missing blocks
+	peers := s.filterPeers(s.hasRequiredHeight(height))
+	if len(peers) == 0 {
+		return nil, errors.New("there are no peers to ask for missing blocks from")
+	}
+
+	load := make(map[string]int)
+	for _, req := range inFlight {
+		load[string(req.peer.PKIID)]++
+	}
+
+	var best []*comm.RemotePeer
+	bestPenalty, bestLoad := 0, 0
+	for _, peer := range peers {
+		penalty, peerLoad := s.penaltyOf(peer), load[string(peer.PKIID)]
+		switch {
+		case len(best) == 0 || penalty < bestPenalty || (penalty == bestPenalty && peerLoad < bestLoad):
+			best = []*comm.RemotePeer{peer}
+			bestPenalty, bestLoad = penalty, peerLoad
+		case penalty == bestPenalty && peerLoad == bestLoad:
+			best = append(best, peer)
+		}
+	}
+
+	// Select peers to ask for blocks
+	return best[util.RandomInt(len(best))], nil
+}
+
+// filterPeers return list of peers which aligns the predicate provided
+func (s *GossipStateProviderImpl) filterPeers(predicate func(peer discovery.NetworkMember) bool) []*comm.RemotePeer {
+	var peers []*comm.RemotePeer
+
+	for _, member := range s.gossip.PeersOfChannel(common2.ChainID(s.chainID)) {
+		if predicate(member) {
+			peers = append(peers, &comm.RemotePeer{Endpoint: member.PreferredEndpoint(), PKIID: member.PKIid})
+		}
+	}
+
+	return peers
+}
+
+// hasRequiredHeight returns predicate which is capable to filter peers with ledger height above than indicated
+// by provided input parameter
+func (s *GossipStateProviderImpl) hasRequiredHeight(height uint64) func(peer discovery.NetworkMember) bool {
+	return func(peer discovery.NetworkMember) bool {
+		if nodeMetadata, err := FromBytes(peer.Metadata); err != nil {
+			logger.Errorf("Unable to de-serialize node meta state, error = %s", err)
+		} else if nodeMetadata.LedgerHeight >= height {
+			return true
+		}
+
+		return false
+	}
+}
+
+// penalize deprioritizes a peer which did not answer a state request in time
+// or sent invalid blocks
+func (s *GossipStateProviderImpl) penalize(peer *comm.RemotePeer) {
+	s.penaltiesLock.Lock()
+	defer s.penaltiesLock.Unlock()
+	s.penalties[string(peer.PKIID)]++
+}
+
+// reward lowers the penalty of a peer which answered a state request
+func (s *GossipStateProviderImpl) reward(peer *comm.RemotePeer) {
+	s.penaltiesLock.Lock()
+	defer s.penaltiesLock.Unlock()
+	if s.penalties[string(peer.PKIID)] > 1 {
+		s.penalties[string(peer.PKIID)]--
+		return
+	}
+	delete(s.penalties, string(peer.PKIID))
+}
+
+func (s *GossipStateProviderImpl) penaltyOf(peer *comm.RemotePeer) int {
+	s.penaltiesLock.Lock()
+	defer s.penaltiesLock.Unlock()
+	return s.penalties[string(peer.PKIID)]
+}
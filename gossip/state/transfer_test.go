@@ -0,0 +1,316 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+type peerBehavior int
+
+const (
+	// Serves the blocks requested
+	servesBlocks peerBehavior = iota
+	// Never answers
+	silent
+	// Serves blocks which fail verification
+	servesInvalidBlocks
+	// Serves at most two blocks per request
+	servesPartially
+)
+
+var invalidBlock = []byte("invalid")
+
+// blockVerifier fails the verification of the invalid blocks
+type blockVerifier struct {
+	cryptoServiceMock
+}
+
+func (*blockVerifier) VerifyBlock(chainID common.ChainID, seqNum uint64, signedBlock []byte) error {
+	if bytes.Equal(signedBlock, invalidBlock) {
+		return errors.New("invalid block")
+	}
+	return nil
+}
+
+type remotePeer struct {
+	height   uint64
+	behavior peerBehavior
+	requests int32
+}
+
+// transferGossip simulates the remote peers answering state requests
+type transferGossip struct {
+	sync.Mutex
+	peers       map[string]*remotePeer
+	responses   chan proto.ReceivedMessage
+	inFlight    int32
+	maxInFlight int32
+}
+
+func newTransferGossip(peers map[string]*remotePeer) *transferGossip {
+	return &transferGossip{
+		peers:     peers,
+		responses: make(chan proto.ReceivedMessage, defChannelBufferSize),
+	}
+}
+
+func (g *transferGossip) Send(msg *proto.GossipMessage, peers ...*comm.RemotePeer) {
+	request := msg.GetStateRequest()
+	peer := g.peers[peers[0].Endpoint]
+	atomic.AddInt32(&peer.requests, 1)
+	if peer.behavior == silent {
+		return
+	}
+
+	inFlight := atomic.AddInt32(&g.inFlight, 1)
+	g.Lock()
+	if inFlight > g.maxInFlight {
+		g.maxInFlight = inFlight
+	}
+	g.Unlock()
+
+	response := &proto.RemoteStateResponse{}
+	for seqNum := request.StartSeqNum; seqNum <= request.EndSeqNum; seqNum++ {
+		if peer.behavior == servesPartially && seqNum >= request.StartSeqNum+2 {
+			break
+		}
+		data := []byte{byte(seqNum)}
+		if peer.behavior == servesInvalidBlocks {
+			data = invalidBlock
+		}
+		response.Payloads = append(response.Payloads, &proto.Payload{SeqNum: seqNum, Data: data})
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		sMsg, _ := (&proto.GossipMessage{
+			Nonce:   msg.Nonce,
+			Content: &proto.GossipMessage_StateResponse{StateResponse: response},
+		}).NoopSign()
+		atomic.AddInt32(&g.inFlight, -1)
+		g.responses <- &comm.ReceivedMessageImpl{SignedGossipMessage: sMsg}
+	}()
+}
+
+func (g *transferGossip) Accept(acceptor common.MessageAcceptor, passThrough bool) (<-chan *proto.GossipMessage, <-chan proto.ReceivedMessage) {
+	return nil, nil
+}
+
+func (g *transferGossip) UpdateChannelMetadata(metadata []byte, chainID common.ChainID) {
+}
+
+func (g *transferGossip) PeersOfChannel(common.ChainID) []discovery.NetworkMember {
+	var members []discovery.NetworkMember
+	for endpoint, peer := range g.peers {
+		metadata, _ := NewNodeMetastate(peer.height).Bytes()
+		members = append(members, discovery.NetworkMember{
+			Endpoint: endpoint,
+			PKIid:    common.PKIidType(endpoint),
+			Metadata: metadata,
+		})
+	}
+	return members
+}
+
+func newTransferStateProvider(g *transferGossip, mcs api.MessageCryptoService) *GossipStateProviderImpl {
+	s := &GossipStateProviderImpl{
+		mcs:             mcs,
+		chainID:         "testchainid",
+		gossip:          g,
+		payloads:        NewPayloadsBuffer(1),
+		stateResponseCh: make(chan proto.ReceivedMessage, defChannelBufferSize),
+		stopCh:          make(chan struct{}, 1),
+		config: &stateConfig{
+			responseTimeout: 100 * time.Millisecond,
+			batchSize:       5,
+			parallelism:     3,
+			maxRetries:      3,
+		},
+		penalties: make(map[string]int),
+	}
+	// Forward the responses as directMessage would
+	go func() {
+		for msg := range g.responses {
+			s.stateResponseCh <- msg
+		}
+	}()
+	return s
+}
+
+// commitPayloads pops the payloads from the buffer in order, as deliverPayloads does
+func commitPayloads(s *GossipStateProviderImpl) (committed func() []uint64) {
+	var lock sync.Mutex
+	var seqNums []uint64
+	go func() {
+		for range s.payloads.Ready() {
+			for payload := s.payloads.Pop(); payload != nil; payload = s.payloads.Pop() {
+				lock.Lock()
+				seqNums = append(seqNums, payload.SeqNum)
+				lock.Unlock()
+			}
+		}
+	}()
+	return func() []uint64 {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]uint64(nil), seqNums...)
+	}
+}
+
+// waitForCommit waits for the blocks up to the one with sequence number end to be committed
+func waitForCommit(committed func() []uint64, end uint64) []uint64 {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if seqNums := committed(); len(seqNums) > 0 && seqNums[len(seqNums)-1] >= end {
+			return seqNums
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return committed()
+}
+
+func sequence(start, end uint64) []uint64 {
+	var seqNums []uint64
+	for seqNum := start; seqNum <= end; seqNum++ {
+		seqNums = append(seqNums, seqNum)
+	}
+	return seqNums
+}
+
+func TestStateTransferParallel(t *testing.T) {
+	g := newTransferGossip(map[string]*remotePeer{
+		"p1": {height: 40},
+		"p2": {height: 40},
+		"p3": {height: 40},
+		"p4": {height: 20},
+	})
+	s := newTransferStateProvider(g, &cryptoServiceMock{})
+	committed := commitPayloads(s)
+
+	s.requestBlocksInRange(1, 40)
+
+	assert.Equal(t, sequence(1, 40), waitForCommit(committed, 40))
+	g.Lock()
+	assert.True(t, g.maxInFlight <= 3, "No more than parallelism requests should have been in flight, got %d", g.maxInFlight)
+	g.Unlock()
+	for endpoint, peer := range g.peers {
+		assert.True(t, atomic.LoadInt32(&peer.requests) > 0, "Requests should have been spread across peers, %s got none", endpoint)
+	}
+}
+
+func TestStateTransferDeprioritizesFaultyPeers(t *testing.T) {
+	g := newTransferGossip(map[string]*remotePeer{
+		"good":    {height: 40},
+		"silent":  {height: 40, behavior: silent},
+		"invalid": {height: 40, behavior: servesInvalidBlocks},
+	})
+	s := newTransferStateProvider(g, &blockVerifier{})
+	committed := commitPayloads(s)
+
+	s.requestBlocksInRange(1, 40)
+
+	assert.Equal(t, sequence(1, 40), waitForCommit(committed, 40))
+	// The faulty peers may be asked again before their first failure is
+	// noticed, but not once they are penalized
+	assert.True(t, atomic.LoadInt32(&g.peers["silent"].requests) <= 2, "The unresponsive peer should have been deprioritized")
+	assert.True(t, atomic.LoadInt32(&g.peers["invalid"].requests) <= 2, "The peer sending invalid blocks should have been deprioritized")
+	assert.True(t, s.penaltyOf(&comm.RemotePeer{PKIID: common.PKIidType("silent")}) > 0)
+	assert.True(t, s.penaltyOf(&comm.RemotePeer{PKIID: common.PKIidType("invalid")}) > 0)
+	assert.Equal(t, 0, s.penaltyOf(&comm.RemotePeer{PKIID: common.PKIidType("good")}))
+}
+
+func TestStateTransferPartialResponses(t *testing.T) {
+	g := newTransferGossip(map[string]*remotePeer{
+		"p1": {height: 12, behavior: servesPartially},
+	})
+	s := newTransferStateProvider(g, &cryptoServiceMock{})
+	committed := commitPayloads(s)
+
+	s.requestBlocksInRange(1, 12)
+
+	assert.Equal(t, sequence(1, 12), waitForCommit(committed, 12))
+	assert.Equal(t, 0, s.penaltyOf(&comm.RemotePeer{PKIID: common.PKIidType("p1")}))
+}
+
+func TestStateTransferGivesUp(t *testing.T) {
+	g := newTransferGossip(map[string]*remotePeer{
+		"silent": {height: 40, behavior: silent},
+	})
+	s := newTransferStateProvider(g, &cryptoServiceMock{})
+	s.config.parallelism = 1
+
+	s.requestBlocksInRange(1, 40)
+
+	assert.Equal(t, int32(s.config.maxRetries+1), atomic.LoadInt32(&g.peers["silent"].requests))
+	assert.Equal(t, 0, s.payloads.Size())
+}
+
+func TestStateTransferWindow(t *testing.T) {
+	g := newTransferGossip(map[string]*remotePeer{
+		"p1": {height: 100},
+		"p2": {height: 100},
+	})
+	s := newTransferStateProvider(g, &cryptoServiceMock{})
+
+	done := make(chan struct{})
+	go func() {
+		s.requestBlocksInRange(1, 100)
+		close(done)
+	}()
+
+	// Nothing commits the blocks received, hence no more than a window of
+	// blocks should be requested
+	window := int(s.config.batchSize) * s.config.parallelism
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, window, s.payloads.Size())
+
+	s.stopCh <- struct{}{}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("State transfer should have stopped")
+	}
+}
+
+func TestTransferRanges(t *testing.T) {
+	tr := newTransfer(1, 12, 5)
+
+	r := tr.peek(1)
+	assert.Equal(t, &blockRange{start: 1, end: 5}, r)
+	tr.take(r)
+
+	r = tr.peek(1)
+	assert.Equal(t, &blockRange{start: 6, end: 10}, r)
+	tr.take(r)
+
+	// The first range is requested again before the rest
+	tr.retry(&blockRange{start: 1, end: 5, attempts: 1})
+	r = tr.peek(1)
+	assert.Equal(t, &blockRange{start: 1, end: 5, attempts: 1}, r)
+	tr.take(r)
+
+	// Blocks received meanwhile are skipped
+	r = tr.peek(11)
+	assert.Equal(t, &blockRange{start: 11, end: 12}, r)
+	tr.take(r)
+
+	assert.Nil(t, tr.peek(1))
+	assert.True(t, tr.done())
+}
@@ -24,6 +24,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp"
@@ -35,6 +36,10 @@ import (
 var mspIdentityLogger = flogging.MustGetLogger("msp/identity")
 
 type identity struct {
+	// sequence of the MSP config under which this instance was last found
+	// valid, accessed atomically; 0 if it never was
+	validSequence uint64
+
 	// id contains the identifier (MSPID and identity identifier) for this instance
 	id *IdentityIdentifier
 
@@ -76,7 +81,21 @@ func (id *identity) GetMSPIdentifier() string {
 
 // IsValid returns nil if this instance is a valid identity or an error otherwise
 func (id *identity) Validate() error {
-	return id.msp.Validate(id)
+	seq := id.msp.configSequence()
+	if seq != 0 && atomic.LoadUint64(&id.validSequence) == seq && !id.expired() {
+		return nil
+	}
+
+	if err := id.msp.Validate(id); err != nil {
+		return err
+	}
+	atomic.StoreUint64(&id.validSequence, seq)
+	return nil
+}
+
+// expired returns whether the certificate of this instance is no longer valid
+func (id *identity) expired() bool {
+	return !id.msp.now().Before(id.cert.NotAfter)
 }
 
 // GetOrganizationalUnits returns the OU for this instance
@@ -123,6 +123,14 @@ func XXXSetMSPManager(chainID string, manager msp.MSPManager) {
 	mspMap[chainID] = manager
 }
 
+// XXXRemoveMSPManager removes the MSP manager of a chain the peer left
+func XXXRemoveMSPManager(chainID string) {
+	m.Lock()
+	defer m.Unlock()
+
+	delete(mspMap, chainID)
+}
+
 // GetLocalMSP returns the local msp (and creates it if it doesn't exist)
 func GetLocalMSP() msp.MSP {
 	var lclMsp msp.MSP
@@ -56,6 +56,15 @@ func TestGetManagerForChains_usingMSPConfigHandlers(t *testing.T) {
 	}
 }
 
+func TestXXXRemoveMSPManager(t *testing.T) {
+	mgr := &configvaluesmsp.MSPConfigHandler{MSPManager: msp.NewMSPManager()}
+	XXXSetMSPManager("bar", mgr)
+	assert.Contains(t, GetDeserializers(), "bar")
+
+	XXXRemoveMSPManager("bar")
+	assert.NotContains(t, GetDeserializers(), "bar")
+}
+
 func TestGetIdentityDeserializer(t *testing.T) {
 	XXXSetMSPManager("baz", &configvaluesmsp.MSPConfigHandler{MSPManager: msp.NewMSPManager()})
 	ids := GetIdentityDeserializer("baz")
@@ -347,6 +347,79 @@ func TestSerializeIdentitiesWithMSPManager(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestMSPManagerIdentityCache(t *testing.T) {
+	id, err := localMsp.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	serializedID, err := id.Serialize()
+	assert.NoError(t, err)
+
+	mgr := NewMSPManager()
+	err = mgr.Setup([]MSP{localMsp})
+	assert.NoError(t, err)
+
+	id1, err := mgr.DeserializeIdentity(serializedID)
+	assert.NoError(t, err)
+	id2, err := mgr.DeserializeIdentity(serializedID)
+	assert.NoError(t, err)
+	assert.True(t, id1 == id2, "The second deserialization should have been served from the cache")
+	assert.Equal(t, 1, mgr.(*mspManagerImpl).identityCache.Len())
+
+	// A new manager, as created upon a config update, starts afresh
+	mgr = NewMSPManager()
+	err = mgr.Setup([]MSP{localMsp})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, mgr.(*mspManagerImpl).identityCache.Len())
+}
+
+func TestMSPManagerIdentityCacheInvalidation(t *testing.T) {
+	mspDir, err := config.GetDevMspDir()
+	assert.NoError(t, err)
+	thisMSP := getLocalMSP(t, mspDir)
+
+	id, err := thisMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	serializedID, err := id.Serialize()
+	assert.NoError(t, err)
+
+	mgr := NewMSPManager()
+	err = mgr.Setup([]MSP{thisMSP})
+	assert.NoError(t, err)
+
+	// The validation done upon caching is reused by the callers
+	id1, err := mgr.DeserializeIdentity(serializedID)
+	assert.NoError(t, err)
+	assert.Equal(t, thisMSP.(*bccspmsp).configSequence(), id1.(*identity).validSequence)
+	assert.NoError(t, id1.Validate())
+
+	// Setting the MSP up again leaves the cached identity behind
+	conf, err := GetLocalMspConfig(mspDir, nil, "DEFAULT")
+	assert.NoError(t, err)
+	err = thisMSP.Setup(conf)
+	assert.NoError(t, err)
+	id2, err := mgr.DeserializeIdentity(serializedID)
+	assert.NoError(t, err)
+	assert.False(t, id1 == id2, "The identity cached under the previous config should not have been returned")
+	assert.Equal(t, thisMSP.(*bccspmsp).configSequence(), id2.(*identity).validSequence)
+
+	// An expired identity is not served from the cache: it is deserialized,
+	// and so validated, again, and is not cached anew
+	opts := thisMSP.(*bccspmsp).opts
+	currentTime := opts.CurrentTime
+	defer func() { opts.CurrentTime = currentTime }()
+	opts.CurrentTime = id2.(*identity).cert.NotAfter
+	id2.(*identity).validSequence = 0
+	id3, err := mgr.DeserializeIdentity(serializedID)
+	assert.NoError(t, err)
+	assert.Equal(t, thisMSP.(*bccspmsp).configSequence(), id3.(*identity).validSequence)
+
+	mgr = NewMSPManager()
+	err = mgr.Setup([]MSP{thisMSP})
+	assert.NoError(t, err)
+	_, err = mgr.DeserializeIdentity(serializedID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, mgr.(*mspManagerImpl).identityCache.Len())
+}
+
 func TestIdentitiesGetters(t *testing.T) {
 	id, err := localMsp.GetDefaultSigningIdentity()
 	if err != nil {
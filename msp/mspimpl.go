@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -37,6 +38,11 @@ const (
 // This is an instantiation of an MSP that
 // uses BCCSP for its cryptographic primitives.
 type bccspmsp struct {
+	// number of times this MSP has been set up, accessed atomically;
+	// identities validated under an earlier sequence must be validated
+	// again, as the config they were validated against is gone
+	sequence uint64
+
 	// cache for DeserializeIdentity.
 	deserializeIdentityCache *arc.ARC
 
@@ -104,13 +110,18 @@ func NewBccspMsp() (MSP, error) {
 	bccsp := factory.GetDefault()
 	theMsp := &bccspmsp{}
 	theMsp.bccsp = bccsp
-	theMsp.deserializeIdentityCache = arc.New(deserializeIdentityCacheSize)
-	theMsp.satisfiesPrincipalCache = arc.New(satisfiesPrincipalCacheSize)
-	theMsp.validateIdentityCache = arc.New(validateIdentityCacheSize)
+	theMsp.resetCaches()
 
 	return theMsp, nil
 }
 
+// resetCaches discards everything cached by this MSP
+func (msp *bccspmsp) resetCaches() {
+	msp.deserializeIdentityCache = arc.New(deserializeIdentityCacheSize)
+	msp.satisfiesPrincipalCache = arc.New(satisfiesPrincipalCacheSize)
+	msp.validateIdentityCache = arc.New(validateIdentityCacheSize)
+}
+
 func (msp *bccspmsp) getCertFromPem(idBytes []byte) (*x509.Certificate, error) {
 	if idBytes == nil {
 		return nil, fmt.Errorf("getIdentityFromConf error: nil idBytes")
@@ -315,6 +326,13 @@ func (msp *bccspmsp) Setup(conf1 *m.MSPConfig) error {
 	msp.name = conf.Name
 	mspLogger.Debugf("Setting up MSP instance %s", msp.name)
 
+	// anything cached under a previous config, such as the validity of an
+	// identity which has since been revoked, must be discarded; the
+	// sequence only moves once setup is over, so that identities validated
+	// while it was in progress are validated again
+	msp.resetCaches()
+	defer atomic.AddUint64(&msp.sequence, 1)
+
 	// setup crypto config
 	if err := msp.setupCrypto(conf); err != nil {
 		return err
@@ -368,6 +386,19 @@ func (msp *bccspmsp) Setup(conf1 *m.MSPConfig) error {
 	return nil
 }
 
+// configSequence returns the number of times this MSP has been set up
+func (msp *bccspmsp) configSequence() uint64 {
+	return atomic.LoadUint64(&msp.sequence)
+}
+
+// now returns the time against which certificates are checked
+func (msp *bccspmsp) now() time.Time {
+	if msp.opts != nil && !msp.opts.CurrentTime.IsZero() {
+		return msp.opts.CurrentTime
+	}
+	return time.Now()
+}
+
 // GetType returns the type for this MSP
 func (msp *bccspmsp) GetType() ProviderType {
 	return FABRIC
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/msp/arc"
 	"github.com/hyperledger/fabric/protos/msp"
 
 	"github.com/golang/protobuf/proto"
@@ -27,14 +28,28 @@ import (
 
 var mspLogger = flogging.MustGetLogger("msp")
 
+const identityCacheSize = 1000
+
 type mspManagerImpl struct {
 	// map that contains all MSPs that we have setup or otherwise added
 	mspsMap map[string]MSP
 
+	// cache of deserialized identities which were valid when they were
+	// added, keyed by identityCacheKey so that setting up their MSP again
+	// (for instance with new CRLs) leaves the entries behind
+	identityCache *arc.ARC
+
 	// error that might have occurred at startup
 	up bool
 }
 
+// identityCacheKey identifies an identity in the cache of a manager by its
+// serialized form and the config sequence of its MSP when it was validated
+type identityCacheKey struct {
+	serializedID   string
+	configSequence uint64
+}
+
 // NewMSPManager returns a new MSP manager instance;
 // note that this instance is not initialized until
 // the Setup method is called
@@ -61,6 +76,7 @@ func (mgr *mspManagerImpl) Setup(msps []MSP) error {
 
 	// create the map that assigns MSP IDs to their manager instance - once
 	mgr.mspsMap = make(map[string]MSP)
+	mgr.identityCache = arc.New(identityCacheSize)
 
 	for _, msp := range msps {
 		// add the MSP to the map of active MSPs
@@ -98,10 +114,37 @@ func (mgr *mspManagerImpl) DeserializeIdentity(serializedID []byte) (Identity, e
 		return nil, fmt.Errorf("MSP %s is unknown", sId.Mspid)
 	}
 
+	key := identityCacheKey{serializedID: string(serializedID)}
+	if t, ok := msp.(*bccspmsp); ok {
+		key.configSequence = t.configSequence()
+	}
+	if mgr.identityCache != nil {
+		if id, ok := mgr.identityCache.Get(key); ok {
+			if t, ok := id.(*identity); !ok || !t.expired() {
+				return id.(Identity), nil
+			}
+		}
+	}
+
+	var id Identity
 	switch t := msp.(type) {
 	case *bccspmsp:
-		return t.deserializeIdentityInternal(sId.IdBytes)
+		id, err = t.deserializeIdentityInternal(sId.IdBytes)
 	default:
-		return t.DeserializeIdentity(serializedID)
+		id, err = t.DeserializeIdentity(serializedID)
+	}
+	if err != nil {
+		return nil, err
 	}
+
+	// Invalid identities are not cached, so that they are rejected
+	// again by the callers which validate them; valid ones remember
+	// it, so that those callers do not validate them a second time
+	if mgr.identityCache != nil && id.Validate() == nil {
+		if t, ok := id.(*identity); !ok || !t.expired() {
+			mgr.identityCache.Put(key, id)
+		}
+	}
+
+	return id, nil
 }
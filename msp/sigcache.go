@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package msp
+
+import (
+	"crypto/sha256"
+
+	"github.com/hyperledger/fabric/msp/arc"
+)
+
+// SignatureCache remembers the (identity, message digest, signature) triples
+// which have been successfully verified, so that the same signature seen again,
+// e.g. by VSCC and by gossip for the same block, is not verified twice.
+// Failed verifications are never cached. A nil *SignatureCache is valid and
+// simply verifies every signature.
+type SignatureCache struct {
+	verified *arc.ARC
+}
+
+// NewSignatureCache returns a SignatureCache holding at most size entries
+func NewSignatureCache(size int) *SignatureCache {
+	return &SignatureCache{verified: arc.New(size)}
+}
+
+// Verify checks that signature is a valid signature of msg by identity, whose
+// serialized form is serializedIdentity, consulting the cache first
+func (sc *SignatureCache) Verify(identity Identity, serializedIdentity, msg, signature []byte) error {
+	if sc == nil {
+		return identity.Verify(msg, signature)
+	}
+
+	key := signatureCacheKey(serializedIdentity, msg, signature)
+	if _, ok := sc.verified.Get(key); ok {
+		return nil
+	}
+
+	if err := identity.Verify(msg, signature); err != nil {
+		return err
+	}
+
+	sc.verified.Put(key, true)
+	return nil
+}
+
+// Len returns the number of verified signatures currently cached
+func (sc *SignatureCache) Len() int {
+	if sc == nil {
+		return 0
+	}
+	return sc.verified.Len()
+}
+
+func signatureCacheKey(serializedIdentity, msg, signature []byte) string {
+	idDigest := sha256.Sum256(serializedIdentity)
+	msgDigest := sha256.Sum256(msg)
+	sigDigest := sha256.Sum256(signature)
+	return string(idDigest[:]) + string(msgDigest[:]) + string(sigDigest[:])
+}
@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package msp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingIdentity struct {
+	Identity
+	verified int
+}
+
+func (ci *countingIdentity) Verify(msg []byte, sig []byte) error {
+	ci.verified++
+	return ci.Identity.Verify(msg, sig)
+}
+
+func TestSignatureCache(t *testing.T) {
+	id, err := localMsp.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	serializedID, err := id.Serialize()
+	assert.NoError(t, err)
+
+	msg := []byte("foo")
+	sig, err := id.Sign(msg)
+	assert.NoError(t, err)
+
+	ci := &countingIdentity{Identity: id}
+	sc := NewSignatureCache(10)
+
+	assert.NoError(t, sc.Verify(ci, serializedID, msg, sig))
+	assert.NoError(t, sc.Verify(ci, serializedID, msg, sig))
+	assert.Equal(t, 1, ci.verified, "A verified signature should have been served from the cache")
+	assert.Equal(t, 1, sc.Len())
+
+	// Failed verifications are not cached
+	assert.Error(t, sc.Verify(ci, serializedID, []byte("bar"), sig))
+	assert.Error(t, sc.Verify(ci, serializedID, []byte("bar"), sig))
+	assert.Equal(t, 3, ci.verified)
+	assert.Equal(t, 1, sc.Len())
+
+	// The signer is part of the key
+	assert.NoError(t, sc.Verify(ci, []byte("other identity"), msg, sig))
+	assert.Equal(t, 4, ci.verified)
+}
+
+func TestNilSignatureCache(t *testing.T) {
+	id, err := localMsp.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+
+	msg := []byte("foo")
+	sig, err := id.Sign(msg)
+	assert.NoError(t, err)
+
+	var sc *SignatureCache
+	ci := &countingIdentity{Identity: id}
+	assert.NoError(t, sc.Verify(ci, nil, msg, sig))
+	assert.NoError(t, sc.Verify(ci, nil, msg, sig))
+	assert.Equal(t, 2, ci.verified)
+	assert.Equal(t, 0, sc.Len())
+}
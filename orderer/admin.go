@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/orderer/common/admin"
+	config "github.com/hyperledger/fabric/orderer/localconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+
+	"github.com/golang/protobuf/jsonpb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// printChannelStatus queries the Admin service of the orderer at address, or
+// at the listen address of conf when empty, for the status of the given
+// channel, or of all the channels when chainID is empty, and prints it to out
+// as JSON. The request is signed by the local MSP, which must be initialized.
+func printChannelStatus(out io.Writer, conf *config.TopLevel, address, caFile string, timeout time.Duration, chainID string) error {
+	if address == "" {
+		address = fmt.Sprintf("%s:%d", conf.General.ListenAddress, conf.General.ListenPort)
+	}
+
+	opts := []grpc.DialOption{grpc.WithBlock(), grpc.WithTimeout(timeout)}
+	if conf.General.TLS.Enabled {
+		tlsConfig, err := adminClientTLSConfig(conf, caFile)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	conn, err := grpc.Dial(address, opts...)
+	if err != nil {
+		return fmt.Errorf("error connecting to %s: %s", address, err)
+	}
+	defer conn.Close()
+
+	request, err := admin.NewChannelStatusRequest(chainID, localmsp.NewSigner())
+	if err != nil {
+		return fmt.Errorf("error signing request: %s", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	response, err := ab.NewAdminClient(conn).ChannelStatus(ctx, request)
+	if err != nil {
+		return fmt.Errorf("error querying %s: %s", address, err)
+	}
+	if response.Status != cb.Status_SUCCESS {
+		return fmt.Errorf("request rejected with status %s: %s", response.Status, response.Info)
+	}
+
+	marshaler := &jsonpb.Marshaler{Indent: "  ", EmitDefaults: true}
+	for _, status := range response.Channels {
+		if err := marshaler.Marshal(out, status); err != nil {
+			return err
+		}
+		fmt.Fprintln(out)
+	}
+	return nil
+}
+
+// adminClientTLSConfig verifies the orderer against caFile, or against the
+// root CAs of conf when empty, and presents the certificate of the orderer
+// itself when client authentication is required
+func adminClientTLSConfig(conf *config.TopLevel, caFile string) (*tls.Config, error) {
+	caFiles := conf.General.TLS.RootCAs
+	if caFile != "" {
+		caFiles = []string{caFile}
+	}
+	roots := x509.NewCertPool()
+	for _, file := range caFiles {
+		root, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA certificate %s: %s", file, err)
+		}
+		if !roots.AppendCertsFromPEM(root) {
+			return nil, fmt.Errorf("no PEM encoded certificate found in %s", file)
+		}
+	}
+	tlsConfig := &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+
+	if conf.General.TLS.ClientAuthEnabled {
+		cert, err := tls.LoadX509KeyPair(conf.General.TLS.Certificate, conf.General.TLS.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("error loading the TLS certificate of the orderer: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
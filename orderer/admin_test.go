@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"bytes"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
+	"github.com/hyperledger/fabric/common/localmsp"
+	coreconfig "github.com/hyperledger/fabric/core/config"
+	config "github.com/hyperledger/fabric/orderer/localconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminChannelStatus(t *testing.T) {
+	// get a free random port
+	listenAddr := func() string {
+		l, _ := net.Listen("tcp", "localhost:0")
+		l.Close()
+		return l.Addr().String()
+	}()
+	host := strings.Split(listenAddr, ":")[0]
+	port, _ := strconv.ParseUint(strings.Split(listenAddr, ":")[1], 10, 16)
+
+	localMSPDir, _ := coreconfig.GetDevMspDir()
+	conf := &config.TopLevel{
+		General: config.General{
+			LedgerType:     "ram",
+			ListenAddress:  host,
+			ListenPort:     uint16(port),
+			GenesisMethod:  "provisional",
+			GenesisProfile: "SampleSingleMSPSolo",
+			LocalMSPDir:    localMSPDir,
+			LocalMSPID:     "DEFAULT",
+			BCCSP: &factory.FactoryOpts{
+				ProviderName: "SW",
+				SwOpts: &factory.SwOpts{
+					HashFamily: "SHA2",
+					SecLevel:   256,
+					Ephemeral:  true,
+				},
+			},
+		},
+		Admin: config.Admin{Enabled: true, TimeWindow: time.Minute},
+	}
+	initializeLocalMsp(conf)
+	manager := initializeMultiChainManager(conf, localmsp.NewSigner())
+	grpcServer := initializeGrpcServer(conf)
+	initializeAdminService(conf, grpcServer, manager)
+	go grpcServer.Start()
+	defer grpcServer.Stop()
+
+	t.Run("AllChannels", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		assert.NoError(t, printChannelStatus(out, conf, "", "", 5*time.Second, ""))
+		assert.Contains(t, out.String(), `"channelId": "`+provisional.TestChainID+`"`)
+		assert.Contains(t, out.String(), `"consensusType": "solo"`)
+		assert.Contains(t, out.String(), `"height": "1"`)
+	})
+
+	t.Run("UnknownChannel", func(t *testing.T) {
+		err := printChannelStatus(&bytes.Buffer{}, conf, "", "", 5*time.Second, "foo")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "NOT_FOUND")
+	})
+}
@@ -0,0 +1,164 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package admin
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/ratelimit"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mspproto "github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/op/go-logging"
+	"golang.org/x/net/context"
+)
+
+var logger = logging.MustGetLogger("orderer/common/admin")
+
+// Support provides the state of a chain
+type Support interface {
+	// Status returns the current state of the chain
+	Status() *ab.ChannelStatus
+}
+
+// SupportManager provides a way for the server to look up the chains served by the orderer
+type SupportManager interface {
+	// GetChain retrieves the Support for a chain (and whether it is served)
+	GetChain(chainID string) (Support, bool)
+
+	// ChainIDs returns the IDs of the chains which have a ledger on this orderer
+	ChainIDs() []string
+}
+
+type server struct {
+	sm         SupportManager
+	localMSP   msp.MSP
+	timeWindow time.Duration
+	now        func() time.Time
+}
+
+// NewServer creates an Admin server answering the requests signed by an admin
+// of the local MSP, whose timestamp is within timeWindow of the orderer's clock.
+func NewServer(sm SupportManager, localMSP msp.MSP, timeWindow time.Duration) ab.AdminServer {
+	return &server{
+		sm:         sm,
+		localMSP:   localMSP,
+		timeWindow: timeWindow,
+		now:        time.Now,
+	}
+}
+
+// NewChannelStatusRequest creates a ChannelStatus request for the given chain,
+// or for all the chains when chainID is empty, signed by signer
+func NewChannelStatusRequest(chainID string, signer crypto.LocalSigner) (*cb.Envelope, error) {
+	signatureHeader, err := signer.NewSignatureHeader()
+	if err != nil {
+		return nil, err
+	}
+	payloadBytes := utils.MarshalOrPanic(&cb.Payload{
+		Header: utils.MakePayloadHeader(utils.MakeChannelHeader(cb.HeaderType_MESSAGE, 0, chainID, 0), signatureHeader),
+	})
+	signature, err := signer.Sign(payloadBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &cb.Envelope{Payload: payloadBytes, Signature: signature}, nil
+}
+
+// ChannelStatus reports the channel of the channel header, or all the
+// channels served by the orderer when the channel ID is empty
+func (s *server) ChannelStatus(ctx context.Context, env *cb.Envelope) (*ab.AdminResponse, error) {
+	chdr, status, err := s.authenticate(env)
+	if err != nil {
+		logger.Warningf("Rejecting admin request: %s", err)
+		return &ab.AdminResponse{Status: status, Info: err.Error()}, nil
+	}
+
+	if chdr.ChannelId != "" {
+		chain, ok := s.sm.GetChain(chdr.ChannelId)
+		if !ok {
+			return &ab.AdminResponse{Status: cb.Status_NOT_FOUND, Info: fmt.Sprintf("channel %s is not served by this orderer", chdr.ChannelId)}, nil
+		}
+		return &ab.AdminResponse{Status: cb.Status_SUCCESS, Channels: []*ab.ChannelStatus{channelStatus(chain)}}, nil
+	}
+
+	response := &ab.AdminResponse{Status: cb.Status_SUCCESS}
+	for _, chainID := range s.sm.ChainIDs() {
+		chain, ok := s.sm.GetChain(chainID)
+		if !ok {
+			// The ledger of the channel is kept but the channel is not served
+			continue
+		}
+		response.Channels = append(response.Channels, channelStatus(chain))
+	}
+	return response, nil
+}
+
+// channelStatus returns the state of a chain along with the Broadcast rate
+// limit counters of its MSPs
+func channelStatus(chain Support) *ab.ChannelStatus {
+	status := chain.Status()
+	status.BroadcastCounts = ratelimit.Counts(status.ChannelId)
+	return status
+}
+
+// authenticate checks that the request is signed by an admin of the local MSP,
+// returning its channel header or the status to reply with
+func (s *server) authenticate(env *cb.Envelope) (*cb.ChannelHeader, cb.Status, error) {
+	if env == nil {
+		return nil, cb.Status_BAD_REQUEST, fmt.Errorf("missing envelope")
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, cb.Status_BAD_REQUEST, fmt.Errorf("bad payload: %s", err)
+	}
+	if payload.Header == nil {
+		return nil, cb.Status_BAD_REQUEST, fmt.Errorf("missing header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, cb.Status_BAD_REQUEST, fmt.Errorf("bad channel header: %s", err)
+	}
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return nil, cb.Status_BAD_REQUEST, fmt.Errorf("bad signature header: %s", err)
+	}
+
+	if chdr.Timestamp == nil {
+		return nil, cb.Status_BAD_REQUEST, fmt.Errorf("missing timestamp")
+	}
+	timestamp := time.Unix(chdr.Timestamp.Seconds, int64(chdr.Timestamp.Nanos))
+	now := s.now()
+	if timestamp.Before(now.Add(-s.timeWindow)) || timestamp.After(now.Add(s.timeWindow)) {
+		return nil, cb.Status_BAD_REQUEST, fmt.Errorf("timestamp %s is more than %s away from now", timestamp, s.timeWindow)
+	}
+
+	identity, err := s.localMSP.DeserializeIdentity(shdr.Creator)
+	if err != nil {
+		return nil, cb.Status_FORBIDDEN, fmt.Errorf("creator is not a member of the local MSP: %s", err)
+	}
+	if err = identity.Verify(env.Payload, env.Signature); err != nil {
+		return nil, cb.Status_FORBIDDEN, fmt.Errorf("bad signature: %s", err)
+	}
+	mspID, err := s.localMSP.GetIdentifier()
+	if err != nil {
+		return nil, cb.Status_INTERNAL_SERVER_ERROR, fmt.Errorf("cannot identify the local MSP: %s", err)
+	}
+	principal := &mspproto.MSPPrincipal{
+		PrincipalClassification: mspproto.MSPPrincipal_ROLE,
+		Principal:               utils.MarshalOrPanic(&mspproto.MSPRole{Role: mspproto.MSPRole_ADMIN, MspIdentifier: mspID}),
+	}
+	if err = s.localMSP.SatisfiesPrincipal(identity, principal); err != nil {
+		return nil, cb.Status_FORBIDDEN, fmt.Errorf("creator is not an admin of the local MSP: %s", err)
+	}
+	return chdr, cb.Status_SUCCESS, nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package admin
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/localmsp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/orderer/common/ratelimit"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestMain(m *testing.M) {
+	if err := mspmgmt.LoadDevMsp(); err != nil {
+		panic(fmt.Sprintf("Could not load the dev MSP: %s", err))
+	}
+	os.Exit(m.Run())
+}
+
+type mockSupport struct {
+	status *ab.ChannelStatus
+}
+
+func (ms *mockSupport) Status() *ab.ChannelStatus {
+	return ms.status
+}
+
+type mockSupportManager struct {
+	chains   map[string]*mockSupport
+	chainIDs []string
+}
+
+func (msm *mockSupportManager) GetChain(chainID string) (Support, bool) {
+	chain, ok := msm.chains[chainID]
+	return chain, ok
+}
+
+func (msm *mockSupportManager) ChainIDs() []string {
+	return msm.chainIDs
+}
+
+func newMockSupportManager() *mockSupportManager {
+	return &mockSupportManager{
+		chains: map[string]*mockSupport{
+			"foo": {status: &ab.ChannelStatus{ChannelId: "foo", Height: 3, ConsensusType: "solo"}},
+			"bar": {status: &ab.ChannelStatus{ChannelId: "bar", Height: 7, ConsensusType: "kafka", Kafka: &ab.KafkaChannelStatus{Topic: "bar", LastOffsetPersisted: 5, LastOffsetConsumed: 6}}},
+		},
+		// "baz" has a ledger but is not served
+		chainIDs: []string{"bar", "baz", "foo"},
+	}
+}
+
+type signerFunc func(message []byte) ([]byte, error)
+
+func (sf signerFunc) NewSignatureHeader() (*cb.SignatureHeader, error) {
+	return localmsp.NewSigner().NewSignatureHeader()
+}
+
+func (sf signerFunc) Sign(message []byte) ([]byte, error) {
+	return sf(message)
+}
+
+func newRequest(t *testing.T, chainID string, signer crypto.LocalSigner) *cb.Envelope {
+	env, err := NewChannelStatusRequest(chainID, signer)
+	assert.NoError(t, err)
+	return env
+}
+
+func TestChannelStatus(t *testing.T) {
+	sm := newMockSupportManager()
+	s := NewServer(sm, mspmgmt.GetLocalMSP(), 15*time.Minute)
+
+	t.Run("AllChannels", func(t *testing.T) {
+		response, err := s.ChannelStatus(context.Background(), newRequest(t, "", localmsp.NewSigner()))
+		assert.NoError(t, err)
+		assert.Equal(t, cb.Status_SUCCESS, response.Status, response.Info)
+		assert.Equal(t, []*ab.ChannelStatus{sm.chains["bar"].status, sm.chains["foo"].status}, response.Channels)
+	})
+
+	t.Run("OneChannel", func(t *testing.T) {
+		response, err := s.ChannelStatus(context.Background(), newRequest(t, "foo", localmsp.NewSigner()))
+		assert.NoError(t, err)
+		assert.Equal(t, cb.Status_SUCCESS, response.Status, response.Info)
+		assert.Equal(t, []*ab.ChannelStatus{sm.chains["foo"].status}, response.Channels)
+	})
+
+	t.Run("BroadcastCounts", func(t *testing.T) {
+		limiter := ratelimit.New(ratelimit.Limits{MSP: ratelimit.Limit{Rate: 1}})
+		assert.True(t, limiter.Allow("foo", "Org1MSP", []byte("cert"), nil))
+		assert.False(t, limiter.Allow("foo", "Org1MSP", []byte("cert"), nil))
+
+		response, err := s.ChannelStatus(context.Background(), newRequest(t, "foo", localmsp.NewSigner()))
+		assert.NoError(t, err)
+		assert.Equal(t, cb.Status_SUCCESS, response.Status, response.Info)
+		assert.Equal(t, []*ab.BroadcastCounts{{MspId: "Org1MSP", Accepted: 1, Rejected: 1}}, response.Channels[0].BroadcastCounts)
+	})
+
+	t.Run("UnknownChannel", func(t *testing.T) {
+		response, err := s.ChannelStatus(context.Background(), newRequest(t, "baz", localmsp.NewSigner()))
+		assert.NoError(t, err)
+		assert.Equal(t, cb.Status_NOT_FOUND, response.Status)
+		assert.Empty(t, response.Channels)
+	})
+}
+
+func TestChannelStatusAuthentication(t *testing.T) {
+	s := NewServer(newMockSupportManager(), mspmgmt.GetLocalMSP(), 15*time.Minute)
+
+	badRequest := func(env *cb.Envelope) {
+		response, err := s.ChannelStatus(context.Background(), env)
+		assert.NoError(t, err)
+		assert.Equal(t, cb.Status_BAD_REQUEST, response.Status)
+		assert.Empty(t, response.Channels)
+	}
+	forbidden := func(env *cb.Envelope) {
+		response, err := s.ChannelStatus(context.Background(), env)
+		assert.NoError(t, err)
+		assert.Equal(t, cb.Status_FORBIDDEN, response.Status)
+		assert.Empty(t, response.Channels)
+	}
+
+	t.Run("MissingEnvelope", func(t *testing.T) {
+		badRequest(nil)
+	})
+
+	t.Run("BadPayload", func(t *testing.T) {
+		badRequest(&cb.Envelope{Payload: []byte("garbage")})
+	})
+
+	t.Run("MissingHeader", func(t *testing.T) {
+		badRequest(&cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{})})
+	})
+
+	t.Run("StaleTimestamp", func(t *testing.T) {
+		s.(*server).now = func() time.Time { return time.Now().Add(time.Hour) }
+		defer func() { s.(*server).now = time.Now }()
+		badRequest(newRequest(t, "", localmsp.NewSigner()))
+	})
+
+	t.Run("BadCreator", func(t *testing.T) {
+		payloadBytes := utils.MarshalOrPanic(&cb.Payload{
+			Header: utils.MakePayloadHeader(utils.MakeChannelHeader(cb.HeaderType_MESSAGE, 0, "", 0), &cb.SignatureHeader{Creator: []byte("garbage")}),
+		})
+		forbidden(&cb.Envelope{Payload: payloadBytes})
+	})
+
+	t.Run("BadSignature", func(t *testing.T) {
+		forbidden(newRequest(t, "", signerFunc(func(message []byte) ([]byte, error) {
+			return []byte("garbage"), nil
+		})))
+	})
+}
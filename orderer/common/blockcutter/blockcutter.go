@@ -17,6 +17,8 @@ limitations under the License.
 package blockcutter
 
 import (
+	"sync"
+
 	"github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/orderer/common/filter"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -49,6 +51,10 @@ type Receiver interface {
 
 	// Cut returns the current batch and starts a new one
 	Cut() ([]*cb.Envelope, []filter.Committer)
+
+	// PendingBatchSize returns the number of messages, and their size in bytes, of the current batch
+	// Unlike the other methods, it may be invoked concurrently with them
+	PendingBatchSize() (messageCount uint32, sizeBytes uint32)
 }
 
 type receiver struct {
@@ -57,6 +63,7 @@ type receiver struct {
 	pendingBatch          []*cb.Envelope
 	pendingBatchSizeBytes uint32
 	pendingCommitters     []filter.Committer
+	pendingLock           sync.RWMutex // guards the pending batch against PendingBatchSize
 }
 
 // NewReceiverImpl creates a Receiver implementation based on the given configtxorderer manager and filters
@@ -133,9 +140,11 @@ func (r *receiver) Ordered(msg *cb.Envelope) ([][]*cb.Envelope, [][]filter.Commi
 	}
 
 	logger.Debugf("Enqueuing message into batch")
+	r.pendingLock.Lock()
 	r.pendingBatch = append(r.pendingBatch, msg)
 	r.pendingBatchSizeBytes += messageSizeBytes
 	r.pendingCommitters = append(r.pendingCommitters, committer)
+	r.pendingLock.Unlock()
 
 	if uint32(len(r.pendingBatch)) >= r.sharedConfigManager.BatchSize().MaxMessageCount {
 		logger.Debugf("Batch size met, cutting batch")
@@ -155,6 +164,8 @@ func (r *receiver) Ordered(msg *cb.Envelope) ([][]*cb.Envelope, [][]filter.Commi
 
 // Cut returns the current batch and starts a new one
 func (r *receiver) Cut() ([]*cb.Envelope, []filter.Committer) {
+	r.pendingLock.Lock()
+	defer r.pendingLock.Unlock()
 	batch := r.pendingBatch
 	r.pendingBatch = nil
 	committers := r.pendingCommitters
@@ -163,6 +174,13 @@ func (r *receiver) Cut() ([]*cb.Envelope, []filter.Committer) {
 	return batch, committers
 }
 
+// PendingBatchSize returns the number of messages, and their size in bytes, of the current batch
+func (r *receiver) PendingBatchSize() (uint32, uint32) {
+	r.pendingLock.RLock()
+	defer r.pendingLock.RUnlock()
+	return uint32(len(r.pendingBatch)), r.pendingBatchSizeBytes
+}
+
 func messageSizeBytes(message *cb.Envelope) uint32 {
 	return uint32(len(message.Payload) + len(message.Signature))
 }
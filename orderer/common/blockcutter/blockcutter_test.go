@@ -340,3 +340,25 @@ func TestBatchSizePreferredMaxBytesOverflowNoPending(t *testing.T) {
 	}
 
 }
+
+func TestPendingBatchSize(t *testing.T) {
+	filters := getFilters()
+	r := NewReceiverImpl(&mockconfig.Orderer{BatchSizeVal: &ab.BatchSize{MaxMessageCount: 3, AbsoluteMaxBytes: 1000, PreferredMaxBytes: 100}}, filters)
+
+	if count, size := r.PendingBatchSize(); count != 0 || size != 0 {
+		t.Fatalf("Should have had an empty pending batch, got %d messages of %d bytes", count, size)
+	}
+
+	r.Ordered(goodTx)
+	r.Ordered(goodTx)
+
+	if count, size := r.PendingBatchSize(); count != 2 || size != 2*messageSizeBytes(goodTx) {
+		t.Fatalf("Should have had 2 pending messages of %d bytes, got %d messages of %d bytes", 2*messageSizeBytes(goodTx), count, size)
+	}
+
+	r.Cut()
+
+	if count, size := r.PendingBatchSize(); count != 0 || size != 0 {
+		t.Fatalf("Should have had an empty pending batch after cutting, got %d messages of %d bytes", count, size)
+	}
+}
@@ -28,6 +28,10 @@ import (
 	"io"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/config"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/msp"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/utils"
 )
 
@@ -63,10 +67,27 @@ type Support interface {
 
 	// Filters returns the set of broadcast filters for this chain
 	Filters() *filter.RuleSet
+
+	// SharedConfig provides the shared config of the chain
+	SharedConfig() config.Orderer
+
+	// ChannelConfig provides the channel config of the chain
+	ChannelConfig() config.Channel
+
+	// MSPManager returns the MSP manager of the chain
+	MSPManager() msp.MSPManager
+}
+
+// RateLimiter decides whether a client may broadcast another envelope on a channel
+type RateLimiter interface {
+	// Allow returns true if the client, identified by its MSP ID and certificate,
+	// is within the limits, which the channel may override
+	Allow(channelID, mspID string, clientCert []byte, overrides *ab.BroadcastRateLimits) bool
 }
 
 type handlerImpl struct {
-	sm SupportManager
+	sm      SupportManager
+	limiter RateLimiter
 }
 
 // NewHandlerImpl constructs a new implementation of the Handler interface
@@ -76,6 +97,15 @@ func NewHandlerImpl(sm SupportManager) Handler {
 	}
 }
 
+// NewHandlerImplWithRateLimiter constructs a new implementation of the Handler
+// interface which rejects the envelopes of the clients exceeding the limits of limiter
+func NewHandlerImplWithRateLimiter(sm SupportManager, limiter RateLimiter) Handler {
+	return &handlerImpl{
+		sm:      sm,
+		limiter: limiter,
+	}
+}
+
 // Handle starts a service thread for a given gRPC connection and services the broadcast connection
 func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 	logger.Debugf("Starting new broadcast loop")
@@ -107,12 +137,18 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST})
 		}
 
+		// CONFIG_UPDATE processing replaces the header, so keep the client's
+		signatureHeader := payload.Header.SignatureHeader
+		tlsCertHash := chdr.TlsCertHash
+		var configUpdateMsg *cb.Envelope
+
 		if chdr.Type == int32(cb.HeaderType_CONFIG_UPDATE) {
 			logger.Debugf("Preprocessing CONFIG_UPDATE")
+			configUpdateMsg = msg
 			msg, err = bh.sm.Process(msg)
 			if err != nil {
 				logger.Warningf("Rejecting CONFIG_UPDATE because: %s", err)
-				return srv.Send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST})
+				return srv.Send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: err.Error()})
 			}
 
 			err = proto.Unmarshal(msg.Payload, payload)
@@ -139,6 +175,11 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_NOT_FOUND})
 		}
 
+		if err = comm.VerifyTLSBinding(srv.Context(), tlsCertHash, support.ChannelConfig().Capabilities().TLSBinding()); err != nil {
+			logger.Warningf("[channel: %s] Rejecting broadcast message: %s", chdr.ChannelId, err)
+			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_FORBIDDEN, Info: err.Error()})
+		}
+
 		logger.Debugf("[channel: %s] Broadcast is filtering message of type %s", chdr.ChannelId, cb.HeaderType_name[chdr.Type])
 
 		// Normal transaction for existing chain
@@ -152,6 +193,30 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST})
 		}
 
+		// The limits are applied once the creator is authenticated, so that a
+		// client cannot exhaust the quota of another MSP or client. The filters
+		// authenticate the creator of a normal message, but the message
+		// generated from a CONFIG_UPDATE is signed by this orderer, so the
+		// signature of the CONFIG_UPDATE envelope is checked here instead
+		if bh.limiter != nil {
+			mspID, clientCert := creator(signatureHeader)
+			if configUpdateMsg != nil {
+				if err = verifyCreator(support.MSPManager(), configUpdateMsg, signatureHeader); err != nil {
+					logger.Warningf("[channel: %s] Rejecting CONFIG_UPDATE because its creator could not be authenticated: %s", chdr.ChannelId, err)
+					return srv.Send(&ab.BroadcastResponse{Status: cb.Status_FORBIDDEN, Info: err.Error()})
+				}
+			}
+			if !bh.limiter.Allow(chdr.ChannelId, mspID, clientCert, support.SharedConfig().BroadcastRateLimits()) {
+				logger.Warningf("[channel: %s] Rejecting broadcast message from a client of MSP %s because of rate limiting", chdr.ChannelId, mspID)
+				err = srv.Send(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: "rate limit exceeded, retry later"})
+				if err != nil {
+					logger.Warningf("[channel: %s] Error sending to stream: %s", chdr.ChannelId, err)
+					return err
+				}
+				continue
+			}
+		}
+
 		myt = time.Now()
 		fmt.Fprintf(f, "%s BEFORE support.Enqueue(msg)\n", myt)
 		success := support.Enqueue(msg)
@@ -172,3 +237,37 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 		}
 	}
 }
+
+// verifyCreator checks that an envelope is signed by the valid identity named
+// in its signature header
+func verifyCreator(deserializer msp.IdentityDeserializer, env *cb.Envelope, signatureHeader []byte) error {
+	shdr, err := utils.GetSignatureHeader(signatureHeader)
+	if err != nil {
+		return fmt.Errorf("bad signature header: %s", err)
+	}
+	identity, err := deserializer.DeserializeIdentity(shdr.Creator)
+	if err != nil {
+		return fmt.Errorf("could not deserialize the creator: %s", err)
+	}
+	if err = identity.Validate(); err != nil {
+		return fmt.Errorf("invalid creator: %s", err)
+	}
+	if err = identity.Verify(env.Payload, env.Signature); err != nil {
+		return fmt.Errorf("bad signature: %s", err)
+	}
+	return nil
+}
+
+// creator returns the MSP ID and the certificate of the creator of a message,
+// both empty if the signature header cannot be parsed
+func creator(signatureHeader []byte) (string, []byte) {
+	shdr, err := utils.GetSignatureHeader(signatureHeader)
+	if err != nil {
+		return "", nil
+	}
+	sid := &mspprotos.SerializedIdentity{}
+	if err := proto.Unmarshal(shdr.Creator, sid); err != nil {
+		return "", shdr.Creator
+	}
+	return sid.Mspid, sid.IdBytes
+}
@@ -17,19 +17,29 @@ limitations under the License.
 package broadcast
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/common/config"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/orderer/common/filter"
 	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
 
 	logging "github.com/op/go-logging"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 )
 
 func init() {
@@ -40,6 +50,7 @@ var systemChain = "systemChain"
 
 type mockB struct {
 	grpc.ServerStream
+	ctx      context.Context
 	recvChan chan *cb.Envelope
 	sendChan chan *ab.BroadcastResponse
 }
@@ -51,6 +62,13 @@ func newMockB() *mockB {
 	}
 }
 
+func (m *mockB) Context() context.Context {
+	if m.ctx == nil {
+		return context.Background()
+	}
+	return m.ctx
+}
+
 func (m *mockB) Send(br *ab.BroadcastResponse) error {
 	m.sendChan <- br
 	return nil
@@ -89,6 +107,10 @@ func (m *erroneousSendMockB) Send(br *ab.BroadcastResponse) error {
 	return io.ErrUnexpectedEOF
 }
 
+func (m *erroneousSendMockB) Context() context.Context {
+	return context.Background()
+}
+
 func (m *erroneousSendMockB) Recv() (*cb.Envelope, error) {
 	return m.recvVal, nil
 }
@@ -121,12 +143,27 @@ func (mm *mockSupportManager) Process(configTx *cb.Envelope) (*cb.Envelope, erro
 type mockSupport struct {
 	filters       *filter.RuleSet
 	rejectEnqueue bool
+	sharedConfig  *mockconfig.Orderer
+	tlsBinding    bool
+	mspManager    *mockMSPManager
 }
 
 func (ms *mockSupport) Filters() *filter.RuleSet {
 	return ms.filters
 }
 
+func (ms *mockSupport) SharedConfig() config.Orderer {
+	return ms.sharedConfig
+}
+
+func (ms *mockSupport) ChannelConfig() config.Channel {
+	return &mockconfig.Channel{CapabilitiesVal: &mockconfig.ChannelCapabilities{TLSBindingVal: ms.tlsBinding}}
+}
+
+func (ms *mockSupport) MSPManager() msp.MSPManager {
+	return ms.mspManager
+}
+
 // Enqueue sends a message for ordering
 func (ms *mockSupport) Enqueue(env *cb.Envelope) bool {
 	return !ms.rejectEnqueue
@@ -170,7 +207,9 @@ func getMockSupportManager() (*mockSupportManager, *mockSupport) {
 		chains: make(map[string]*mockSupport),
 	}
 	mSysChain := &mockSupport{
-		filters: filters,
+		filters:      filters,
+		sharedConfig: &mockconfig.Orderer{},
+		mspManager:   &mockMSPManager{},
 	}
 	mm.chains[string(systemChain)] = mSysChain
 	return mm, mSysChain
@@ -308,6 +347,21 @@ func TestRejected(t *testing.T) {
 	assert.Equal(t, cb.Status_BAD_REQUEST, reply.Status, "Should have rejected CONFIG_UPDATE")
 }
 
+func TestConfigUpdateRejectedInfo(t *testing.T) {
+	mm := &mockSupportManager{
+		chains: map[string]*mockSupport{string(systemChain): {filters: filter.NewRuleSet([]filter.Rule{filter.AcceptRule})}},
+	}
+	bh := NewHandlerImpl(mm)
+	m := newMockB()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	m.recvChan <- makeConfigMessage("New Chain")
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_BAD_REQUEST, reply.Status, "Should have rejected CONFIG_UPDATE")
+	assert.Equal(t, "Nil result implies error", reply.Info, "Should have returned the reason for the rejection")
+}
+
 func TestBadStreamRecv(t *testing.T) {
 	bh := NewHandlerImpl(nil)
 	assert.Error(t, bh.Handle(&erroneousRecvMockB{}), "Should catch unexpected stream error")
@@ -408,3 +462,201 @@ func TestEmptyChannelIDAfterProcessing(t *testing.T) {
 	reply := <-m.sendChan
 	assert.Equal(t, cb.Status_INTERNAL_SERVER_ERROR, reply.Status, "Should respond with internal server error")
 }
+
+type mockRateLimiter struct {
+	allowed   int
+	mspID     string
+	cert      []byte
+	overrides *ab.BroadcastRateLimits
+}
+
+func (mrl *mockRateLimiter) Allow(channelID, mspID string, clientCert []byte, overrides *ab.BroadcastRateLimits) bool {
+	mrl.mspID, mrl.cert, mrl.overrides = mspID, clientCert, overrides
+	if mrl.allowed == 0 {
+		return false
+	}
+	mrl.allowed--
+	return true
+}
+
+func makeSignedMessage(chainID, mspID string, cert []byte) *cb.Envelope {
+	payload := &cb.Payload{
+		Data: []byte("Some bytes"),
+		Header: &cb.Header{
+			ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+				ChannelId: chainID,
+			}),
+			SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{
+				Creator: utils.MarshalOrPanic(&mspprotos.SerializedIdentity{Mspid: mspID, IdBytes: cert}),
+			}),
+		},
+	}
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(payload),
+	}
+}
+
+func TestRateLimited(t *testing.T) {
+	mm, mSysChain := getMockSupportManager()
+	overrides := &ab.BroadcastRateLimits{Client: &ab.RateLimit{Rate: 1}}
+	mSysChain.sharedConfig.BroadcastRateLimitsVal = overrides
+	limiter := &mockRateLimiter{allowed: 1}
+	bh := NewHandlerImplWithRateLimiter(mm, limiter)
+	m := newMockB()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	m.recvChan <- makeSignedMessage(systemChain, "Org1MSP", []byte("cert"))
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status, "Should have accepted the message within the limits")
+	assert.Equal(t, "Org1MSP", limiter.mspID)
+	assert.Equal(t, []byte("cert"), limiter.cert)
+	assert.Equal(t, overrides, limiter.overrides, "Should have applied the channel overrides")
+
+	m.recvChan <- makeSignedMessage(systemChain, "Org1MSP", []byte("cert"))
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, reply.Status, "Should have rejected the message over the limits")
+	assert.NotEmpty(t, reply.Info)
+
+	limiter.allowed = 1
+	m.recvChan <- makeSignedMessage(systemChain, "Org1MSP", []byte("cert"))
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status, "Stream should remain open after a rate limited message")
+}
+
+func TestRateLimitedAfterFilters(t *testing.T) {
+	mm, mSysChain := getMockSupportManager()
+	mSysChain.filters = filter.NewRuleSet([]filter.Rule{RejectRule})
+	limiter := &mockRateLimiter{}
+	bh := NewHandlerImplWithRateLimiter(mm, limiter)
+	m := newMockB()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	m.recvChan <- makeSignedMessage(systemChain, "Org1MSP", []byte("cert"))
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_BAD_REQUEST, reply.Status, "Filters should apply before the rate limits")
+	assert.Empty(t, limiter.mspID, "Rejected messages should not be counted against the limits")
+}
+
+func makeBoundMessage(chainID string, tlsCertHash []byte) *cb.Envelope {
+	payload := &cb.Payload{
+		Data: []byte("Some bytes"),
+		Header: &cb.Header{
+			ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+				ChannelId:   chainID,
+				TlsCertHash: tlsCertHash,
+			}),
+		},
+	}
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(payload),
+	}
+}
+
+func TestTLSBinding(t *testing.T) {
+	mm, mSysChain := getMockSupportManager()
+	bh := NewHandlerImpl(mm)
+	cert := &x509.Certificate{Raw: []byte("client cert")}
+	m := newMockB()
+	m.ctx = peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}},
+	})
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	m.recvChan <- makeMessage(systemChain, []byte("Some bytes"))
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status, "Unbound messages should be accepted unless the channel requires the binding")
+
+	m.recvChan <- makeBoundMessage(systemChain, comm.CertificateHash(cert.Raw))
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status, "Should have accepted a message bound to the client certificate")
+
+	mSysChain.tlsBinding = true
+	m.recvChan <- makeBoundMessage(systemChain, comm.CertificateHash(cert.Raw))
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status, "Should have accepted a message bound to the client certificate")
+
+	m.recvChan <- makeMessage(systemChain, []byte("Some bytes"))
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_FORBIDDEN, reply.Status, "Should have rejected an unbound message")
+	assert.NotEmpty(t, reply.Info)
+}
+
+func TestTLSBindingMismatch(t *testing.T) {
+	mm, _ := getMockSupportManager()
+	bh := NewHandlerImpl(mm)
+	m := newMockB()
+	m.ctx = peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{{Raw: []byte("client cert")}}}},
+	})
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	m.recvChan <- makeBoundMessage(systemChain, comm.CertificateHash([]byte("another client cert")))
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_FORBIDDEN, reply.Status, "Should have rejected a message replayed by another client")
+}
+
+type mockMSPManager struct {
+	msp.MSPManager
+	verifyErr error
+}
+
+func (mm *mockMSPManager) DeserializeIdentity(serializedID []byte) (msp.Identity, error) {
+	return &mockIdentity{verifyErr: mm.verifyErr}, nil
+}
+
+type mockIdentity struct {
+	msp.Identity
+	verifyErr error
+}
+
+func (mi *mockIdentity) Validate() error {
+	return nil
+}
+
+func (mi *mockIdentity) Verify(msg []byte, sig []byte) error {
+	return mi.verifyErr
+}
+
+func makeSignedConfigMessage(chainID, mspID string, cert []byte) *cb.Envelope {
+	payload := &cb.Payload{
+		Data: utils.MarshalOrPanic(&cb.ConfigEnvelope{}),
+		Header: &cb.Header{
+			ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+				ChannelId: chainID,
+				Type:      int32(cb.HeaderType_CONFIG_UPDATE),
+			}),
+			SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{
+				Creator: utils.MarshalOrPanic(&mspprotos.SerializedIdentity{Mspid: mspID, IdBytes: cert}),
+			}),
+		},
+	}
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(payload),
+	}
+}
+
+func TestRateLimitedConfigUpdate(t *testing.T) {
+	mm, mSysChain := getMockSupportManager()
+	mm.ProcessVal = &cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{Header: &cb.Header{ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{ChannelId: systemChain})}})}
+	limiter := &mockRateLimiter{allowed: 1}
+	bh := NewHandlerImplWithRateLimiter(mm, limiter)
+	m := newMockB()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	m.recvChan <- makeSignedConfigMessage("New Chain", "Org1MSP", []byte("cert"))
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status, "Should have accepted the CONFIG_UPDATE of an authenticated creator")
+	assert.Equal(t, "Org1MSP", limiter.mspID, "The limits should apply to the creator of the CONFIG_UPDATE")
+
+	limiter.mspID = ""
+	mSysChain.mspManager.verifyErr = fmt.Errorf("bad signature")
+	m.recvChan <- makeSignedConfigMessage("New Chain", "Org2MSP", []byte("cert"))
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_FORBIDDEN, reply.Status, "Should have rejected the CONFIG_UPDATE of an unauthenticated creator")
+	assert.Empty(t, limiter.mspID, "The CONFIG_UPDATE of an unauthenticated creator should not be counted against the limits of the MSP it names")
+}
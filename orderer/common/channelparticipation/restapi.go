@@ -0,0 +1,191 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package channelparticipation implements the orderer admin REST API which
+// joins, lists and removes the channels an orderer serves.
+package channelparticipation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/hyperledger/fabric/orderer/multichain"
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/mux"
+	"github.com/op/go-logging"
+)
+
+var logger = logging.MustGetLogger("orderer/channelparticipation")
+
+const (
+	// URLBaseV1 is the prefix of all the channel participation API paths
+	URLBaseV1 = "/participation/v1/"
+
+	// URLBaseV1Channels is the path of the channels collection
+	URLBaseV1Channels = URLBaseV1 + "channels"
+
+	// FormDataConfigBlockKey is the multipart form field holding the block to join
+	FormDataConfigBlockKey = "config-block"
+
+	channelIDKey = "channelID"
+)
+
+// ChannelManager is the subset of the multichain.Manager functions the API relies on
+type ChannelManager interface {
+	SystemChannelID() string
+	JoinChannel(configBlock *cb.Block) (multichain.ChannelInfo, error)
+	RemoveChannel(chainID string) error
+	ChannelList() []multichain.ChannelInfo
+	ChannelInfo(chainID string) (multichain.ChannelInfo, error)
+}
+
+// ChannelList is the response to a request listing the channels
+type ChannelList struct {
+	SystemChannel string                   `json:"systemChannel,omitempty"`
+	Channels      []multichain.ChannelInfo `json:"channels"`
+}
+
+// Error is the body of every failed request
+type Error struct {
+	Error string `json:"error"`
+}
+
+// Handler serves the channel participation API
+type Handler struct {
+	manager            ChannelManager
+	maxRequestBodySize int64
+	router             *mux.Router
+}
+
+// NewHandler creates a Handler serving the channel participation API for the
+// channels of manager, request bodies larger than maxRequestBodySize are rejected
+func NewHandler(manager ChannelManager, maxRequestBodySize int64) *Handler {
+	h := &Handler{
+		manager:            manager,
+		maxRequestBodySize: maxRequestBodySize,
+		router:             mux.NewRouter().StrictSlash(true),
+	}
+
+	h.router.
+		HandleFunc(URLBaseV1Channels, h.listChannels).
+		Methods("GET")
+	h.router.
+		HandleFunc(URLBaseV1Channels, h.joinChannel).
+		Methods("POST")
+	h.router.
+		HandleFunc(URLBaseV1Channels+"/{"+channelIDKey+"}", h.channelDetails).
+		Methods("GET")
+	h.router.
+		HandleFunc(URLBaseV1Channels+"/{"+channelIDKey+"}", h.removeChannel).
+		Methods("DELETE")
+
+	return h
+}
+
+// ServeHTTP routes the request to the matching API function
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.router.ServeHTTP(w, r)
+}
+
+func (h *Handler) listChannels(w http.ResponseWriter, r *http.Request) {
+	h.sendResponse(w, http.StatusOK, &ChannelList{
+		SystemChannel: h.manager.SystemChannelID(),
+		Channels:      h.manager.ChannelList(),
+	})
+}
+
+func (h *Handler) channelDetails(w http.ResponseWriter, r *http.Request) {
+	info, err := h.manager.ChannelInfo(mux.Vars(r)[channelIDKey])
+	if err != nil {
+		h.sendError(w, statusFor(err), err)
+		return
+	}
+	h.sendResponse(w, http.StatusOK, &info)
+}
+
+func (h *Handler) joinChannel(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxRequestBodySize)
+
+	blockFile, _, err := r.FormFile(FormDataConfigBlockKey)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, fmt.Errorf("error reading form field '%s': %s", FormDataConfigBlockKey, err))
+		return
+	}
+	defer blockFile.Close()
+
+	blockBytes, err := ioutil.ReadAll(blockFile)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, fmt.Errorf("error reading form field '%s': %s", FormDataConfigBlockKey, err))
+		return
+	}
+
+	block := &cb.Block{}
+	if err := proto.Unmarshal(blockBytes, block); err != nil {
+		h.sendError(w, http.StatusBadRequest, fmt.Errorf("error unmarshaling block: %s", err))
+		return
+	}
+
+	info, err := h.manager.JoinChannel(block)
+	if err != nil {
+		h.sendError(w, statusFor(err), fmt.Errorf("cannot join channel: %s", err))
+		return
+	}
+
+	logger.Infof("Joined channel %s through the channel participation API", info.Name)
+	w.Header().Set("Location", URLBaseV1Channels+"/"+info.Name)
+	h.sendResponse(w, http.StatusCreated, &info)
+}
+
+func (h *Handler) removeChannel(w http.ResponseWriter, r *http.Request) {
+	channelID := mux.Vars(r)[channelIDKey]
+	if err := h.manager.RemoveChannel(channelID); err != nil {
+		h.sendError(w, statusFor(err), fmt.Errorf("cannot remove channel: %s", err))
+		return
+	}
+
+	logger.Infof("Removed channel %s through the channel participation API", channelID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func statusFor(err error) int {
+	switch err {
+	case multichain.ErrChannelNotExist:
+		return http.StatusNotFound
+	case multichain.ErrChannelExists, multichain.ErrChannelRemoving:
+		return http.StatusConflict
+	case multichain.ErrSystemChannel:
+		return http.StatusMethodNotAllowed
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func (h *Handler) sendError(w http.ResponseWriter, code int, err error) {
+	logger.Debugf("Channel participation request failed with status %d: %s", code, err)
+	h.sendResponse(w, code, &Error{Error: err.Error()})
+}
+
+func (h *Handler) sendResponse(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Errorf("Failed to encode channel participation response: %s", err)
+	}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channelparticipation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/orderer/multichain"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockChannelManager struct {
+	systemChannelID string
+	channels        map[string]multichain.ChannelInfo
+	joinErr         error
+	joined          *cb.Block
+}
+
+func newMockChannelManager() *mockChannelManager {
+	return &mockChannelManager{
+		channels: map[string]multichain.ChannelInfo{
+			"foo": {Name: "foo", Height: 3, Status: multichain.ChannelStatusActive},
+		},
+	}
+}
+
+func (m *mockChannelManager) SystemChannelID() string {
+	return m.systemChannelID
+}
+
+func (m *mockChannelManager) JoinChannel(genesisBlock *cb.Block) (multichain.ChannelInfo, error) {
+	if m.joinErr != nil {
+		return multichain.ChannelInfo{}, m.joinErr
+	}
+	m.joined = genesisBlock
+	info := multichain.ChannelInfo{Name: "bar", Height: 1, Status: multichain.ChannelStatusActive}
+	m.channels[info.Name] = info
+	return info, nil
+}
+
+func (m *mockChannelManager) RemoveChannel(chainID string) error {
+	if chainID == m.systemChannelID {
+		return multichain.ErrSystemChannel
+	}
+	if _, ok := m.channels[chainID]; !ok {
+		return multichain.ErrChannelNotExist
+	}
+	delete(m.channels, chainID)
+	return nil
+}
+
+func (m *mockChannelManager) ChannelList() []multichain.ChannelInfo {
+	var infos []multichain.ChannelInfo
+	for _, info := range m.channels {
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+func (m *mockChannelManager) ChannelInfo(chainID string) (multichain.ChannelInfo, error) {
+	info, ok := m.channels[chainID]
+	if !ok {
+		return multichain.ChannelInfo{}, multichain.ErrChannelNotExist
+	}
+	return info, nil
+}
+
+func joinRequest(t *testing.T, blockBytes []byte) *http.Request {
+	buffer := &bytes.Buffer{}
+	mpw := multipart.NewWriter(buffer)
+	ffw, err := mpw.CreateFormFile(FormDataConfigBlockKey, "genesis.block")
+	assert.NoError(t, err)
+	_, err = ffw.Write(blockBytes)
+	assert.NoError(t, err)
+	assert.NoError(t, mpw.Close())
+
+	req, err := http.NewRequest("POST", URLBaseV1Channels, buffer)
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", mpw.FormDataContentType())
+	return req
+}
+
+func serve(h *Handler, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestListChannels(t *testing.T) {
+	m := newMockChannelManager()
+	m.systemChannelID = "system"
+	h := NewHandler(m, 1024*1024)
+
+	req, _ := http.NewRequest("GET", URLBaseV1Channels, nil)
+	rec := serve(h, req)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	list := &ChannelList{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), list))
+	assert.Equal(t, &ChannelList{
+		SystemChannel: "system",
+		Channels:      []multichain.ChannelInfo{{Name: "foo", Height: 3, Status: multichain.ChannelStatusActive}},
+	}, list)
+}
+
+func TestChannelDetails(t *testing.T) {
+	h := NewHandler(newMockChannelManager(), 1024*1024)
+
+	req, _ := http.NewRequest("GET", URLBaseV1Channels+"/foo", nil)
+	rec := serve(h, req)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	info := multichain.ChannelInfo{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &info))
+	assert.Equal(t, multichain.ChannelInfo{Name: "foo", Height: 3, Status: multichain.ChannelStatusActive}, info)
+
+	req, _ = http.NewRequest("GET", URLBaseV1Channels+"/missing", nil)
+	rec = serve(h, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+	apiErr := &Error{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), apiErr))
+	assert.Equal(t, multichain.ErrChannelNotExist.Error(), apiErr.Error)
+}
+
+func TestJoinChannel(t *testing.T) {
+	block := cb.NewBlock(0, nil)
+	blockBytes := utils.MarshalOrPanic(block)
+
+	t.Run("Success", func(t *testing.T) {
+		m := newMockChannelManager()
+		h := NewHandler(m, 1024*1024)
+		rec := serve(h, joinRequest(t, blockBytes))
+		assert.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		assert.Equal(t, URLBaseV1Channels+"/bar", rec.Header().Get("Location"))
+		assert.Equal(t, block.Header.Number, m.joined.Header.Number)
+		_, ok := m.channels["bar"]
+		assert.True(t, ok)
+	})
+
+	t.Run("AlreadyExists", func(t *testing.T) {
+		m := newMockChannelManager()
+		m.joinErr = multichain.ErrChannelExists
+		rec := serve(NewHandler(m, 1024*1024), joinRequest(t, blockBytes))
+		assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+	})
+
+	t.Run("InvalidBlock", func(t *testing.T) {
+		m := newMockChannelManager()
+		m.joinErr = fmt.Errorf("not a genesis block")
+		rec := serve(NewHandler(m, 1024*1024), joinRequest(t, blockBytes))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), "not a genesis block")
+	})
+
+	t.Run("BadBytes", func(t *testing.T) {
+		rec := serve(NewHandler(newMockChannelManager(), 1024*1024), joinRequest(t, []byte("garbage")))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	})
+
+	t.Run("MissingField", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", URLBaseV1Channels, nil)
+		rec := serve(NewHandler(newMockChannelManager(), 1024*1024), req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	})
+
+	t.Run("BodyTooLarge", func(t *testing.T) {
+		m := newMockChannelManager()
+		rec := serve(NewHandler(m, 16), joinRequest(t, blockBytes))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+		assert.Nil(t, m.joined)
+	})
+}
+
+func TestRemoveChannel(t *testing.T) {
+	m := newMockChannelManager()
+	m.systemChannelID = "system"
+	h := NewHandler(m, 1024*1024)
+
+	req, _ := http.NewRequest("DELETE", URLBaseV1Channels+"/foo", nil)
+	rec := serve(h, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+	assert.Empty(t, m.channels)
+
+	rec = serve(h, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+
+	req, _ = http.NewRequest("DELETE", URLBaseV1Channels+"/system", nil)
+	rec = serve(h, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code, rec.Body.String())
+}
+
+func TestUnsupportedMethod(t *testing.T) {
+	req, _ := http.NewRequest("PUT", URLBaseV1Channels+"/foo", nil)
+	rec := serve(NewHandler(newMockChannelManager(), 1024*1024), req)
+	assert.NotEqual(t, http.StatusOK, rec.Code)
+}
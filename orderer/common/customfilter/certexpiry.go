@@ -0,0 +1,85 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customfilter
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// CertExpiryRuleName rejects messages whose creator certificate expires
+// within the MinValidity parameter, one hour by default
+const CertExpiryRuleName = "CertExpiry"
+
+func init() {
+	Register(CertExpiryRuleName, newCertExpiryRule)
+}
+
+type certExpiryRule struct {
+	minValidity time.Duration
+	now         func() time.Time
+}
+
+func newCertExpiryRule(values map[string]string) (filter.Rule, error) {
+	p := newParams(values)
+	minValidity, err := p.duration("MinValidity", time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.unknown(); err != nil {
+		return nil, err
+	}
+	return &certExpiryRule{minValidity: minValidity, now: time.Now}, nil
+}
+
+func (r *certExpiryRule) Apply(message *cb.Envelope) (filter.Action, filter.Committer) {
+	payload, err := utils.UnmarshalPayload(message.Payload)
+	if err != nil || payload.Header == nil {
+		return filter.Reject, nil
+	}
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return filter.Reject, nil
+	}
+	sid := &mspprotos.SerializedIdentity{}
+	if err := proto.Unmarshal(shdr.Creator, sid); err != nil {
+		return filter.Reject, nil
+	}
+	block, _ := pem.Decode(sid.IdBytes)
+	if block == nil {
+		logger.Debugf("Rejecting message whose creator is not a PEM encoded certificate")
+		return filter.Reject, nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		logger.Debugf("Rejecting message whose creator certificate cannot be parsed: %s", err)
+		return filter.Reject, nil
+	}
+
+	if cert.NotAfter.Before(r.now().Add(r.minValidity)) {
+		logger.Warningf("Rejecting message from %s (MSP %s) whose certificate expires at %s, less than %s from now", cert.Subject.CommonName, sid.Mspid, cert.NotAfter, r.minValidity)
+		return filter.Reject, nil
+	}
+	return filter.Forward, nil
+}
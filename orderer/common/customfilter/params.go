@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customfilter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// params reads the parameters of a rule and tracks the ones which were read,
+// so that misspelled parameters are reported instead of silently ignored
+type params struct {
+	values map[string]string
+	read   map[string]bool
+}
+
+func newParams(values map[string]string) *params {
+	return &params{values: values, read: make(map[string]bool)}
+}
+
+func (p *params) lookup(name string) (string, bool) {
+	p.read[name] = true
+	value, ok := p.values[name]
+	return value, ok && value != ""
+}
+
+func (p *params) duration(name string, defaultValue time.Duration) (time.Duration, error) {
+	value, ok := p.lookup(name)
+	if !ok {
+		return defaultValue, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("parameter %s: %s", name, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("parameter %s must be positive, was %s", name, d)
+	}
+	return d, nil
+}
+
+func (p *params) requiredUint32(name string) (uint32, error) {
+	value, ok := p.lookup(name)
+	if !ok {
+		return 0, fmt.Errorf("parameter %s is required", name)
+	}
+	n, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("parameter %s: %s", name, err)
+	}
+	return uint32(n), nil
+}
+
+// unknown returns an error naming the parameters which were never read
+func (p *params) unknown() error {
+	var unknown []string
+	for name := range p.values {
+		if !p.read[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("unknown parameters %v", unknown)
+}
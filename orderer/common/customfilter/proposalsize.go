@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customfilter
+
+import (
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// MaxProposalPayloadSizeRuleName rejects endorser transactions carrying a
+// chaincode proposal payload, i.e. the chaincode input, larger than the
+// required MaxBytes parameter
+const MaxProposalPayloadSizeRuleName = "MaxProposalPayloadSize"
+
+func init() {
+	Register(MaxProposalPayloadSizeRuleName, newMaxProposalPayloadSizeRule)
+}
+
+type maxProposalPayloadSizeRule struct {
+	maxBytes uint32
+}
+
+func newMaxProposalPayloadSizeRule(values map[string]string) (filter.Rule, error) {
+	p := newParams(values)
+	maxBytes, err := p.requiredUint32("MaxBytes")
+	if err != nil {
+		return nil, err
+	}
+	if err := p.unknown(); err != nil {
+		return nil, err
+	}
+	return &maxProposalPayloadSizeRule{maxBytes: maxBytes}, nil
+}
+
+func (r *maxProposalPayloadSizeRule) Apply(message *cb.Envelope) (filter.Action, filter.Committer) {
+	payload, err := utils.UnmarshalPayload(message.Payload)
+	if err != nil || payload.Header == nil {
+		return filter.Reject, nil
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return filter.Reject, nil
+	}
+	if chdr.Type != int32(cb.HeaderType_ENDORSER_TRANSACTION) {
+		return filter.Forward, nil
+	}
+
+	tx, err := utils.GetTransaction(payload.Data)
+	if err != nil {
+		return filter.Reject, nil
+	}
+	for _, action := range tx.Actions {
+		cap, err := utils.GetChaincodeActionPayload(action.Payload)
+		if err != nil {
+			return filter.Reject, nil
+		}
+		if size := len(cap.ChaincodeProposalPayload); size > int(r.maxBytes) {
+			logger.Warningf("[channel: %s] Rejecting transaction %s whose %d byte proposal payload exceeds the maximum of %d bytes", chdr.ChannelId, chdr.TxId, size, r.maxBytes)
+			return filter.Reject, nil
+		}
+	}
+	return filter.Forward, nil
+}
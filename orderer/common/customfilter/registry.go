@@ -0,0 +1,157 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package customfilter provides a registry of the broadcast filter rules
+// which the orderer configuration may enable, in addition to the standard
+// rules, together with a set of such rules.
+package customfilter
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/op/go-logging"
+)
+
+var logger = logging.MustGetLogger("orderer/common/customfilter")
+
+// Factory creates a rule from the parameters it is configured with, it returns
+// an error if a parameter is missing, unknown or invalid
+type Factory func(params map[string]string) (filter.Rule, error)
+
+// Config enables a rule by name and sets its parameters
+type Config struct {
+	Name   string
+	Params map[string]string
+}
+
+var (
+	factories     = make(map[string]Factory)
+	factoriesLock sync.RWMutex
+)
+
+// Register makes a rule available to the orderer configuration under name,
+// it panics if a rule is already registered under the same name
+func Register(name string, factory Factory) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+
+	if _, ok := factories[name]; ok {
+		logger.Panicf("Filter rule %s registered twice", name)
+	}
+	factories[name] = factory
+}
+
+// Registered returns the names of the registered rules, sorted
+func Registered() []string {
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+	return registered()
+}
+
+// registered returns the names of the registered rules, sorted. The caller
+// must hold factoriesLock, which is not reentrant.
+func registered() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates, in order, the rules enabled by configs. Each rule may only be
+// enabled once.
+func New(configs []Config) ([]filter.Rule, error) {
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+
+	rules := make([]filter.Rule, 0, len(configs))
+	enabled := make(map[string]bool)
+	for i, config := range configs {
+		factory, ok := factories[config.Name]
+		if !ok {
+			return nil, fmt.Errorf("filter rule %d: unknown rule %s, available rules are %v", i, config.Name, registered())
+		}
+		if enabled[config.Name] {
+			return nil, fmt.Errorf("filter rule %d: rule %s is enabled more than once", i, config.Name)
+		}
+		enabled[config.Name] = true
+
+		rule, err := factory(config.Params)
+		if err != nil {
+			return nil, fmt.Errorf("filter rule %d: invalid configuration of rule %s: %s", i, config.Name, err)
+		}
+		rules = append(rules, &forwardOnly{name: config.Name, rule: rule})
+	}
+	return rules, nil
+}
+
+// forwardOnly keeps a custom rule from accepting a message, which would skip
+// the standard rules following it, such as the config transaction filter
+type forwardOnly struct {
+	name string
+	rule filter.Rule
+}
+
+func (fo *forwardOnly) Apply(message *cb.Envelope) (filter.Action, filter.Committer) {
+	action, _ := fo.rule.Apply(message)
+	if action == filter.Reject {
+		logger.Debugf("Message rejected by filter rule %s", fo.name)
+		return filter.Reject, nil
+	}
+	return filter.Forward, nil
+}
+
+// Rules holds the custom rules of each channel
+type Rules struct {
+	defaults []Config
+	channels map[string][]Config
+}
+
+// NewRules validates the rule configurations, the default ones apply to the
+// channels which have no configuration of their own
+func NewRules(defaults []Config, channels map[string][]Config) (*Rules, error) {
+	if _, err := New(defaults); err != nil {
+		return nil, fmt.Errorf("default rules: %s", err)
+	}
+	for chainID, configs := range channels {
+		if _, err := New(configs); err != nil {
+			return nil, fmt.Errorf("rules of channel %s: %s", chainID, err)
+		}
+	}
+	return &Rules{defaults: defaults, channels: channels}, nil
+}
+
+// ForChannel creates the custom rules of a channel. The configurations were
+// validated by NewRules, so the rules are always created.
+func (r *Rules) ForChannel(chainID string) []filter.Rule {
+	if r == nil {
+		return nil
+	}
+	configs, ok := r.channels[chainID]
+	if !ok {
+		configs = r.defaults
+	}
+	rules, err := New(configs)
+	if err != nil {
+		logger.Panicf("Programming error, filter rules of channel %s were validated: %s", chainID, err)
+	}
+	return rules
+}
@@ -0,0 +1,94 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customfilter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type staticRule struct {
+	action filter.Action
+}
+
+func (sr *staticRule) Apply(message *cb.Envelope) (filter.Action, filter.Committer) {
+	return sr.action, nil
+}
+
+func init() {
+	Register("Accept", func(params map[string]string) (filter.Rule, error) {
+		return &staticRule{action: filter.Accept}, nil
+	})
+	Register("Reject", func(params map[string]string) (filter.Rule, error) {
+		if params["Fail"] != "" {
+			return nil, fmt.Errorf("asked to fail")
+		}
+		return &staticRule{action: filter.Reject}, nil
+	})
+}
+
+func TestRegister(t *testing.T) {
+	assert.Panics(t, func() { Register(CertExpiryRuleName, newCertExpiryRule) }, "Registering a name twice should panic")
+	assert.Contains(t, Registered(), CertExpiryRuleName)
+	assert.Contains(t, Registered(), MaxProposalPayloadSizeRuleName)
+	assert.Contains(t, Registered(), TimestampWindowRuleName)
+}
+
+func TestNew(t *testing.T) {
+	rules, err := New([]Config{{Name: "Accept"}, {Name: "Reject"}})
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+
+	action, committer := rules[0].Apply(&cb.Envelope{})
+	assert.Equal(t, filter.Action(filter.Forward), action, "Custom rules should not be able to accept a message")
+	assert.Nil(t, committer)
+	action, _ = rules[1].Apply(&cb.Envelope{})
+	assert.Equal(t, filter.Action(filter.Reject), action)
+
+	_, err = New([]Config{{Name: "Unknown"}})
+	assert.Error(t, err, "Unknown rules should be rejected")
+
+	_, err = New([]Config{{Name: "Accept"}, {Name: "Accept"}})
+	assert.Error(t, err, "Rules enabled twice should be rejected")
+
+	_, err = New([]Config{{Name: "Reject", Params: map[string]string{"Fail": "true"}}})
+	assert.Error(t, err, "Rule configuration errors should be returned")
+}
+
+func TestRules(t *testing.T) {
+	rules, err := NewRules([]Config{{Name: "Reject"}}, map[string][]Config{
+		"foo": {{Name: "Accept"}, {Name: "Reject"}},
+		"bar": {},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, rules.ForChannel("foo"), 2)
+	assert.Len(t, rules.ForChannel("bar"), 0, "Channel rules should replace the default ones")
+	assert.Len(t, rules.ForChannel("baz"), 1, "Default rules should apply to unlisted channels")
+
+	var nilRules *Rules
+	assert.Nil(t, nilRules.ForChannel("foo"))
+
+	_, err = NewRules([]Config{{Name: "Unknown"}}, nil)
+	assert.Error(t, err)
+	_, err = NewRules(nil, map[string][]Config{"foo": {{Name: "Unknown"}}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,205 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customfilter
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/common/config"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeCreator(t *testing.T, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	return utils.MarshalOrPanic(&mspprotos.SerializedIdentity{
+		Mspid:   "SampleOrg",
+		IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	})
+}
+
+func makeEnvelope(headerType cb.HeaderType, ts *timestamp.Timestamp, creator []byte, data []byte) *cb.Envelope {
+	chdr := utils.MakeChannelHeader(headerType, 0, "foo", 0)
+	chdr.Timestamp = ts
+	return &cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{
+		Header: utils.MakePayloadHeader(chdr, utils.MakeSignatureHeader(creator, nil)),
+		Data:   data,
+	})}
+}
+
+func makeTransaction(proposalPayloadSizes ...int) []byte {
+	tx := &pb.Transaction{}
+	for _, size := range proposalPayloadSizes {
+		tx.Actions = append(tx.Actions, &pb.TransactionAction{
+			Payload: utils.MarshalOrPanic(&pb.ChaincodeActionPayload{ChaincodeProposalPayload: make([]byte, size)}),
+		})
+	}
+	return utils.MarshalOrPanic(tx)
+}
+
+func TestCertExpiryRule(t *testing.T) {
+	now := time.Now()
+	rule, err := newCertExpiryRule(map[string]string{"MinValidity": "2h"})
+	assert.NoError(t, err)
+	rule.(*certExpiryRule).now = func() time.Time { return now }
+
+	action, _ := rule.Apply(makeEnvelope(cb.HeaderType_MESSAGE, nil, makeCreator(t, now.Add(3*time.Hour)), nil))
+	assert.Equal(t, filter.Action(filter.Forward), action, "Certificates valid for longer than MinValidity should be forwarded")
+
+	action, _ = rule.Apply(makeEnvelope(cb.HeaderType_MESSAGE, nil, makeCreator(t, now.Add(time.Hour)), nil))
+	assert.Equal(t, filter.Action(filter.Reject), action, "Certificates expiring within MinValidity should be rejected")
+
+	action, _ = rule.Apply(makeEnvelope(cb.HeaderType_MESSAGE, nil, []byte("garbage"), nil))
+	assert.Equal(t, filter.Action(filter.Reject), action, "Unparseable creators should be rejected")
+
+	action, _ = rule.Apply(&cb.Envelope{Payload: []byte("garbage")})
+	assert.Equal(t, filter.Action(filter.Reject), action, "Unparseable payloads should be rejected")
+
+	rule, err = newCertExpiryRule(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, rule.(*certExpiryRule).minValidity)
+
+	_, err = newCertExpiryRule(map[string]string{"MinValidity": "soon"})
+	assert.Error(t, err)
+	_, err = newCertExpiryRule(map[string]string{"MinValidity": "-1h"})
+	assert.Error(t, err)
+	_, err = newCertExpiryRule(map[string]string{"MinValidty": "1h"})
+	assert.Error(t, err, "Misspelled parameters should be rejected")
+}
+
+func TestMaxProposalPayloadSizeRule(t *testing.T) {
+	rule, err := newMaxProposalPayloadSizeRule(map[string]string{"MaxBytes": "10"})
+	assert.NoError(t, err)
+
+	action, _ := rule.Apply(makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, nil, nil, makeTransaction(5, 10)))
+	assert.Equal(t, filter.Action(filter.Forward), action)
+
+	action, _ = rule.Apply(makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, nil, nil, makeTransaction(5, 11)))
+	assert.Equal(t, filter.Action(filter.Reject), action, "Oversized proposal payloads should be rejected")
+
+	action, _ = rule.Apply(makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, nil, nil, []byte("garbage")))
+	assert.Equal(t, filter.Action(filter.Reject), action, "Unparseable transactions should be rejected")
+
+	action, _ = rule.Apply(makeEnvelope(cb.HeaderType_CONFIG_UPDATE, nil, nil, make([]byte, 100)))
+	assert.Equal(t, filter.Action(filter.Forward), action, "Other message types should be forwarded")
+
+	_, err = newMaxProposalPayloadSizeRule(nil)
+	assert.Error(t, err, "MaxBytes should be required")
+	_, err = newMaxProposalPayloadSizeRule(map[string]string{"MaxBytes": "-1"})
+	assert.Error(t, err)
+}
+
+func TestTimestampWindowRule(t *testing.T) {
+	now := time.Now()
+	rule, err := newTimestampWindowRule(map[string]string{"Window": "5m"})
+	assert.NoError(t, err)
+	rule.(*timestampWindowRule).now = func() time.Time { return now }
+
+	at := func(t time.Time) *timestamp.Timestamp {
+		return &timestamp.Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+	}
+
+	for _, tc := range []struct {
+		name      string
+		timestamp *timestamp.Timestamp
+		action    filter.Action
+	}{
+		{"Now", at(now), filter.Forward},
+		{"WithinPast", at(now.Add(-4 * time.Minute)), filter.Forward},
+		{"WithinFuture", at(now.Add(4 * time.Minute)), filter.Forward},
+		{"TooOld", at(now.Add(-6 * time.Minute)), filter.Reject},
+		{"TooFarAhead", at(now.Add(6 * time.Minute)), filter.Reject},
+		{"Missing", nil, filter.Reject},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			action, _ := rule.Apply(makeEnvelope(cb.HeaderType_MESSAGE, tc.timestamp, nil, nil))
+			assert.Equal(t, tc.action, action)
+		})
+	}
+
+	rule, err = newTimestampWindowRule(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, rule.(*timestampWindowRule).window())
+}
+
+type mockChannelConfigSupport struct {
+	channelConfig *mockconfig.Channel
+}
+
+func (ms *mockChannelConfigSupport) ChannelConfig() config.Channel {
+	return ms.channelConfig
+}
+
+func TestTransactionTimestampWindow(t *testing.T) {
+	now := time.Now()
+	support := &mockChannelConfigSupport{channelConfig: &mockconfig.Channel{TransactionTimestampWindowVal: 10 * time.Minute}}
+	rule := NewTransactionTimestampWindow(support)
+	rule.(*timestampWindowRule).now = func() time.Time { return now }
+
+	at := func(t time.Time) *timestamp.Timestamp {
+		return &timestamp.Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		envelope *cb.Envelope
+		action   filter.Action
+	}{
+		{"Now", makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, at(now), nil, nil), filter.Forward},
+		{"WithinPast", makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, at(now.Add(-9*time.Minute)), nil, nil), filter.Forward},
+		{"WithinFuture", makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, at(now.Add(9*time.Minute)), nil, nil), filter.Forward},
+		{"TooOld", makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, at(now.Add(-11*time.Minute)), nil, nil), filter.Reject},
+		{"TooFarAhead", makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, at(now.Add(11*time.Minute)), nil, nil), filter.Reject},
+		{"Missing", makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, nil, nil, nil), filter.Reject},
+		{"NotEndorserTransaction", makeEnvelope(cb.HeaderType_CONFIG_UPDATE, at(now.Add(-time.Hour)), nil, nil), filter.Forward},
+		{"BadPayload", &cb.Envelope{Payload: []byte("garbage")}, filter.Reject},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			action, committer := rule.Apply(tc.envelope)
+			assert.Equal(t, tc.action, action)
+			assert.Nil(t, committer)
+		})
+	}
+
+	support.channelConfig.TransactionTimestampWindowVal = 0
+	action, _ := rule.Apply(makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, at(now.Add(-time.Hour)), nil, nil))
+	assert.Equal(t, filter.Action(filter.Forward), action, "Transactions should not be checked without a window")
+}
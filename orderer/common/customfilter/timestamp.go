@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customfilter
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/common/config"
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// TimestampWindowRuleName rejects messages whose channel header timestamp is
+// missing or further than the Window parameter, 15 minutes by default, from
+// the orderer's clock
+const TimestampWindowRuleName = "TimestampWindow"
+
+func init() {
+	Register(TimestampWindowRuleName, newTimestampWindowRule)
+}
+
+// ChannelConfigSupport provides the channel config holding the transaction
+// timestamp window
+type ChannelConfigSupport interface {
+	// ChannelConfig returns the current channel config
+	ChannelConfig() config.Channel
+}
+
+type timestampWindowRule struct {
+	window       func() time.Duration
+	endorserOnly bool
+	now          func() time.Time
+}
+
+func newTimestampWindowRule(values map[string]string) (filter.Rule, error) {
+	p := newParams(values)
+	window, err := p.duration("Window", 15*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.unknown(); err != nil {
+		return nil, err
+	}
+	return &timestampWindowRule{window: func() time.Duration { return window }, now: time.Now}, nil
+}
+
+// NewTransactionTimestampWindow creates the TimestampWindow rule for the
+// endorser transactions of a channel, whose window is the
+// TransactionTimestampWindow of the channel config, a zero window disabling the
+// rule. As the window may be updated, it is retrieved at each evaluation. Like
+// the configured rules, the rule depends on the orderer's clock, so it must only
+// be applied when a message is received, never when the ordered messages are
+// cut into blocks.
+func NewTransactionTimestampWindow(support ChannelConfigSupport) filter.Rule {
+	return &timestampWindowRule{
+		window:       func() time.Duration { return support.ChannelConfig().TransactionTimestampWindow() },
+		endorserOnly: true,
+		now:          time.Now,
+	}
+}
+
+// Apply rejects the messages outside of the window, resulting in Reject or Forward, never Accept and always with nil Committer
+func (r *timestampWindowRule) Apply(message *cb.Envelope) (filter.Action, filter.Committer) {
+	window := r.window()
+	if window == 0 {
+		return filter.Forward, nil
+	}
+
+	payload, err := utils.UnmarshalPayload(message.Payload)
+	if err != nil || payload.Header == nil {
+		return filter.Reject, nil
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return filter.Reject, nil
+	}
+	if r.endorserOnly && chdr.Type != int32(cb.HeaderType_ENDORSER_TRANSACTION) {
+		return filter.Forward, nil
+	}
+	if chdr.Timestamp == nil {
+		logger.Warningf("[channel: %s] Rejecting message %s without a timestamp", chdr.ChannelId, chdr.TxId)
+		return filter.Reject, nil
+	}
+
+	timestamp := time.Unix(chdr.Timestamp.Seconds, int64(chdr.Timestamp.Nanos))
+	now := r.now()
+	if timestamp.Before(now.Add(-window)) || timestamp.After(now.Add(window)) {
+		logger.Warningf("[channel: %s] Rejecting message %s whose timestamp %s is more than %s away from now", chdr.ChannelId, chdr.TxId, timestamp, window)
+		return filter.Reject, nil
+	}
+	return filter.Forward, nil
+}
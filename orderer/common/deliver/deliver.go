@@ -19,7 +19,9 @@ package deliver
 import (
 	"io"
 
+	"github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/orderer/common/filter"
 	"github.com/hyperledger/fabric/orderer/common/sigfilter"
 	"github.com/hyperledger/fabric/orderer/ledger"
@@ -33,6 +35,10 @@ import (
 
 var logger = logging.MustGetLogger("orderer/common/deliver")
 
+// prunedBlockInfo is returned to the clients requesting a block which is no
+// longer on the ledger
+const prunedBlockInfo = "the requested block is not on the ledger of the orderer, it may have been pruned by the retention policy of the channel and must then be fetched from a peer"
+
 // Handler defines an interface which handles Deliver requests
 type Handler interface {
 	Handle(srv ab.AtomicBroadcast_DeliverServer) error
@@ -56,6 +62,9 @@ type Support interface {
 
 	// Errored returns a channel which closes when the backing consenter has errored
 	Errored() <-chan struct{}
+
+	// ChannelConfig provides the channel config of the chain
+	ChannelConfig() config.Channel
 }
 
 type deliverServer struct {
@@ -118,6 +127,11 @@ func (ds *deliverServer) Handle(srv ab.AtomicBroadcast_DeliverServer) error {
 
 		}
 
+		if err = comm.VerifyTLSBinding(srv.Context(), chdr.TlsCertHash, chain.ChannelConfig().Capabilities().TLSBinding()); err != nil {
+			logger.Warningf("[channel: %s] Rejecting deliver request: %s", chdr.ChannelId, err)
+			return sendStatusReply(srv, cb.Status_FORBIDDEN)
+		}
+
 		lastConfigSequence := chain.Sequence()
 
 		sf := sigfilter.New(policies.ChannelReaders, chain.PolicyManager())
@@ -183,6 +197,10 @@ func (ds *deliverServer) Handle(srv ab.AtomicBroadcast_DeliverServer) error {
 			}
 
 			block, status := cursor.Next()
+			if status == cb.Status_NOT_FOUND {
+				logger.Warningf("[channel: %s] Requested block is not on the ledger, it may have been pruned by the retention policy and must then be fetched from a peer", chdr.ChannelId)
+				return sendStatusReplyWithInfo(srv, status, prunedBlockInfo)
+			}
 			if status != cb.Status_SUCCESS {
 				logger.Errorf("[channel: %s] Error reading from channel, cause was: %v", chdr.ChannelId, status)
 				return sendStatusReply(srv, status)
@@ -210,10 +228,14 @@ func (ds *deliverServer) Handle(srv ab.AtomicBroadcast_DeliverServer) error {
 }
 
 func sendStatusReply(srv ab.AtomicBroadcast_DeliverServer, status cb.Status) error {
+	return sendStatusReplyWithInfo(srv, status, "")
+}
+
+func sendStatusReplyWithInfo(srv ab.AtomicBroadcast_DeliverServer, status cb.Status, info string) error {
 	return srv.Send(&ab.DeliverResponse{
 		Type: &ab.DeliverResponse_Status{Status: status},
+		Info: info,
 	})
-
 }
 
 func sendBlockReply(srv ab.AtomicBroadcast_DeliverServer, block *cb.Block) error {
@@ -17,14 +17,19 @@ limitations under the License.
 package deliver
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
 	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/orderer/ledger"
 	ramledger "github.com/hyperledger/fabric/orderer/ledger/ram"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -32,7 +37,10 @@ import (
 	"github.com/hyperledger/fabric/protos/utils"
 	logging "github.com/op/go-logging"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 )
 
 var genesisBlock = cb.NewBlock(0, nil)
@@ -47,6 +55,7 @@ func init() {
 
 type mockD struct {
 	grpc.ServerStream
+	ctx      context.Context
 	recvChan chan *cb.Envelope
 	sendChan chan *ab.DeliverResponse
 }
@@ -58,6 +67,13 @@ func newMockD() *mockD {
 	}
 }
 
+func (m *mockD) Context() context.Context {
+	if m.ctx == nil {
+		return context.Background()
+	}
+	return m.ctx
+}
+
 func (m *mockD) Send(br *ab.DeliverResponse) error {
 	m.sendChan <- br
 	return nil
@@ -96,6 +112,10 @@ func (m *erroneousSendMockD) Send(br *ab.DeliverResponse) error {
 	return io.ErrUnexpectedEOF
 }
 
+func (m *erroneousSendMockD) Context() context.Context {
+	return context.Background()
+}
+
 func (m *erroneousSendMockD) Recv() (*cb.Envelope, error) {
 	return m.recvVal, nil
 }
@@ -114,6 +134,7 @@ type mockSupport struct {
 	policyManager *mockpolicies.Manager
 	erroredChan   chan struct{}
 	configSeq     uint64
+	tlsBinding    bool
 }
 
 func (mcs *mockSupport) Errored() <-chan struct{} {
@@ -132,6 +153,10 @@ func (mcs *mockSupport) Reader() ledger.Reader {
 	return mcs.ledger
 }
 
+func (mcs *mockSupport) ChannelConfig() config.Channel {
+	return &mockconfig.Channel{CapabilitiesVal: &mockconfig.ChannelCapabilities{TLSBindingVal: mcs.tlsBinding}}
+}
+
 func NewRAMLedger() ledger.ReadWriter {
 	rlf := ramledger.New(ledgerSize + 1)
 	rl, _ := rlf.GetOrCreate(provisional.TestChainID)
@@ -619,6 +644,34 @@ func TestChainNotFound(t *testing.T) {
 	}
 }
 
+func TestPrunedBlockSeek(t *testing.T) {
+	rlf := ramledger.New(ledgerSize + 1)
+	rlf.SetRetentionPolicies(ledger.RetentionPolicies{Default: ledger.RetentionPolicy{RetainedBlocks: 2}})
+	rl, _ := rlf.GetOrCreate(systemChainID)
+	rl.Append(genesisBlock)
+	for i := 1; i < ledgerSize; i++ {
+		rl.Append(ledger.CreateNextBlock(rl, []*cb.Envelope{&cb.Envelope{Payload: []byte(fmt.Sprintf("%d", i))}}))
+	}
+	mm := newMockMultichainManager()
+	mm.chains[systemChainID].ledger = rl
+
+	m := newMockD()
+	defer close(m.recvChan)
+
+	ds := NewHandlerImpl(mm)
+	go ds.Handle(m)
+
+	m.recvChan <- makeSeek(systemChainID, &ab.SeekInfo{Start: seekSpecified(1), Stop: seekSpecified(1), Behavior: ab.SeekInfo_BLOCK_UNTIL_READY})
+
+	select {
+	case deliverReply := <-m.sendChan:
+		assert.Equal(t, cb.Status_NOT_FOUND, deliverReply.GetStatus(), "Received wrong error on the reply channel")
+		assert.Equal(t, prunedBlockInfo, deliverReply.GetInfo(), "The client should be told to fetch the block from a peer")
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting to get all blocks")
+	}
+}
+
 func TestBadSeekInfoPayload(t *testing.T) {
 	m := newMockD()
 	defer close(m.recvChan)
@@ -672,3 +725,47 @@ func TestMissingSeekPosition(t *testing.T) {
 		t.Fatalf("Timed out waiting to get all blocks")
 	}
 }
+
+func TestTLSBindingSeek(t *testing.T) {
+	mm := newMockMultichainManager()
+	mm.chains[systemChainID].tlsBinding = true
+	ds := NewHandlerImpl(mm)
+	cert := &x509.Certificate{Raw: []byte("client cert")}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}},
+	})
+	seekInfo := &ab.SeekInfo{Start: seekNewest, Stop: seekNewest, Behavior: ab.SeekInfo_BLOCK_UNTIL_READY}
+
+	m := newMockD()
+	m.ctx = ctx
+	defer close(m.recvChan)
+	go ds.Handle(m)
+
+	m.recvChan <- makeSeek(systemChainID, seekInfo)
+	select {
+	case deliverReply := <-m.sendChan:
+		assert.Equal(t, cb.Status_FORBIDDEN, deliverReply.GetStatus(), "Should have rejected an unbound seek")
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for the reply")
+	}
+
+	m = newMockD()
+	m.ctx = ctx
+	defer close(m.recvChan)
+	go ds.Handle(m)
+
+	env := makeSeek(systemChainID, seekInfo)
+	payload := utils.UnmarshalPayloadOrPanic(env.Payload)
+	payload.Header.ChannelHeader = utils.MarshalOrPanic(&cb.ChannelHeader{
+		ChannelId:   systemChainID,
+		TlsCertHash: comm.CertificateHash(cert.Raw),
+	})
+	env.Payload = utils.MarshalOrPanic(payload)
+	m.recvChan <- env
+	select {
+	case deliverReply := <-m.sendChan:
+		assert.NotNil(t, deliverReply.GetBlock(), "Should have delivered blocks for a bound seek")
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for the reply")
+	}
+}
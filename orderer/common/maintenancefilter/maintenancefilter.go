@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenancefilter
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/config"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/op/go-logging"
+)
+
+var logger = logging.MustGetLogger("orderer/common/maintenancefilter")
+
+// Support provides the orderer config holding the consensus type and state
+type Support interface {
+	// SharedConfig returns the current orderer config
+	SharedConfig() config.Orderer
+}
+
+type maintenanceFilter struct {
+	support        Support
+	consensusTypes map[string]struct{}
+}
+
+// New creates a filter which, while the channel is in maintenance, rejects
+// every message but config transactions. It also rejects the config
+// transactions setting a consensus type which is not one of consensusTypes,
+// the consenters this orderer is able to migrate the channel to.
+func New(support Support, consensusTypes map[string]struct{}) filter.Rule {
+	return &maintenanceFilter{
+		support:        support,
+		consensusTypes: consensusTypes,
+	}
+}
+
+// Apply rejects non-config messages in maintenance and config messages with an
+// unknown consensus type, resulting in Reject or Forward, never Accept and always with nil Committer
+func (mf *maintenanceFilter) Apply(message *cb.Envelope) (filter.Action, filter.Committer) {
+	payload, err := utils.UnmarshalPayload(message.Payload)
+	if err != nil || payload.Header == nil {
+		return filter.Reject, nil
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return filter.Reject, nil
+	}
+
+	if chdr.Type != int32(cb.HeaderType_CONFIG) {
+		if mf.support.SharedConfig().ConsensusState() == ab.ConsensusType_STATE_MAINTENANCE {
+			logger.Warningf("[channel: %s] Rejecting message of type %d as the channel is in maintenance", chdr.ChannelId, chdr.Type)
+			return filter.Reject, nil
+		}
+		return filter.Forward, nil
+	}
+
+	consensusType, err := proposedConsensusType(payload.Data)
+	if err != nil {
+		logger.Warningf("[channel: %s] Rejecting config transaction: %s", chdr.ChannelId, err)
+		return filter.Reject, nil
+	}
+	if _, ok := mf.consensusTypes[consensusType.Type]; !ok {
+		logger.Warningf("[channel: %s] Rejecting config transaction setting consensus type %s which this orderer does not support", chdr.ChannelId, consensusType.Type)
+		return filter.Reject, nil
+	}
+	return filter.Forward, nil
+}
+
+// proposedConsensusType extracts the ConsensusType of the config carried by a
+// CONFIG transaction payload
+func proposedConsensusType(data []byte) (*ab.ConsensusType, error) {
+	configEnvelope, err := configtx.UnmarshalConfigEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+	if configEnvelope.Config == nil || configEnvelope.Config.ChannelGroup == nil {
+		return nil, fmt.Errorf("config is missing its channel group")
+	}
+	ordererGroup, ok := configEnvelope.Config.ChannelGroup.Groups[config.OrdererGroupKey]
+	if !ok {
+		return nil, fmt.Errorf("config is missing the %s group", config.OrdererGroupKey)
+	}
+	value, ok := ordererGroup.Values[config.ConsensusTypeKey]
+	if !ok {
+		return nil, fmt.Errorf("config is missing the %s value", config.ConsensusTypeKey)
+	}
+	consensusType := &ab.ConsensusType{}
+	if err := proto.Unmarshal(value.Value, consensusType); err != nil {
+		return nil, fmt.Errorf("error unmarshaling %s: %s", config.ConsensusTypeKey, err)
+	}
+	return consensusType, nil
+}
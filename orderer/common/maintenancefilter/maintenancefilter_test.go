@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenancefilter
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/config"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/op/go-logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	logging.SetLevel(logging.DEBUG, "")
+}
+
+type mockSupport struct {
+	sharedConfig *mockconfig.Orderer
+}
+
+func (ms *mockSupport) SharedConfig() config.Orderer {
+	return ms.sharedConfig
+}
+
+func makeEnvelope(headerType cb.HeaderType, data []byte) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader:   utils.MarshalOrPanic(&cb.ChannelHeader{Type: int32(headerType), ChannelId: "foo"}),
+				SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{}),
+			},
+			Data: data,
+		}),
+	}
+}
+
+func makeConfigEnvelope(consensusType string) *cb.Envelope {
+	channelGroup := cb.NewConfigGroup()
+	channelGroup.Groups[config.OrdererGroupKey] = config.TemplateConsensusType(consensusType).Groups[config.OrdererGroupKey]
+	return makeEnvelope(cb.HeaderType_CONFIG, utils.MarshalOrPanic(&cb.ConfigEnvelope{
+		Config: &cb.Config{ChannelGroup: channelGroup},
+	}))
+}
+
+func TestMaintenanceFilter(t *testing.T) {
+	support := &mockSupport{sharedConfig: &mockconfig.Orderer{ConsensusTypeVal: "kafka"}}
+	mf := New(support, map[string]struct{}{"kafka": {}, "solo": {}})
+
+	testCases := []struct {
+		name     string
+		state    ab.ConsensusType_State
+		envelope *cb.Envelope
+		action   filter.Action
+	}{
+		{"NormalTransaction", ab.ConsensusType_STATE_NORMAL, makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, nil), filter.Forward},
+		{"NormalConfig", ab.ConsensusType_STATE_NORMAL, makeConfigEnvelope("kafka"), filter.Forward},
+		{"MaintenanceTransaction", ab.ConsensusType_STATE_MAINTENANCE, makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, nil), filter.Reject},
+		{"MaintenanceChannelCreation", ab.ConsensusType_STATE_MAINTENANCE, makeEnvelope(cb.HeaderType_ORDERER_TRANSACTION, nil), filter.Reject},
+		{"MaintenanceConfig", ab.ConsensusType_STATE_MAINTENANCE, makeConfigEnvelope("solo"), filter.Forward},
+		{"UnknownConsensusType", ab.ConsensusType_STATE_MAINTENANCE, makeConfigEnvelope("sbft"), filter.Reject},
+		{"MissingConsensusType", ab.ConsensusType_STATE_MAINTENANCE, makeEnvelope(cb.HeaderType_CONFIG, utils.MarshalOrPanic(&cb.ConfigEnvelope{Config: &cb.Config{ChannelGroup: cb.NewConfigGroup()}})), filter.Reject},
+		{"BadConfig", ab.ConsensusType_STATE_MAINTENANCE, makeEnvelope(cb.HeaderType_CONFIG, []byte("garbage")), filter.Reject},
+		{"BadPayload", ab.ConsensusType_STATE_NORMAL, &cb.Envelope{Payload: []byte("garbage")}, filter.Reject},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			support.sharedConfig.ConsensusStateVal = tc.state
+			action, committer := mf.Apply(tc.envelope)
+			assert.Equal(t, tc.action, action)
+			assert.Nil(t, committer)
+		})
+	}
+}
@@ -0,0 +1,240 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit implements the token bucket limits applied to the
+// envelopes clients submit through Broadcast.
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"expvar"
+	"sync"
+	"time"
+
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/op/go-logging"
+)
+
+var logger = logging.MustGetLogger("orderer/common/ratelimit")
+
+// sweepInterval is how often the buckets which have been refilled, and so
+// behave like newly created ones, are discarded
+const sweepInterval = time.Minute
+
+// counters holds, per channel and then per MSP ID, the number of envelopes
+// which were accepted and rejected; it is served by the expvar handler at
+// /debug/vars of the orderer profiling service, and reported by the Admin
+// service through Counts
+var counters = expvar.NewMap("orderer_broadcast_ratelimit")
+var countersLock sync.Mutex
+
+// Limit is a token bucket which refills at Rate tokens per second up to Burst
+// tokens; a Rate of 0 disables the limit
+type Limit struct {
+	Rate  uint32
+	Burst uint32
+}
+
+// capacity is the number of tokens the bucket holds when full, a Burst of 0
+// allows one second worth of envelopes
+func (l Limit) capacity() float64 {
+	if l.Burst == 0 {
+		return float64(l.Rate)
+	}
+	return float64(l.Burst)
+}
+
+// Limits holds the limits shared by all the clients of a channel, by the
+// clients of each MSP, and applied to each client certificate
+type Limits struct {
+	Channel Limit
+	MSP     Limit
+	Client  Limit
+}
+
+// Override returns the limits with the ones set in rateLimits replacing them
+func (l Limits) Override(rateLimits *ab.BroadcastRateLimits) Limits {
+	if rateLimits == nil {
+		return l
+	}
+	if rateLimits.Channel != nil {
+		l.Channel = Limit{Rate: rateLimits.Channel.Rate, Burst: rateLimits.Channel.Burst}
+	}
+	if rateLimits.Msp != nil {
+		l.MSP = Limit{Rate: rateLimits.Msp.Rate, Burst: rateLimits.Msp.Burst}
+	}
+	if rateLimits.Client != nil {
+		l.Client = Limit{Rate: rateLimits.Client.Rate, Burst: rateLimits.Client.Burst}
+	}
+	return l
+}
+
+type bucket struct {
+	limit  Limit
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens accumulated since the last refill and reports
+// whether the bucket is full
+func (b *bucket) refill(limit Limit, now time.Time) bool {
+	b.limit = limit
+	b.tokens += now.Sub(b.last).Seconds() * float64(limit.Rate)
+	b.last = now
+	if b.tokens >= limit.capacity() {
+		b.tokens = limit.capacity()
+		return true
+	}
+	return false
+}
+
+// Limiter applies Limits to the envelopes of each channel, MSP and client
+type Limiter struct {
+	defaults Limits
+	now      func() time.Time
+
+	mutex     sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// New creates a Limiter applying the given limits to the channels which do
+// not override them
+func New(defaults Limits) *Limiter {
+	return &Limiter{
+		defaults:  defaults,
+		now:       time.Now,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow consumes a token from the buckets of the channel, of the MSP and of
+// the client certificate, and returns false without consuming any if one of
+// them is empty. The channel config overrides the default limits.
+func (l *Limiter) Allow(channelID, mspID string, clientCert []byte, overrides *ab.BroadcastRateLimits) bool {
+	limits := l.defaults.Override(overrides)
+	certDigest := sha256.Sum256(clientCert)
+
+	type keyedLimit struct {
+		key   string
+		limit Limit
+	}
+	keyed := []keyedLimit{
+		{key: "channel\x00" + channelID, limit: limits.Channel},
+		{key: "msp\x00" + channelID + "\x00" + mspID, limit: limits.MSP},
+		{key: "client\x00" + channelID + "\x00" + string(certDigest[:]), limit: limits.Client},
+	}
+
+	l.mutex.Lock()
+	now := l.now()
+	l.sweep(now)
+
+	allowed := true
+	var buckets []*bucket
+	for _, kl := range keyed {
+		if kl.limit.Rate == 0 {
+			continue
+		}
+		b, ok := l.buckets[kl.key]
+		if !ok {
+			b = &bucket{last: now, tokens: kl.limit.capacity()}
+			l.buckets[kl.key] = b
+		}
+		b.refill(kl.limit, now)
+		if b.tokens < 1 {
+			allowed = false
+		}
+		buckets = append(buckets, b)
+	}
+	if allowed {
+		for _, b := range buckets {
+			b.tokens--
+		}
+	}
+	l.mutex.Unlock()
+
+	count(channelID, mspID, allowed)
+	if !allowed {
+		logger.Debugf("[channel: %s] Rate limit exceeded by a client of MSP %s", channelID, mspID)
+	}
+	return allowed
+}
+
+// sweep discards the buckets which are full, as they are equivalent to the
+// ones which will be created again on demand
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if b.refill(b.limit, now) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func count(channelID, mspID string, allowed bool) {
+	countersLock.Lock()
+	defer countersLock.Unlock()
+
+	channelCounters, ok := counters.Get(channelID).(*expvar.Map)
+	if !ok {
+		channelCounters = new(expvar.Map).Init()
+		counters.Set(channelID, channelCounters)
+	}
+	mspCounters, ok := channelCounters.Get(mspID).(*expvar.Map)
+	if !ok {
+		mspCounters = new(expvar.Map).Init()
+		channelCounters.Set(mspID, mspCounters)
+	}
+
+	if allowed {
+		mspCounters.Add("accepted", 1)
+	} else {
+		mspCounters.Add("rejected", 1)
+	}
+}
+
+// Counts returns the number of envelopes of the given channel which were
+// accepted and rejected, per MSP ID in ascending order
+func Counts(channelID string) []*ab.BroadcastCounts {
+	countersLock.Lock()
+	defer countersLock.Unlock()
+
+	channelCounters, ok := counters.Get(channelID).(*expvar.Map)
+	if !ok {
+		return nil
+	}
+	var counts []*ab.BroadcastCounts
+	channelCounters.Do(func(kv expvar.KeyValue) {
+		mspCounters := kv.Value.(*expvar.Map)
+		counts = append(counts, &ab.BroadcastCounts{
+			MspId:    kv.Key,
+			Accepted: counterValue(mspCounters, "accepted"),
+			Rejected: counterValue(mspCounters, "rejected"),
+		})
+	})
+	return counts
+}
+
+func counterValue(m *expvar.Map, key string) uint64 {
+	if counter, ok := m.Get(key).(*expvar.Int); ok {
+		return uint64(counter.Value())
+	}
+	return 0
+}
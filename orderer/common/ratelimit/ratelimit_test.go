@@ -0,0 +1,143 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"expvar"
+	"testing"
+	"time"
+
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (fc *fakeClock) Now() time.Time {
+	return fc.now
+}
+
+func newTestLimiter(defaults Limits) (*Limiter, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	l := New(defaults)
+	l.now = clock.Now
+	l.lastSweep = clock.now
+	return l, clock
+}
+
+func allowN(l *Limiter, n int, channelID, mspID string, cert []byte, overrides *ab.BroadcastRateLimits) int {
+	allowed := 0
+	for i := 0; i < n; i++ {
+		if l.Allow(channelID, mspID, cert, overrides) {
+			allowed++
+		}
+	}
+	return allowed
+}
+
+func TestNoLimits(t *testing.T) {
+	l, _ := newTestLimiter(Limits{})
+	assert.Equal(t, 1000, allowN(l, 1000, "foo", "Org1MSP", []byte("cert1"), nil))
+	assert.Empty(t, l.buckets, "Disabled limits should not allocate buckets")
+}
+
+func TestClientLimit(t *testing.T) {
+	l, clock := newTestLimiter(Limits{Client: Limit{Rate: 10, Burst: 5}})
+
+	assert.Equal(t, 5, allowN(l, 10, "foo", "Org1MSP", []byte("cert1"), nil), "Burst should be accepted at once")
+	assert.Equal(t, 5, allowN(l, 10, "foo", "Org1MSP", []byte("cert2"), nil), "Other clients should have their own bucket")
+
+	clock.now = clock.now.Add(200 * time.Millisecond)
+	assert.Equal(t, 2, allowN(l, 10, "foo", "Org1MSP", []byte("cert1"), nil), "Bucket should refill at the rate")
+
+	clock.now = clock.now.Add(time.Hour)
+	assert.Equal(t, 5, allowN(l, 10, "foo", "Org1MSP", []byte("cert1"), nil), "Bucket should not refill above the burst")
+}
+
+func TestMSPAndChannelLimits(t *testing.T) {
+	l, _ := newTestLimiter(Limits{
+		Channel: Limit{Rate: 8},
+		MSP:     Limit{Rate: 5},
+	})
+
+	assert.Equal(t, 5, allowN(l, 10, "foo", "Org1MSP", []byte("cert1"), nil), "MSP limit should apply")
+	assert.Equal(t, 0, allowN(l, 10, "foo", "Org1MSP", []byte("cert2"), nil), "MSP limit should be shared by its clients")
+	assert.Equal(t, 3, allowN(l, 10, "foo", "Org2MSP", []byte("cert3"), nil), "Channel limit should be shared by all MSPs")
+	assert.Equal(t, 5, allowN(l, 10, "bar", "Org2MSP", []byte("cert3"), nil), "Other channels should have their own buckets")
+}
+
+func TestRejectedEnvelopeConsumesNoToken(t *testing.T) {
+	l, _ := newTestLimiter(Limits{
+		MSP:    Limit{Rate: 3},
+		Client: Limit{Rate: 2},
+	})
+
+	assert.Equal(t, 2, allowN(l, 5, "foo", "Org1MSP", []byte("cert1"), nil))
+	assert.Equal(t, 1, allowN(l, 5, "foo", "Org1MSP", []byte("cert2"), nil), "Rejections by the client limit should not consume MSP tokens")
+}
+
+func TestOverrides(t *testing.T) {
+	defaults := Limits{Channel: Limit{Rate: 100}, Client: Limit{Rate: 10}}
+
+	limits := defaults.Override(&ab.BroadcastRateLimits{
+		Client: &ab.RateLimit{Rate: 1, Burst: 2},
+		Msp:    &ab.RateLimit{Rate: 0},
+	})
+	assert.Equal(t, Limits{Channel: Limit{Rate: 100}, Client: Limit{Rate: 1, Burst: 2}}, limits)
+	assert.Equal(t, defaults, defaults.Override(nil))
+	assert.Equal(t, defaults, defaults.Override(&ab.BroadcastRateLimits{}))
+
+	l, _ := newTestLimiter(defaults)
+	overrides := &ab.BroadcastRateLimits{Client: &ab.RateLimit{Rate: 1, Burst: 2}}
+	assert.Equal(t, 2, allowN(l, 10, "foo", "Org1MSP", []byte("cert1"), overrides))
+	assert.Equal(t, 10, allowN(l, 20, "bar", "Org1MSP", []byte("cert1"), nil))
+}
+
+func TestSweep(t *testing.T) {
+	l, clock := newTestLimiter(Limits{Client: Limit{Rate: 10}})
+
+	allowN(l, 5, "foo", "Org1MSP", []byte("cert1"), nil)
+	allowN(l, 5, "foo", "Org1MSP", []byte("cert2"), nil)
+	assert.Len(t, l.buckets, 2)
+
+	clock.now = clock.now.Add(sweepInterval)
+	allowN(l, 1, "foo", "Org1MSP", []byte("cert3"), nil)
+	assert.Len(t, l.buckets, 1, "Refilled buckets should have been discarded")
+}
+
+func TestCounters(t *testing.T) {
+	l, _ := newTestLimiter(Limits{MSP: Limit{Rate: 2}})
+	allowN(l, 5, "counted", "Org1MSP", []byte("cert1"), nil)
+
+	mspCounters := counters.Get("counted").(*expvar.Map).Get("Org1MSP").(*expvar.Map)
+	assert.Equal(t, "2", mspCounters.Get("accepted").String())
+	assert.Equal(t, "3", mspCounters.Get("rejected").String())
+}
+
+func TestCounts(t *testing.T) {
+	l, _ := newTestLimiter(Limits{MSP: Limit{Rate: 2}})
+	allowN(l, 3, "reported", "Org2MSP", []byte("cert2"), nil)
+	allowN(l, 1, "reported", "Org1MSP", []byte("cert1"), nil)
+
+	assert.Equal(t, []*ab.BroadcastCounts{
+		{MspId: "Org1MSP", Accepted: 1},
+		{MspId: "Org2MSP", Accepted: 2, Rejected: 1},
+	}, Counts("reported"))
+	assert.Empty(t, Counts("unknown"))
+}
@@ -62,10 +62,16 @@ type Processor struct {
 	systemChannelSupport Support
 }
 
+// New creates a Processor, systemChannelID may be empty for orderers without a
+// system channel, in which case channel creation requests are rejected
 func New(systemChannelID string, supportManager SupportManager, signer crypto.LocalSigner) *Processor {
-	support, ok := supportManager.GetChain(systemChannelID)
-	if !ok {
-		logger.Panicf("Supplied a SupportManager which did not contain a system channel")
+	var support Support
+	if systemChannelID != "" {
+		var ok bool
+		support, ok = supportManager.GetChain(systemChannelID)
+		if !ok {
+			logger.Panicf("Supplied a SupportManager which did not contain a system channel")
+		}
 	}
 
 	return &Processor{
@@ -131,6 +137,10 @@ func (p *Processor) proposeNewChannelToSystemChannel(newChannelEnvConfig *cb.Env
 }
 
 func (p *Processor) newChannelConfig(channelID string, envConfigUpdate *cb.Envelope) (*cb.Envelope, error) {
+	if p.systemChannelID == "" {
+		return nil, fmt.Errorf("Failing to create channel %s because this orderer has no system channel", channelID)
+	}
+
 	ctxm, err := p.manager.NewChannelConfig(envConfigUpdate)
 	if err != nil {
 		return nil, err
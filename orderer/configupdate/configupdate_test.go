@@ -167,3 +167,11 @@ func TestNewChannel(t *testing.T) {
 
 	assert.Equal(t, int32(cb.HeaderType_ORDERER_TRANSACTION), chdr.Type, "Wrong wrapper tx type")
 }
+
+func TestNewChannelWithoutSystemChannel(t *testing.T) {
+	msm := &mockSupportManager{}
+	p := New("", msm, mockcrypto.FakeLocalSigner)
+
+	_, err := p.Process(testConfigUpdate())
+	assert.Error(t, err, "Channel creation should fail without a system channel")
+}
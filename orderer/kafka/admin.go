@@ -0,0 +1,312 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kafka
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/Shopify/sarama"
+	localconfig "github.com/hyperledger/fabric/orderer/localconfig"
+)
+
+// The vendored sarama client does not implement the admin requests of the
+// Kafka protocol. The few the orderer needs to provision the topics of its
+// channels are encoded here, and sent over a connection of their own which
+// honors the TLS and SASL settings of the broker config.
+
+const (
+	saslHandshakeKey int16 = 17
+	createTopicsKey  int16 = 19
+
+	errTopicAlreadyExists sarama.KError = 36
+	errNotController      sarama.KError = 41
+)
+
+// provisionTopic creates the topic of the channel with the given options,
+// unless it exists already, and returns the replication factor of its
+// partition.
+func provisionTopic(brokers []string, brokerConfig *sarama.Config, options localconfig.Topic, channel channel) (int16, error) {
+	client, err := sarama.NewClient(brokers, brokerConfig)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+
+	configs := make(map[string]string, len(options.Configs))
+	for _, entry := range options.Configs {
+		configs[entry.Name] = entry.Value
+	}
+
+	// Topics can only be created by the controller, which the metadata
+	// returned to this client does not identify, so each broker is tried
+	var admin *adminConn
+	err = fmt.Errorf("no broker is known")
+	for _, broker := range client.Brokers() {
+		if admin, err = dialAdmin(broker.Addr(), brokerConfig); err != nil {
+			continue
+		}
+		err = admin.createTopic(channel.topic(), options.ReplicationFactor, configs)
+		if err == nil || err == errTopicAlreadyExists {
+			break
+		}
+		admin.close()
+		admin = nil
+		if err != errNotController {
+			return 0, fmt.Errorf("cannot create topic %s: %s", channel.topic(), err)
+		}
+		err = fmt.Errorf("broker %s is not the controller", broker.Addr())
+	}
+	if admin == nil {
+		return 0, fmt.Errorf("cannot create topic %s: %s", channel.topic(), err)
+	}
+	admin.close()
+
+	if err = client.RefreshMetadata(channel.topic()); err != nil {
+		return 0, err
+	}
+	replicas, err := client.Replicas(channel.topic(), channel.partition())
+	if err != nil {
+		return 0, err
+	}
+	return int16(len(replicas)), nil
+}
+
+type adminConn struct {
+	conn          net.Conn
+	brokerConfig  *sarama.Config
+	correlationID int32
+}
+
+func dialAdmin(addr string, brokerConfig *sarama.Config) (*adminConn, error) {
+	dialer := net.Dialer{
+		Timeout:   brokerConfig.Net.DialTimeout,
+		KeepAlive: brokerConfig.Net.KeepAlive,
+	}
+
+	var conn net.Conn
+	var err error
+	if brokerConfig.Net.TLS.Enable {
+		conn, err = tls.DialWithDialer(&dialer, "tcp", addr, brokerConfig.Net.TLS.Config)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	admin := &adminConn{conn: conn, brokerConfig: brokerConfig}
+	if brokerConfig.Net.SASL.Enable {
+		if err = admin.authenticate(); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return admin, nil
+}
+
+func (admin *adminConn) close() error {
+	return admin.conn.Close()
+}
+
+// authenticate performs a SASL/PLAIN exchange, the only mechanism supported
+// by the broker config.
+func (admin *adminConn) authenticate() error {
+	req := &adminEncoder{}
+	req.putString("PLAIN")
+	res, err := admin.roundTrip(saslHandshakeKey, 0, req.bytes())
+	if err != nil {
+		return err
+	}
+	if errCode := sarama.KError(res.getInt16()); res.err == nil && errCode != sarama.ErrNoError {
+		return fmt.Errorf("SASL handshake failed: %s", errCode)
+	}
+
+	token := "\x00" + admin.brokerConfig.Net.SASL.User + "\x00" + admin.brokerConfig.Net.SASL.Password
+	if err = admin.writeFrame([]byte(token)); err != nil {
+		return err
+	}
+	// A successful authentication is acknowledged with an empty response,
+	// otherwise the broker closes the connection
+	if _, err = admin.readFrame(); err != nil {
+		return fmt.Errorf("SASL authentication failed: %s", err)
+	}
+	return nil
+}
+
+// createTopic sends a CreateTopics request (v0) for a single topic with a
+// single partition, since a channel only uses its first partition.
+func (admin *adminConn) createTopic(topic string, replicationFactor int16, configs map[string]string) error {
+	req := &adminEncoder{}
+	req.putArrayLength(1)
+	req.putString(topic)
+	req.putInt32(1)
+	req.putInt16(replicationFactor)
+	req.putArrayLength(0) // The replicas are assigned by the controller
+	req.putArrayLength(len(configs))
+	for name, value := range configs {
+		req.putString(name)
+		req.putString(value)
+	}
+	req.putInt32(int32(admin.brokerConfig.Net.ReadTimeout / time.Millisecond))
+
+	res, err := admin.roundTrip(createTopicsKey, 0, req.bytes())
+	if err != nil {
+		return err
+	}
+	for i := res.getArrayLength(); i > 0 && res.err == nil; i-- {
+		name := res.getString()
+		errCode := sarama.KError(res.getInt16())
+		if res.err == nil && name == topic {
+			if errCode != sarama.ErrNoError {
+				return errCode
+			}
+			return nil
+		}
+	}
+	if res.err != nil {
+		return res.err
+	}
+	return fmt.Errorf("topic %s is missing from the CreateTopics response", topic)
+}
+
+// roundTrip sends a request with the given key, version and body, and returns
+// a decoder over the body of its response.
+func (admin *adminConn) roundTrip(key, version int16, body []byte) (*adminDecoder, error) {
+	admin.correlationID++
+
+	req := &adminEncoder{}
+	req.putInt16(key)
+	req.putInt16(version)
+	req.putInt32(admin.correlationID)
+	req.putString(admin.brokerConfig.ClientID)
+	if err := admin.writeFrame(append(req.bytes(), body...)); err != nil {
+		return nil, err
+	}
+
+	frame, err := admin.readFrame()
+	if err != nil {
+		return nil, err
+	}
+	res := &adminDecoder{buf: frame}
+	if correlationID := res.getInt32(); res.err == nil && correlationID != admin.correlationID {
+		return nil, fmt.Errorf("expected correlation ID %d, got %d", admin.correlationID, correlationID)
+	}
+	return res, res.err
+}
+
+func (admin *adminConn) writeFrame(payload []byte) error {
+	if err := admin.conn.SetWriteDeadline(time.Now().Add(admin.brokerConfig.Net.WriteTimeout)); err != nil {
+		return err
+	}
+	frame := make([]byte, 4+len(payload))
+	binary.BigEndian.PutUint32(frame, uint32(len(payload)))
+	copy(frame[4:], payload)
+	_, err := admin.conn.Write(frame)
+	return err
+}
+
+func (admin *adminConn) readFrame() ([]byte, error) {
+	if err := admin.conn.SetReadDeadline(time.Now().Add(admin.brokerConfig.Net.ReadTimeout)); err != nil {
+		return nil, err
+	}
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(admin.conn, header); err != nil {
+		return nil, err
+	}
+	length := int32(binary.BigEndian.Uint32(header))
+	if length < 0 || length > sarama.MaxResponseSize {
+		return nil, fmt.Errorf("invalid response length %d", length)
+	}
+	frame := make([]byte, length)
+	if _, err := io.ReadFull(admin.conn, frame); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+// adminEncoder writes the primitive types of the Kafka protocol.
+type adminEncoder struct {
+	buf []byte
+}
+
+func (e *adminEncoder) putInt16(v int16) {
+	e.buf = append(e.buf, byte(uint16(v)>>8), byte(v))
+}
+
+func (e *adminEncoder) putInt32(v int32) {
+	e.buf = append(e.buf, byte(uint32(v)>>24), byte(uint32(v)>>16), byte(uint32(v)>>8), byte(v))
+}
+
+func (e *adminEncoder) putString(v string) {
+	e.putInt16(int16(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+func (e *adminEncoder) putArrayLength(n int) {
+	e.putInt32(int32(n))
+}
+
+func (e *adminEncoder) bytes() []byte {
+	return e.buf
+}
+
+// adminDecoder reads the primitive types of the Kafka protocol. The first
+// error is kept, and every later read returns a zero value.
+type adminDecoder struct {
+	buf []byte
+	err error
+}
+
+func (d *adminDecoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n > len(d.buf) {
+		d.err = fmt.Errorf("insufficient data to decode response")
+		return nil
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b
+}
+
+func (d *adminDecoder) getInt16() int16 {
+	if b := d.next(2); b != nil {
+		return int16(binary.BigEndian.Uint16(b))
+	}
+	return 0
+}
+
+func (d *adminDecoder) getInt32() int32 {
+	if b := d.next(4); b != nil {
+		return int32(binary.BigEndian.Uint32(b))
+	}
+	return 0
+}
+
+// getString returns an empty string for a null one.
+func (d *adminDecoder) getString() string {
+	n := d.getInt16()
+	if n <= 0 {
+		return ""
+	}
+	return string(d.next(int(n)))
+}
+
+// getArrayLength returns zero for a null array.
+func (d *adminDecoder) getArrayLength() int {
+	n := d.getInt32()
+	if n <= 0 {
+		return 0
+	}
+	return int(n)
+}
@@ -0,0 +1,271 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kafka
+
+import (
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	localconfig "github.com/hyperledger/fabric/orderer/localconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupTopicForChannel(t *testing.T) {
+	mockChannel := newChannel(channelNameForTest(t), defaultPartition)
+	topicOptions := localconfig.Topic{
+		ReplicationFactor: 3,
+		Configs:           []localconfig.TopicConfig{{Name: "min.insync.replicas", Value: "2"}},
+	}
+
+	testCases := []struct {
+		name        string
+		createErr   sarama.KError
+		replicas    []int32
+		errContains string
+	}{
+		{
+			name:      "Created",
+			createErr: sarama.ErrNoError,
+			replicas:  []int32{1, 2, 3},
+		},
+		{
+			name:      "AlreadyExists",
+			createErr: errTopicAlreadyExists,
+			replicas:  []int32{1, 2, 3},
+		},
+		{
+			name:        "ReplicationFactorMismatch",
+			createErr:   errTopicAlreadyExists,
+			replicas:    []int32{1},
+			errContains: "replication factor of 1 instead of 3",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := newMockAdminBroker(t, tc.createErr)
+			defer controller.close()
+
+			// One of the brokers is not the controller, and must be skipped if
+			// it is tried first
+			follower := newMockAdminBroker(t, errNotController)
+			defer follower.close()
+
+			mockBroker := newMockTopicMetadataBroker(t, mockChannel, tc.replicas, follower, controller)
+			defer mockBroker.Close()
+
+			brokerConfig := newMockBrokerConfig(mockLocalConfig.General.TLS, mockLocalConfig.Kafka.Retry, sarama.V0_10_1_0, defaultPartition)
+			err := setupTopicForChannel(mockRetryOptions, make(chan struct{}), []string{mockBroker.Addr()}, brokerConfig, topicOptions, mockChannel)
+			if tc.errContains != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errContains)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			topics := controller.createdTopics()
+			require.Len(t, topics, 1)
+			assert.Equal(t, mockChannel.topic(), topics[0].name)
+			assert.Equal(t, int32(1), topics[0].partitions)
+			assert.Equal(t, int16(3), topics[0].replicationFactor)
+			assert.Equal(t, map[string]string{"min.insync.replicas": "2"}, topics[0].configs)
+			assert.True(t, len(follower.createdTopics()) <= 1)
+		})
+	}
+
+	t.Run("CreationFailed", func(t *testing.T) {
+		controller := newMockAdminBroker(t, sarama.KError(38)) // INVALID_REPLICATION_FACTOR
+		defer controller.close()
+		mockBroker := newMockTopicMetadataBroker(t, mockChannel, nil, controller)
+		defer mockBroker.Close()
+
+		brokerConfig := newMockBrokerConfig(mockLocalConfig.General.TLS, mockLocalConfig.Kafka.Retry, sarama.V0_10_1_0, defaultPartition)
+		_, err := provisionTopic([]string{mockBroker.Addr()}, brokerConfig, topicOptions, mockChannel)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot create topic")
+	})
+
+	t.Run("NoController", func(t *testing.T) {
+		follower := newMockAdminBroker(t, errNotController)
+		defer follower.close()
+		mockBroker := newMockTopicMetadataBroker(t, mockChannel, nil, follower)
+		defer mockBroker.Close()
+
+		brokerConfig := newMockBrokerConfig(mockLocalConfig.General.TLS, mockLocalConfig.Kafka.Retry, sarama.V0_10_1_0, defaultPartition)
+		_, err := provisionTopic([]string{mockBroker.Addr()}, brokerConfig, topicOptions, mockChannel)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "is not the controller")
+	})
+
+	t.Run("NoReplicationFactor", func(t *testing.T) {
+		// No broker is reachable, the topic must not even be looked up
+		brokerConfig := newMockBrokerConfig(mockLocalConfig.General.TLS, mockLocalConfig.Kafka.Retry, sarama.V0_10_1_0, defaultPartition)
+		err := setupTopicForChannel(mockRetryOptions, make(chan struct{}), []string{"127.0.0.1:0"}, brokerConfig, localconfig.Topic{}, mockChannel)
+		assert.NoError(t, err)
+	})
+
+	t.Run("OldKafkaVersion", func(t *testing.T) {
+		// No broker is reachable, the topic must not even be looked up
+		err := setupTopicForChannel(mockRetryOptions, make(chan struct{}), []string{"127.0.0.1:0"}, mockBrokerConfig, topicOptions, mockChannel)
+		assert.NoError(t, err)
+	})
+}
+
+func TestAdminConnSASL(t *testing.T) {
+	controller := newMockAdminBroker(t, sarama.ErrNoError)
+	defer controller.close()
+
+	brokerConfig := newBrokerConfig(localconfig.TLS{}, localconfig.SASL{Enabled: true, User: "orderer", Password: "secret"}, mockLocalConfig.Kafka.Retry, sarama.V0_10_1_0, defaultPartition)
+	assert.True(t, brokerConfig.Net.SASL.Enable)
+	assert.Equal(t, "orderer", brokerConfig.Net.SASL.User)
+	assert.Equal(t, "secret", brokerConfig.Net.SASL.Password)
+
+	admin, err := dialAdmin(controller.addr(), brokerConfig)
+	require.NoError(t, err)
+	defer admin.close()
+	assert.NoError(t, admin.createTopic("foo", 1, nil))
+
+	controller.lock.Lock()
+	defer controller.lock.Unlock()
+	assert.Equal(t, []string{"PLAIN"}, controller.mechanisms)
+	assert.Equal(t, []string{"\x00orderer\x00secret"}, controller.tokens)
+}
+
+// Test helper functions and mock objects defined here
+
+type mockCreatedTopic struct {
+	name              string
+	partitions        int32
+	replicationFactor int16
+	configs           map[string]string
+}
+
+// mockAdminBroker answers the admin requests which sarama.MockBroker does not
+// know about.
+type mockAdminBroker struct {
+	t         *testing.T
+	listener  net.Listener
+	createErr sarama.KError
+
+	lock       sync.Mutex
+	topics     []mockCreatedTopic
+	mechanisms []string
+	tokens     []string
+}
+
+func newMockAdminBroker(t *testing.T, createErr sarama.KError) *mockAdminBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	mb := &mockAdminBroker{t: t, listener: listener, createErr: createErr}
+	go mb.serve()
+	return mb
+}
+
+func (mb *mockAdminBroker) addr() string {
+	return mb.listener.Addr().String()
+}
+
+func (mb *mockAdminBroker) createdTopics() []mockCreatedTopic {
+	mb.lock.Lock()
+	defer mb.lock.Unlock()
+	return mb.topics
+}
+
+func (mb *mockAdminBroker) close() {
+	mb.listener.Close()
+}
+
+func (mb *mockAdminBroker) serve() {
+	for {
+		conn, err := mb.listener.Accept()
+		if err != nil {
+			return
+		}
+		go mb.handle(conn)
+	}
+}
+
+func (mb *mockAdminBroker) handle(conn net.Conn) {
+	defer conn.Close()
+	peer := &adminConn{conn: conn, brokerConfig: mockBrokerConfig}
+	authenticating := false
+	for {
+		frame, err := peer.readFrame()
+		if err != nil {
+			return
+		}
+		if authenticating {
+			mb.lock.Lock()
+			mb.tokens = append(mb.tokens, string(frame))
+			mb.lock.Unlock()
+			peer.writeFrame(nil)
+			authenticating = false
+			continue
+		}
+
+		req := &adminDecoder{buf: frame}
+		key := req.getInt16()
+		req.getInt16() // Version
+		correlationID := req.getInt32()
+		req.getString() // Client ID
+
+		res := &adminEncoder{}
+		res.putInt32(correlationID)
+		mb.lock.Lock()
+		switch key {
+		case saslHandshakeKey:
+			mb.mechanisms = append(mb.mechanisms, req.getString())
+			res.putInt16(int16(sarama.ErrNoError))
+			res.putArrayLength(1)
+			res.putString("PLAIN")
+			authenticating = true
+		case createTopicsKey:
+			req.getArrayLength()
+			topic := mockCreatedTopic{
+				name:              req.getString(),
+				partitions:        req.getInt32(),
+				replicationFactor: req.getInt16(),
+				configs:           make(map[string]string),
+			}
+			req.getArrayLength() // Replica assignment
+			for i := req.getArrayLength(); i > 0; i-- {
+				topic.configs[req.getString()] = req.getString()
+			}
+			mb.topics = append(mb.topics, topic)
+			res.putArrayLength(1)
+			res.putString(topic.name)
+			res.putInt16(int16(mb.createErr))
+		default:
+			mb.t.Errorf("Unexpected request key %d", key)
+		}
+		mb.lock.Unlock()
+		if req.err != nil {
+			mb.t.Errorf("Malformed request: %s", req.err)
+		}
+		peer.writeFrame(res.bytes())
+	}
+}
+
+// newMockTopicMetadataBroker returns a broker which serves the metadata of the
+// cluster made of the given admin brokers, whose partition for the channel is
+// replicated on the given replicas.
+func newMockTopicMetadataBroker(t *testing.T, channel channel, replicas []int32, adminBrokers ...*mockAdminBroker) *sarama.MockBroker {
+	metadataResponse := new(sarama.MetadataResponse)
+	for i, adminBroker := range adminBrokers {
+		metadataResponse.AddBroker(adminBroker.addr(), int32(i+1))
+	}
+	metadataResponse.AddTopicPartition(channel.topic(), channel.partition(), 1, replicas, replicas, sarama.ErrNoError)
+
+	mockBroker := sarama.NewMockBroker(t, 0)
+	mockBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockWrapper(metadataResponse),
+	})
+	return mockBroker
+}
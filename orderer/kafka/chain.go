@@ -9,10 +9,13 @@ package kafka
 import (
 	"fmt"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	localconfig "github.com/hyperledger/fabric/orderer/localconfig"
 	"github.com/hyperledger/fabric/orderer/multichain"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -46,13 +49,16 @@ func newChain(consenter commonConsenter, support multichain.ConsenterSupport, la
 	return &chainImpl{
 		consenter:           consenter,
 		support:             support,
+		kafkaVersion:        consenter.brokerConfig().Version,
 		channel:             newChannel(support.ChainID(), defaultPartition),
 		lastOffsetPersisted: lastOffsetPersisted,
+		lastOffsetConsumed:  lastOffsetPersisted,
 		lastCutBlockNumber:  lastCutBlockNumber,
 
 		errorChan: errorChan,
 		haltChan:  make(chan struct{}),
 		startChan: make(chan struct{}),
+		doneChan:  make(chan struct{}),
 	}, nil
 }
 
@@ -60,8 +66,11 @@ type chainImpl struct {
 	consenter commonConsenter
 	support   multichain.ConsenterSupport
 
+	kafkaVersion sarama.KafkaVersion // the version of the brokers, which decides whether messages carry timestamps
+
 	channel             channel
-	lastOffsetPersisted int64
+	lastOffsetPersisted int64 // updated atomically, as it is read by Status()
+	lastOffsetConsumed  int64 // updated atomically, as it is read by Status()
 	lastCutBlockNumber  uint64
 
 	producer        sarama.SyncProducer
@@ -77,6 +86,12 @@ type chainImpl struct {
 	haltChan chan struct{}
 	// // Close when the retriable steps in Start have completed.
 	startChan chan struct{}
+	// Serializes the closing of startChan and haltChan, so that the Kafka
+	// objects are closed by Halt() once set up, or by the thread launched by
+	// Start() if the chain is halted before then.
+	startLock sync.Mutex
+	// Close when the thread launched by Start exits.
+	doneChan chan struct{}
 }
 
 // Errored returns a channel which will close when a partition consumer error
@@ -85,6 +100,23 @@ func (chain *chainImpl) Errored() <-chan struct{} {
 	return chain.errorChan
 }
 
+// Done returns a channel which will close once the thread launched by Start()
+// has exited. Implements the multichain.Chain interface.
+func (chain *chainImpl) Done() <-chan struct{} {
+	return chain.doneChan
+}
+
+// Status reports the offsets of the chain in its partition. Implements the
+// multichain.StatusReporter interface.
+func (chain *chainImpl) Status(status *ab.ChannelStatus) {
+	status.Kafka = &ab.KafkaChannelStatus{
+		Topic:               chain.channel.topic(),
+		Partition:           chain.channel.partition(),
+		LastOffsetPersisted: atomic.LoadInt64(&chain.lastOffsetPersisted),
+		LastOffsetConsumed:  atomic.LoadInt64(&chain.lastOffsetConsumed),
+	}
+}
+
 // Start allocates the necessary resources for staying up to date with this
 // Chain. Implements the multichain.Chain interface. Called by
 // multichain.NewManagerImpl() which is invoked when the ordering process is
@@ -105,8 +137,13 @@ func (chain *chainImpl) Halt() {
 		logger.Warningf("[channel: %s] Halting of chain requested again", chain.support.ChainID())
 	default:
 		logger.Criticalf("[channel: %s] Halting of chain requested", chain.support.ChainID())
+		chain.startLock.Lock()
 		close(chain.haltChan)
-		chain.closeKafkaObjects() // Also close the producer and the consumer
+		started := chain.started()
+		chain.startLock.Unlock()
+		if started {
+			chain.closeKafkaObjects() // Also close the producer and the consumer
+		}
 		logger.Debugf("[channel: %s] Closed the haltChan", chain.support.ChainID())
 	}
 }
@@ -145,37 +182,74 @@ func (chain *chainImpl) Enqueue(env *cb.Envelope) bool {
 
 // Called by Start().
 func startThread(chain *chainImpl) {
+	defer close(chain.doneChan)
 	var err error
 
+	// If the chain is halted before the Start phase completes, close the Kafka
+	// objects set up so far, as Halt() leaves them to this thread
+	defer func() {
+		if !chain.started() {
+			chain.closeKafkaObjects()
+		}
+	}()
+
+	// Create the topic, which the producer would otherwise have the brokers
+	// create automatically with their default settings
+	err = setupTopicForChannel(chain.consenter.retryOptions(), chain.haltChan, chain.support.SharedConfig().KafkaBrokers(), chain.consenter.brokerConfig(), chain.consenter.topicOptions(), chain.channel)
+	if _, ok := err.(topicMismatchError); ok {
+		// Only this channel is affected, so it is left unavailable rather
+		// than taking the other channels of the orderer down with it
+		logger.Criticalf("[channel: %s] %s, the channel is unavailable until the topic is fixed", chain.channel.topic(), err)
+		return
+	}
+	if err != nil {
+		chain.startFailed("Cannot set up topic", err)
+		return
+	}
+	logger.Infof("[channel: %s] Topic set up successfully", chain.channel.topic())
+
 	// Set up the producer
 	chain.producer, err = setupProducerForChannel(chain.consenter.retryOptions(), chain.haltChan, chain.support.SharedConfig().KafkaBrokers(), chain.consenter.brokerConfig(), chain.channel)
 	if err != nil {
-		logger.Panicf("[channel: %s] Cannot set up producer = %s", chain.channel.topic(), err)
+		chain.startFailed("Cannot set up producer", err)
+		return
 	}
 	logger.Infof("[channel: %s] Producer set up successfully", chain.support.ChainID())
 
 	// Have the producer post the CONNECT message
 	if err = sendConnectMessage(chain.consenter.retryOptions(), chain.haltChan, chain.producer, chain.channel); err != nil {
-		logger.Panicf("[channel: %s] Cannot post CONNECT message = %s", chain.channel.topic(), err)
+		chain.startFailed("Cannot post CONNECT message", err)
+		return
 	}
 	logger.Infof("[channel: %s] CONNECT message posted successfully", chain.channel.topic())
 
 	// Set up the parent consumer
 	chain.parentConsumer, err = setupParentConsumerForChannel(chain.consenter.retryOptions(), chain.haltChan, chain.support.SharedConfig().KafkaBrokers(), chain.consenter.brokerConfig(), chain.channel)
 	if err != nil {
-		logger.Panicf("[channel: %s] Cannot set up parent consumer = %s", chain.channel.topic(), err)
+		chain.startFailed("Cannot set up parent consumer", err)
+		return
 	}
 	logger.Infof("[channel: %s] Parent consumer set up successfully", chain.channel.topic())
 
 	// Set up the channel consumer
 	chain.channelConsumer, err = setupChannelConsumerForChannel(chain.consenter.retryOptions(), chain.haltChan, chain.parentConsumer, chain.channel, chain.lastOffsetPersisted+1)
 	if err != nil {
-		logger.Panicf("[channel: %s] Cannot set up channel consumer = %s", chain.channel.topic(), err)
+		chain.startFailed("Cannot set up channel consumer", err)
+		return
 	}
 	logger.Infof("[channel: %s] Channel consumer set up successfully", chain.channel.topic())
 
+	chain.startLock.Lock()
+	select {
+	case <-chain.haltChan:
+		chain.startLock.Unlock()
+		logger.Warningf("[channel: %s] Consenter for channel halted before the start phase completed", chain.support.ChainID())
+		return
+	default:
+	}
 	close(chain.startChan)                // Broadcast requests will now go through
 	chain.errorChan = make(chan struct{}) // Deliver requests will also go through
+	chain.startLock.Unlock()
 
 	logger.Infof("[channel: %s] Start phase completed successfully", chain.channel.topic())
 
@@ -232,6 +306,7 @@ func (chain *chainImpl) processMessagesToBlocks() ([]uint64, error) {
 				logger.Infof("[channel: %s] Marked consenter as available again", chain.support.ChainID())
 			default:
 			}
+			atomic.StoreInt64(&chain.lastOffsetConsumed, in.Offset)
 			if err := proto.Unmarshal(in.Value, msg); err != nil {
 				// This shouldn't happen, it should be filtered at ingress
 				logger.Criticalf("[channel: %s] Unable to unmarshal consumed message = %s", chain.support.ChainID(), err)
@@ -241,12 +316,13 @@ func (chain *chainImpl) processMessagesToBlocks() ([]uint64, error) {
 				logger.Debugf("[channel: %s] Successfully unmarshalled consumed message, offset is %d. Inspecting type...", chain.support.ChainID(), in.Offset)
 				counts[indexRecvPass]++
 			}
+			lastCutBlockNumber := chain.lastCutBlockNumber
 			switch msg.Type.(type) {
 			case *ab.KafkaMessage_Connect:
 				_ = processConnect(chain.support.ChainID())
 				counts[indexProcessConnectPass]++
 			case *ab.KafkaMessage_TimeToCut:
-				if err := processTimeToCut(msg.GetTimeToCut(), chain.support, &chain.lastCutBlockNumber, &timer, in.Offset); err != nil {
+				if err := processTimeToCut(msg.GetTimeToCut(), chain.support, &chain.lastCutBlockNumber, &timer, in.Offset, in.Timestamp); err != nil {
 					logger.Warningf("[channel: %s] %s", chain.support.ChainID(), err)
 					logger.Criticalf("[channel: %s] Consenter for channel exiting", chain.support.ChainID())
 					counts[indexProcessTimeToCutError]++
@@ -254,13 +330,23 @@ func (chain *chainImpl) processMessagesToBlocks() ([]uint64, error) {
 				}
 				counts[indexProcessTimeToCutPass]++
 			case *ab.KafkaMessage_Regular:
-				if err := processRegular(msg.GetRegular(), chain.support, &timer, in.Offset, &chain.lastCutBlockNumber); err != nil {
+				if err := processRegular(msg.GetRegular(), chain.support, &timer, in.Offset, in.Timestamp, &chain.lastCutBlockNumber); err != nil {
 					logger.Warningf("[channel: %s] Error when processing incoming message of type REGULAR = %s", chain.support.ChainID(), err)
 					counts[indexProcessRegularError]++
 				} else {
 					counts[indexProcessRegularPass]++
 				}
 			}
+			if chain.lastCutBlockNumber != lastCutBlockNumber {
+				// The blocks just cut record this offset in their metadata
+				atomic.StoreInt64(&chain.lastOffsetPersisted, in.Offset)
+				// A config update may have set a transaction timestamp window
+				if err := checkTimestampWindow(chain.kafkaVersion, chain.support); err != nil {
+					logger.Criticalf("[channel: %s] %s", chain.support.ChainID(), err)
+					logger.Criticalf("[channel: %s] Consenter for channel exiting", chain.support.ChainID())
+					return counts, err
+				}
+			}
 		case <-timer:
 			if err := sendTimeToCut(chain.producer, chain.channel, chain.lastCutBlockNumber+1, &timer); err != nil {
 				logger.Errorf("[channel: %s] cannot post time-to-cut message = %s", chain.support.ChainID(), err)
@@ -273,31 +359,60 @@ func (chain *chainImpl) processMessagesToBlocks() ([]uint64, error) {
 	}
 }
 
+// started returns true once the Start phase has completed
+func (chain *chainImpl) started() bool {
+	select {
+	case <-chain.startChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// startFailed handles the failure of a step of the Start phase. The steps fail
+// when the chain is halted while they are retried, e.g. because the channel is
+// removed, in which case the chain exits quietly.
+func (chain *chainImpl) startFailed(step string, err error) {
+	select {
+	case <-chain.haltChan:
+		logger.Warningf("[channel: %s] Consenter for channel halted before the start phase completed: %s = %s", chain.support.ChainID(), step, err)
+	default:
+		logger.Panicf("[channel: %s] %s = %s", chain.channel.topic(), step, err)
+	}
+}
+
+// closeKafkaObjects closes the producer and the consumers which were set up
 func (chain *chainImpl) closeKafkaObjects() []error {
 	var errs []error
 
-	err := chain.channelConsumer.Close()
-	if err != nil {
-		logger.Errorf("[channel: %s] could not close channelConsumer cleanly = %s", chain.support.ChainID(), err)
-		errs = append(errs, err)
-	} else {
-		logger.Debugf("[channel: %s] Closed the channel consumer", chain.support.ChainID())
+	if chain.channelConsumer != nil {
+		err := chain.channelConsumer.Close()
+		if err != nil {
+			logger.Errorf("[channel: %s] could not close channelConsumer cleanly = %s", chain.support.ChainID(), err)
+			errs = append(errs, err)
+		} else {
+			logger.Debugf("[channel: %s] Closed the channel consumer", chain.support.ChainID())
+		}
 	}
 
-	err = chain.parentConsumer.Close()
-	if err != nil {
-		logger.Errorf("[channel: %s] could not close parentConsumer cleanly = %s", chain.support.ChainID(), err)
-		errs = append(errs, err)
-	} else {
-		logger.Debugf("[channel: %s] Closed the parent consumer", chain.support.ChainID())
+	if chain.parentConsumer != nil {
+		err := chain.parentConsumer.Close()
+		if err != nil {
+			logger.Errorf("[channel: %s] could not close parentConsumer cleanly = %s", chain.support.ChainID(), err)
+			errs = append(errs, err)
+		} else {
+			logger.Debugf("[channel: %s] Closed the parent consumer", chain.support.ChainID())
+		}
 	}
 
-	err = chain.producer.Close()
-	if err != nil {
-		logger.Errorf("[channel: %s] could not close producer cleanly = %s", chain.support.ChainID(), err)
-		errs = append(errs, err)
-	} else {
-		logger.Debugf("[channel: %s] Closed the producer", chain.support.ChainID())
+	if chain.producer != nil {
+		err := chain.producer.Close()
+		if err != nil {
+			logger.Errorf("[channel: %s] could not close producer cleanly = %s", chain.support.ChainID(), err)
+			errs = append(errs, err)
+		} else {
+			logger.Debugf("[channel: %s] Closed the producer", chain.support.ChainID())
+		}
 	}
 
 	return errs
@@ -322,6 +437,28 @@ func getLastOffsetPersisted(metadataValue []byte, chainID string) int64 {
 	return (sarama.OffsetOldest - 1) // default
 }
 
+// setBlockTimestamp replaces the local time the block was stamped with by the
+// timestamp Kafka recorded for the message which caused the block to be cut, so
+// that all the orderers write the same timestamp. Kafka versions prior to 0.10
+// do not record timestamps, in which case the block is left without one.
+func setBlockTimestamp(block *cb.Block, receivedTimestamp time.Time) {
+	if receivedTimestamp.IsZero() {
+		utils.SetBlockTimestamp(block, nil)
+		return
+	}
+	utils.SetBlockTimestamp(block, &timestamp.Timestamp{Seconds: receivedTimestamp.Unix(), Nanos: int32(receivedTimestamp.Nanosecond())})
+}
+
+// checkTimestampWindow ensures that the blocks of a channel setting a
+// transaction timestamp window are timestamped by Kafka. Otherwise the peers
+// would have no consensus timestamp to check the transactions against.
+func checkTimestampWindow(kafkaVersion sarama.KafkaVersion, support multichain.ConsenterSupport) error {
+	if support.ChannelConfig().TransactionTimestampWindow() == 0 || kafkaVersion.IsAtLeast(sarama.V0_10_0_0) {
+		return nil
+	}
+	return fmt.Errorf("channel %s sets a TransactionTimestampWindow, which requires Kafka.Version 0.10.0.0 or later", support.ChainID())
+}
+
 func newConnectMessage() *ab.KafkaMessage {
 	return &ab.KafkaMessage{
 		Type: &ab.KafkaMessage_Connect{
@@ -365,7 +502,7 @@ func processConnect(channelName string) error {
 	return nil
 }
 
-func processRegular(regularMessage *ab.KafkaMessageRegular, support multichain.ConsenterSupport, timer *<-chan time.Time, receivedOffset int64, lastCutBlockNumber *uint64) error {
+func processRegular(regularMessage *ab.KafkaMessageRegular, support multichain.ConsenterSupport, timer *<-chan time.Time, receivedOffset int64, receivedTimestamp time.Time, lastCutBlockNumber *uint64) error {
 	env := new(cb.Envelope)
 	if err := proto.Unmarshal(regularMessage.Payload, env); err != nil {
 		// This shouldn't happen, it should be filtered at ingress
@@ -385,6 +522,7 @@ func processRegular(regularMessage *ab.KafkaMessageRegular, support multichain.C
 		// offset to this function.
 		offset := receivedOffset - int64(len(batches)-i-1)
 		block := support.CreateNextBlock(batch)
+		setBlockTimestamp(block, receivedTimestamp)
 		encodedLastOffsetPersisted := utils.MarshalOrPanic(&ab.KafkaMetadata{LastOffsetPersisted: offset})
 		support.WriteBlock(block, committers[i], encodedLastOffsetPersisted)
 		*lastCutBlockNumber++
@@ -396,7 +534,7 @@ func processRegular(regularMessage *ab.KafkaMessageRegular, support multichain.C
 	return nil
 }
 
-func processTimeToCut(ttcMessage *ab.KafkaMessageTimeToCut, support multichain.ConsenterSupport, lastCutBlockNumber *uint64, timer *<-chan time.Time, receivedOffset int64) error {
+func processTimeToCut(ttcMessage *ab.KafkaMessageTimeToCut, support multichain.ConsenterSupport, lastCutBlockNumber *uint64, timer *<-chan time.Time, receivedOffset int64, receivedTimestamp time.Time) error {
 	ttcNumber := ttcMessage.GetBlockNumber()
 	logger.Debugf("[channel: %s] It's a time-to-cut message for block %d", support.ChainID(), ttcNumber)
 	if ttcNumber == *lastCutBlockNumber+1 {
@@ -408,6 +546,7 @@ func processTimeToCut(ttcMessage *ab.KafkaMessageTimeToCut, support multichain.C
 				" no pending requests though; this might indicate a bug", *lastCutBlockNumber+1)
 		}
 		block := support.CreateNextBlock(batch)
+		setBlockTimestamp(block, receivedTimestamp)
 		encodedLastOffsetPersisted := utils.MarshalOrPanic(&ab.KafkaMetadata{LastOffsetPersisted: receivedOffset})
 		support.WriteBlock(block, committers, encodedLastOffsetPersisted)
 		*lastCutBlockNumber++
@@ -480,6 +619,60 @@ func setupParentConsumerForChannel(retryOptions localconfig.Retry, haltChan chan
 	return parentConsumer, setupParentConsumer.retry()
 }
 
+// topicMismatchError is returned for an existing topic whose replication
+// factor does not match the topic options.
+type topicMismatchError struct {
+	topic    string
+	actual   int16
+	expected int16
+}
+
+func (e topicMismatchError) Error() string {
+	return fmt.Sprintf("topic %s has a replication factor of %d instead of %d", e.topic, e.actual, e.expected)
+}
+
+// Creates the topic of a channel through the Kafka admin API, unless it
+// exists already, using the given retry options. An existing topic whose
+// replication factor does not match the given topic options is an error.
+// Without a replication factor, the creation is left to the brokers.
+func setupTopicForChannel(retryOptions localconfig.Retry, haltChan chan struct{}, brokers []string, brokerConfig *sarama.Config, topicOptions localconfig.Topic, channel channel) error {
+	if topicOptions.ReplicationFactor == 0 {
+		logger.Debugf("[channel: %s] Topic replication factor unset, leaving topic creation to the brokers", channel.topic())
+		return nil
+	}
+
+	// CreateTopics requests were introduced in Kafka 0.10.1.0, earlier
+	// brokers only create topics automatically, on first use
+	if !brokerConfig.Version.IsAtLeast(sarama.V0_10_1_0) {
+		logger.Infof("[channel: %s] Kafka version does not support topic creation, skipping", channel.topic())
+		return nil
+	}
+
+	logger.Infof("[channel: %s] Setting up the topic for this channel...", channel.topic())
+
+	var replicationFactor int16
+	retryMsg := "Creating Kafka topic"
+	setupTopic := newRetryProcess(retryOptions, haltChan, channel, retryMsg, func() (err error) {
+		replicationFactor, err = provisionTopic(brokers, brokerConfig, topicOptions, channel)
+		return err
+	})
+	if err := setupTopic.retry(); err != nil {
+		return err
+	}
+
+	// Retrying would not help, a mismatching topic has to be fixed by hand
+	if replicationFactor != topicOptions.ReplicationFactor {
+		return topicMismatchError{topic: channel.topic(), actual: replicationFactor, expected: topicOptions.ReplicationFactor}
+	}
+	// Reading the settings of an existing topic takes a DescribeConfigs
+	// request, which requires Kafka 0.11.0.0, a version the Kafka client
+	// cannot be configured with
+	if len(topicOptions.Configs) > 0 {
+		logger.Infof("[channel: %s] Topic settings are only applied when the topic is created, not checked on an existing topic", channel.topic())
+	}
+	return nil
+}
+
 // Sets up the writer/producer for a channel using the given retry options.
 func setupProducerForChannel(retryOptions localconfig.Retry, haltChan chan struct{}, brokers []string, brokerConfig *sarama.Config, channel channel) (sarama.SyncProducer, error) {
 	var err error
@@ -14,6 +14,7 @@ import (
 	"github.com/Shopify/sarama"
 	"github.com/Shopify/sarama/mocks"
 	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	localconfig "github.com/hyperledger/fabric/orderer/localconfig"
 	mockblockcutter "github.com/hyperledger/fabric/orderer/mocks/blockcutter"
 	mockmultichain "github.com/hyperledger/fabric/orderer/mocks/multichain"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -195,6 +196,60 @@ func TestChain(t *testing.T) {
 		assert.Panics(t, func() { startThread(chain) }, "Expected the Start() call to panic")
 	})
 
+	t.Run("StartWithTopicMismatch", func(t *testing.T) {
+		mockChannel, mockBroker, mockSupport := newMocks(t)
+		defer func() { mockBroker.Close() }()
+
+		// The existing topic is replicated on a single broker instead of 3
+		controller := newMockAdminBroker(t, errTopicAlreadyExists)
+		defer controller.close()
+		metadataBroker := newMockTopicMetadataBroker(t, mockChannel, []int32{1}, controller)
+		defer metadataBroker.Close()
+		mockSupportCopy := *mockSupport
+		mockSupportCopy.SharedConfigVal = &mockconfig.Orderer{KafkaBrokersVal: []string{metadataBroker.Addr()}}
+
+		brokerConfig := newMockBrokerConfig(mockLocalConfig.General.TLS, mockLocalConfig.Kafka.Retry, sarama.V0_10_1_0, defaultPartition)
+		consenter := newMockConsenter(brokerConfig, mockLocalConfig.General.TLS, mockRetryOptions, sarama.V0_10_1_0)
+		consenter.topicOptionsVal = localconfig.Topic{ReplicationFactor: 3}
+		chain, _ := newChain(consenter, &mockSupportCopy, newestOffset-1)
+
+		assert.NotPanics(t, func() { startThread(chain) }, "A mismatching topic should only fail its channel")
+		assert.False(t, chain.started(), "The start phase should not have completed")
+		assert.False(t, chain.Enqueue(newMockEnvelope("fooMessage")), "Expected Enqueue call to return false")
+	})
+
+	t.Run("HaltBeforeStartCompletes", func(t *testing.T) {
+		mockChannel, mockBroker, mockSupport := newMocks(t)
+		defer func() { mockBroker.Close() }()
+
+		// Keep retrying the CONNECT message until the chain is halted
+		retryOptions := mockRetryOptions
+		retryOptions.LongTotal = longTimeout
+		consenter := newMockConsenter(mockBrokerConfig, mockLocalConfig.General.TLS, retryOptions, mockLocalConfig.Kafka.Version)
+		chain, _ := newChain(consenter, mockSupport, newestOffset-1)
+
+		mockBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+			"MetadataRequest": sarama.NewMockMetadataResponse(t).
+				SetBroker(mockBroker.Addr(), mockBroker.BrokerID()).
+				SetLeader(mockChannel.topic(), mockChannel.partition(), mockBroker.BrokerID()),
+			"ProduceRequest": sarama.NewMockProduceResponse(t).
+				SetError(mockChannel.topic(), mockChannel.partition(), sarama.ErrNotLeaderForPartition),
+		})
+
+		chain.Start()
+		time.Sleep(hitBranch)
+		assert.NotPanics(t, func() { chain.Halt() }, "Halting a chain which is starting shouldn't panic")
+
+		select {
+		case <-chain.Done():
+			logger.Debug("Start() exited as it should have")
+		case <-time.After(shortTimeout):
+			t.Fatal("Start() should have exited once the chain was halted")
+		}
+		assert.False(t, chain.started(), "The start phase should not have completed")
+		assert.False(t, chain.Enqueue(newMockEnvelope("fooMessage")), "Expected Enqueue call to return false")
+	})
+
 	t.Run("EnqueueIfNotStarted", func(t *testing.T) {
 		mockChannel, mockBroker, mockSupport := newMocks(t)
 		defer func() { mockBroker.Close() }()
@@ -528,6 +583,23 @@ func TestCloseKafkaObjects(t *testing.T) {
 	})
 }
 
+func TestChainStatus(t *testing.T) {
+	chain := &chainImpl{
+		channel:             newChannel("mockChannelFoo", defaultPartition),
+		lastOffsetPersisted: 40,
+		lastOffsetConsumed:  42,
+	}
+
+	status := &ab.ChannelStatus{ChannelId: "mockChannelFoo"}
+	chain.Status(status)
+	assert.Equal(t, &ab.KafkaChannelStatus{
+		Topic:               "mockChannelFoo",
+		Partition:           defaultPartition,
+		LastOffsetPersisted: 40,
+		LastOffsetConsumed:  42,
+	}, status.Kafka)
+}
+
 // Test helper functions here.
 
 func TestGetLastCutBlockNumber(t *testing.T) {
@@ -884,6 +956,10 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 		assert.Equal(t, uint64(1), counts[indexRecvPass], "Expected 1 message received and unmarshaled")
 		assert.Equal(t, uint64(1), counts[indexProcessRegularPass], "Expected 1 REGULAR message processed")
 		assert.Equal(t, lastCutBlockNumber+1, bareMinimumChain.lastCutBlockNumber, "Expected lastCutBlockNumber to be bumped up by one")
+
+		status := &ab.ChannelStatus{}
+		bareMinimumChain.Status(status)
+		assert.Equal(t, status.Kafka.LastOffsetConsumed, status.Kafka.LastOffsetPersisted, "Expected the offset of the message just cut to be persisted")
 	})
 
 	t.Run("ReceiveTwoRegularAndCutTwoBlocks", func(t *testing.T) {
@@ -24,13 +24,14 @@ func init() {
 }
 
 // New creates a Kafka-based consenter. Called by orderer's main.go.
-func New(tlsConfig localconfig.TLS, retryOptions localconfig.Retry, kafkaVersion sarama.KafkaVersion) multichain.Consenter {
-	brokerConfig := newBrokerConfig(tlsConfig, retryOptions, kafkaVersion, defaultPartition)
+func New(tlsConfig localconfig.TLS, saslConfig localconfig.SASL, retryOptions localconfig.Retry, kafkaVersion sarama.KafkaVersion, topicOptions localconfig.Topic) multichain.Consenter {
+	brokerConfig := newBrokerConfig(tlsConfig, saslConfig, retryOptions, kafkaVersion, defaultPartition)
 	return &consenterImpl{
 		brokerConfigVal: brokerConfig,
 		tlsConfigVal:    tlsConfig,
 		retryOptionsVal: retryOptions,
-		kafkaVersionVal: kafkaVersion}
+		kafkaVersionVal: kafkaVersion,
+		topicOptionsVal: topicOptions}
 }
 
 // consenterImpl holds the implementation of type that satisfies the
@@ -41,6 +42,7 @@ type consenterImpl struct {
 	tlsConfigVal    localconfig.TLS
 	retryOptionsVal localconfig.Retry
 	kafkaVersionVal sarama.KafkaVersion
+	topicOptionsVal localconfig.Topic
 }
 
 // HandleChain creates/returns a reference to a multichain.Chain object for the
@@ -49,6 +51,9 @@ type consenterImpl struct {
 // multichain.NewManagerImpl() when ranging over the ledgerFactory's
 // existingChains.
 func (consenter *consenterImpl) HandleChain(support multichain.ConsenterSupport, metadata *cb.Metadata) (multichain.Chain, error) {
+	if err := checkTimestampWindow(consenter.kafkaVersionVal, support); err != nil {
+		return nil, err
+	}
 	lastOffsetPersisted := getLastOffsetPersisted(metadata.Value, support.ChainID())
 	return newChain(consenter, support, lastOffsetPersisted)
 }
@@ -60,6 +65,7 @@ func (consenter *consenterImpl) HandleChain(support multichain.ConsenterSupport,
 type commonConsenter interface {
 	brokerConfig() *sarama.Config
 	retryOptions() localconfig.Retry
+	topicOptions() localconfig.Topic
 }
 
 func (consenter *consenterImpl) brokerConfig() *sarama.Config {
@@ -70,6 +76,10 @@ func (consenter *consenterImpl) retryOptions() localconfig.Retry {
 	return consenter.retryOptionsVal
 }
 
+func (consenter *consenterImpl) topicOptions() localconfig.Topic {
+	return consenter.topicOptionsVal
+}
+
 // closeable allows the shut down of the calling resource.
 type closeable interface {
 	close() error
@@ -59,11 +59,11 @@ func init() {
 }
 
 func TestNew(t *testing.T) {
-	_ = multichain.Consenter(New(mockLocalConfig.General.TLS, mockLocalConfig.Kafka.Retry, mockLocalConfig.Kafka.Version))
+	_ = multichain.Consenter(New(mockLocalConfig.General.TLS, mockLocalConfig.Kafka.SASL, mockLocalConfig.Kafka.Retry, mockLocalConfig.Kafka.Version, mockLocalConfig.Kafka.Topic))
 }
 
 func TestHandleChain(t *testing.T) {
-	consenter := multichain.Consenter(New(mockLocalConfig.General.TLS, mockLocalConfig.Kafka.Retry, mockLocalConfig.Kafka.Version))
+	consenter := multichain.Consenter(New(mockLocalConfig.General.TLS, mockLocalConfig.Kafka.SASL, mockLocalConfig.Kafka.Retry, mockLocalConfig.Kafka.Version, mockLocalConfig.Kafka.Topic))
 
 	oldestOffset := int64(0)
 	newestOffset := int64(5)
@@ -98,6 +98,27 @@ func TestHandleChain(t *testing.T) {
 	assert.NoError(t, err, "Expected the HandleChain call to return without errors")
 }
 
+func TestHandleChainTimestampWindow(t *testing.T) {
+	mockSupport := &mockmultichain.ConsenterSupport{
+		ChainIDVal:       channelNameForTest(t),
+		SharedConfigVal:  &mockconfig.Orderer{},
+		ChannelConfigVal: &mockconfig.Channel{TransactionTimestampWindowVal: time.Minute},
+	}
+	mockMetadata := &cb.Metadata{}
+
+	t.Run("KafkaWithoutTimestamps", func(t *testing.T) {
+		consenter := New(mockLocalConfig.General.TLS, mockLocalConfig.Kafka.SASL, mockLocalConfig.Kafka.Retry, sarama.V0_9_0_1, mockLocalConfig.Kafka.Topic)
+		_, err := consenter.HandleChain(mockSupport, mockMetadata)
+		assert.Error(t, err, "Expected the HandleChain call to fail as Kafka does not timestamp the messages")
+	})
+
+	t.Run("KafkaWithTimestamps", func(t *testing.T) {
+		consenter := New(mockLocalConfig.General.TLS, mockLocalConfig.Kafka.SASL, mockLocalConfig.Kafka.Retry, sarama.V0_10_0_0, mockLocalConfig.Kafka.Topic)
+		_, err := consenter.HandleChain(mockSupport, mockMetadata)
+		assert.NoError(t, err, "Expected the HandleChain call to return without errors")
+	})
+}
+
 // Test helper functions and mock objects defined here
 
 var mockConsenter commonConsenter
@@ -113,7 +134,7 @@ func extractEncodedOffset(marshalledOrdererMetadata []byte) int64 {
 }
 
 func newMockBrokerConfig(tlsConfig localconfig.TLS, retryOptions localconfig.Retry, kafkaVersion sarama.KafkaVersion, chosenStaticPartition int32) *sarama.Config {
-	brokerConfig := newBrokerConfig(tlsConfig, retryOptions, kafkaVersion, chosenStaticPartition)
+	brokerConfig := newBrokerConfig(tlsConfig, localconfig.SASL{}, retryOptions, kafkaVersion, chosenStaticPartition)
 	brokerConfig.ClientID = "test"
 	return brokerConfig
 }
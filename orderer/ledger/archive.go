@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Archiver takes over the files holding the blocks pruned from the ledgers
+type Archiver interface {
+	// Archive moves the file at path, which holds the blocks firstBlockNum to
+	// lastBlockNum of the given chain, out of the ledger
+	Archive(chainID string, path string, firstBlockNum, lastBlockNum uint64) error
+}
+
+type dirArchiver struct {
+	directory string
+}
+
+// NewDirArchiver returns an Archiver moving the files of each chain to a
+// sub-directory of directory, named after the blocks they hold
+func NewDirArchiver(directory string) Archiver {
+	return &dirArchiver{directory: directory}
+}
+
+// Archive moves the file at path to the directory of the chain
+func (da *dirArchiver) Archive(chainID string, path string, firstBlockNum, lastBlockNum uint64) error {
+	dir := filepath.Join(da.directory, chainID)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	target := filepath.Join(dir, fmt.Sprintf("blocks_%020d-%020d%s", firstBlockNum, lastBlockNum, filepath.Ext(path)))
+	if err := os.Rename(path, target); err == nil {
+		return nil
+	}
+
+	// The archive may be on another file system
+	if err := copyFile(path, target); err != nil {
+		os.Remove(target)
+		return err
+	}
+	return os.Remove(path)
+}
+
+func copyFile(source, target string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0640)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err = out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package fileledger
 
 import (
+	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
@@ -28,6 +30,10 @@ type fileLedgerFactory struct {
 	blkstorageProvider blkstorage.BlockStoreProvider
 	ledgers            map[string]ledger.ReadWriter
 	mutex              sync.Mutex
+	directory          string
+
+	policies   ledger.RetentionPolicies
+	policyLock sync.RWMutex
 }
 
 // GetOrCreate gets an existing ledger (if it exists) or creates it if it does not
@@ -46,9 +52,21 @@ func (flf *fileLedgerFactory) GetOrCreate(chainID string) (ledger.ReadWriter, er
 	if err != nil {
 		return nil, err
 	}
-	ledger = &fileLedger{blockStore: blockStore, signal: make(chan struct{})}
-	flf.ledgers[key] = ledger
-	return ledger, nil
+	fl := &fileLedger{
+		chainID:    chainID,
+		blockStore: blockStore,
+		signal:     make(chan struct{}),
+		policies:   flf.retentionPolicies,
+		keptDir:    filepath.Join(flf.directory, KeptBlocksDir, chainID),
+	}
+	if store, ok := blockStore.(blkstorage.ArchivableBlockStore); ok {
+		if fl.firstBlock, err = store.FirstBlockNumber(); err != nil {
+			blockStore.Shutdown()
+			return nil, err
+		}
+	}
+	flf.ledgers[key] = fl
+	return fl, nil
 }
 
 // ChainIDs returns the chain IDs the factory is aware of
@@ -60,8 +78,44 @@ func (flf *fileLedgerFactory) ChainIDs() []string {
 	return chainIDs
 }
 
+// Remove deletes the ledger of the given chainID along with its blocks
+func (flf *fileLedgerFactory) Remove(chainID string) error {
+	flf.mutex.Lock()
+	defer flf.mutex.Unlock()
+
+	if l, ok := flf.ledgers[chainID]; ok {
+		fl := l.(*fileLedger)
+		fl.pruneWG.Wait()
+		fl.blockStore.Shutdown()
+		delete(flf.ledgers, chainID)
+	}
+	if err := flf.blkstorageProvider.Remove(chainID); err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(flf.directory, KeptBlocksDir, chainID))
+}
+
+// SetRetentionPolicies sets the policies determining which blocks are pruned
+// from the ledgers as new blocks are appended to them
+func (flf *fileLedgerFactory) SetRetentionPolicies(policies ledger.RetentionPolicies) {
+	flf.policyLock.Lock()
+	defer flf.policyLock.Unlock()
+	flf.policies = policies
+}
+
+func (flf *fileLedgerFactory) retentionPolicies() ledger.RetentionPolicies {
+	flf.policyLock.RLock()
+	defer flf.policyLock.RUnlock()
+	return flf.policies
+}
+
 // Close releases all resources acquired by the factory
 func (flf *fileLedgerFactory) Close() {
+	flf.mutex.Lock()
+	for _, l := range flf.ledgers {
+		l.(*fileLedger).pruneWG.Wait()
+	}
+	flf.mutex.Unlock()
 	flf.blkstorageProvider.Close()
 }
 
@@ -73,6 +127,7 @@ func New(directory string) ledger.Factory {
 			&blkstorage.IndexConfig{
 				AttrsToIndex: []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum}},
 		),
-		ledgers: make(map[string]ledger.ReadWriter),
+		ledgers:   make(map[string]ledger.ReadWriter),
+		directory: directory,
 	}
 }
@@ -50,6 +50,18 @@ func (mbsp *mockBlockStoreProvider) List() ([]string, error) {
 	return mbsp.list, mbsp.error
 }
 
+func (mbsp *mockBlockStoreProvider) Remove(ledgerid string) error {
+	return mbsp.error
+}
+
+func (mbsp *mockBlockStoreProvider) DropIndex(ledgerid string) error {
+	return mbsp.error
+}
+
+func (mbsp *mockBlockStoreProvider) Rollback(ledgerid string, blockNum uint64) error {
+	return mbsp.error
+}
+
 func (mbsp *mockBlockStoreProvider) Close() {
 }
 
@@ -90,3 +102,23 @@ func TestMultiReinitialization(t *testing.T) {
 	assert.Equal(t, 3, len(flf.ChainIDs()), "Expected chain to be recovered")
 	flf.Close()
 }
+
+func TestRemove(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hyperledger_fabric")
+	assert.NoError(t, err, "Error creating temp dir: %s", err)
+
+	flf := New(dir)
+	defer flf.Close()
+	fl, err := flf.GetOrCreate("foo")
+	assert.NoError(t, err, "Error creating chain")
+	assert.NoError(t, fl.Append(ledger.CreateNextBlock(fl, nil)), "Error appending block")
+	_, err = flf.GetOrCreate("bar")
+	assert.NoError(t, err, "Error creating chain")
+
+	assert.NoError(t, flf.Remove("foo"), "Error removing chain")
+	assert.Equal(t, []string{"bar"}, flf.ChainIDs(), "Expected removed chain to be gone")
+
+	fl, err = flf.GetOrCreate("foo")
+	assert.NoError(t, err, "Error re-creating chain")
+	assert.Equal(t, uint64(0), fl.Height(), "Expected re-created chain to be empty")
+}
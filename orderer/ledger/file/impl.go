@@ -17,10 +17,16 @@ limitations under the License.
 package fileledger
 
 import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	ledger "github.com/hyperledger/fabric/orderer/ledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/op/go-logging"
 )
 
@@ -33,8 +39,25 @@ func init() {
 }
 
 type fileLedger struct {
+	// firstBlock is the number of the first block left in the block store, it
+	// is accessed atomically
+	firstBlock uint64
+	chainID    string
 	blockStore blkstorage.BlockStore
 	signal     chan struct{}
+	// policies returns the retention policies of the ledgers, blocks are never
+	// pruned when it is nil
+	policies func() ledger.RetentionPolicies
+	// keptDir holds the config blocks of the pruned block files
+	keptDir string
+
+	// pruneLock guards pruning and pruneAgain. A single prune runs at a time,
+	// in the background, and it runs again if blocks were appended meanwhile
+	pruneLock  sync.Mutex
+	pruning    bool
+	pruneAgain bool
+	// pruneWG is waited for before the block store is shut down
+	pruneWG sync.WaitGroup
 }
 
 type fileLedgerIterator struct {
@@ -47,12 +70,11 @@ type fileLedgerIterator struct {
 func (i *fileLedgerIterator) Next() (*cb.Block, cb.Status) {
 	for {
 		if i.blockNumber < i.ledger.Height() {
-			block, err := i.ledger.blockStore.RetrieveBlockByNumber(i.blockNumber)
-			if err != nil {
-				return nil, cb.Status_SERVICE_UNAVAILABLE
+			block, status := i.ledger.retrieveBlock(i.blockNumber)
+			if status == cb.Status_SUCCESS {
+				i.blockNumber++
 			}
-			i.blockNumber++
-			return block, cb.Status_SUCCESS
+			return block, status
 		}
 		<-i.ledger.signal
 	}
@@ -72,7 +94,8 @@ func (i *fileLedgerIterator) ReadyChan() <-chan struct{} {
 func (fl *fileLedger) Iterator(startPosition *ab.SeekPosition) (ledger.Iterator, uint64) {
 	switch start := startPosition.Type.(type) {
 	case *ab.SeekPosition_Oldest:
-		return &fileLedgerIterator{ledger: fl, blockNumber: 0}, 0
+		oldestBlockNumber := fl.oldestBlockNumber()
+		return &fileLedgerIterator{ledger: fl, blockNumber: oldestBlockNumber}, oldestBlockNumber
 	case *ab.SeekPosition_Newest:
 		info, err := fl.blockStore.GetBlockchainInfo()
 		if err != nil {
@@ -106,6 +129,153 @@ func (fl *fileLedger) Append(block *cb.Block) error {
 	if err == nil {
 		close(fl.signal)
 		fl.signal = make(chan struct{})
+		fl.schedulePrune()
 	}
 	return err
 }
+
+// Bootstrap appends block as the first block of an empty ledger
+func (fl *fileLedger) Bootstrap(block *cb.Block) error {
+	store, ok := fl.blockStore.(blkstorage.ArchivableBlockStore)
+	if !ok {
+		return fmt.Errorf("The block store of channel %s cannot start from block %d", fl.chainID, block.Header.Number)
+	}
+	if err := store.BootstrapFromBlock(block); err != nil {
+		return err
+	}
+	atomic.StoreUint64(&fl.firstBlock, block.Header.Number)
+	close(fl.signal)
+	fl.signal = make(chan struct{})
+	return nil
+}
+
+// schedulePrune prunes the ledger in the background, so that appending blocks
+// does not wait for the block files to be archived
+func (fl *fileLedger) schedulePrune() {
+	if fl.policies == nil {
+		return
+	}
+	fl.pruneLock.Lock()
+	defer fl.pruneLock.Unlock()
+	if fl.pruning {
+		fl.pruneAgain = true
+		return
+	}
+	fl.pruning = true
+	fl.pruneWG.Add(1)
+	go func() {
+		defer fl.pruneWG.Done()
+		for {
+			fl.prune()
+			fl.pruneLock.Lock()
+			if !fl.pruneAgain {
+				fl.pruning = false
+				fl.pruneLock.Unlock()
+				return
+			}
+			fl.pruneAgain = false
+			fl.pruneLock.Unlock()
+		}
+	}()
+}
+
+// retrieveBlock returns the given block from the block store, or from the
+// config blocks kept aside once it has been pruned
+func (fl *fileLedger) retrieveBlock(blockNumber uint64) (*cb.Block, cb.Status) {
+	if blockNumber >= fl.firstBlockNumber() {
+		block, err := fl.blockStore.RetrieveBlockByNumber(blockNumber)
+		if err == nil {
+			return block, cb.Status_SUCCESS
+		}
+		// The block may have been pruned since it was looked up
+		if blockNumber >= fl.firstBlockNumber() {
+			return nil, cb.Status_SERVICE_UNAVAILABLE
+		}
+	}
+	block, err := fl.keptBlock(blockNumber)
+	if err != nil {
+		logger.Errorf("[channel: %s] Could not read kept block [%d]: %s", fl.chainID, blockNumber, err)
+		return nil, cb.Status_SERVICE_UNAVAILABLE
+	}
+	if block == nil {
+		logger.Debugf("[channel: %s] Block [%d] has been pruned from the ledger", fl.chainID, blockNumber)
+		return nil, cb.Status_NOT_FOUND
+	}
+	return block, cb.Status_SUCCESS
+}
+
+// firstBlockNumber returns the number of the first block left in the block
+// store
+func (fl *fileLedger) firstBlockNumber() uint64 {
+	return atomic.LoadUint64(&fl.firstBlock)
+}
+
+// oldestBlockNumber returns the number of the oldest block which can be
+// retrieved, which is a kept config block once blocks have been pruned
+func (fl *fileLedger) oldestBlockNumber() uint64 {
+	oldest := fl.firstBlockNumber()
+	numbers, err := fl.keptBlockNumbers()
+	if err != nil {
+		logger.Errorf("[channel: %s] Could not list kept blocks: %s", fl.chainID, err)
+		return oldest
+	}
+	if len(numbers) > 0 && numbers[0] < oldest {
+		return numbers[0]
+	}
+	return oldest
+}
+
+// prune archives the block files which only hold blocks not retained by the
+// retention policy of the chain, after keeping their config blocks aside.
+// Failures are logged, the files are pruned again on the next append.
+func (fl *fileLedger) prune() {
+	if fl.policies == nil {
+		return
+	}
+	store, ok := fl.blockStore.(blkstorage.ArchivableBlockStore)
+	if !ok {
+		return
+	}
+	policies := fl.policies()
+	policy := policies.For(fl.chainID)
+	height := fl.Height()
+	if policy.RetainedBlocks == 0 || height <= policy.RetainedBlocks {
+		return
+	}
+
+	firstBlock, err := store.ArchiveBlockfiles(height-policy.RetainedBlocks, func(path string, firstBlockNum, lastBlockNum uint64) error {
+		if err := fl.keepConfigBlocks(firstBlockNum, lastBlockNum, policy.KeepConfigBlocks); err != nil {
+			return err
+		}
+		// The blocks of the file are no longer served from the block store
+		atomic.StoreUint64(&fl.firstBlock, lastBlockNum+1)
+		logger.Infof("[channel: %s] Pruning blocks [%d] to [%d] from the ledger", fl.chainID, firstBlockNum, lastBlockNum)
+		if policies.Archiver == nil {
+			return os.Remove(path)
+		}
+		return policies.Archiver.Archive(fl.chainID, path, firstBlockNum, lastBlockNum)
+	})
+	if err != nil {
+		logger.Errorf("[channel: %s] Could not prune the blocks below block [%d]: %s", fl.chainID, height-policy.RetainedBlocks, err)
+		return
+	}
+	atomic.StoreUint64(&fl.firstBlock, firstBlock)
+}
+
+// keepConfigBlocks keeps aside the config blocks among the given blocks, only
+// the latest one is kept unless keepAll is set
+func (fl *fileLedger) keepConfigBlocks(firstBlockNum, lastBlockNum uint64, keepAll bool) error {
+	for blockNumber := firstBlockNum; blockNumber <= lastBlockNum; blockNumber++ {
+		block, err := fl.blockStore.RetrieveBlockByNumber(blockNumber)
+		if err != nil {
+			return err
+		}
+		if !utils.IsConfigBlock(block) {
+			continue
+		}
+		if err = fl.keepBlock(block, !keepAll); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -20,14 +20,18 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
 	cl "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
 	"github.com/hyperledger/fabric/orderer/ledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 	logging "github.com/op/go-logging"
 	"github.com/stretchr/testify/assert"
 )
@@ -307,3 +311,136 @@ func TestBlockstoreError(t *testing.T) {
 		assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, status, "Expected service unavailable error")
 	}
 }
+
+func makeConfigEnvelope() *cb.Envelope {
+	return &cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{
+		Header: &cb.Header{ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{Type: int32(cb.HeaderType_CONFIG)})},
+	})}
+}
+
+func TestRetention(t *testing.T) {
+	testCases := []struct {
+		name             string
+		keepConfigBlocks bool
+		keptBlocks       []uint64
+	}{
+		{"LatestConfigBlock", false, []uint64{10}},
+		{"AllConfigBlocks", true, []uint64{0, 10}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, err := ioutil.TempDir("", "hyperledger_fabric")
+			assert.NoError(t, err, "Error creating temp dir: %s", err)
+			defer os.RemoveAll(name)
+			archiveDir := filepath.Join(name, "archive")
+
+			// Small block files, so that some of them are pruned
+			newFactory := func() ledger.Factory {
+				flf := &fileLedgerFactory{
+					blkstorageProvider: fsblkstorage.NewProvider(
+						fsblkstorage.NewConf(name, 1024),
+						&blkstorage.IndexConfig{AttrsToIndex: []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum}},
+					),
+					ledgers:   make(map[string]ledger.ReadWriter),
+					directory: name,
+				}
+				flf.SetRetentionPolicies(ledger.RetentionPolicies{
+					Default:  ledger.RetentionPolicy{RetainedBlocks: 5, KeepConfigBlocks: tc.keepConfigBlocks},
+					Archiver: ledger.NewDirArchiver(archiveDir),
+				})
+				return flf
+			}
+			flf := newFactory()
+			rw, err := flf.GetOrCreate(provisional.TestChainID)
+			assert.NoError(t, err, "Error GetOrCreate chain")
+			fl := rw.(*fileLedger)
+
+			assert.NoError(t, fl.Append(ledger.CreateNextBlock(fl, []*cb.Envelope{makeConfigEnvelope()})))
+			for i := 1; i < 30; i++ {
+				envelope := &cb.Envelope{Payload: make([]byte, 300)}
+				if i == 10 {
+					envelope = makeConfigEnvelope()
+				}
+				assert.NoError(t, fl.Append(ledger.CreateNextBlock(fl, []*cb.Envelope{envelope})))
+			}
+			// The blocks are pruned in the background
+			fl.pruneWG.Wait()
+
+			first := fl.firstBlockNumber()
+			assert.True(t, first > 10 && first <= 25, "Expected blocks up to block 10 to be pruned, first block is %d", first)
+			for number := first; number < 30; number++ {
+				assert.NotNil(t, ledger.GetBlock(fl, number), "Expected block %d to be retained", number)
+			}
+			for _, number := range tc.keptBlocks {
+				block := ledger.GetBlock(fl, number)
+				assert.NotNil(t, block, "Expected config block %d to be kept", number)
+				assert.True(t, utils.IsConfigBlock(block))
+			}
+			it, number := fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+			assert.Equal(t, tc.keptBlocks[0], number, "Expected oldest block to be the first kept block")
+			block, status := it.Next()
+			assert.Equal(t, cb.Status_SUCCESS, status)
+			assert.Equal(t, tc.keptBlocks[0], block.Header.Number)
+
+			it, _ = fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 1}}})
+			<-it.ReadyChan()
+			_, status = it.Next()
+			assert.Equal(t, cb.Status_NOT_FOUND, status, "Expected pruned block to be not found")
+
+			archived, err := ioutil.ReadDir(filepath.Join(archiveDir, provisional.TestChainID))
+			assert.NoError(t, err, "Error reading archive")
+			assert.NotEmpty(t, archived, "Expected pruned block files to be archived")
+
+			// The pruned blocks stay pruned on restart
+			flf.Close()
+			flf = newFactory()
+			defer flf.Close()
+			rw, err = flf.GetOrCreate(provisional.TestChainID)
+			assert.NoError(t, err, "Error GetOrCreate chain")
+			assert.Equal(t, first, rw.(*fileLedger).firstBlockNumber())
+			assert.Equal(t, uint64(30), rw.Height())
+			assert.NotNil(t, ledger.GetBlock(rw, 10), "Expected config block to be kept")
+
+			assert.NoError(t, flf.Remove(provisional.TestChainID))
+			_, err = os.Stat(filepath.Join(name, KeptBlocksDir, provisional.TestChainID))
+			assert.True(t, os.IsNotExist(err), "Expected kept blocks to be removed with the chain")
+		})
+	}
+}
+
+func TestBootstrap(t *testing.T) {
+	name, err := ioutil.TempDir("", "hyperledger_fabric")
+	assert.NoError(t, err)
+	defer os.RemoveAll(name)
+	flf := New(name)
+	chain, err := flf.GetOrCreate(provisional.TestChainID)
+	assert.NoError(t, err)
+	fl := chain.(*fileLedger)
+
+	block := cb.NewBlock(5, []byte("previous hash"))
+	block.Header.DataHash = block.Data.Hash()
+	assert.NoError(t, fl.Bootstrap(block))
+	assert.Equal(t, uint64(6), fl.Height())
+	assert.Error(t, fl.Bootstrap(block), "Bootstrapping a ledger holding blocks should fail")
+
+	it, num := fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+	assert.Equal(t, uint64(5), num)
+	got, status := it.Next()
+	assert.Equal(t, cb.Status_SUCCESS, status)
+	assert.Equal(t, uint64(5), got.Header.Number)
+	it, _ = fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 4}}})
+	_, status = it.Next()
+	assert.Equal(t, cb.Status_NOT_FOUND, status)
+
+	assert.NoError(t, fl.Append(ledger.CreateNextBlock(fl, []*cb.Envelope{&cb.Envelope{Payload: []byte("My Data")}})))
+
+	// The ledger starts from the bootstrap block on restart
+	flf.Close()
+	flf = New(name)
+	defer flf.Close()
+	chain, err = flf.GetOrCreate(provisional.TestChainID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), chain.Height())
+	assert.Equal(t, uint64(5), chain.(*fileLedger).firstBlockNumber())
+}
@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fileledger
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric/protos/common"
+)
+
+// KeptBlocksDir is the sub-directory of the ledger directory holding the
+// config blocks kept after their block files were pruned
+const KeptBlocksDir = "keptblocks"
+
+const keptBlockPrefix = "block_"
+
+// keepBlock saves a block aside before it is pruned, dropping the blocks kept
+// before it when replace is set
+func (fl *fileLedger) keepBlock(block *cb.Block, replace bool) error {
+	if err := os.MkdirAll(fl.keptDir, 0750); err != nil {
+		return err
+	}
+	blockBytes, err := proto.Marshal(block)
+	if err != nil {
+		return err
+	}
+	path := fl.keptBlockPath(block.Header.Number)
+	if err = ioutil.WriteFile(path+".tmp", blockBytes, 0640); err != nil {
+		return err
+	}
+	if err = os.Rename(path+".tmp", path); err != nil {
+		return err
+	}
+	if !replace {
+		return nil
+	}
+
+	numbers, err := fl.keptBlockNumbers()
+	if err != nil {
+		return err
+	}
+	for _, number := range numbers {
+		if number >= block.Header.Number {
+			break
+		}
+		if err = os.Remove(fl.keptBlockPath(number)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keptBlock returns the given kept block, or nil if it was not kept
+func (fl *fileLedger) keptBlock(blockNumber uint64) (*cb.Block, error) {
+	if fl.keptDir == "" {
+		return nil, nil
+	}
+	blockBytes, err := ioutil.ReadFile(fl.keptBlockPath(blockNumber))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	block := &cb.Block{}
+	if err = proto.Unmarshal(blockBytes, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// keptBlockNumbers returns the numbers of the kept blocks in ascending order
+func (fl *fileLedger) keptBlockNumbers() ([]uint64, error) {
+	if fl.keptDir == "" {
+		return nil, nil
+	}
+	infos, err := ioutil.ReadDir(fl.keptDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The file names are zero padded, and so sorted by block number
+	var numbers []uint64
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasPrefix(info.Name(), keptBlockPrefix) {
+			continue
+		}
+		number, err := strconv.ParseUint(strings.TrimPrefix(info.Name(), keptBlockPrefix), 10, 64)
+		if err != nil {
+			continue
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
+
+func (fl *fileLedger) keptBlockPath(blockNumber uint64) string {
+	return filepath.Join(fl.keptDir, fmt.Sprintf("%s%020d", keptBlockPrefix, blockNumber))
+}
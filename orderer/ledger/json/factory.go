@@ -31,6 +31,9 @@ type jsonLedgerFactory struct {
 	directory string
 	ledgers   map[string]ledger.ReadWriter
 	mutex     sync.Mutex
+
+	policies   ledger.RetentionPolicies
+	policyLock sync.RWMutex
 }
 
 // GetOrCreate gets an existing ledger (if it exists) or creates it if it does not
@@ -54,31 +57,38 @@ func (jlf *jsonLedgerFactory) GetOrCreate(chainID string) (ledger.ReadWriter, er
 		return nil, err
 	}
 
-	ch := newChain(directory)
+	ch := newChain(chainID, directory, jlf.retentionPolicies)
 	jlf.ledgers[key] = ch
 	return ch, nil
 }
 
 // newChain creates a new chain backed by a JSON ledger
-func newChain(directory string) ledger.ReadWriter {
+func newChain(chainID string, directory string, policies func() ledger.RetentionPolicies) ledger.ReadWriter {
 	jl := &jsonLedger{
+		chainID:   chainID,
 		directory: directory,
 		signal:    make(chan struct{}),
 		marshaler: &jsonpb.Marshaler{Indent: "  "},
+		policies:  policies,
 	}
 	jl.initializeBlockHeight()
 	logger.Debugf("Initialized to block height %d with hash %x", jl.height-1, jl.lastHash)
 	return jl
 }
 
-// initializeBlockHeight verifies that all blocks exist between 0 and the block
-// height, and populates the lastHash
+// initializeBlockHeight verifies that all blocks exist between the first block
+// not pruned and the block height, and populates the lastHash
 func (jl *jsonLedger) initializeBlockHeight() {
 	infos, err := ioutil.ReadDir(jl.directory)
 	if err != nil {
 		logger.Panic(err)
 	}
-	nextNumber := uint64(0)
+	first, err := jl.readFirstBlockNumber()
+	if err != nil {
+		logger.Panicf("Error reading the first block number: %s", err)
+	}
+	jl.firstBlock = first
+	nextNumber := first
 	for _, info := range infos {
 		if info.IsDir() {
 			continue
@@ -88,6 +98,10 @@ func (jl *jsonLedger) initializeBlockHeight() {
 		if err != nil {
 			continue
 		}
+		if number < first {
+			// A config block kept after the blocks around it were pruned
+			continue
+		}
 		if number != nextNumber {
 			logger.Panicf("Missing block %d in the chain", nextNumber)
 		}
@@ -122,6 +136,29 @@ func (jlf *jsonLedgerFactory) ChainIDs() []string {
 	return ids
 }
 
+// Remove deletes the directory holding the ledger of the given chainID
+func (jlf *jsonLedgerFactory) Remove(chainID string) error {
+	jlf.mutex.Lock()
+	defer jlf.mutex.Unlock()
+
+	delete(jlf.ledgers, chainID)
+	return os.RemoveAll(filepath.Join(jlf.directory, fmt.Sprintf(chainDirectoryFormatString, chainID)))
+}
+
+// SetRetentionPolicies sets the policies determining which blocks are pruned
+// from the ledgers as new blocks are appended to them
+func (jlf *jsonLedgerFactory) SetRetentionPolicies(policies ledger.RetentionPolicies) {
+	jlf.policyLock.Lock()
+	defer jlf.policyLock.Unlock()
+	jlf.policies = policies
+}
+
+func (jlf *jsonLedgerFactory) retentionPolicies() ledger.RetentionPolicies {
+	jlf.policyLock.RLock()
+	defer jlf.policyLock.RUnlock()
+	return jlf.policies
+}
+
 // Close is a no-op for the JSON ledger
 func (jlf *jsonLedgerFactory) Close() {
 	return // nothing to do
@@ -19,13 +19,18 @@ package jsonledger
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 
 	ledger "github.com/hyperledger/fabric/orderer/ledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/op/go-logging"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -43,6 +48,7 @@ func init() {
 const (
 	blockFileFormatString      = "block_%020d.json"
 	chainDirectoryFormatString = "chain_%s"
+	firstBlockFileName         = "first_block"
 )
 
 type cursor struct {
@@ -51,11 +57,18 @@ type cursor struct {
 }
 
 type jsonLedger struct {
-	directory string
-	height    uint64
-	signal    chan struct{}
-	lastHash  []byte
-	marshaler *jsonpb.Marshaler
+	// firstBlock is the number of the first block not pruned from the ledger,
+	// it is accessed atomically
+	firstBlock uint64
+	chainID    string
+	directory  string
+	height     uint64
+	signal     chan struct{}
+	lastHash   []byte
+	marshaler  *jsonpb.Marshaler
+	// policies returns the retention policies of the ledgers, blocks are never
+	// pruned when it is nil
+	policies func() ledger.RetentionPolicies
 }
 
 // readBlock returns the block or nil, and whether the block was found or not, (nil,true) generally indicates an irrecoverable problem
@@ -95,6 +108,10 @@ func (cu *cursor) Next() (*cb.Block, cb.Status) {
 			cu.blockNumber++
 			return block, cb.Status_SUCCESS
 		}
+		if cu.blockNumber < cu.jl.firstBlockNumber() {
+			logger.Debugf("[channel: %s] Block [%d] has been pruned from the ledger", cu.jl.chainID, cu.blockNumber)
+			return nil, cb.Status_NOT_FOUND
+		}
 		<-cu.jl.signal
 	}
 }
@@ -102,7 +119,7 @@ func (cu *cursor) Next() (*cb.Block, cb.Status) {
 // ReadyChan supplies a channel which will block until Next will not block
 func (cu *cursor) ReadyChan() <-chan struct{} {
 	signal := cu.jl.signal
-	if _, err := os.Stat(cu.jl.blockFilename(cu.blockNumber)); os.IsNotExist(err) {
+	if _, err := os.Stat(cu.jl.blockFilename(cu.blockNumber)); os.IsNotExist(err) && cu.blockNumber >= cu.jl.firstBlockNumber() {
 		return signal
 	}
 	return closedChan
@@ -113,7 +130,8 @@ func (cu *cursor) ReadyChan() <-chan struct{} {
 func (jl *jsonLedger) Iterator(startPosition *ab.SeekPosition) (ledger.Iterator, uint64) {
 	switch start := startPosition.Type.(type) {
 	case *ab.SeekPosition_Oldest:
-		return &cursor{jl: jl, blockNumber: 0}, 0
+		oldest := jl.oldestBlockNumber()
+		return &cursor{jl: jl, blockNumber: oldest}, oldest
 	case *ab.SeekPosition_Newest:
 		high := jl.height - 1
 		return &cursor{jl: jl, blockNumber: high}, high
@@ -147,9 +165,169 @@ func (jl *jsonLedger) Append(block *cb.Block) error {
 	jl.height++
 	close(jl.signal)
 	jl.signal = make(chan struct{})
+	jl.prune()
+	return nil
+}
+
+// Bootstrap appends block as the first block of an empty ledger
+func (jl *jsonLedger) Bootstrap(block *cb.Block) error {
+	if jl.height != 0 {
+		return fmt.Errorf("Cannot bootstrap a ledger holding %d blocks", jl.height)
+	}
+	if err := jl.writeFirstBlockNumber(block.Header.Number); err != nil {
+		return fmt.Errorf("Error recording the first block number: %s", err)
+	}
+
+	atomic.StoreUint64(&jl.firstBlock, block.Header.Number)
+	jl.writeBlock(block)
+	jl.lastHash = block.Header.Hash()
+	jl.height = block.Header.Number + 1
+	close(jl.signal)
+	jl.signal = make(chan struct{})
 	return nil
 }
 
+// firstBlockNumber returns the number of the first block not pruned from the
+// ledger
+func (jl *jsonLedger) firstBlockNumber() uint64 {
+	return atomic.LoadUint64(&jl.firstBlock)
+}
+
+// oldestBlockNumber returns the number of the oldest block which can be
+// retrieved, which is a kept config block once blocks have been pruned
+func (jl *jsonLedger) oldestBlockNumber() uint64 {
+	numbers, err := jl.keptBlockNumbers()
+	if err != nil {
+		logger.Errorf("[channel: %s] Could not list kept blocks: %s", jl.chainID, err)
+	}
+	if len(numbers) > 0 {
+		return numbers[0]
+	}
+	return jl.firstBlockNumber()
+}
+
+// keptBlockNumbers returns the numbers of the config blocks kept below the
+// first block, in ascending order
+func (jl *jsonLedger) keptBlockNumbers() ([]uint64, error) {
+	infos, err := ioutil.ReadDir(jl.directory)
+	if err != nil {
+		return nil, err
+	}
+	first := jl.firstBlockNumber()
+	var numbers []uint64
+	for _, info := range infos {
+		var number uint64
+		if _, err := fmt.Sscanf(info.Name(), blockFileFormatString, &number); err != nil || info.IsDir() {
+			continue
+		}
+		if number >= first {
+			break
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
+
+// prune archives the blocks not retained by the retention policy of the
+// chain, apart from the config blocks it keeps. Failures are logged, the
+// blocks are then left on the ledger.
+func (jl *jsonLedger) prune() {
+	if jl.policies == nil {
+		return
+	}
+	policies := jl.policies()
+	policy := policies.For(jl.chainID)
+	if policy.RetainedBlocks == 0 || jl.height <= policy.RetainedBlocks {
+		return
+	}
+	first := jl.firstBlockNumber()
+	cut := jl.height - policy.RetainedBlocks
+	if cut <= first {
+		return
+	}
+
+	// The blocks below the recorded first block are no longer expected on
+	// restart
+	if err := jl.writeFirstBlockNumber(cut); err != nil {
+		logger.Errorf("[channel: %s] Could not prune the blocks below block [%d]: %s", jl.chainID, cut, err)
+		return
+	}
+	atomic.StoreUint64(&jl.firstBlock, cut)
+	logger.Debugf("[channel: %s] Pruning blocks [%d] to [%d] from the ledger", jl.chainID, first, cut-1)
+	for number := first; number < cut; number++ {
+		if err := jl.pruneBlock(number, policy.KeepConfigBlocks, policies.Archiver); err != nil {
+			logger.Errorf("[channel: %s] Could not prune block [%d]: %s", jl.chainID, number, err)
+		}
+	}
+}
+
+// pruneBlock archives the given block unless it is a config block, which is
+// kept along with the ones before it when keepAll is set
+func (jl *jsonLedger) pruneBlock(number uint64, keepAll bool, archiver ledger.Archiver) error {
+	block, found := jl.readBlock(number)
+	if !found {
+		return nil
+	}
+	if block == nil {
+		return fmt.Errorf("error reading block %d", number)
+	}
+	if !utils.IsConfigBlock(block) {
+		return jl.archiveBlock(number, archiver)
+	}
+	if keepAll {
+		return nil
+	}
+	kept, err := jl.keptBlockNumbers()
+	if err != nil {
+		return err
+	}
+	for _, keptNumber := range kept {
+		if keptNumber >= number {
+			break
+		}
+		if err = jl.archiveBlock(keptNumber, archiver); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveBlock moves the file of a block to the archive, or deletes it when
+// there is no archiver
+func (jl *jsonLedger) archiveBlock(number uint64, archiver ledger.Archiver) error {
+	fileLock.Lock()
+	defer fileLock.Unlock()
+
+	name := jl.blockFilename(number)
+	if archiver == nil {
+		return os.Remove(name)
+	}
+	return archiver.Archive(jl.chainID, name, number, number)
+}
+
+// readFirstBlockNumber returns the number of the first block not pruned from
+// the ledger, as recorded on disk
+func (jl *jsonLedger) readFirstBlockNumber() (uint64, error) {
+	contents, err := ioutil.ReadFile(filepath.Join(jl.directory, firstBlockFileName))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(contents)), 10, 64)
+}
+
+// writeFirstBlockNumber records the number of the first block not pruned from
+// the ledger on disk
+func (jl *jsonLedger) writeFirstBlockNumber(number uint64) error {
+	name := filepath.Join(jl.directory, firstBlockFileName)
+	if err := ioutil.WriteFile(name+".tmp", []byte(strconv.FormatUint(number, 10)), 0600); err != nil {
+		return err
+	}
+	return os.Rename(name+".tmp", name)
+}
+
 // writeBlock commits a block to disk
 func (jl *jsonLedger) writeBlock(block *cb.Block) {
 	name := jl.blockFilename(block.Header.Number)
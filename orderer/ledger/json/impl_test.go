@@ -17,8 +17,10 @@ limitations under the License.
 package jsonledger
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -26,6 +28,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/ledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 
 	logging "github.com/op/go-logging"
 	"github.com/stretchr/testify/assert"
@@ -266,3 +269,105 @@ func TestInvalidAddition(t *testing.T) {
 		assert.Error(t, fl.Append(block), "Addition of block with invalid previousHash should fail")
 	}
 }
+
+func makeConfigEnvelope() *cb.Envelope {
+	return &cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{
+		Header: &cb.Header{ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{Type: int32(cb.HeaderType_CONFIG)})},
+	})}
+}
+
+func TestRetention(t *testing.T) {
+	testCases := []struct {
+		name             string
+		keepConfigBlocks bool
+		keptBlocks       []uint64
+	}{
+		{"LatestConfigBlock", false, []uint64{5}},
+		{"AllConfigBlocks", true, []uint64{0, 5}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, err := ioutil.TempDir("", "hyperledger_fabric")
+			assert.NoError(t, err, "Error creating temp dir: %s", err)
+			defer os.RemoveAll(name)
+			archiveDir := filepath.Join(name, "archive")
+
+			newFactory := func() ledger.Factory {
+				jlf := New(name)
+				jlf.SetRetentionPolicies(ledger.RetentionPolicies{
+					Default:  ledger.RetentionPolicy{RetainedBlocks: 3, KeepConfigBlocks: tc.keepConfigBlocks},
+					Archiver: ledger.NewDirArchiver(archiveDir),
+				})
+				return jlf
+			}
+			rw, err := newFactory().GetOrCreate(provisional.TestChainID)
+			assert.NoError(t, err, "Error GetOrCreate chain")
+			jl := rw.(*jsonLedger)
+
+			assert.NoError(t, jl.Append(ledger.CreateNextBlock(jl, []*cb.Envelope{makeConfigEnvelope()})))
+			for i := 1; i < 10; i++ {
+				envelope := &cb.Envelope{Payload: []byte("My Data")}
+				if i == 5 {
+					envelope = makeConfigEnvelope()
+				}
+				assert.NoError(t, jl.Append(ledger.CreateNextBlock(jl, []*cb.Envelope{envelope})))
+			}
+
+			assert.Equal(t, uint64(7), jl.firstBlockNumber())
+			for number := uint64(7); number < 10; number++ {
+				assert.NotNil(t, ledger.GetBlock(jl, number), "Expected block %d to be retained", number)
+			}
+			for _, number := range tc.keptBlocks {
+				assert.NotNil(t, ledger.GetBlock(jl, number), "Expected config block %d to be kept", number)
+			}
+			_, number := jl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+			assert.Equal(t, tc.keptBlocks[0], number, "Expected oldest block to be the first kept block")
+
+			it, _ := jl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 1}}})
+			<-it.ReadyChan()
+			_, status := it.Next()
+			assert.Equal(t, cb.Status_NOT_FOUND, status, "Expected pruned block to be not found")
+
+			_, err = os.Stat(filepath.Join(archiveDir, provisional.TestChainID, fmt.Sprintf("blocks_%020d-%020d.json", 1, 1)))
+			assert.NoError(t, err, "Expected pruned block to be archived")
+
+			// The chain is loaded again despite the pruned blocks
+			rw, err = newFactory().GetOrCreate(provisional.TestChainID)
+			assert.NoError(t, err, "Error GetOrCreate chain")
+			assert.Equal(t, uint64(10), rw.Height())
+			assert.Equal(t, uint64(7), rw.(*jsonLedger).firstBlockNumber())
+		})
+	}
+}
+
+func TestBootstrap(t *testing.T) {
+	name, err := ioutil.TempDir("", "hyperledger_fabric")
+	assert.NoError(t, err)
+	defer os.RemoveAll(name)
+	chain, err := New(name).GetOrCreate(provisional.TestChainID)
+	assert.NoError(t, err)
+	jl := chain.(*jsonLedger)
+
+	block := cb.NewBlock(5, []byte("previous hash"))
+	assert.NoError(t, jl.Bootstrap(block))
+	assert.Equal(t, uint64(6), jl.Height())
+	assert.Error(t, jl.Bootstrap(block), "Bootstrapping a ledger holding blocks should fail")
+
+	it, num := jl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+	assert.Equal(t, uint64(5), num)
+	got, status := it.Next()
+	assert.Equal(t, cb.Status_SUCCESS, status)
+	assert.Equal(t, uint64(5), got.Header.Number)
+	it, _ = jl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 4}}})
+	_, status = it.Next()
+	assert.Equal(t, cb.Status_NOT_FOUND, status)
+
+	assert.NoError(t, jl.Append(ledger.CreateNextBlock(jl, []*cb.Envelope{&cb.Envelope{Payload: []byte("My Data")}})))
+
+	// The ledger starts from the bootstrap block on restart
+	chain, err = New(name).GetOrCreate(provisional.TestChainID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), chain.Height())
+	assert.Equal(t, uint64(5), chain.(*jsonLedger).firstBlockNumber())
+}
@@ -30,10 +30,45 @@ type Factory interface {
 	// ChainIDs returns the chain IDs the Factory is aware of
 	ChainIDs() []string
 
+	// Remove deletes the ledger of the given chainID and all of its blocks
+	Remove(chainID string) error
+
+	// SetRetentionPolicies sets the policies determining which blocks are
+	// pruned from the ledgers as new blocks are appended to them
+	SetRetentionPolicies(policies RetentionPolicies)
+
 	// Close releases all resources acquired by the factory
 	Close()
 }
 
+// RetentionPolicy determines the blocks kept by a ledger. Only the last
+// RetainedBlocks blocks are kept, along with every config block when
+// KeepConfigBlocks is set, or with the latest config block otherwise since it
+// is needed to load the chain. A RetainedBlocks of 0 keeps every block.
+// Implementations may keep more blocks than the policy requires, for instance
+// to prune whole block files at once.
+type RetentionPolicy struct {
+	RetainedBlocks   uint64
+	KeepConfigBlocks bool
+}
+
+// RetentionPolicies holds the retention policies of specific chains, and the
+// default one of the other chains. The files holding pruned blocks are passed
+// to the Archiver, or deleted when it is nil.
+type RetentionPolicies struct {
+	Default  RetentionPolicy
+	Chains   map[string]RetentionPolicy
+	Archiver Archiver
+}
+
+// For returns the retention policy of the given chainID
+func (rp RetentionPolicies) For(chainID string) RetentionPolicy {
+	if policy, ok := rp.Chains[chainID]; ok {
+		return policy
+	}
+	return rp.Default
+}
+
 // Iterator is useful for a chain Reader to stream blocks as they are created
 type Iterator interface {
 	// Next blocks until there is a new block available, or returns an error if
@@ -58,6 +93,15 @@ type Writer interface {
 	Append(block *cb.Block) error
 }
 
+// Bootstrapper is implemented by the ledgers which can start from a block
+// other than the genesis block, such as the latest config block of a channel
+// joined after it was created
+type Bootstrapper interface {
+	// Bootstrap appends block as the first block of an empty ledger, the
+	// blocks preceding it are reported as pruned
+	Bootstrap(block *cb.Block) error
+}
+
 // ReadWriter encapsulates the read/write functions of the ledger
 type ReadWriter interface {
 	Reader
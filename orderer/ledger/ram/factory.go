@@ -27,6 +27,9 @@ type ramLedgerFactory struct {
 	maxSize int
 	ledgers map[string]ledger.ReadWriter
 	mutex   sync.Mutex
+
+	policies   ledger.RetentionPolicies
+	policyLock sync.RWMutex
 }
 
 // GetOrCreate gets an existing ledger (if it exists) or creates it if it does not
@@ -41,13 +44,13 @@ func (rlf *ramLedgerFactory) GetOrCreate(chainID string) (ledger.ReadWriter, err
 		return l, nil
 	}
 
-	ch := newChain(rlf.maxSize)
+	ch := newChain(chainID, rlf.maxSize, rlf.retentionPolicies)
 	rlf.ledgers[key] = ch
 	return ch, nil
 }
 
 // newChain creates a new chain backed by a RAM ledger
-func newChain(maxSize int) ledger.ReadWriter {
+func newChain(chainID string, maxSize int, policies func() ledger.RetentionPolicies) ledger.ReadWriter {
 	preGenesis := &cb.Block{
 		Header: &cb.BlockHeader{
 			Number: ^uint64(0),
@@ -55,8 +58,10 @@ func newChain(maxSize int) ledger.ReadWriter {
 	}
 
 	rl := &ramLedger{
-		maxSize: maxSize,
-		size:    1,
+		chainID:  chainID,
+		maxSize:  maxSize,
+		policies: policies,
+		size:     1,
 		oldest: &simpleList{
 			signal: make(chan struct{}),
 			block:  preGenesis,
@@ -81,6 +86,30 @@ func (rlf *ramLedgerFactory) ChainIDs() []string {
 	return ids
 }
 
+// Remove forgets the ledger of the given chainID
+func (rlf *ramLedgerFactory) Remove(chainID string) error {
+	rlf.mutex.Lock()
+	defer rlf.mutex.Unlock()
+
+	delete(rlf.ledgers, chainID)
+	return nil
+}
+
+// SetRetentionPolicies sets the policies determining which blocks are pruned
+// from the ledgers as new blocks are appended to them. The RAM ledger drops
+// the pruned blocks, no Archiver is used.
+func (rlf *ramLedgerFactory) SetRetentionPolicies(policies ledger.RetentionPolicies) {
+	rlf.policyLock.Lock()
+	defer rlf.policyLock.Unlock()
+	rlf.policies = policies
+}
+
+func (rlf *ramLedgerFactory) retentionPolicies() ledger.RetentionPolicies {
+	rlf.policyLock.RLock()
+	defer rlf.policyLock.RUnlock()
+	return rlf.policies
+}
+
 // Close is a no-op for the RAM ledger
 func (rlf *ramLedgerFactory) Close() {
 	return // nothing to do
@@ -19,14 +19,22 @@ package ramledger
 import (
 	"bytes"
 	"fmt"
+	"sync"
 
 	"github.com/hyperledger/fabric/orderer/ledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/op/go-logging"
 )
 
 var logger = logging.MustGetLogger("orderer/ramledger")
+var closedChan chan struct{}
+
+func init() {
+	closedChan = make(chan struct{})
+	close(closedChan)
+}
 
 type cursor struct {
 	list *simpleList
@@ -38,11 +46,27 @@ type simpleList struct {
 	block  *cb.Block
 }
 
+// keptCursor returns a config block kept after the blocks around it were
+// pruned, and then carries on with the blocks following it
+type keptCursor struct {
+	rl     *ramLedger
+	number uint64
+	block  *cb.Block
+	next   ledger.Iterator
+}
+
 type ramLedger struct {
+	chainID string
 	maxSize int
 	size    int
 	oldest  *simpleList
 	newest  *simpleList
+	// policies returns the retention policies of the ledgers, it is nil when
+	// only maxSize applies
+	policies func() ledger.RetentionPolicies
+
+	keptLock sync.RWMutex
+	kept     map[uint64]*cb.Block
 }
 
 // Next blocks until there is a new block available, or returns an error if the
@@ -63,12 +87,40 @@ func (cu *cursor) ReadyChan() <-chan struct{} {
 	return cu.list.signal
 }
 
+// Next returns the kept block, and then the blocks following it
+func (kc *keptCursor) Next() (*cb.Block, cb.Status) {
+	if kc.block != nil {
+		block := kc.block
+		kc.block = nil
+		return block, cb.Status_SUCCESS
+	}
+	return kc.nextIterator().Next()
+}
+
+// ReadyChan supplies a channel which will block until Next will not block
+func (kc *keptCursor) ReadyChan() <-chan struct{} {
+	if kc.block != nil {
+		return closedChan
+	}
+	return kc.nextIterator().ReadyChan()
+}
+
+func (kc *keptCursor) nextIterator() ledger.Iterator {
+	if kc.next == nil {
+		kc.next, _ = kc.rl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: kc.number + 1}}})
+	}
+	return kc.next
+}
+
 // Iterator returns an Iterator, as specified by a cb.SeekInfo message, and its
 // starting block number
 func (rl *ramLedger) Iterator(startPosition *ab.SeekPosition) (ledger.Iterator, uint64) {
 	var list *simpleList
 	switch start := startPosition.Type.(type) {
 	case *ab.SeekPosition_Oldest:
+		if block := rl.oldestKeptBlock(); block != nil {
+			return &keptCursor{rl: rl, number: block.Header.Number, block: block}, block.Header.Number
+		}
 		oldest := rl.oldest
 		list = &simpleList{
 			block:  &cb.Block{Header: &cb.BlockHeader{Number: oldest.block.Header.Number - 1}},
@@ -89,6 +141,10 @@ func (rl *ramLedger) Iterator(startPosition *ab.SeekPosition) (ledger.Iterator,
 		specified := start.Specified.Number
 		logger.Debugf("Attempting to return block %d", specified)
 
+		if block := rl.keptBlock(specified); block != nil && specified+1 < oldest.block.Header.Number+1 {
+			return &keptCursor{rl: rl, number: specified, block: block}, specified
+		}
+
 		// Note the two +1's here is to accommodate the 'preGenesis' block of ^uint64(0)
 		if specified+1 < oldest.block.Header.Number+1 || specified > rl.newest.block.Header.Number+1 {
 			logger.Debugf("Returning error iterator because specified seek was %d with oldest %d and newest %d",
@@ -149,6 +205,21 @@ func (rl *ramLedger) Append(block *cb.Block) error {
 	return nil
 }
 
+// Bootstrap appends block as the first block of an empty ledger
+func (rl *ramLedger) Bootstrap(block *cb.Block) error {
+	if rl.Height() != 0 {
+		return fmt.Errorf("Cannot bootstrap a ledger holding %d blocks", rl.Height())
+	}
+
+	// The pre-genesis placeholder takes the number preceding block, and is
+	// dropped once block is appended
+	rl.newest.block = &cb.Block{Header: &cb.BlockHeader{Number: block.Header.Number - 1}}
+	rl.appendBlock(block)
+	rl.oldest = rl.newest
+	rl.size = 1
+	return nil
+}
+
 func (rl *ramLedger) appendBlock(block *cb.Block) {
 	rl.newest.next = &simpleList{
 		signal: make(chan struct{}),
@@ -162,10 +233,56 @@ func (rl *ramLedger) appendBlock(block *cb.Block) {
 
 	rl.size++
 
-	if rl.size > rl.maxSize {
+	// Config blocks are only kept when a retention policy applies, the
+	// history size alone drops every block
+	maxSize := rl.maxSize
+	var policy ledger.RetentionPolicy
+	if rl.policies != nil {
+		policy = rl.policies().For(rl.chainID)
+		if policy.RetainedBlocks > 0 && policy.RetainedBlocks < uint64(maxSize) {
+			maxSize = int(policy.RetainedBlocks)
+		}
+	}
+
+	for rl.size > maxSize && rl.oldest != rl.newest {
 		logger.Debugf("RAM ledger max size about to be exceeded, removing oldest item: %d",
 			rl.oldest.block.Header.Number)
+		if policy.RetainedBlocks > 0 && utils.IsConfigBlock(rl.oldest.block) {
+			rl.keepBlock(rl.oldest.block, !policy.KeepConfigBlocks)
+		}
 		rl.oldest = rl.oldest.next
 		rl.size--
 	}
 }
+
+// keepBlock keeps a config block removed from the ledger, dropping the blocks
+// kept before it when replace is set
+func (rl *ramLedger) keepBlock(block *cb.Block, replace bool) {
+	rl.keptLock.Lock()
+	defer rl.keptLock.Unlock()
+	if replace || rl.kept == nil {
+		rl.kept = make(map[uint64]*cb.Block)
+	}
+	rl.kept[block.Header.Number] = block
+}
+
+// keptBlock returns the given kept block, or nil if it was not kept
+func (rl *ramLedger) keptBlock(number uint64) *cb.Block {
+	rl.keptLock.RLock()
+	defer rl.keptLock.RUnlock()
+	return rl.kept[number]
+}
+
+// oldestKeptBlock returns the kept block with the lowest number, or nil if no
+// block was kept
+func (rl *ramLedger) oldestKeptBlock() *cb.Block {
+	rl.keptLock.RLock()
+	defer rl.keptLock.RUnlock()
+	var oldest *cb.Block
+	for number, block := range rl.kept {
+		if oldest == nil || number < oldest.Header.Number {
+			oldest = block
+		}
+	}
+	return oldest
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/ledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 
 	logging "github.com/op/go-logging"
 )
@@ -208,3 +209,93 @@ func TestAppendBadBLock(t *testing.T) {
 		}
 	})
 }
+
+func makeConfigEnvelope() *cb.Envelope {
+	return &cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{
+		Header: &cb.Header{ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{Type: int32(cb.HeaderType_CONFIG)})},
+	})}
+}
+
+// TestRetention ensures that the retention policy limits the blocks stored
+// below maxSize, and that the latest config block is kept
+func TestRetention(t *testing.T) {
+	rlf := New(100)
+	rlf.SetRetentionPolicies(ledger.RetentionPolicies{Default: ledger.RetentionPolicy{RetainedBlocks: 3}})
+	chain, err := rlf.GetOrCreate(provisional.TestChainID)
+	if err != nil {
+		t.Fatalf("Error creating chain: %s", err)
+	}
+	rl := chain.(*ramLedger)
+	rl.Append(ledger.CreateNextBlock(rl, []*cb.Envelope{makeConfigEnvelope()}))
+	for i := 1; i < 10; i++ {
+		envelope := &cb.Envelope{Payload: []byte("My Data")}
+		if i == 5 {
+			envelope = makeConfigEnvelope()
+		}
+		rl.Append(ledger.CreateNextBlock(rl, []*cb.Envelope{envelope}))
+	}
+
+	if rl.oldest.block.Header.Number != 7 {
+		t.Fatalf("Oldest block should be 7, but is %d", rl.oldest.block.Header.Number)
+	}
+	if block := ledger.GetBlock(rl, 0); block != nil {
+		t.Fatalf("Config block 0 should have been replaced by config block 5")
+	}
+
+	it, num := rl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+	if num != 5 {
+		t.Fatalf("Oldest block should be the kept config block 5, but is %d", num)
+	}
+	block, status := it.Next()
+	if status != cb.Status_SUCCESS || block.Header.Number != 5 {
+		t.Fatalf("Expected to retrieve the kept config block 5")
+	}
+	if _, status = it.Next(); status != cb.Status_NOT_FOUND {
+		t.Fatalf("Expected pruned block 6 to be not found, got %v", status)
+	}
+
+	it, _ = rl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 6}}})
+	if _, status = it.Next(); status != cb.Status_NOT_FOUND {
+		t.Fatalf("Expected pruned block 6 to be not found, got %v", status)
+	}
+	it, _ = rl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 8}}})
+	if block, status = it.Next(); status != cb.Status_SUCCESS || block.Header.Number != 8 {
+		t.Fatalf("Expected to retrieve retained block 8")
+	}
+}
+
+func TestBootstrap(t *testing.T) {
+	rl := New(3).(*ramLedgerFactory)
+	chain, _ := rl.GetOrCreate(provisional.TestChainID)
+	l := chain.(*ramLedger)
+
+	block := cb.NewBlock(5, []byte("previous hash"))
+	if err := l.Bootstrap(block); err != nil {
+		t.Fatalf("Error bootstrapping the ledger: %s", err)
+	}
+	if l.Height() != 6 {
+		t.Fatalf("Expected height 6, got %d", l.Height())
+	}
+	if err := l.Bootstrap(block); err == nil {
+		t.Fatalf("Bootstrapping a ledger holding blocks should fail")
+	}
+
+	it, num := l.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+	if num != 5 {
+		t.Fatalf("Expected the oldest block to be 5, got %d", num)
+	}
+	if got, status := it.Next(); status != cb.Status_SUCCESS || got.Header.Number != 5 {
+		t.Fatalf("Expected to retrieve block 5, got status %v", status)
+	}
+	it, _ = l.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 4}}})
+	if _, status := it.Next(); status != cb.Status_NOT_FOUND {
+		t.Fatalf("Expected the block preceding the bootstrap block to be NOT_FOUND, got %v", status)
+	}
+
+	if err := l.Append(ledger.CreateNextBlock(l, []*cb.Envelope{&cb.Envelope{Payload: []byte("My Data")}})); err != nil {
+		t.Fatalf("Error appending to the bootstrapped ledger: %s", err)
+	}
+	if l.Height() != 7 {
+		t.Fatalf("Expected height 7, got %d", l.Height())
+	}
+}
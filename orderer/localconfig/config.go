@@ -60,10 +60,15 @@ func init() {
 // modify the default mapping, see the "Unmarshal"
 // section of https://github.com/spf13/viper for more info
 type TopLevel struct {
-	General    General
-	FileLedger FileLedger
-	RAMLedger  RAMLedger
-	Kafka      Kafka
+	General              General
+	FileLedger           FileLedger
+	RAMLedger            RAMLedger
+	Kafka                Kafka
+	ChannelParticipation ChannelParticipation
+	Broadcast            Broadcast
+	Filters              Filters
+	Retention            Retention
+	Admin                Admin
 }
 
 // General contains config which should be common among all orderer types.
@@ -90,6 +95,9 @@ type TLS struct {
 	RootCAs           []string
 	ClientAuthEnabled bool
 	ClientRootCAs     []string
+	// ReloadInterval is the interval at which the certificate and private key
+	// files of the gRPC server are checked for changes, 0 disables the checks
+	ReloadInterval time.Duration
 }
 
 // Profile contains configuration for Go pprof profiling.
@@ -100,8 +108,9 @@ type Profile struct {
 
 // FileLedger contains configuration for the file-based ledger.
 type FileLedger struct {
-	Location string
-	Prefix   string
+	Location        string
+	Prefix          string
+	ArchiveLocation string
 }
 
 // RAMLedger contains configuration for the RAM ledger.
@@ -115,6 +124,97 @@ type Kafka struct {
 	Verbose bool
 	Version sarama.KafkaVersion // TODO Move this to global config
 	TLS     TLS
+	SASL    SASL
+	Topic   Topic
+}
+
+// SASL contains the credentials the orderer uses to authenticate to the Kafka
+// cluster with SASL/PLAIN, the only mechanism the Kafka client supports.
+type SASL struct {
+	Enabled  bool
+	User     string
+	Password string
+}
+
+// Topic contains the settings of the topics the orderer creates for its
+// channels on the Kafka cluster. A zero ReplicationFactor leaves the creation
+// of the topics to the brokers.
+type Topic struct {
+	ReplicationFactor int16
+	Configs           []TopicConfig
+}
+
+// TopicConfig is a topic-level Kafka configuration entry, such as
+// min.insync.replicas.
+type TopicConfig struct {
+	Name  string
+	Value string
+}
+
+// ChannelParticipation contains configuration for the channel participation
+// REST API, through which channels are joined and removed.
+type ChannelParticipation struct {
+	Enabled            bool
+	ListenAddress      string
+	MaxRequestBodySize uint32
+	TLS                TLS
+}
+
+// Broadcast contains configuration for the Broadcast service.
+type Broadcast struct {
+	RateLimits RateLimits
+}
+
+// RateLimits contains the token bucket limits applied to the envelopes
+// submitted through Broadcast, channels may override them in their config.
+type RateLimits struct {
+	Channel RateLimit
+	MSP     RateLimit
+	Client  RateLimit
+}
+
+// RateLimit contains the sustained rate, in envelopes per second, and the
+// burst of a limit. A rate of 0 disables the limit.
+type RateLimit struct {
+	Rate  uint32
+	Burst uint32
+}
+
+// Filters contains the custom filter rules applied to the messages submitted
+// through Broadcast, in addition to the standard ones. The Default rules apply
+// to the channels which are not listed in Channels.
+type Filters struct {
+	Default  []FilterRule
+	Channels map[string][]FilterRule
+}
+
+// FilterRule enables a registered filter rule by name and sets its parameters.
+type FilterRule struct {
+	Name   string
+	Params map[string]string
+}
+
+// Retention contains the policies determining which blocks are pruned from the
+// ledgers. The Default policy applies to the channels which are not listed in
+// Channels.
+type Retention struct {
+	Default  RetentionPolicy
+	Channels map[string]RetentionPolicy
+}
+
+// RetentionPolicy contains the number of most recent blocks kept on a ledger,
+// 0 keeping every block, and whether every config block is kept along with
+// them rather than only the latest one.
+type RetentionPolicy struct {
+	RetainedBlocks   uint64
+	KeepConfigBlocks bool
+}
+
+// Admin contains configuration for the Admin service, served alongside the
+// AtomicBroadcast service to the admins of the local MSP.
+type Admin struct {
+	Enabled    bool
+	TimeWindow time.Duration
 }
 
 // Retry contains configuration related to retries and timeouts when the
@@ -212,6 +312,18 @@ var defaults = TopLevel{
 		TLS: TLS{
 			Enabled: false,
 		},
+		SASL: SASL{
+			Enabled: false,
+		},
+	},
+	ChannelParticipation: ChannelParticipation{
+		Enabled:            false,
+		ListenAddress:      "127.0.0.1:7059",
+		MaxRequestBodySize: 1024 * 1024,
+	},
+	Admin: Admin{
+		Enabled:    false,
+		TimeWindow: 15 * time.Minute,
 	},
 }
 
@@ -251,6 +363,9 @@ func (c *TopLevel) completeInitialization(configDir string) {
 		cf.TranslatePathInPlace(configDir, &c.General.TLS.Certificate)
 		cf.TranslatePathInPlace(configDir, &c.General.GenesisFile)
 		cf.TranslatePathInPlace(configDir, &c.General.LocalMSPDir)
+		c.ChannelParticipation.TLS.ClientRootCAs = translateCAs(configDir, c.ChannelParticipation.TLS.ClientRootCAs)
+		cf.TranslatePathInPlace(configDir, &c.ChannelParticipation.TLS.PrivateKey)
+		cf.TranslatePathInPlace(configDir, &c.ChannelParticipation.TLS.Certificate)
 	}()
 
 	for {
@@ -283,6 +398,12 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			logger.Panicf("General.Kafka.TLS.PrivateKey must be set if General.Kafka.TLS.Enabled is set to true.")
 		case c.Kafka.TLS.Enabled && c.Kafka.TLS.RootCAs == nil:
 			logger.Panicf("General.Kafka.TLS.CertificatePool must be set if General.Kafka.TLS.Enabled is set to true.")
+		case c.Kafka.SASL.Enabled && c.Kafka.SASL.User == "":
+			logger.Panicf("Kafka.SASL.User must be set if Kafka.SASL.Enabled is set to true.")
+		case c.Kafka.Topic.ReplicationFactor < 0:
+			logger.Panicf("Kafka.Topic.ReplicationFactor must not be negative, got %d.", c.Kafka.Topic.ReplicationFactor)
+		case c.Kafka.Topic.ReplicationFactor == 0 && len(c.Kafka.Topic.Configs) > 0:
+			logger.Panicf("Kafka.Topic.ReplicationFactor must be set if Kafka.Topic.Configs is set, as topics are not created otherwise.")
 
 		case c.General.Profile.Enabled && c.General.Profile.Address == "":
 			logger.Infof("Profiling enabled and General.Profile.Address unset, setting to %s", defaults.General.Profile.Address)
@@ -340,6 +461,23 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			logger.Infof("Kafka.Retry.Consumer.RetryBackoff unset, setting to %v", defaults.Kafka.Retry.Consumer.RetryBackoff)
 			c.Kafka.Retry.Consumer.RetryBackoff = defaults.Kafka.Retry.Consumer.RetryBackoff
 
+		case c.ChannelParticipation.Enabled && c.ChannelParticipation.ListenAddress == "":
+			logger.Infof("ChannelParticipation.ListenAddress unset, setting to %s", defaults.ChannelParticipation.ListenAddress)
+			c.ChannelParticipation.ListenAddress = defaults.ChannelParticipation.ListenAddress
+		case c.ChannelParticipation.Enabled && c.ChannelParticipation.MaxRequestBodySize == 0:
+			logger.Infof("ChannelParticipation.MaxRequestBodySize unset, setting to %d", defaults.ChannelParticipation.MaxRequestBodySize)
+			c.ChannelParticipation.MaxRequestBodySize = defaults.ChannelParticipation.MaxRequestBodySize
+		case c.ChannelParticipation.TLS.Enabled && c.ChannelParticipation.TLS.Certificate == "":
+			logger.Panicf("ChannelParticipation.TLS.Certificate must be set if ChannelParticipation.TLS.Enabled is set to true.")
+		case c.ChannelParticipation.TLS.Enabled && c.ChannelParticipation.TLS.PrivateKey == "":
+			logger.Panicf("ChannelParticipation.TLS.PrivateKey must be set if ChannelParticipation.TLS.Enabled is set to true.")
+		case c.General.GenesisMethod == "none" && !c.ChannelParticipation.Enabled:
+			logger.Panicf("General.GenesisMethod may only be set to none if ChannelParticipation.Enabled is set to true.")
+
+		case c.Admin.Enabled && c.Admin.TimeWindow == 0:
+			logger.Infof("Admin.TimeWindow unset, setting to %v", defaults.Admin.TimeWindow)
+			c.Admin.TimeWindow = defaults.Admin.TimeWindow
+
 		case c.Kafka.Version == sarama.KafkaVersion{}:
 			logger.Infof("Kafka.Version unset, setting to %v", defaults.Kafka.Version)
 			c.Kafka.Version = defaults.Kafka.Version
@@ -21,6 +21,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -111,8 +112,144 @@ func TestKafkaTLSConfig(t *testing.T) {
 	}
 }
 
+func TestKafkaSASLConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		sasl        SASL
+		shouldPanic bool
+	}{
+		{"Disabled", SASL{Enabled: false}, false},
+		{"Enabled", SASL{Enabled: true, User: "orderer", Password: "secret"}, false},
+		{"EnabledNoUser", SASL{Enabled: true, Password: "secret"}, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uconf := &TopLevel{Kafka: Kafka{SASL: tc.sasl}}
+			if tc.shouldPanic {
+				assert.Panics(t, func() { uconf.completeInitialization(DummyPath) }, "should panic")
+			} else {
+				assert.NotPanics(t, func() { uconf.completeInitialization(DummyPath) }, "should not panic")
+			}
+		})
+	}
+}
+
+func TestKafkaTopicConfig(t *testing.T) {
+	uconf := &TopLevel{}
+	uconf.completeInitialization(DummyPath)
+	assert.Equal(t, int16(0), uconf.Kafka.Topic.ReplicationFactor, "Expected replication factor to be left unset")
+
+	uconf = &TopLevel{Kafka: Kafka{Topic: Topic{ReplicationFactor: -1}}}
+	assert.Panics(t, func() { uconf.completeInitialization(DummyPath) }, "should panic")
+
+	uconf = &TopLevel{Kafka: Kafka{Topic: Topic{Configs: []TopicConfig{{Name: "min.insync.replicas", Value: "2"}}}}}
+	assert.Panics(t, func() { uconf.completeInitialization(DummyPath) }, "should panic")
+}
+
 func TestProfileConfig(t *testing.T) {
 	uconf := &TopLevel{General: General{Profile: Profile{Enabled: true}}}
 	uconf.completeInitialization(DummyPath)
 	assert.Equal(t, defaults.General.Profile.Address, uconf.General.Profile.Address, "Expected profile address to be filled with default value")
 }
+
+func TestAdminConfig(t *testing.T) {
+	uconf := &TopLevel{Admin: Admin{Enabled: true}}
+	uconf.completeInitialization(DummyPath)
+	assert.Equal(t, defaults.Admin.TimeWindow, uconf.Admin.TimeWindow, "Expected time window to be filled with default value")
+}
+
+func TestChannelParticipationConfig(t *testing.T) {
+	uconf := &TopLevel{ChannelParticipation: ChannelParticipation{Enabled: true}}
+	uconf.completeInitialization(DummyPath)
+	assert.Equal(t, defaults.ChannelParticipation.ListenAddress, uconf.ChannelParticipation.ListenAddress, "Expected listen address to be filled with default value")
+	assert.Equal(t, defaults.ChannelParticipation.MaxRequestBodySize, uconf.ChannelParticipation.MaxRequestBodySize, "Expected max request body size to be filled with default value")
+
+	testCases := []struct {
+		name        string
+		conf        TopLevel
+		shouldPanic bool
+	}{
+		{"NoGenesisWithParticipation", TopLevel{General: General{GenesisMethod: "none"}, ChannelParticipation: ChannelParticipation{Enabled: true}}, false},
+		{"NoGenesisWithoutParticipation", TopLevel{General: General{GenesisMethod: "none"}}, true},
+		{"TLSNoPrivateKey", TopLevel{ChannelParticipation: ChannelParticipation{Enabled: true, TLS: TLS{Enabled: true, Certificate: "public.key"}}}, true},
+		{"TLSNoPublicKey", TopLevel{ChannelParticipation: ChannelParticipation{Enabled: true, TLS: TLS{Enabled: true, PrivateKey: "private.key"}}}, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uconf := tc.conf
+			if tc.shouldPanic {
+				assert.Panics(t, func() { uconf.completeInitialization(DummyPath) }, "should panic")
+			} else {
+				assert.NotPanics(t, func() { uconf.completeInitialization(DummyPath) }, "should not panic")
+			}
+		})
+	}
+}
+
+func TestFiltersConfig(t *testing.T) {
+	name, err := ioutil.TempDir("", "hyperledger_fabric")
+	assert.Nil(t, err, "Error creating temp dir: %s", err)
+	defer os.RemoveAll(name)
+
+	sample, err := ioutil.ReadFile(filepath.Join("..", "..", "sampleconfig", "orderer.yaml"))
+	assert.NoError(t, err, "Error reading sample config")
+	filters := `
+Filters:
+    Default:
+        - Name: CertExpiry
+          Params:
+              MinValidity: 2h
+        - Name: TimestampWindow
+    Channels:
+        mychannel:
+            - Name: MaxProposalPayloadSize
+              Params:
+                  MaxBytes: 1024
+        otherchannel: []
+`
+	yaml := strings.Replace(string(sample), "\nFilters:", "\nIgnoredFilters:", 1)
+	yaml = yaml[:strings.Index(yaml, "\nIgnoredFilters:")] + filters
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(name, "orderer.yaml"), []byte(yaml), 0600))
+
+	os.Setenv("FABRIC_CFG_PATH", name)
+	defer os.Unsetenv("FABRIC_CFG_PATH")
+	config := Load()
+
+	assert.Equal(t, []FilterRule{
+		{Name: "CertExpiry", Params: map[string]string{"MinValidity": "2h"}},
+		{Name: "TimestampWindow"},
+	}, config.Filters.Default)
+	assert.Equal(t, map[string][]FilterRule{
+		"mychannel":    {{Name: "MaxProposalPayloadSize", Params: map[string]string{"MaxBytes": "1024"}}},
+		"otherchannel": {},
+	}, config.Filters.Channels)
+}
+
+func TestRetentionConfig(t *testing.T) {
+	name, err := ioutil.TempDir("", "hyperledger_fabric")
+	assert.Nil(t, err, "Error creating temp dir: %s", err)
+	defer os.RemoveAll(name)
+
+	sample, err := ioutil.ReadFile(filepath.Join("..", "..", "sampleconfig", "orderer.yaml"))
+	assert.NoError(t, err, "Error reading sample config")
+	retention := `
+Retention:
+    Default:
+        RetainedBlocks: 1000
+        KeepConfigBlocks: true
+    Channels:
+        mychannel:
+            RetainedBlocks: 10
+`
+	yaml := string(sample)
+	yaml = yaml[:strings.Index(yaml, "\nRetention:")] + retention
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(name, "orderer.yaml"), []byte(yaml), 0600))
+
+	os.Setenv("FABRIC_CFG_PATH", name)
+	defer os.Unsetenv("FABRIC_CFG_PATH")
+	config := Load()
+
+	assert.Equal(t, RetentionPolicy{RetainedBlocks: 1000, KeepConfigBlocks: true}, config.Retention.Default)
+	assert.Equal(t, map[string]RetentionPolicy{"mychannel": {RetainedBlocks: 10}}, config.Retention.Channels)
+	assert.Equal(t, "", config.FileLedger.ArchiveLocation)
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package main
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -24,13 +26,19 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"syscall"
 
 	genesisconfig "github.com/hyperledger/fabric/common/configtx/tool/localconfig"
 	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/orderer/common/admin"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
+	"github.com/hyperledger/fabric/orderer/common/broadcast"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
+	"github.com/hyperledger/fabric/orderer/common/customfilter"
+	"github.com/hyperledger/fabric/orderer/common/ratelimit"
 	"github.com/hyperledger/fabric/orderer/kafka"
 	"github.com/hyperledger/fabric/orderer/ledger"
 	"github.com/hyperledger/fabric/orderer/localconfig"
@@ -56,6 +64,13 @@ var (
 
 	start   = app.Command("start", "Start the orderer node").Default()
 	version = app.Command("version", "Show version information")
+
+	adminCmd       = app.Command("admin", "Query the Admin service of a running orderer node, as an admin of the local MSP")
+	adminAddress   = adminCmd.Flag("orderer", "Address of the orderer node, defaults to the listen address of the config").String()
+	adminCAFile    = adminCmd.Flag("cafile", "PEM encoded CA certificate verifying the TLS certificate of the orderer node, defaults to the root CAs of the config").String()
+	adminTimeout   = adminCmd.Flag("timeout", "Timeout of the request").Default("5s").Duration()
+	adminChannels  = adminCmd.Command("channels", "Show the status of the channels served by the orderer node")
+	adminChannelID = adminChannels.Arg("channel", "Only show the status of this channel").String()
 )
 
 func main() {
@@ -73,13 +88,23 @@ func main() {
 		initializeLocalMsp(conf)
 		signer := localmsp.NewSigner()
 		manager := initializeMultiChainManager(conf, signer)
-		server := NewServer(manager, signer)
+		initializeChannelParticipation(conf, manager)
+		server := NewServer(manager, signer, initializeBroadcastRateLimiter(conf))
 		ab.RegisterAtomicBroadcastServer(grpcServer.Server(), server)
+		initializeAdminService(conf, grpcServer, manager)
 		logger.Info("Beginning to serve requests")
 		grpcServer.Start()
 	// "version" command
 	case version.FullCommand():
 		fmt.Println(metadata.GetVersionInfo())
+	// "admin channels" command
+	case adminChannels.FullCommand():
+		conf := config.Load()
+		initializeLocalMsp(conf)
+		if err := printChannelStatus(os.Stdout, conf, *adminAddress, *adminCAFile, *adminTimeout, *adminChannelID); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
 	}
 
 }
@@ -192,6 +217,16 @@ func initializeGrpcServer(conf *config.TopLevel) comm.GRPCServer {
 		logger.Fatal("Failed to return new GRPC server:", err)
 	}
 
+	if secureConfig.UseTLS {
+		// Serve new connections with the certificate once it is renewed
+		reloader, err := comm.NewCertificateReloader(conf.General.TLS.Certificate, conf.General.TLS.PrivateKey)
+		if err != nil {
+			logger.Fatal("Failed to watch the TLS certificate:", err)
+		}
+		reloader.OnReload(grpcServer.SetServerCertificate)
+		go reloader.Watch(conf.General.TLS.ReloadInterval, syscall.SIGHUP)
+	}
+
 	return grpcServer
 }
 
@@ -206,15 +241,109 @@ func initializeLocalMsp(conf *config.TopLevel) {
 func initializeMultiChainManager(conf *config.TopLevel, signer crypto.LocalSigner) multichain.Manager {
 	lf, _ := createLedgerFactory(conf)
 	// Are we bootstrapping?
-	if len(lf.ChainIDs()) == 0 {
-		initializeBootstrapChannel(conf, lf)
-	} else {
+	switch {
+	case len(lf.ChainIDs()) != 0:
 		logger.Info("Not bootstrapping because of existing chains")
+	case conf.General.GenesisMethod == "none":
+		logger.Info("Not bootstrapping a system channel, channels are joined through the channel participation API")
+	default:
+		initializeBootstrapChannel(conf, lf)
 	}
 
 	consenters := make(map[string]multichain.Consenter)
 	consenters["solo"] = solo.New()
-	consenters["kafka"] = kafka.New(conf.Kafka.TLS, conf.Kafka.Retry, conf.Kafka.Version)
+	consenters["kafka"] = kafka.New(conf.Kafka.TLS, conf.Kafka.SASL, conf.Kafka.Retry, conf.Kafka.Version, conf.Kafka.Topic)
+
+	customFilters := initializeCustomFilters(conf)
+
+	if conf.ChannelParticipation.Enabled {
+		return multichain.NewParticipationManagerImpl(lf, consenters, signer, customFilters)
+	}
+	return multichain.NewManagerImpl(lf, consenters, signer, customFilters)
+}
+
+// Validate the custom filter rules before any channel is started, so that a
+// misconfigured rule prevents the orderer from starting.
+func initializeCustomFilters(conf *config.TopLevel) *customfilter.Rules {
+	toConfigs := func(rules []config.FilterRule) []customfilter.Config {
+		configs := make([]customfilter.Config, len(rules))
+		for i, rule := range rules {
+			configs[i] = customfilter.Config{Name: rule.Name, Params: rule.Params}
+		}
+		return configs
+	}
+
+	channels := make(map[string][]customfilter.Config)
+	for chainID, rules := range conf.Filters.Channels {
+		channels[chainID] = toConfigs(rules)
+	}
+	customFilters, err := customfilter.NewRules(toConfigs(conf.Filters.Default), channels)
+	if err != nil {
+		logger.Panicf("Invalid custom filter configuration: %s", err)
+	}
+	return customFilters
+}
+
+// Create the broadcast rate limiter. It is created even when no limit is
+// configured here, as channels may set limits in their config.
+func initializeBroadcastRateLimiter(conf *config.TopLevel) broadcast.RateLimiter {
+	rateLimits := conf.Broadcast.RateLimits
+	limits := ratelimit.Limits{
+		Channel: ratelimit.Limit{Rate: rateLimits.Channel.Rate, Burst: rateLimits.Channel.Burst},
+		MSP:     ratelimit.Limit{Rate: rateLimits.MSP.Rate, Burst: rateLimits.MSP.Burst},
+		Client:  ratelimit.Limit{Rate: rateLimits.Client.Rate, Burst: rateLimits.Client.Burst},
+	}
+	logger.Infof("Broadcast rate limits per channel %+v, per MSP %+v and per client %+v (a rate of 0 means unlimited)", limits.Channel, limits.MSP, limits.Client)
+	return ratelimit.New(limits)
+}
+
+// Serve the Admin service alongside the AtomicBroadcast service if enabled.
+func initializeAdminService(conf *config.TopLevel, grpcServer comm.GRPCServer, manager multichain.Manager) {
+	if !conf.Admin.Enabled {
+		return
+	}
+	logger.Infof("Serving the Admin service to the admins of the local MSP, with a time window of %s", conf.Admin.TimeWindow)
+	ab.RegisterAdminServer(grpcServer.Server(), admin.NewServer(adminSupport{Manager: manager}, mspmgmt.GetLocalMSP(), conf.Admin.TimeWindow))
+}
+
+// Start the channel participation API if enabled.
+func initializeChannelParticipation(conf *config.TopLevel, manager multichain.Manager) {
+	if !conf.ChannelParticipation.Enabled {
+		return
+	}
+
+	server := &http.Server{
+		Addr:    conf.ChannelParticipation.ListenAddress,
+		Handler: channelparticipation.NewHandler(manager, int64(conf.ChannelParticipation.MaxRequestBodySize)),
+	}
+
+	tlsConf := conf.ChannelParticipation.TLS
+	if !tlsConf.Enabled {
+		go func() {
+			logger.Info("Starting channel participation API on:", server.Addr)
+			logger.Panic("Channel participation API failed:", server.ListenAndServe())
+		}()
+		return
+	}
+
+	server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	if tlsConf.ClientAuthEnabled {
+		clientRoots := x509.NewCertPool()
+		for _, clientRoot := range tlsConf.ClientRootCAs {
+			root, err := ioutil.ReadFile(clientRoot)
+			if err != nil {
+				logger.Fatalf("Failed to load ChannelParticipation ClientRootCAs file '%s' (%s)", clientRoot, err)
+			}
+			if !clientRoots.AppendCertsFromPEM(root) {
+				logger.Fatalf("Failed to parse ChannelParticipation ClientRootCAs file '%s'", clientRoot)
+			}
+		}
+		server.TLSConfig.ClientCAs = clientRoots
+		server.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
 
-	return multichain.NewManagerImpl(lf, consenters, signer)
+	go func() {
+		logger.Info("Starting channel participation API with TLS on:", server.Addr)
+		logger.Panic("Channel participation API failed:", server.ListenAndServeTLS(tlsConf.Certificate, tlsConf.PrivateKey))
+	}()
 }
@@ -102,3 +102,8 @@ func (mbc *Receiver) Cut() ([]*cb.Envelope, []filter.Committer) {
 	mbc.CurBatch = nil
 	return res, noopCommitters(len(res))
 }
+
+// PendingBatchSize returns the length of CurBatch, the size in bytes is not tracked
+func (mbc *Receiver) PendingBatchSize() (uint32, uint32) {
+	return uint32(len(mbc.CurBatch)), 0
+}
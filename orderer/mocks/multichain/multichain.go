@@ -36,6 +36,9 @@ type ConsenterSupport struct {
 	// SharedConfigVal is the value returned by SharedConfig()
 	SharedConfigVal *mockconfig.Orderer
 
+	// ChannelConfigVal is the value returned by ChannelConfig(), an empty channel config if nil
+	ChannelConfigVal *mockconfig.Channel
+
 	// BlockCutterVal is the value returned by BlockCutter()
 	BlockCutterVal *mockblockcutter.Receiver
 
@@ -62,6 +65,14 @@ func (mcs *ConsenterSupport) SharedConfig() config.Orderer {
 	return mcs.SharedConfigVal
 }
 
+// ChannelConfig returns ChannelConfigVal
+func (mcs *ConsenterSupport) ChannelConfig() config.Channel {
+	if mcs.ChannelConfigVal == nil {
+		return &mockconfig.Channel{}
+	}
+	return mcs.ChannelConfigVal
+}
+
 // CreateNextBlock creates a simple block structure with the given data
 func (mcs *ConsenterSupport) CreateNextBlock(data []*cb.Envelope) *cb.Block {
 	block := cb.NewBlock(0, nil)
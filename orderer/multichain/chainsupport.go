@@ -17,6 +17,9 @@ limitations under the License.
 package multichain
 
 import (
+	"sync"
+	"time"
+
 	"github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/policies"
@@ -24,12 +27,17 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/broadcast"
 	"github.com/hyperledger/fabric/orderer/common/configtxfilter"
+	"github.com/hyperledger/fabric/orderer/common/customfilter"
 	"github.com/hyperledger/fabric/orderer/common/filter"
+	"github.com/hyperledger/fabric/orderer/common/maintenancefilter"
 	"github.com/hyperledger/fabric/orderer/common/sigfilter"
 	"github.com/hyperledger/fabric/orderer/common/sizefilter"
 	"github.com/hyperledger/fabric/orderer/ledger"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
 )
 
 // Consenter defines the backing ordering mechanism
@@ -65,6 +73,10 @@ type Chain interface {
 
 	// Halt frees the resources which were allocated for this Chain
 	Halt()
+
+	// Done returns a channel which closes once the chain, after being halted,
+	// has stopped processing messages and no longer writes to the ledger
+	Done() <-chan struct{}
 }
 
 // ConsenterSupport provides the resources available to a Consenter implementation
@@ -72,6 +84,10 @@ type ConsenterSupport interface {
 	crypto.LocalSigner
 	BlockCutter() blockcutter.Receiver
 	SharedConfig() config.Orderer
+	ChannelConfig() config.Channel
+	// CreateNextBlock creates the next block from messages, timestamped with the
+	// current time. Consenters whose orderers must agree on the block contents
+	// replace the timestamp with one assigned through consensus.
 	CreateNextBlock(messages []*cb.Envelope) *cb.Block
 	WriteBlock(block *cb.Block, committers []filter.Committer, encodedMetadataValue []byte) *cb.Block
 	ChainID() string // ChainID returns the chain ID this specific consenter instance is associated with
@@ -100,26 +116,33 @@ type ChainSupport interface {
 
 	// ProposeConfigUpdate applies a CONFIG_UPDATE to an existing config to produce a *cb.ConfigEnvelope
 	ProposeConfigUpdate(env *cb.Envelope) (*cb.ConfigEnvelope, error)
+
+	// Status returns the current state of the chain
+	Status() *ab.ChannelStatus
 }
 
 type chainSupport struct {
 	*ledgerResources
 	chain         Chain
+	chainLock     sync.RWMutex // guards chain, which is replaced on consensus type migration
+	consenters    map[string]Consenter
+	consensusType string // the consensus type of the running chain
 	cutter        blockcutter.Receiver
-	filters       *filter.RuleSet
+	filters       *chainFilters
+	reader        *closableReader
 	signer        crypto.LocalSigner
 	lastConfig    uint64
 	lastConfigSeq uint64
 }
 
 func newChainSupport(
-	filters *filter.RuleSet,
+	filters *chainFilters,
 	ledgerResources *ledgerResources,
 	consenters map[string]Consenter,
 	signer crypto.LocalSigner,
 ) *chainSupport {
 
-	cutter := blockcutter.NewReceiverImpl(ledgerResources.SharedConfig(), filters)
+	cutter := blockcutter.NewReceiverImpl(ledgerResources.SharedConfig(), filters.ordering)
 	consenterType := ledgerResources.SharedConfig().ConsensusType()
 	consenter, ok := consenters[consenterType]
 	if !ok {
@@ -128,8 +151,11 @@ func newChainSupport(
 
 	cs := &chainSupport{
 		ledgerResources: ledgerResources,
+		consenters:      consenters,
+		consensusType:   consenterType,
 		cutter:          cutter,
 		filters:         filters,
+		reader:          newClosableReader(ledgerResources.ledger),
 		signer:          signer,
 	}
 
@@ -164,32 +190,63 @@ func newChainSupport(
 	return cs
 }
 
-// createStandardFilters creates the set of filters for a normal (non-system) chain
-func createStandardFilters(ledgerResources *ledgerResources) *filter.RuleSet {
-	return filter.NewRuleSet([]filter.Rule{
+// chainFilters holds the filters applied to the messages of a chain. The
+// ordering filters are applied again by the block cutter of every orderer
+// while cutting blocks, so they must only depend on the channel config. The
+// broadcast filters add the rules depending on the clock or the local
+// configuration of this orderer, and are only evaluated when a message is
+// received through Broadcast.
+type chainFilters struct {
+	ordering  *filter.RuleSet
+	broadcast *filter.RuleSet
+}
+
+func newChainFilters(ml *multiLedger, ledgerResources *ledgerResources, tail ...filter.Rule) *chainFilters {
+	head := []filter.Rule{
 		filter.EmptyRejectRule,
 		sizefilter.MaxBytesRule(ledgerResources.SharedConfig().BatchSize().AbsoluteMaxBytes),
 		sigfilter.New(policies.ChannelWriters, ledgerResources.PolicyManager()),
-		configtxfilter.NewFilter(ledgerResources),
-		filter.AcceptRule,
-	})
+		maintenancefilter.New(ledgerResources, ml.consensusTypes()),
+	}
+	tail = append(tail, filter.AcceptRule)
+
+	var ordering, broadcast []filter.Rule
+	ordering = append(append(ordering, head...), tail...)
+	broadcast = append(broadcast, head...)
+	broadcast = append(broadcast, customfilter.NewTransactionTimestampWindow(ledgerResources))
+	broadcast = append(broadcast, ml.customFilters.ForChannel(ledgerResources.ChainID())...)
+	broadcast = append(broadcast, tail...)
+
+	return &chainFilters{
+		ordering:  filter.NewRuleSet(ordering),
+		broadcast: filter.NewRuleSet(broadcast),
+	}
+}
 
+// createStandardFilters creates the set of filters for a normal (non-system) chain
+func createStandardFilters(ml *multiLedger, ledgerResources *ledgerResources) *chainFilters {
+	return newChainFilters(ml, ledgerResources,
+		configtxfilter.NewFilter(ledgerResources),
+	)
 }
 
 // createSystemChainFilters creates the set of filters for the ordering system chain
-func createSystemChainFilters(ml *multiLedger, ledgerResources *ledgerResources) *filter.RuleSet {
-	return filter.NewRuleSet([]filter.Rule{
-		filter.EmptyRejectRule,
-		sizefilter.MaxBytesRule(ledgerResources.SharedConfig().BatchSize().AbsoluteMaxBytes),
-		sigfilter.New(policies.ChannelWriters, ledgerResources.PolicyManager()),
+func createSystemChainFilters(ml *multiLedger, ledgerResources *ledgerResources) *chainFilters {
+	return newChainFilters(ml, ledgerResources,
 		newSystemChainFilter(ledgerResources, ml),
 		configtxfilter.NewFilter(ledgerResources),
-		filter.AcceptRule,
-	})
+	)
 }
 
 func (cs *chainSupport) start() {
-	cs.chain.Start()
+	cs.currentChain().Start()
+}
+
+// currentChain returns the chain of the consenter currently ordering for this chain support
+func (cs *chainSupport) currentChain() Chain {
+	cs.chainLock.RLock()
+	defer cs.chainLock.RUnlock()
+	return cs.chain
 }
 
 func (cs *chainSupport) NewSignatureHeader() (*cb.SignatureHeader, error) {
@@ -200,8 +257,9 @@ func (cs *chainSupport) Sign(message []byte) ([]byte, error) {
 	return cs.signer.Sign(message)
 }
 
+// Filters returns the filters applied to the messages received through Broadcast
 func (cs *chainSupport) Filters() *filter.RuleSet {
-	return cs.filters
+	return cs.filters.broadcast
 }
 
 func (cs *chainSupport) BlockCutter() blockcutter.Receiver {
@@ -209,19 +267,22 @@ func (cs *chainSupport) BlockCutter() blockcutter.Receiver {
 }
 
 func (cs *chainSupport) Reader() ledger.Reader {
-	return cs.ledger
+	return cs.reader
 }
 
 func (cs *chainSupport) Enqueue(env *cb.Envelope) bool {
-	return cs.chain.Enqueue(env)
+	return cs.currentChain().Enqueue(env)
 }
 
 func (cs *chainSupport) Errored() <-chan struct{} {
-	return cs.chain.Errored()
+	return cs.currentChain().Errored()
 }
 
 func (cs *chainSupport) CreateNextBlock(messages []*cb.Envelope) *cb.Block {
-	return ledger.CreateNextBlock(cs.ledger, messages)
+	block := ledger.CreateNextBlock(cs.ledger, messages)
+	now := time.Now()
+	utils.SetBlockTimestamp(block, &timestamp.Timestamp{Seconds: now.Unix(), Nanos: int32(now.Nanosecond())})
+	return block
 }
 
 func (cs *chainSupport) addBlockSignature(block *cb.Block) {
@@ -271,16 +332,48 @@ func (cs *chainSupport) addLastConfigSignature(block *cb.Block) {
 	})
 }
 
+func (cs *chainSupport) addTimestampSignature(block *cb.Block) {
+	blockTimestamp, err := utils.GetBlockTimestamp(block)
+	if err != nil {
+		logger.Panicf("[channel: %s] Could not read the timestamp of block %d: %s", cs.ChainID(), block.Header.Number, err)
+	}
+	if blockTimestamp == nil {
+		return
+	}
+
+	timestampSignature := &cb.MetadataSignature{
+		SignatureHeader: utils.MarshalOrPanic(utils.NewSignatureHeaderOrPanic(cs.signer)),
+	}
+
+	timestampValue := utils.MarshalOrPanic(blockTimestamp)
+	timestampSignature.Signature = utils.SignOrPanic(cs.signer, util.ConcatenateBytes(timestampValue, timestampSignature.SignatureHeader, block.Header.Bytes()))
+
+	block.Metadata.Metadata[cb.BlockMetadataIndex_TIMESTAMP] = utils.MarshalOrPanic(&cb.Metadata{
+		Value: timestampValue,
+		Signatures: []*cb.MetadataSignature{
+			timestampSignature,
+		},
+	})
+}
+
 func (cs *chainSupport) WriteBlock(block *cb.Block, committers []filter.Committer, encodedMetadataValue []byte) *cb.Block {
 	for _, committer := range committers {
 		committer.Commit()
 	}
+	// A config block migrating the consensus type carries the metadata the new
+	// consenter resumes from, if any, in place of the one of the current consenter
+	migrating := cs.Sequence() != cs.lastConfigSeq && cs.SharedConfig().ConsensusType() != cs.consensusType
+	if migrating && len(cs.SharedConfig().ConsensusMetadata()) > 0 {
+		encodedMetadataValue = cs.SharedConfig().ConsensusMetadata()
+	}
 	// Set the orderer-related metadata field
 	if encodedMetadataValue != nil {
 		block.Metadata.Metadata[cb.BlockMetadataIndex_ORDERER] = utils.MarshalOrPanic(&cb.Metadata{Value: encodedMetadataValue})
 	}
+	priorConfigSeq := cs.lastConfigSeq
 	cs.addBlockSignature(block)
 	cs.addLastConfigSignature(block)
+	cs.addTimestampSignature(block)
 
 	err := cs.ledger.Append(block)
 	if err != nil {
@@ -288,9 +381,60 @@ func (cs *chainSupport) WriteBlock(block *cb.Block, committers []filter.Committe
 	}
 	logger.Debugf("[channel: %s] Wrote block %d", cs.ChainID(), block.GetHeader().Number)
 
+	// If the block carried a config update which requires capabilities this orderer
+	// does not have, stop ordering for the channel rather than risk forking from
+	// the orderers which do
+	if cs.lastConfigSeq != priorConfigSeq {
+		if err := cs.capabilitiesSupported(); err != nil {
+			logger.Criticalf("[channel: %s] Channel requires capabilities which this orderer does not support, upgrade the orderer to continue processing it: %s", cs.ChainID(), err)
+			cs.currentChain().Halt()
+			return block
+		}
+	}
+
+	if migrating {
+		cs.migrateConsenter(block)
+	}
+
 	return block
 }
 
+// migrateConsenter replaces the chain of the current consenter by one of the
+// consenter the config now specifies, resuming from the tip of the ledger.
+// As the channel is in maintenance, no transaction is in flight but config
+// updates, which the migration invalidates by bumping the config sequence.
+// Should the new consenter fail to take over the channel, the current one keeps
+// ordering so that the migration can be rolled back by a further config update.
+func (cs *chainSupport) migrateConsenter(block *cb.Block) {
+	consensusType := cs.SharedConfig().ConsensusType()
+	consenter, ok := cs.consenters[consensusType]
+	if !ok {
+		logger.Criticalf("[channel: %s] Cannot migrate from consensus type %s to unknown consensus type %s, roll back the consensus type to continue", cs.ChainID(), cs.consensusType, consensusType)
+		return
+	}
+
+	metadata, err := utils.GetMetadataFromBlock(block, cb.BlockMetadataIndex_ORDERER)
+	if err != nil {
+		logger.Panicf("[channel: %s] Error extracting orderer metadata: %s", cs.ChainID(), err)
+	}
+
+	chain, err := consenter.HandleChain(cs, metadata)
+	if err != nil {
+		logger.Criticalf("[channel: %s] Error migrating from consensus type %s to %s, roll back the consensus type to continue: %s", cs.ChainID(), cs.consensusType, consensusType, err)
+		return
+	}
+
+	logger.Infof("[channel: %s] Migrating from consensus type %s to %s at block %d", cs.ChainID(), cs.consensusType, consensusType, block.Header.Number)
+	cs.chainLock.Lock()
+	previous := cs.chain
+	cs.chain = chain
+	cs.consensusType = consensusType
+	cs.chainLock.Unlock()
+
+	previous.Halt()
+	chain.Start()
+}
+
 func (cs *chainSupport) Height() uint64 {
-	return cs.Reader().Height()
+	return cs.ledger.Height()
 }
@@ -17,9 +17,11 @@ limitations under the License.
 package multichain
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	mockconfigtx "github.com/hyperledger/fabric/common/mocks/configtx"
 	"github.com/hyperledger/fabric/common/mocks/crypto"
 	"github.com/hyperledger/fabric/orderer/common/filter"
@@ -108,6 +110,27 @@ func TestWriteBlockOrdererMetadata(t *testing.T) {
 	assert.True(t, proto.Equal(expected, actual), "Orderer metadata not written to block correctly")
 }
 
+func TestWriteBlockTimestamp(t *testing.T) {
+	ml := &mockLedgerReadWriter{}
+	cm := &mockconfigtx.Manager{}
+	cs := &chainSupport{ledgerResources: &ledgerResources{configResources: &configResources{Manager: cm}, ledger: ml}, signer: mockCrypto()}
+
+	block := cs.CreateNextBlock([]*cb.Envelope{{Payload: []byte("foo")}})
+	expected, err := utils.GetBlockTimestamp(block)
+	assert.NoError(t, err)
+	assert.NotNil(t, expected, "New block should have been timestamped")
+
+	md := utils.GetMetadataFromBlockOrPanic(cs.WriteBlock(block, nil, nil), cb.BlockMetadataIndex_TIMESTAMP)
+	assert.Len(t, md.Signatures, 1, "Block timestamp should have been signed")
+	actual, err := utils.GetBlockTimestamp(block)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(expected, actual), "Block timestamp should have been preserved")
+
+	block = cb.NewBlock(1, nil)
+	cs.WriteBlock(block, nil, nil)
+	assert.Empty(t, block.Metadata.Metadata[cb.BlockMetadataIndex_TIMESTAMP], "Block without timestamp should be left without one")
+}
+
 func TestSignature(t *testing.T) {
 	ml := &mockLedgerReadWriter{}
 	cm := &mockconfigtx.Manager{}
@@ -122,9 +145,20 @@ func TestSignature(t *testing.T) {
 	assert.Equal(t, crypto.FakeLocalSigner.Nonce, signatureHeader.Nonce)
 }
 
+func newMockConfigManager() *mockconfigtx.Manager {
+	return &mockconfigtx.Manager{
+		Initializer: mockconfigtx.Initializer{
+			Resources: mockconfigtx.Resources{
+				ChannelConfigVal: &mockconfig.Channel{CapabilitiesVal: &mockconfig.ChannelCapabilities{}},
+				OrdererConfigVal: &mockconfig.Orderer{CapabilitiesVal: &mockconfig.OrdererCapabilities{}},
+			},
+		},
+	}
+}
+
 func TestWriteLastConfig(t *testing.T) {
 	ml := &mockLedgerReadWriter{}
-	cm := &mockconfigtx.Manager{}
+	cm := newMockConfigManager()
 	cs := &chainSupport{ledgerResources: &ledgerResources{configResources: &configResources{Manager: cm}, ledger: ml}, signer: mockCrypto()}
 
 	expected := uint64(0)
@@ -153,3 +187,27 @@ func TestWriteLastConfig(t *testing.T) {
 		assert.Equal(t, expected, lc, "Second block should have config block index of %d, but got %d")
 	})
 }
+
+func TestWriteBlockUnsupportedCapabilities(t *testing.T) {
+	ml := &mockLedgerReadWriter{}
+	cm := newMockConfigManager()
+	mch := &mockChain{queue: make(chan *cb.Envelope)}
+	cs := &chainSupport{ledgerResources: &ledgerResources{configResources: &configResources{Manager: cm}, ledger: ml}, signer: mockCrypto(), chain: mch}
+
+	t.Run("NoConfigChange", func(t *testing.T) {
+		cm.ChannelConfigVal.(*mockconfig.Channel).CapabilitiesVal = &mockconfig.ChannelCapabilities{SupportedErr: fmt.Errorf("unsupported")}
+		cs.WriteBlock(cb.NewBlock(0, nil), nil, nil)
+		select {
+		case <-mch.queue:
+			t.Fatalf("Chain should not have been halted without a config change")
+		default:
+		}
+	})
+
+	t.Run("ConfigChange", func(t *testing.T) {
+		cm.SequenceVal = 1
+		cs.WriteBlock(cb.NewBlock(1, nil), nil, nil)
+		_, open := <-mch.queue
+		assert.False(t, open, "Chain should have been halted")
+	})
+}
@@ -18,11 +18,13 @@ package multichain
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/common/configtx"
 	configtxapi "github.com/hyperledger/fabric/common/configtx/api"
 	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/orderer/common/customfilter"
 	"github.com/hyperledger/fabric/orderer/ledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -50,6 +52,19 @@ type Manager interface {
 	// NewChannelConfig returns a bare bones configuration ready for channel
 	// creation request to be applied on top of it
 	NewChannelConfig(envConfigUpdate *cb.Envelope) (configtxapi.Manager, error)
+
+	// JoinChannel creates the ledger of a channel from its genesis block, or
+	// from its latest config block, and starts serving the channel
+	JoinChannel(configBlock *cb.Block) (ChannelInfo, error)
+
+	// RemoveChannel stops serving a channel and deletes its ledger
+	RemoveChannel(chainID string) error
+
+	// ChannelList returns the channels which have a ledger on this orderer
+	ChannelList() []ChannelInfo
+
+	// ChannelInfo returns the details of a channel which has a ledger on this orderer
+	ChannelInfo(chainID string) (ChannelInfo, error)
 }
 
 type configResources struct {
@@ -64,6 +79,20 @@ func (cr *configResources) SharedConfig() config.Orderer {
 	return oc
 }
 
+// capabilitiesSupported checks that this orderer supports the capabilities
+// required by the channel and orderer portions of the config
+func (cr *configResources) capabilitiesSupported() error {
+	if err := cr.ChannelConfig().Capabilities().Supported(); err != nil {
+		return fmt.Errorf("channel capabilities not supported: %s", err)
+	}
+
+	if err := cr.SharedConfig().Capabilities().Supported(); err != nil {
+		return fmt.Errorf("orderer capabilities not supported: %s", err)
+	}
+
+	return nil
+}
+
 type ledgerResources struct {
 	*configResources
 	ledger ledger.ReadWriter
@@ -71,11 +100,14 @@ type ledgerResources struct {
 
 type multiLedger struct {
 	chains          map[string]*chainSupport
+	chainsLock      sync.RWMutex        // serializes the copy-on-write updates of chains
+	removing        map[string]struct{} // the channels being removed, guarded by chainsLock
 	consenters      map[string]Consenter
 	ledgerFactory   ledger.Factory
 	signer          crypto.LocalSigner
 	systemChannelID string
 	systemChannel   *chainSupport
+	customFilters   *customfilter.Rules
 }
 
 func getConfigTx(reader ledger.Reader) *cb.Envelope {
@@ -92,13 +124,41 @@ func getConfigTx(reader ledger.Reader) *cb.Envelope {
 	return utils.ExtractEnvelopeOrPanic(configBlock, 0)
 }
 
-// NewManagerImpl produces an instance of a Manager
-func NewManagerImpl(ledgerFactory ledger.Factory, consenters map[string]Consenter, signer crypto.LocalSigner) Manager {
+// NewManagerImpl produces an instance of a Manager, it refuses to start unless
+// one of the existing chains is a system channel. The customFilters, which may
+// be nil, are applied to the broadcast messages of each channel in addition to
+// the standard filters.
+func NewManagerImpl(ledgerFactory ledger.Factory, consenters map[string]Consenter, signer crypto.LocalSigner, customFilters *customfilter.Rules) Manager {
+	ml := newMultiLedger(ledgerFactory, consenters, signer, customFilters)
+
+	if ml.systemChannelID == "" {
+		logger.Panicf("No system chain found.  If bootstrapping, does your system channel contain a consortiums group definition?")
+	}
+
+	return ml
+}
+
+// NewParticipationManagerImpl produces an instance of a Manager which does not
+// require a system channel, for orderers whose channels are joined and removed
+// through the channel participation API. A system channel is still used if one exists.
+func NewParticipationManagerImpl(ledgerFactory ledger.Factory, consenters map[string]Consenter, signer crypto.LocalSigner, customFilters *customfilter.Rules) Manager {
+	ml := newMultiLedger(ledgerFactory, consenters, signer, customFilters)
+
+	if ml.systemChannelID == "" {
+		logger.Infof("Starting without a system channel, channels are managed through the channel participation API")
+	}
+
+	return ml
+}
+
+func newMultiLedger(ledgerFactory ledger.Factory, consenters map[string]Consenter, signer crypto.LocalSigner, customFilters *customfilter.Rules) *multiLedger {
 	ml := &multiLedger{
 		chains:        make(map[string]*chainSupport),
+		removing:      make(map[string]struct{}),
 		ledgerFactory: ledgerFactory,
 		consenters:    consenters,
 		signer:        signer,
+		customFilters: customFilters,
 	}
 
 	existingChains := ledgerFactory.ChainIDs()
@@ -118,6 +178,9 @@ func NewManagerImpl(ledgerFactory ledger.Factory, consenters map[string]Consente
 			if ml.systemChannelID != "" {
 				logger.Panicf("There appear to be two system chains %s and %s", ml.systemChannelID, chainID)
 			}
+			if err := ledgerResources.capabilitiesSupported(); err != nil {
+				logger.Panicf("System channel %s requires capabilities which this orderer does not support: %s", chainID, err)
+			}
 			chain := newChainSupport(createSystemChainFilters(ml, ledgerResources),
 				ledgerResources,
 				consenters,
@@ -129,8 +192,12 @@ func NewManagerImpl(ledgerFactory ledger.Factory, consenters map[string]Consente
 			// We delay starting this chain, as it might try to copy and replace the chains map via newChain before the map is fully built
 			defer chain.start()
 		} else {
+			if err := ledgerResources.capabilitiesSupported(); err != nil {
+				logger.Errorf("Not starting chain %s, it requires capabilities which this orderer does not support: %s", chainID, err)
+				continue
+			}
 			logger.Debugf("Starting chain: %s", chainID)
-			chain := newChainSupport(createStandardFilters(ledgerResources),
+			chain := newChainSupport(createStandardFilters(ml, ledgerResources),
 				ledgerResources,
 				consenters,
 				signer)
@@ -140,10 +207,6 @@ func NewManagerImpl(ledgerFactory ledger.Factory, consenters map[string]Consente
 
 	}
 
-	if ml.systemChannelID == "" {
-		logger.Panicf("No system chain found.  If bootstrapping, does your system channel contain a consortiums group definition?")
-	}
-
 	return ml
 }
 
@@ -178,6 +241,9 @@ func (ml *multiLedger) newLedgerResources(configTx *cb.Envelope) *ledgerResource
 }
 
 func (ml *multiLedger) newChain(configtx *cb.Envelope) {
+	ml.chainsLock.Lock()
+	defer ml.chainsLock.Unlock()
+
 	ledgerResources := ml.newLedgerResources(configtx)
 	ledgerResources.ledger.Append(ledger.CreateNextBlock(ledgerResources.ledger, []*cb.Envelope{configtx}))
 
@@ -187,7 +253,7 @@ func (ml *multiLedger) newChain(configtx *cb.Envelope) {
 		newChains[key] = value
 	}
 
-	cs := newChainSupport(createStandardFilters(ledgerResources), ledgerResources, ml.consenters, ml.signer)
+	cs := newChainSupport(createStandardFilters(ml, ledgerResources), ledgerResources, ml.consenters, ml.signer)
 	chainID := ledgerResources.ChainID()
 
 	logger.Infof("Created and starting new chain %s", chainID)
@@ -198,6 +264,16 @@ func (ml *multiLedger) newChain(configtx *cb.Envelope) {
 	ml.chains = newChains
 }
 
+// consensusTypes returns the consensus types this orderer has a consenter for,
+// which are the ones channels may be migrated to
+func (ml *multiLedger) consensusTypes() map[string]struct{} {
+	consensusTypes := make(map[string]struct{}, len(ml.consenters))
+	for consensusType := range ml.consenters {
+		consensusTypes[consensusType] = struct{}{}
+	}
+	return consensusTypes
+}
+
 func (ml *multiLedger) channelsCount() int {
 	return len(ml.chains)
 }
@@ -250,6 +326,10 @@ func (ml *multiLedger) NewChannelConfig(envConfigUpdate *cb.Envelope) (configtxa
 		return nil, fmt.Errorf("Error reading unmarshaling consortium name: %s", err)
 	}
 
+	if ml.systemChannel == nil {
+		return nil, fmt.Errorf("Channel creation requires a system channel, which this orderer does not have; join the channel through the channel participation API instead")
+	}
+
 	applicationGroup := cb.NewConfigGroup()
 	consortiumsConfig, ok := ml.systemChannel.ConsortiumsConfig()
 	if !ok {
@@ -308,6 +388,12 @@ func (ml *multiLedger) NewChannelConfig(envConfigUpdate *cb.Envelope) (configtxa
 	channelGroup.Groups[config.ApplicationGroupKey] = applicationGroup
 	channelGroup.Values[config.ConsortiumKey] = config.TemplateConsortium(consortium.Name).Values[config.ConsortiumKey]
 
+	// Older orderers left the channel mod policy empty, which orderers supporting
+	// the predictable template capability derive from the system channel instead
+	if ml.systemChannel.SharedConfig().Capabilities().PredictableChannelTemplate() {
+		channelGroup.ModPolicy = systemChannelGroup.ModPolicy
+	}
+
 	templateConfig, _ := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, configUpdate.ChannelId, ml.signer, &cb.ConfigEnvelope{
 		Config: &cb.Config{
 			ChannelGroup: channelGroup,
@@ -29,6 +29,7 @@ import (
 	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
 	mockcrypto "github.com/hyperledger/fabric/common/mocks/crypto"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/customfilter"
 	"github.com/hyperledger/fabric/orderer/ledger"
 	ramledger "github.com/hyperledger/fabric/orderer/ledger/ram"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -146,7 +147,7 @@ func TestNoSystemChain(t *testing.T) {
 	consenters := make(map[string]Consenter)
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
 
-	assert.Panics(t, func() { NewManagerImpl(lf, consenters, mockCrypto()) }, "Should have panicked when starting without a system chain")
+	assert.Panics(t, func() { NewManagerImpl(lf, consenters, mockCrypto(), nil) }, "Should have panicked when starting without a system chain")
 }
 
 // This test checks to make sure that the orderer refuses to come up if there are multiple system channels
@@ -164,7 +165,7 @@ func TestMultiSystemChannel(t *testing.T) {
 	consenters := make(map[string]Consenter)
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
 
-	assert.Panics(t, func() { NewManagerImpl(lf, consenters, mockCrypto()) }, "Two system channels should have caused panic")
+	assert.Panics(t, func() { NewManagerImpl(lf, consenters, mockCrypto(), nil) }, "Two system channels should have caused panic")
 }
 
 // This test checks to make sure that the orderer creates different type of filters given different type of channel
@@ -192,7 +193,7 @@ func TestFilterCreation(t *testing.T) {
 	consenters := make(map[string]Consenter)
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
 
-	manager := NewManagerImpl(lf, consenters, mockCrypto())
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), nil)
 
 	_, ok := manager.GetChain(provisional.TestChainID)
 	assert.True(t, ok, "Should have found chain: %d", provisional.TestChainID)
@@ -242,7 +243,7 @@ func TestManagerImpl(t *testing.T) {
 	consenters := make(map[string]Consenter)
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
 
-	manager := NewManagerImpl(lf, consenters, mockCrypto())
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), nil)
 
 	_, ok := manager.GetChain("Fake")
 	assert.False(t, ok, "Should not have found a chain that was not created")
@@ -277,7 +278,7 @@ func TestNewChannelConfig(t *testing.T) {
 
 	consenters := make(map[string]Consenter)
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
-	manager := NewManagerImpl(lf, consenters, mockCrypto())
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), nil)
 
 	t.Run("BadPayload", func(t *testing.T) {
 		_, err := manager.NewChannelConfig(&cb.Envelope{Payload: []byte("bad payload")})
@@ -528,7 +529,7 @@ func TestMismatchedChannelIDs(t *testing.T) {
 	consenters := make(map[string]Consenter)
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
 
-	manager := NewManagerImpl(lf, consenters, mockCrypto())
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), nil)
 
 	_, err = manager.NewChannelConfig(createTx)
 	assert.Error(t, err, "Mismatched channel IDs")
@@ -546,7 +547,7 @@ func TestNewChain(t *testing.T) {
 	consenters := make(map[string]Consenter)
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
 
-	manager := NewManagerImpl(lf, consenters, mockCrypto())
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), nil)
 
 	envConfigUpdate, err := configtx.MakeChainCreationTransaction(newChainID, genesisconfig.SampleConsortiumName, mockSigningIdentity)
 	assert.NoError(t, err, "Constructing chain creation tx")
@@ -651,3 +652,32 @@ func testLastConfigBlockNumber(t *testing.T, block *cb.Block, expectedBlockNumbe
 	assert.NoError(t, err, "LAST_CONFIG metadata item should carry last config value")
 	assert.Equal(t, expectedBlockNumber, lastConfig.Index, "LAST_CONFIG value should point to last config block")
 }
+
+func TestCustomFilters(t *testing.T) {
+	lf, _ := NewRAMLedgerAndFactory(10)
+
+	consenters := make(map[string]Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+
+	customFilters, err := customfilter.NewRules(nil, map[string][]customfilter.Config{
+		provisional.TestChainID: {{Name: customfilter.TimestampWindowRuleName}},
+	})
+	assert.NoError(t, err)
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), customFilters)
+
+	cs, ok := manager.GetChain(provisional.TestChainID)
+	assert.True(t, ok, "Should have gotten chain which was initialized by ramledger")
+
+	chdr := utils.MakeChannelHeader(cb.HeaderType_MESSAGE, 0, provisional.TestChainID, 0)
+	chdr.Timestamp.Seconds -= 3600
+	msg := &cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{
+		Header: utils.MakePayloadHeader(chdr, &cb.SignatureHeader{}),
+		Data:   []byte("data"),
+	})}
+	_, err = cs.Filters().Apply(msg)
+	assert.Error(t, err, "Message with a stale timestamp should have been rejected by the custom filter")
+	assert.Contains(t, err.Error(), "customfilter")
+
+	_, err = cs.(*chainSupport).filters.ordering.Apply(msg)
+	assert.NoError(t, err, "Custom filters should not be applied when cutting blocks")
+}
@@ -0,0 +1,373 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multichain
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/config"
+	genesisconfig "github.com/hyperledger/fabric/common/configtx/tool/localconfig"
+	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
+	ramledger "github.com/hyperledger/fabric/orderer/ledger/ram"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const migrationChannel = "migration-channel"
+
+// brokerStandIn stands in for a Kafka cluster, keeping the messages sent for
+// each channel in a log which outlives the chains consuming it
+type brokerStandIn struct {
+	lock   sync.Mutex
+	topics map[string][]*cb.Envelope
+	chains map[string]*brokerChain
+}
+
+func newBrokerStandIn() *brokerStandIn {
+	return &brokerStandIn{
+		topics: make(map[string][]*cb.Envelope),
+		chains: make(map[string]*brokerChain),
+	}
+}
+
+func (bs *brokerStandIn) topicSize(chainID string) int {
+	bs.lock.Lock()
+	defer bs.lock.Unlock()
+	return len(bs.topics[chainID])
+}
+
+func (bs *brokerStandIn) produce(chainID string, env *cb.Envelope) {
+	bs.lock.Lock()
+	defer bs.lock.Unlock()
+	bs.topics[chainID] = append(bs.topics[chainID], env)
+	if chain, ok := bs.chains[chainID]; ok {
+		select {
+		case chain.produced <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (bs *brokerStandIn) consume(chainID string, offset int64) (*cb.Envelope, bool) {
+	bs.lock.Lock()
+	defer bs.lock.Unlock()
+	if offset >= int64(len(bs.topics[chainID])) {
+		return nil, false
+	}
+	return bs.topics[chainID][offset], true
+}
+
+// HandleChain resumes consuming the channel log after the offset recorded in
+// the metadata, as the Kafka consenter does
+func (bs *brokerStandIn) HandleChain(support ConsenterSupport, metadata *cb.Metadata) (Chain, error) {
+	lastOffsetPersisted := int64(-1)
+	if metadata.Value != nil {
+		kafkaMetadata := &ab.KafkaMetadata{}
+		if err := proto.Unmarshal(metadata.Value, kafkaMetadata); err != nil {
+			return nil, err
+		}
+		lastOffsetPersisted = kafkaMetadata.LastOffsetPersisted
+	}
+
+	chain := &brokerChain{
+		broker:              bs,
+		support:             support,
+		cutter:              support.BlockCutter(),
+		lastOffsetPersisted: lastOffsetPersisted,
+		produced:            make(chan struct{}, 1),
+		exit:                make(chan struct{}),
+		done:                make(chan struct{}),
+	}
+	bs.lock.Lock()
+	bs.chains[support.ChainID()] = chain
+	bs.lock.Unlock()
+	return chain, nil
+}
+
+type brokerChain struct {
+	broker              *brokerStandIn
+	support             ConsenterSupport
+	cutter              blockcutter.Receiver
+	lastOffsetPersisted int64
+	produced            chan struct{}
+	exit                chan struct{}
+	done                chan struct{}
+}
+
+func (bc *brokerChain) Errored() <-chan struct{} {
+	return nil
+}
+
+func (bc *brokerChain) Enqueue(env *cb.Envelope) bool {
+	select {
+	case <-bc.exit:
+		return false
+	default:
+	}
+	bc.broker.produce(bc.support.ChainID(), env)
+	return true
+}
+
+func (bc *brokerChain) Start() {
+	go func() {
+		defer close(bc.done)
+		for {
+			msg, ok := bc.broker.consume(bc.support.ChainID(), bc.lastOffsetPersisted+1)
+			if !ok {
+				select {
+				case <-bc.produced:
+					continue
+				case <-bc.exit:
+					return
+				}
+			}
+			select {
+			case <-bc.exit:
+				return
+			default:
+			}
+
+			// Cut a block after each message, as if a time-to-cut message followed it
+			offset := bc.lastOffsetPersisted + 1
+			batches, committers, _ := bc.cutter.Ordered(msg)
+			if batch, committer := bc.cutter.Cut(); len(batch) > 0 {
+				batches = append(batches, batch)
+				committers = append(committers, committer)
+			}
+			for i, batch := range batches {
+				block := bc.support.CreateNextBlock(batch)
+				bc.support.WriteBlock(block, committers[i], utils.MarshalOrPanic(&ab.KafkaMetadata{LastOffsetPersisted: offset}))
+			}
+			bc.lastOffsetPersisted = offset
+		}
+	}()
+}
+
+func (bc *brokerChain) Halt() {
+	close(bc.exit)
+}
+
+func (bc *brokerChain) Done() <-chan struct{} {
+	return bc.done
+}
+
+type failingConsenter struct{}
+
+func (fc *failingConsenter) HandleChain(support ConsenterSupport, metadata *cb.Metadata) (Chain, error) {
+	return nil, fmt.Errorf("failing consenter")
+}
+
+// newMigrationNetwork creates an orderer with a system channel and a standard
+// channel, both ordered by the Kafka stand-in
+func newMigrationNetwork(t *testing.T, broker *brokerStandIn) Manager {
+	systemConf := genesisconfig.Load(genesisconfig.SampleInsecureProfile)
+	systemConf.Orderer.OrdererType = "kafka"
+	channelConf := genesisconfig.Load("SampleNoConsortium")
+	channelConf.Orderer.OrdererType = "kafka"
+
+	lf := ramledger.New(100)
+	for chainID, block := range map[string]*cb.Block{
+		provisional.TestChainID: provisional.New(systemConf).GenesisBlock(),
+		migrationChannel:        provisional.New(channelConf).GenesisBlockForChannel(migrationChannel),
+	} {
+		rl, err := lf.GetOrCreate(chainID)
+		require.NoError(t, err)
+		require.NoError(t, rl.Append(block))
+	}
+
+	consenters := map[string]Consenter{
+		"kafka":   broker,
+		"solo":    &mockConsenter{},
+		"failing": &failingConsenter{},
+	}
+	return NewManagerImpl(lf, consenters, mockCrypto(), nil)
+}
+
+// consensusTypeUpdate proposes a config update modifying the consensus type
+// of the channel, returning the resulting config transaction
+func consensusTypeUpdate(cs ChainSupport, modify func(*ab.ConsensusType)) (*cb.Envelope, error) {
+	original := cs.(*chainSupport).ConfigEnvelope().Config
+	updated := proto.Clone(original).(*cb.Config)
+
+	value := updated.ChannelGroup.Groups[config.OrdererGroupKey].Values[config.ConsensusTypeKey]
+	consensusType := &ab.ConsensusType{}
+	if err := proto.Unmarshal(value.Value, consensusType); err != nil {
+		return nil, err
+	}
+	modify(consensusType)
+	value.Value = utils.MarshalOrPanic(consensusType)
+
+	configUpdate, err := update.Compute(original, updated)
+	if err != nil {
+		return nil, err
+	}
+	configUpdate.ChannelId = cs.ChainID()
+
+	configUpdateEnv, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, cs.ChainID(), mockCrypto(), &cb.ConfigUpdateEnvelope{
+		ConfigUpdate: utils.MarshalOrPanic(configUpdate),
+	}, msgVersion, epoch)
+	if err != nil {
+		return nil, err
+	}
+	configEnv, err := cs.ProposeConfigUpdate(configUpdateEnv)
+	if err != nil {
+		return nil, err
+	}
+	return utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, cs.ChainID(), mockCrypto(), configEnv, msgVersion, epoch)
+}
+
+// orderMessage filters and enqueues the message as Broadcast does, and waits for
+// the block it is ordered in
+func orderMessage(t *testing.T, cs ChainSupport, env *cb.Envelope) *cb.Block {
+	_, err := cs.Filters().Apply(env)
+	require.NoError(t, err, "Message should have passed the filters")
+
+	height := cs.Height()
+	it, _ := cs.Reader().Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: height}}})
+	require.True(t, cs.Enqueue(env), "Message should have been enqueued")
+	select {
+	case <-it.ReadyChan():
+		block, status := it.Next()
+		require.Equal(t, cb.Status_SUCCESS, status)
+		return block
+	case <-time.After(time.Second):
+		t.Fatalf("Block %d not produced after timeout", height)
+		return nil
+	}
+}
+
+// orderBatch orders enough messages to fill a block, as the solo stand-in does
+// not cut blocks on timeout
+func orderBatch(t *testing.T, cs ChainSupport) *cb.Block {
+	maxMessageCount := int(cs.SharedConfig().BatchSize().MaxMessageCount)
+	for i := 0; i < maxMessageCount-1; i++ {
+		require.True(t, cs.Enqueue(makeNormalTx(cs.ChainID(), i)))
+	}
+	return orderMessage(t, cs, makeNormalTx(cs.ChainID(), maxMessageCount-1))
+}
+
+func updateConsensusType(t *testing.T, cs ChainSupport, modify func(*ab.ConsensusType)) *cb.Block {
+	env, err := consensusTypeUpdate(cs, modify)
+	require.NoError(t, err)
+	return orderMessage(t, cs, env)
+}
+
+func kafkaOffset(t *testing.T, block *cb.Block) int64 {
+	metadata, err := utils.GetMetadataFromBlock(block, cb.BlockMetadataIndex_ORDERER)
+	require.NoError(t, err)
+	kafkaMetadata := &ab.KafkaMetadata{}
+	require.NoError(t, proto.Unmarshal(metadata.Value, kafkaMetadata))
+	return kafkaMetadata.LastOffsetPersisted
+}
+
+func enterMaintenance(ct *ab.ConsensusType) { ct.State = ab.ConsensusType_STATE_MAINTENANCE }
+func exitMaintenance(ct *ab.ConsensusType)  { ct.State = ab.ConsensusType_STATE_NORMAL }
+
+func migrateTo(consensusType string, metadata []byte) func(*ab.ConsensusType) {
+	return func(ct *ab.ConsensusType) {
+		ct.Type = consensusType
+		ct.Metadata = metadata
+	}
+}
+
+func TestConsensusTypeMigration(t *testing.T) {
+	broker := newBrokerStandIn()
+	manager := newMigrationNetwork(t, broker)
+
+	for _, chainID := range []string{provisional.TestChainID, migrationChannel} {
+		cs, ok := manager.GetChain(chainID)
+		require.True(t, ok, "Should have found channel %s", chainID)
+
+		t.Run(chainID, func(t *testing.T) {
+			block := orderMessage(t, cs, makeNormalTx(chainID, 0))
+			assert.Equal(t, int64(0), kafkaOffset(t, block), "Transaction should have been ordered by kafka")
+
+			_, err := consensusTypeUpdate(cs, migrateTo("solo", nil))
+			assert.Error(t, err, "Should not migrate outside of maintenance")
+
+			updateConsensusType(t, cs, enterMaintenance)
+			assert.Equal(t, ab.ConsensusType_STATE_MAINTENANCE, cs.SharedConfig().ConsensusState())
+			_, err = cs.Filters().Apply(makeNormalTx(chainID, 1))
+			assert.Error(t, err, "Transactions should be rejected in maintenance")
+
+			block = updateConsensusType(t, cs, migrateTo("solo", nil))
+			lastKafkaOffset := kafkaOffset(t, block)
+			assert.Equal(t, "solo", cs.SharedConfig().ConsensusType())
+			assert.IsType(t, &mockChain{}, cs.(*chainSupport).currentChain(), "Solo should have taken over the channel")
+
+			updateConsensusType(t, cs, exitMaintenance)
+			topicSize := broker.topicSize(chainID)
+			orderBatch(t, cs)
+			assert.Equal(t, topicSize, broker.topicSize(chainID), "Transactions should have been ordered by solo")
+
+			updateConsensusType(t, cs, enterMaintenance)
+			updateConsensusType(t, cs, migrateTo("kafka", utils.MarshalOrPanic(&ab.KafkaMetadata{LastOffsetPersisted: lastKafkaOffset})))
+			assert.IsType(t, &brokerChain{}, cs.(*chainSupport).currentChain(), "Kafka should have taken over the channel")
+
+			block = updateConsensusType(t, cs, exitMaintenance)
+			assert.Equal(t, lastKafkaOffset+1, kafkaOffset(t, block), "Kafka should have resumed after the offset it stopped at")
+			block = orderMessage(t, cs, makeNormalTx(chainID, 3))
+			assert.Equal(t, lastKafkaOffset+2, kafkaOffset(t, block))
+			assert.Equal(t, ab.ConsensusType_STATE_NORMAL, cs.SharedConfig().ConsensusState())
+		})
+	}
+}
+
+func TestConsensusTypeMigrationAbort(t *testing.T) {
+	manager := newMigrationNetwork(t, newBrokerStandIn())
+	cs, _ := manager.GetChain(migrationChannel)
+
+	updateConsensusType(t, cs, enterMaintenance)
+	chain := cs.(*chainSupport).currentChain()
+	updateConsensusType(t, cs, exitMaintenance)
+
+	assert.Equal(t, "kafka", cs.SharedConfig().ConsensusType())
+	assert.Equal(t, chain, cs.(*chainSupport).currentChain(), "Chain should not have been replaced")
+	orderMessage(t, cs, makeNormalTx(migrationChannel, 0))
+}
+
+func TestConsensusTypeMigrationRollback(t *testing.T) {
+	manager := newMigrationNetwork(t, newBrokerStandIn())
+	cs, _ := manager.GetChain(migrationChannel)
+
+	updateConsensusType(t, cs, enterMaintenance)
+
+	env, err := consensusTypeUpdate(cs, migrateTo("sbft", nil))
+	require.NoError(t, err)
+	_, err = cs.Filters().Apply(env)
+	assert.Error(t, err, "Should not migrate to a consensus type this orderer has no consenter for")
+
+	chain := cs.(*chainSupport).currentChain()
+	updateConsensusType(t, cs, migrateTo("failing", nil))
+	assert.Equal(t, chain, cs.(*chainSupport).currentChain(), "Kafka should keep ordering when the new consenter fails")
+
+	updateConsensusType(t, cs, migrateTo("kafka", nil))
+	assert.Equal(t, chain, cs.(*chainSupport).currentChain(), "Rolling back should keep the running chain")
+
+	updateConsensusType(t, cs, exitMaintenance)
+	assert.Equal(t, "kafka", cs.SharedConfig().ConsensusType())
+	orderMessage(t, cs, makeNormalTx(migrationChannel, 0))
+}
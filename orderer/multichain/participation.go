@@ -0,0 +1,287 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multichain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/orderer/ledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+const (
+	// ChannelStatusActive is the status of a channel this orderer is serving
+	ChannelStatusActive = "active"
+
+	// ChannelStatusInactive is the status of a channel which has a ledger on
+	// this orderer but is not served, e.g. because it requires capabilities
+	// this orderer does not support
+	ChannelStatusInactive = "inactive"
+)
+
+var (
+	// ErrChannelExists is returned when joining a channel which already has a ledger
+	ErrChannelExists = errors.New("channel already exists")
+
+	// ErrChannelNotExist is returned for a channel which has no ledger on this orderer
+	ErrChannelNotExist = errors.New("channel does not exist")
+
+	// ErrChannelRemoving is returned for a channel which is being removed
+	ErrChannelRemoving = errors.New("channel is being removed")
+
+	// ErrSystemChannel is returned when attempting to join or remove a system channel
+	ErrSystemChannel = errors.New("system channels cannot be joined or removed through channel participation")
+)
+
+// ChannelInfo describes a channel which has a ledger on this orderer
+type ChannelInfo struct {
+	Name   string `json:"name"`
+	Height uint64 `json:"height"`
+	Status string `json:"status"`
+}
+
+// JoinChannel creates the ledger of a channel from its genesis block, or from
+// its latest config block, and starts serving the channel. When joining from a
+// later config block the ledger starts from that block, the blocks preceding
+// it are reported as pruned to Deliver clients, which fetch them from peers.
+func (ml *multiLedger) JoinChannel(configBlock *cb.Block) (ChannelInfo, error) {
+	configTx, err := joinConfigTx(configBlock)
+	if err != nil {
+		return ChannelInfo{}, err
+	}
+
+	configManager, err := configtx.NewManagerImpl(configTx, configtx.NewInitializer(), nil)
+	if err != nil {
+		return ChannelInfo{}, fmt.Errorf("invalid channel config: %s", err)
+	}
+	configResources := &configResources{Manager: configManager}
+	chainID := configManager.ChainID()
+
+	if _, ok := configResources.ConsortiumsConfig(); ok {
+		return ChannelInfo{}, ErrSystemChannel
+	}
+	if _, ok := configResources.OrdererConfig(); !ok {
+		return ChannelInfo{}, fmt.Errorf("channel %s has no orderer configuration", chainID)
+	}
+	if err := configResources.capabilitiesSupported(); err != nil {
+		return ChannelInfo{}, err
+	}
+	if _, ok := ml.consenters[configResources.SharedConfig().ConsensusType()]; !ok {
+		return ChannelInfo{}, fmt.Errorf("unknown consensus type %s", configResources.SharedConfig().ConsensusType())
+	}
+
+	ml.chainsLock.Lock()
+	defer ml.chainsLock.Unlock()
+
+	if _, ok := ml.removing[chainID]; ok {
+		return ChannelInfo{}, ErrChannelRemoving
+	}
+	if ml.ledgerExists(chainID) {
+		return ChannelInfo{}, ErrChannelExists
+	}
+
+	rl, err := ml.ledgerFactory.GetOrCreate(chainID)
+	if err != nil {
+		return ChannelInfo{}, fmt.Errorf("error creating ledger for channel %s: %s", chainID, err)
+	}
+	if err := appendJoinBlock(rl, configBlock); err != nil {
+		ml.ledgerFactory.Remove(chainID)
+		return ChannelInfo{}, fmt.Errorf("error appending config block to channel %s: %s", chainID, err)
+	}
+
+	ledgerResources := &ledgerResources{
+		configResources: configResources,
+		ledger:          rl,
+	}
+	cs := newChainSupport(createStandardFilters(ml, ledgerResources), ledgerResources, ml.consenters, ml.signer)
+
+	newChains := make(map[string]*chainSupport)
+	for key, value := range ml.chains {
+		newChains[key] = value
+	}
+	newChains[chainID] = cs
+
+	logger.Infof("Joined and starting channel %s", chainID)
+	cs.start()
+	ml.chains = newChains
+
+	return ChannelInfo{Name: chainID, Height: cs.Height(), Status: ChannelStatusActive}, nil
+}
+
+// RemoveChannel stops serving a channel and deletes its ledger
+func (ml *multiLedger) RemoveChannel(chainID string) error {
+	if ml.systemChannelID != "" && chainID == ml.systemChannelID {
+		return ErrSystemChannel
+	}
+
+	ml.chainsLock.Lock()
+	if _, ok := ml.removing[chainID]; ok {
+		ml.chainsLock.Unlock()
+		return ErrChannelRemoving
+	}
+	if !ml.ledgerExists(chainID) {
+		ml.chainsLock.Unlock()
+		return ErrChannelNotExist
+	}
+
+	cs, ok := ml.chains[chainID]
+	if ok {
+		newChains := make(map[string]*chainSupport)
+		for key, value := range ml.chains {
+			if key != chainID {
+				newChains[key] = value
+			}
+		}
+		ml.chains = newChains
+	}
+	ml.removing[chainID] = struct{}{}
+	ml.chainsLock.Unlock()
+
+	if ok {
+		// Wait for the consenter to stop writing to the ledger and for the
+		// Deliver requests to stop reading from it before removing it. The
+		// lock is not held meanwhile, as stopping the chain may take a while.
+		chain := cs.currentChain()
+		chain.Halt()
+		<-chain.Done()
+		cs.reader.close()
+	}
+
+	ml.chainsLock.Lock()
+	defer ml.chainsLock.Unlock()
+	delete(ml.removing, chainID)
+
+	if err := ml.ledgerFactory.Remove(chainID); err != nil {
+		return fmt.Errorf("error removing ledger of channel %s: %s", chainID, err)
+	}
+
+	logger.Infof("Removed channel %s", chainID)
+	return nil
+}
+
+// ChannelList returns the channels which have a ledger on this orderer, sorted by name
+func (ml *multiLedger) ChannelList() []ChannelInfo {
+	chainIDs := ml.ledgerFactory.ChainIDs()
+	sort.Strings(chainIDs)
+
+	infos := make([]ChannelInfo, 0, len(chainIDs))
+	for _, chainID := range chainIDs {
+		info, err := ml.ChannelInfo(chainID)
+		if err != nil {
+			// The channel was removed since listing the ledgers
+			continue
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// ChannelInfo returns the details of a channel which has a ledger on this orderer
+func (ml *multiLedger) ChannelInfo(chainID string) (ChannelInfo, error) {
+	ml.chainsLock.RLock()
+	defer ml.chainsLock.RUnlock()
+
+	if cs, ok := ml.chains[chainID]; ok {
+		return ChannelInfo{Name: chainID, Height: cs.Height(), Status: ChannelStatusActive}, nil
+	}
+
+	if _, ok := ml.removing[chainID]; ok {
+		return ChannelInfo{}, ErrChannelRemoving
+	}
+	if !ml.ledgerExists(chainID) {
+		return ChannelInfo{}, ErrChannelNotExist
+	}
+
+	rl, err := ml.ledgerFactory.GetOrCreate(chainID)
+	if err != nil {
+		return ChannelInfo{}, fmt.Errorf("error opening ledger of channel %s: %s", chainID, err)
+	}
+	return ChannelInfo{Name: chainID, Height: rl.Height(), Status: ChannelStatusInactive}, nil
+}
+
+func (ml *multiLedger) ledgerExists(chainID string) bool {
+	for _, existing := range ml.ledgerFactory.ChainIDs() {
+		if existing == chainID {
+			return true
+		}
+	}
+	return false
+}
+
+// appendJoinBlock appends the block a channel is joined from to its empty
+// ledger, bootstrapping the ledger when it is not the genesis block
+func appendJoinBlock(rl ledger.ReadWriter, block *cb.Block) error {
+	if block.Header.Number == 0 {
+		return rl.Append(block)
+	}
+	bootstrapper, ok := rl.(ledger.Bootstrapper)
+	if !ok {
+		return fmt.Errorf("the ledger type cannot start from block %d, join from the genesis block instead", block.Header.Number)
+	}
+	return bootstrapper.Bootstrap(block)
+}
+
+// joinConfigTx checks that block is a well formed genesis block, or the latest
+// config block of its channel, and returns the config transaction it carries
+func joinConfigTx(block *cb.Block) (*cb.Envelope, error) {
+	if block == nil || block.Header == nil || block.Data == nil {
+		return nil, fmt.Errorf("block is missing its header or data")
+	}
+	if !bytes.Equal(block.Header.DataHash, block.Data.Hash()) {
+		return nil, fmt.Errorf("block data hash does not match the block header")
+	}
+	if len(block.Data.Data) != 1 {
+		return nil, fmt.Errorf("config block must contain exactly one transaction, has %d", len(block.Data.Data))
+	}
+	if block.Header.Number != 0 {
+		// The genesis block carries no last config index, every later config
+		// block points to itself
+		lastConfig, err := utils.GetLastConfigIndexFromBlock(block)
+		if err != nil {
+			return nil, fmt.Errorf("error extracting last config index of block %d: %s", block.Header.Number, err)
+		}
+		if lastConfig != block.Header.Number {
+			return nil, fmt.Errorf("block %d is not a config block, the last config block is %d", block.Header.Number, lastConfig)
+		}
+	}
+
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error extracting config transaction: %s", err)
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling config transaction payload: %s", err)
+	}
+	if payload.Header == nil {
+		return nil, fmt.Errorf("config transaction is missing its header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling channel header: %s", err)
+	}
+	if chdr.Type != int32(cb.HeaderType_CONFIG) {
+		return nil, fmt.Errorf("config block transaction has type %d, expected a CONFIG transaction", chdr.Type)
+	}
+
+	return env, nil
+}
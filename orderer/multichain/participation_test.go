@@ -0,0 +1,191 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multichain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
+	ramledger "github.com/hyperledger/fabric/orderer/ledger/ram"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newParticipationManager(t *testing.T) Manager {
+	lf := ramledger.New(10)
+	consenters := make(map[string]Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+
+	var manager Manager
+	assert.NotPanics(t, func() { manager = NewParticipationManagerImpl(lf, consenters, mockCrypto(), nil) })
+	return manager
+}
+
+func TestParticipationWithoutSystemChannel(t *testing.T) {
+	manager := newParticipationManager(t)
+
+	assert.Equal(t, "", manager.SystemChannelID())
+	assert.Empty(t, manager.ChannelList())
+
+	_, err := manager.NewChannelConfig(&cb.Envelope{})
+	assert.Error(t, err, "Channel creation requires a system channel")
+}
+
+func TestJoinChannel(t *testing.T) {
+	manager := newParticipationManager(t)
+
+	info, err := manager.JoinChannel(noConsortiumGenesisBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, ChannelInfo{Name: NoConsortiumChain, Height: 1, Status: ChannelStatusActive}, info)
+
+	_, ok := manager.GetChain(NoConsortiumChain)
+	assert.True(t, ok, "Joined channel should be served")
+	assert.Equal(t, []ChannelInfo{info}, manager.ChannelList())
+
+	info, err = manager.ChannelInfo(NoConsortiumChain)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), info.Height)
+
+	_, err = manager.JoinChannel(noConsortiumGenesisBlock)
+	assert.Equal(t, ErrChannelExists, err)
+
+	_, err = manager.ChannelInfo("foo")
+	assert.Equal(t, ErrChannelNotExist, err)
+}
+
+func TestJoinChannelBadBlocks(t *testing.T) {
+	manager := newParticipationManager(t)
+
+	t.Run("NilBlock", func(t *testing.T) {
+		_, err := manager.JoinChannel(nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("NotConfigBlock", func(t *testing.T) {
+		block := proto.Clone(noConsortiumGenesisBlock).(*cb.Block)
+		block.Header.Number = 3
+		block.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&cb.Metadata{
+			Value: utils.MarshalOrPanic(&cb.LastConfig{Index: 2}),
+		})
+		_, err := manager.JoinChannel(block)
+		assert.Error(t, err)
+	})
+
+	t.Run("BadDataHash", func(t *testing.T) {
+		block := proto.Clone(noConsortiumGenesisBlock).(*cb.Block)
+		block.Header.DataHash = []byte("foo")
+		_, err := manager.JoinChannel(block)
+		assert.Error(t, err)
+	})
+
+	t.Run("SystemChannel", func(t *testing.T) {
+		_, err := manager.JoinChannel(genesisBlock)
+		assert.Equal(t, ErrSystemChannel, err)
+	})
+
+	assert.Empty(t, manager.ChannelList(), "No channel should have been joined")
+}
+
+func TestJoinChannelFromConfigBlock(t *testing.T) {
+	manager := newParticipationManager(t)
+
+	block := proto.Clone(noConsortiumGenesisBlock).(*cb.Block)
+	block.Header.Number = 5
+	block.Header.PreviousHash = []byte("previous hash")
+	block.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&cb.Metadata{
+		Value: utils.MarshalOrPanic(&cb.LastConfig{Index: 5}),
+	})
+
+	info, err := manager.JoinChannel(block)
+	assert.NoError(t, err)
+	assert.Equal(t, ChannelInfo{Name: NoConsortiumChain, Height: 6, Status: ChannelStatusActive}, info)
+
+	cs, ok := manager.GetChain(NoConsortiumChain)
+	assert.True(t, ok, "Joined channel should be served")
+	cursor, num := cs.Reader().Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{Oldest: &ab.SeekOldest{}}})
+	assert.Equal(t, uint64(5), num, "The ledger should start from the config block")
+	joined, status := cursor.Next()
+	assert.Equal(t, cb.Status_SUCCESS, status)
+	assert.Equal(t, block.Header, joined.Header)
+
+	cursor, _ = cs.Reader().Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 4}}})
+	_, status = cursor.Next()
+	assert.Equal(t, cb.Status_NOT_FOUND, status, "The blocks preceding the config block should be reported as pruned")
+}
+
+func TestRemoveChannel(t *testing.T) {
+	manager := newParticipationManager(t)
+
+	_, err := manager.JoinChannel(noConsortiumGenesisBlock)
+	assert.NoError(t, err)
+	cs, _ := manager.GetChain(NoConsortiumChain)
+	cursor, _ := cs.Reader().Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{Oldest: &ab.SeekOldest{}}})
+	_, status := cursor.Next()
+	assert.Equal(t, cb.Status_SUCCESS, status)
+	// A Deliver request waiting for the next block
+	ready := cursor.ReadyChan()
+	select {
+	case <-ready:
+		t.Fatalf("There should be no block to read")
+	default:
+	}
+
+	assert.NoError(t, manager.RemoveChannel(NoConsortiumChain))
+	_, ok := manager.GetChain(NoConsortiumChain)
+	assert.False(t, ok, "Removed channel should no longer be served")
+	assert.Empty(t, manager.ChannelList())
+	select {
+	case <-cs.(*chainSupport).chain.(*mockChain).done:
+	case <-time.After(time.Second):
+		t.Fatalf("Removed channel should have been halted")
+	}
+	select {
+	case <-ready:
+	case <-time.After(time.Second):
+		t.Fatalf("Deliver requests waiting for a block of a removed channel should be woken up")
+	}
+	select {
+	case <-cursor.ReadyChan():
+	default:
+		t.Fatalf("Deliver requests of a removed channel should not block")
+	}
+	_, status = cursor.Next()
+	assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, status, "Deliver requests should no longer read from the removed ledger")
+
+	assert.Equal(t, ErrChannelNotExist, manager.RemoveChannel(NoConsortiumChain))
+
+	// The channel may be joined again once removed
+	_, err = manager.JoinChannel(noConsortiumGenesisBlock)
+	assert.NoError(t, err)
+}
+
+func TestRemoveSystemChannel(t *testing.T) {
+	lf, _ := NewRAMLedgerAndFactory(10)
+	consenters := make(map[string]Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), nil)
+
+	assert.Equal(t, ErrSystemChannel, manager.RemoveChannel(provisional.TestChainID))
+
+	info, err := manager.ChannelInfo(provisional.TestChainID)
+	assert.NoError(t, err)
+	assert.Equal(t, ChannelStatusActive, info.Status)
+}
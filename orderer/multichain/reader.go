@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package multichain
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/orderer/ledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+)
+
+var closedChan chan struct{}
+
+func init() {
+	closedChan = make(chan struct{})
+	close(closedChan)
+}
+
+// closableReader wraps the ledger of a chain so that the Deliver requests
+// reading from it can be stopped before the ledger is removed
+type closableReader struct {
+	reader ledger.Reader
+	lock   sync.RWMutex
+	closed bool
+	height uint64 // the height of the ledger when it was closed
+	// done is closed along with the reader, to wake up the Deliver requests
+	// waiting for a block
+	done chan struct{}
+}
+
+func newClosableReader(reader ledger.Reader) *closableReader {
+	return &closableReader{reader: reader, done: make(chan struct{})}
+}
+
+// Iterator returns an Iterator whose reads fail once the reader is closed
+func (cr *closableReader) Iterator(startPosition *ab.SeekPosition) (ledger.Iterator, uint64) {
+	cr.lock.RLock()
+	defer cr.lock.RUnlock()
+	if cr.closed {
+		return &ledger.NotFoundErrorIterator{}, 0
+	}
+	iterator, number := cr.reader.Iterator(startPosition)
+	return &closableIterator{iterator: iterator, reader: cr}, number
+}
+
+// Height returns the number of blocks on the ledger, or on the ledger when it
+// was closed
+func (cr *closableReader) Height() uint64 {
+	cr.lock.RLock()
+	defer cr.lock.RUnlock()
+	if cr.closed {
+		return cr.height
+	}
+	return cr.reader.Height()
+}
+
+// close waits for the ongoing reads to complete, after which the ledger is no
+// longer read from
+func (cr *closableReader) close() {
+	cr.lock.Lock()
+	defer cr.lock.Unlock()
+	if cr.closed {
+		return
+	}
+	cr.height = cr.reader.Height()
+	cr.closed = true
+	close(cr.done)
+}
+
+type closableIterator struct {
+	iterator ledger.Iterator
+	reader   *closableReader
+}
+
+// Next returns the next block, or SERVICE_UNAVAILABLE once the reader is closed
+func (ci *closableIterator) Next() (*cb.Block, cb.Status) {
+	// Wait for the next block without holding the lock, which close acquires
+	<-ci.ReadyChan()
+
+	ci.reader.lock.RLock()
+	defer ci.reader.lock.RUnlock()
+	if ci.reader.closed {
+		return nil, cb.Status_SERVICE_UNAVAILABLE
+	}
+	return ci.iterator.Next()
+}
+
+// ReadyChan supplies a channel which will block until Next will not block,
+// which is immediately the case once the reader is closed
+func (ci *closableIterator) ReadyChan() <-chan struct{} {
+	ci.reader.lock.RLock()
+	defer ci.reader.lock.RUnlock()
+	if ci.reader.closed {
+		return closedChan
+	}
+
+	ready := ci.iterator.ReadyChan()
+	select {
+	case <-ready:
+		return ready
+	default:
+	}
+	readyOrDone := make(chan struct{})
+	go func() {
+		select {
+		case <-ready:
+		case <-ci.reader.done:
+		}
+		close(readyOrDone)
+	}()
+	return readyOrDone
+}
@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package multichain
+
+import (
+	"github.com/hyperledger/fabric/orderer/ledger"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// StatusReporter may be implemented by a Chain to report the state of its
+// consenter in the status of the channel
+type StatusReporter interface {
+	// Status fills in the consenter specific fields of status
+	Status(status *ab.ChannelStatus)
+}
+
+// Status returns the current state of the chain. It may be invoked
+// concurrently with the consenter ordering for the chain.
+func (cs *chainSupport) Status() *ab.ChannelStatus {
+	cs.chainLock.RLock()
+	chain := cs.chain
+	consensusType := cs.consensusType
+	cs.chainLock.RUnlock()
+
+	status := &ab.ChannelStatus{
+		ChannelId:      cs.ChainID(),
+		Height:         cs.Height(),
+		ConsensusType:  consensusType,
+		ConsensusState: cs.SharedConfig().ConsensusState(),
+	}
+	status.PendingBatchMessages, status.PendingBatchBytes = cs.cutter.PendingBatchSize()
+
+	// The tip of the chain records the last config block, bar the genesis
+	// block which is the last config block itself
+	if status.Height > 1 {
+		if block := ledger.GetBlock(cs.Reader(), status.Height-1); block != nil {
+			lastConfig, err := utils.GetLastConfigIndexFromBlock(block)
+			if err != nil {
+				logger.Warningf("[channel: %s] Error extracting last config block from block metadata: %s", cs.ChainID(), err)
+			}
+			status.LastConfigBlockNumber = lastConfig
+		}
+	}
+
+	if reporter, ok := chain.(StatusReporter); ok {
+		reporter.Status(status)
+	}
+	return status
+}
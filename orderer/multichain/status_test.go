@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package multichain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockReportingConsenter struct {
+	mockConsenter
+}
+
+func (mrc *mockReportingConsenter) HandleChain(support ConsenterSupport, metadata *cb.Metadata) (Chain, error) {
+	chain, err := mrc.mockConsenter.HandleChain(support, metadata)
+	return &mockReportingChain{mockChain: chain.(*mockChain)}, err
+}
+
+type mockReportingChain struct {
+	*mockChain
+}
+
+func (mrc *mockReportingChain) Status(status *ab.ChannelStatus) {
+	status.Kafka = &ab.KafkaChannelStatus{Topic: status.ChannelId}
+}
+
+func TestStatus(t *testing.T) {
+	lf, rl := NewRAMLedgerAndFactory(10)
+
+	consenters := make(map[string]Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), nil)
+	chainSupport, ok := manager.GetChain(provisional.TestChainID)
+	assert.True(t, ok, "Should have gotten chain which was initialized by ramledger")
+
+	status := chainSupport.Status()
+	assert.Equal(t, &ab.ChannelStatus{
+		ChannelId:     provisional.TestChainID,
+		Height:        1,
+		ConsensusType: conf.Orderer.OrdererType,
+	}, status)
+
+	chainSupport.Enqueue(makeNormalTx(provisional.TestChainID, 0))
+	// The message is handed over to the chain before being cut
+	deadline := time.Now().Add(time.Second)
+	for status = chainSupport.Status(); status.PendingBatchMessages == 0 && time.Now().Before(deadline); status = chainSupport.Status() {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, uint32(1), status.PendingBatchMessages, "Should have had the message pending")
+	assert.NotZero(t, status.PendingBatchBytes, "Should have had the size of the message pending")
+
+	for i := 1; i < int(conf.Orderer.BatchSize.MaxMessageCount); i++ {
+		chainSupport.Enqueue(makeNormalTx(provisional.TestChainID, i))
+	}
+
+	it, _ := rl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 1}}})
+	select {
+	case <-it.ReadyChan():
+	case <-time.After(time.Second):
+		t.Fatalf("Block 1 not produced after timeout")
+	}
+
+	status = chainSupport.Status()
+	assert.Equal(t, uint64(2), status.Height)
+	assert.Zero(t, status.PendingBatchMessages, "Should have cut the pending messages")
+	assert.Zero(t, status.LastConfigBlockNumber, "Should have had the genesis block as last config block")
+	assert.Nil(t, status.Kafka)
+}
+
+func TestStatusReporter(t *testing.T) {
+	lf, _ := NewRAMLedgerAndFactory(10)
+
+	consenters := make(map[string]Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockReportingConsenter{}
+
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), nil)
+	chainSupport, ok := manager.GetChain(provisional.TestChainID)
+	assert.True(t, ok, "Should have gotten chain which was initialized by ramledger")
+
+	status := chainSupport.Status()
+	assert.Equal(t, &ab.KafkaChannelStatus{Topic: provisional.TestChainID}, status.Kafka, "Should have let the chain report its status")
+}
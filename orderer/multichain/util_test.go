@@ -78,6 +78,10 @@ func (mch *mockChain) Halt() {
 	close(mch.queue)
 }
 
+func (mch *mockChain) Done() <-chan struct{} {
+	return mch.done
+}
+
 func makeConfigTx(chainID string, i int) *cb.Envelope {
 	group := cb.NewConfigGroup()
 	group.Groups[config.OrdererGroupKey] = cb.NewConfigGroup()
@@ -18,6 +18,7 @@ package main
 
 import (
 	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/orderer/common/admin"
 	"github.com/hyperledger/fabric/orderer/common/broadcast"
 	"github.com/hyperledger/fabric/orderer/common/deliver"
 	"github.com/hyperledger/fabric/orderer/configupdate"
@@ -52,19 +53,42 @@ func (bs deliverSupport) GetChain(chainID string) (deliver.Support, bool) {
 	return bs.Manager.GetChain(chainID)
 }
 
+type adminSupport struct {
+	multichain.Manager
+}
+
+func (as adminSupport) GetChain(chainID string) (admin.Support, bool) {
+	return as.Manager.GetChain(chainID)
+}
+
+func (as adminSupport) ChainIDs() []string {
+	infos := as.Manager.ChannelList()
+	chainIDs := make([]string, len(infos))
+	for i, info := range infos {
+		chainIDs[i] = info.Name
+	}
+	return chainIDs
+}
+
 type server struct {
 	bh broadcast.Handler
 	dh deliver.Handler
 }
 
-// NewServer creates an ab.AtomicBroadcastServer based on the broadcast target and ledger Reader
-func NewServer(ml multichain.Manager, signer crypto.LocalSigner) ab.AtomicBroadcastServer {
+// NewServer creates an ab.AtomicBroadcastServer based on the broadcast target and ledger Reader,
+// the broadcast rate limits are only enforced if limiter is not nil
+func NewServer(ml multichain.Manager, signer crypto.LocalSigner, limiter broadcast.RateLimiter) ab.AtomicBroadcastServer {
+	bs := broadcastSupport{
+		Manager:               ml,
+		ConfigUpdateProcessor: configupdate.New(ml.SystemChannelID(), configUpdateSupport{Manager: ml}, signer),
+	}
+
 	s := &server{
 		dh: deliver.NewHandlerImpl(deliverSupport{Manager: ml}),
-		bh: broadcast.NewHandlerImpl(broadcastSupport{
-			Manager:               ml,
-			ConfigUpdateProcessor: configupdate.New(ml.SystemChannelID(), configUpdateSupport{Manager: ml}, signer),
-		}),
+		bh: broadcast.NewHandlerImpl(bs),
+	}
+	if limiter != nil {
+		s.bh = broadcast.NewHandlerImplWithRateLimiter(bs, limiter)
 	}
 	return s
 }
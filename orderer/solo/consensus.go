@@ -35,6 +35,7 @@ type chain struct {
 	batchTimeout time.Duration
 	sendChan     chan *cb.Envelope
 	exitChan     chan struct{}
+	doneChan     chan struct{}
 }
 
 // New creates a new consenter for the solo consensus scheme.
@@ -55,6 +56,7 @@ func newChain(support multichain.ConsenterSupport) *chain {
 		support:      support,
 		sendChan:     make(chan *cb.Envelope),
 		exitChan:     make(chan struct{}),
+		doneChan:     make(chan struct{}),
 	}
 }
 
@@ -86,7 +88,13 @@ func (ch *chain) Errored() <-chan struct{} {
 	return ch.exitChan
 }
 
+// Done closes once the main loop has exited
+func (ch *chain) Done() <-chan struct{} {
+	return ch.doneChan
+}
+
 func (ch *chain) main() {
+	defer close(ch.doneChan)
 	var timer <-chan time.Time
 
 	f, _ := os.Create("transIncoming.BlockOutgoing.log")
@@ -57,9 +57,32 @@ func createLedgerFactory(conf *config.TopLevel) (ledger.Factory, string) {
 	default:
 		lf = ramledger.New(int(conf.RAMLedger.HistorySize))
 	}
+	lf.SetRetentionPolicies(retentionPolicies(conf, ld))
 	return lf, ld
 }
 
+// retentionPolicies returns the retention policies of the ledgers, archiving
+// the pruned blocks under the ledger directory unless an archive location is
+// set
+func retentionPolicies(conf *config.TopLevel, ld string) ledger.RetentionPolicies {
+	policies := ledger.RetentionPolicies{
+		Default: ledger.RetentionPolicy(conf.Retention.Default),
+		Chains:  make(map[string]ledger.RetentionPolicy),
+	}
+	for chainID, policy := range conf.Retention.Channels {
+		policies.Chains[chainID] = ledger.RetentionPolicy(policy)
+	}
+	archiveDir := conf.FileLedger.ArchiveLocation
+	if archiveDir == "" && ld != "" {
+		archiveDir = filepath.Join(ld, "archive")
+	}
+	if archiveDir != "" {
+		logger.Debug("Ledger archive dir:", archiveDir)
+		policies.Archiver = ledger.NewDirArchiver(archiveDir)
+	}
+	return policies
+}
+
 func createTempDir(dirPrefix string) string {
 	dirPath, err := ioutil.TempDir("", dirPrefix)
 	if err != nil {
@@ -20,6 +20,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/hyperledger/fabric/orderer/ledger"
 	config "github.com/hyperledger/fabric/orderer/localconfig"
 	"github.com/stretchr/testify/assert"
 )
@@ -69,6 +70,21 @@ func TestCreateLedgerFactory(t *testing.T) {
 	}
 }
 
+func TestRetentionPolicies(t *testing.T) {
+	conf := &config.TopLevel{Retention: config.Retention{
+		Default:  config.RetentionPolicy{RetainedBlocks: 100, KeepConfigBlocks: true},
+		Channels: map[string]config.RetentionPolicy{"mychannel": {RetainedBlocks: 10}},
+	}}
+
+	policies := retentionPolicies(conf, "")
+	assert.Equal(t, ledger.RetentionPolicy{RetainedBlocks: 100, KeepConfigBlocks: true}, policies.For("otherchannel"))
+	assert.Equal(t, ledger.RetentionPolicy{RetainedBlocks: 10}, policies.For("mychannel"))
+	assert.Nil(t, policies.Archiver, "Expected pruned blocks to be dropped without a ledger directory")
+
+	policies = retentionPolicies(conf, "test-dir")
+	assert.NotNil(t, policies.Archiver, "Expected pruned blocks to be archived under the ledger directory")
+}
+
 func TestCreateSubDir(t *testing.T) {
 	testCases := []struct {
 		name          string
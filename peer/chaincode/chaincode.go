@@ -28,8 +28,8 @@ import (
 
 const (
 	chainFuncName = "chaincode"
-	shortDes      = "Operate a chaincode: install|instantiate|invoke|package|query|signpackage|upgrade."
-	longDes       = "Operate a chaincode: install|instantiate|invoke|package|query|signpackage|upgrade."
+	shortDes      = "Operate a chaincode: install|instantiate|invoke|package|query|setpolicy|signpackage|upgrade."
+	longDes       = "Operate a chaincode: install|instantiate|invoke|package|query|setpolicy|signpackage|upgrade."
 )
 
 var logger = flogging.MustGetLogger("chaincodeCmd")
@@ -38,7 +38,7 @@ func addFlags(cmd *cobra.Command) {
 	flags := cmd.PersistentFlags()
 
 	flags.StringVarP(&orderingEndpoint, "orderer", "o", "", "Ordering service endpoint")
-	flags.BoolVarP(&tls, "tls", "", false, "Use TLS when communicating with the orderer endpoint")
+	flags.BoolVarP(&tlsEnabled, "tls", "", false, "Use TLS when communicating with the orderer endpoint")
 	flags.StringVarP(&caFile, "cafile", "", "", "Path to file containing PEM-encoded trusted certificate(s) for the ordering endpoint")
 }
 
@@ -51,6 +51,7 @@ func Cmd(cf *ChaincodeCmdFactory) *cobra.Command {
 	chaincodeCmd.AddCommand(invokeCmd(cf))
 	chaincodeCmd.AddCommand(packageCmd(cf, nil))
 	chaincodeCmd.AddCommand(queryCmd(cf))
+	chaincodeCmd.AddCommand(setPolicyCmd(cf))
 	chaincodeCmd.AddCommand(signpackageCmd(cf))
 	chaincodeCmd.AddCommand(upgradeCmd(cf))
 
@@ -74,7 +75,7 @@ var (
 	vscc              string
 	policyMarhsalled  []byte
 	orderingEndpoint  string
-	tls               bool
+	tlsEnabled        bool
 	caFile            string
 )
 
@@ -17,6 +17,7 @@ limitations under the License.
 package chaincode
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -27,6 +28,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/peer/common"
@@ -107,6 +109,7 @@ func chaincodeInvokeOrQuery(cmd *cobra.Command, args []string, invoke bool, cf *
 		chainID,
 		invoke,
 		cf.Signer,
+		cf.Certificate,
 		cf.EndorserClient,
 		cf.BroadcastClient)
 
@@ -230,6 +233,7 @@ type ChaincodeCmdFactory struct {
 	EndorserClient  pb.EndorserClient
 	Signer          msp.SigningIdentity
 	BroadcastClient common.BroadcastClient
+	Certificate     tls.Certificate
 }
 
 // InitCmdFactory init the ChaincodeCmdFactory with default clients
@@ -248,6 +252,11 @@ func InitCmdFactory(isEndorserRequired, isOrdererRequired bool) (*ChaincodeCmdFa
 		return nil, fmt.Errorf("Error getting default signer: %s", err)
 	}
 
+	certificate, err := common.GetCertificateFnc()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting client certificate: %s", err)
+	}
+
 	var broadcastClient common.BroadcastClient
 	if isOrdererRequired {
 		if len(orderingEndpoint) == 0 {
@@ -262,7 +271,7 @@ func InitCmdFactory(isEndorserRequired, isOrdererRequired bool) (*ChaincodeCmdFa
 			orderingEndpoint = orderingEndpoints[0]
 		}
 
-		broadcastClient, err = common.GetBroadcastClientFnc(orderingEndpoint, tls, caFile)
+		broadcastClient, err = common.GetBroadcastClientFnc(orderingEndpoint, tlsEnabled, caFile)
 
 		if err != nil {
 			return nil, fmt.Errorf("Error getting broadcast client: %s", err)
@@ -272,6 +281,7 @@ func InitCmdFactory(isEndorserRequired, isOrdererRequired bool) (*ChaincodeCmdFa
 		EndorserClient:  endorserClient,
 		Signer:          signer,
 		BroadcastClient: broadcastClient,
+		Certificate:     certificate,
 	}, nil
 }
 
@@ -289,6 +299,7 @@ func ChaincodeInvokeOrQuery(
 	cID string,
 	invoke bool,
 	signer msp.SigningIdentity,
+	certificate tls.Certificate,
 	endorserClient pb.EndorserClient,
 	bc common.BroadcastClient,
 ) (*pb.ProposalResponse, error) {
@@ -314,6 +325,11 @@ func ChaincodeInvokeOrQuery(
 		return nil, fmt.Errorf("Error creating proposal  %s: %s", funcName, err)
 	}
 
+	// bind the proposal and the transaction to the client TLS certificate
+	if err = putils.BindProposalToTLSCertificate(prop, comm.TLSCertificateHash(certificate)); err != nil {
+		return nil, fmt.Errorf("Error binding proposal  %s: %s", funcName, err)
+	}
+
 	var signedProp *pb.SignedProposal
 	signedProp, err = putils.GetSignedProposal(prop, signer)
 	if err != nil {
@@ -23,6 +23,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"golang.org/x/net/context"
 
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/common/ccpackage"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/peer/common"
@@ -78,6 +79,10 @@ func install(msg proto.Message, cf *ChaincodeCmdFactory) error {
 		return fmt.Errorf("Error creating proposal  %s: %s", chainFuncName, err)
 	}
 
+	if err = utils.BindProposalToTLSCertificate(prop, comm.TLSCertificateHash(cf.Certificate)); err != nil {
+		return fmt.Errorf("Error binding proposal %s: %s", chainFuncName, err)
+	}
+
 	var signedProp *pb.SignedProposal
 	signedProp, err = utils.GetSignedProposal(prop, cf.Signer)
 	if err != nil {
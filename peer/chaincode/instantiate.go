@@ -19,6 +19,7 @@ package chaincode
 import (
 	"fmt"
 
+	"github.com/hyperledger/fabric/core/comm"
 	protcommon "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -80,6 +81,10 @@ func instantiate(cmd *cobra.Command, cf *ChaincodeCmdFactory) (*protcommon.Envel
 		return nil, fmt.Errorf("Error creating proposal  %s: %s", chainFuncName, err)
 	}
 
+	if err = utils.BindProposalToTLSCertificate(prop, comm.TLSCertificateHash(cf.Certificate)); err != nil {
+		return nil, fmt.Errorf("Error binding proposal %s: %s", chainFuncName, err)
+	}
+
 	var signedProp *pb.SignedProposal
 	signedProp, err = utils.GetSignedProposal(prop, cf.Signer)
 	if err != nil {
@@ -18,19 +18,31 @@ package chaincode
 
 import (
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"io"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/common/flogging"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/broadcast"
+	"github.com/hyperledger/fabric/orderer/common/filter"
 	"github.com/hyperledger/fabric/peer/common"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	logging "github.com/op/go-logging"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 )
 
 func TestInvokeCmd(t *testing.T) {
@@ -166,6 +178,111 @@ func TestInvokeCmdEndorsementFailure(t *testing.T) {
 
 }
 
+func TestInvokeCmdTLSBinding(t *testing.T) {
+	InitMSP()
+	mockCF, err := getMockChaincodeCmdFactory()
+	assert.NoError(t, err, "Error getting mock chaincode command factory")
+	bc := &mockCapturingBroadcastClient{}
+	mockCF.BroadcastClient = bc
+	mockCF.Certificate = tls.Certificate{Certificate: [][]byte{[]byte("client cert")}}
+	clientCert := &x509.Certificate{Raw: []byte("client cert")}
+
+	cmd := invokeCmd(mockCF)
+	addFlags(cmd)
+	args := []string{"-n", "example02", "-c", "{\"Args\": [\"invoke\",\"a\",\"b\",\"10\"]}"}
+	cmd.SetArgs(args)
+	assert.NoError(t, cmd.Execute(), "Run chaincode invoke cmd error")
+	assert.Equal(t, cb.Status_SUCCESS, broadcastWithTLSBinding(bc.env, clientCert),
+		"The transaction bound to the client certificate should pass Broadcast")
+	assert.Equal(t, cb.Status_FORBIDDEN, broadcastWithTLSBinding(bc.env, &x509.Certificate{Raw: []byte("another client cert")}),
+		"The transaction should be rejected when broadcast by another client")
+
+	mockCF.Certificate = tls.Certificate{}
+	assert.NoError(t, cmd.Execute(), "Run chaincode invoke cmd error")
+	assert.Equal(t, cb.Status_FORBIDDEN, broadcastWithTLSBinding(bc.env, clientCert),
+		"An unbound transaction should be rejected")
+}
+
+// broadcastWithTLSBinding sends env to the Broadcast handler of the orderer over
+// a connection presenting clientCert, to a channel which requires the TLS binding,
+// and returns the status of the reply
+func broadcastWithTLSBinding(env *cb.Envelope, clientCert *x509.Certificate) cb.Status {
+	stream := &mockBroadcastStream{
+		ctx: peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{clientCert}}},
+		}),
+		env: env,
+	}
+	broadcast.NewHandlerImpl(&mockSupportManager{}).Handle(stream)
+	return stream.reply.Status
+}
+
+type mockCapturingBroadcastClient struct {
+	env *cb.Envelope
+}
+
+func (m *mockCapturingBroadcastClient) Send(env *cb.Envelope) error {
+	m.env = env
+	return nil
+}
+
+func (m *mockCapturingBroadcastClient) Close() error {
+	return nil
+}
+
+// mockBroadcastStream receives a single envelope and records the reply to it
+type mockBroadcastStream struct {
+	grpc.ServerStream
+	ctx   context.Context
+	env   *cb.Envelope
+	reply *ab.BroadcastResponse
+}
+
+func (m *mockBroadcastStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockBroadcastStream) Send(reply *ab.BroadcastResponse) error {
+	m.reply = reply
+	return nil
+}
+
+func (m *mockBroadcastStream) Recv() (*cb.Envelope, error) {
+	if m.env == nil {
+		return nil, io.EOF
+	}
+	env := m.env
+	m.env = nil
+	return env, nil
+}
+
+// mockSupportManager supports any channel, requiring the TLS binding
+type mockSupportManager struct{}
+
+func (mm *mockSupportManager) Process(configTx *cb.Envelope) (*cb.Envelope, error) {
+	return nil, errors.New("config updates are not supported")
+}
+
+func (mm *mockSupportManager) GetChain(chainID string) (broadcast.Support, bool) {
+	return mm, true
+}
+
+func (mm *mockSupportManager) Enqueue(env *cb.Envelope) bool {
+	return true
+}
+
+func (mm *mockSupportManager) Filters() *filter.RuleSet {
+	return filter.NewRuleSet([]filter.Rule{filter.EmptyRejectRule, filter.AcceptRule})
+}
+
+func (mm *mockSupportManager) SharedConfig() config.Orderer {
+	return &mockconfig.Orderer{}
+}
+
+func (mm *mockSupportManager) ChannelConfig() config.Channel {
+	return &mockconfig.Channel{CapabilitiesVal: &mockconfig.ChannelCapabilities{TLSBindingVal: true}}
+}
+
 // Returns mock chaincode command factory
 func getMockChaincodeCmdFactory() (*ChaincodeCmdFactory, error) {
 	signer, err := common.GetDefaultSigner()
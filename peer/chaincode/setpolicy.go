@@ -0,0 +1,159 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/peer/common"
+	protcommon "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var chaincodeSetPolicyCmd *cobra.Command
+
+const setPolicyCmdName = "setpolicy"
+
+// setPolicyCmd returns the cobra command for Chaincode SetPolicy
+func setPolicyCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	chaincodeSetPolicyCmd = &cobra.Command{
+		Use:       setPolicyCmdName,
+		Short:     "Set the endorsement policy of a chaincode.",
+		Long:      "Replace the endorsement policy (and optionally escc and vscc) of an instantiated chaincode without upgrading it. The new policy applies upon the transaction committed.",
+		ValidArgs: []string{"1"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return chaincodeSetPolicy(cmd, args, cf)
+		},
+	}
+	flagList := []string{
+		"name",
+		"channelID",
+		"policy",
+		"escc",
+		"vscc",
+	}
+	attachFlags(chaincodeSetPolicyCmd, flagList)
+
+	return chaincodeSetPolicyCmd
+}
+
+// checkSetPolicyCmdParams checks the parameters of the setpolicy command;
+// unlike instantiate and upgrade, escc and vscc are only sent when they are
+// explicitly provided so that the current ones are retained otherwise
+func checkSetPolicyCmdParams() ([]byte, []byte, []byte, error) {
+	if chaincodeName == common.UndefinedParamValue {
+		return nil, nil, nil, fmt.Errorf("Must supply value for %s name parameter.", chainFuncName)
+	}
+
+	if policy == common.UndefinedParamValue {
+		return nil, nil, nil, fmt.Errorf("Endorsement policy is not provided for %s", setPolicyCmdName)
+	}
+	p, err := cauthdsl.FromString(policy)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("Invalid policy %s", policy)
+	}
+	policyBytes := utils.MarshalOrPanic(p)
+
+	var esccBytes, vsccBytes []byte
+	if escc != common.UndefinedParamValue {
+		logger.Infof("Using escc %s", escc)
+		esccBytes = []byte(escc)
+	}
+	if vscc != common.UndefinedParamValue {
+		logger.Infof("Using vscc %s", vscc)
+		vsccBytes = []byte(vscc)
+	}
+
+	return policyBytes, esccBytes, vsccBytes, nil
+}
+
+//setPolicy sets the endorsement policy via Endorser
+func setPolicy(cmd *cobra.Command, cf *ChaincodeCmdFactory) (*protcommon.Envelope, error) {
+	policyBytes, esccBytes, vsccBytes, err := checkSetPolicyCmdParams()
+	if err != nil {
+		return nil, err
+	}
+
+	creator, err := cf.Signer.Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("Error serializing identity for %s: %s", cf.Signer.GetIdentifier(), err)
+	}
+
+	prop, _, err := utils.CreateSetPolicyProposal(chainID, chaincodeName, creator, policyBytes, esccBytes, vsccBytes)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating proposal %s: %s", chainFuncName, err)
+	}
+
+	if err = utils.BindProposalToTLSCertificate(prop, comm.TLSCertificateHash(cf.Certificate)); err != nil {
+		return nil, fmt.Errorf("Error binding proposal %s: %s", chainFuncName, err)
+	}
+	logger.Debugf("Get setpolicy proposal for chaincode <%s>", chaincodeName)
+
+	var signedProp *pb.SignedProposal
+	signedProp, err = utils.GetSignedProposal(prop, cf.Signer)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating signed proposal  %s: %s", chainFuncName, err)
+	}
+
+	proposalResponse, err := cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return nil, fmt.Errorf("Error endorsing %s: %s", chainFuncName, err)
+	}
+	logger.Debugf("endorse setpolicy proposal, get response <%v>", proposalResponse.Response)
+
+	if proposalResponse != nil {
+		// assemble a signed transaction (it's an Envelope message)
+		env, err := utils.CreateSignedTx(prop, cf.Signer, proposalResponse)
+		if err != nil {
+			return nil, fmt.Errorf("Could not assemble transaction, err %s", err)
+		}
+		logger.Debug("Get Signed envelope")
+		return env, nil
+	}
+
+	return nil, nil
+}
+
+// chaincodeSetPolicy sets the endorsement policy of an instantiated chaincode
+func chaincodeSetPolicy(cmd *cobra.Command, args []string, cf *ChaincodeCmdFactory) error {
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(true, true)
+		if err != nil {
+			return err
+		}
+	}
+	defer cf.BroadcastClient.Close()
+
+	env, err := setPolicy(cmd, cf)
+	if err != nil {
+		return err
+	}
+
+	if env != nil {
+		logger.Debug("Send signed envelope to orderer")
+		err = cf.BroadcastClient.Send(env)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func getSetPolicyMockCF(t *testing.T, status int32, sendErr error) *ChaincodeCmdFactory {
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		t.Fatalf("Get default signer error: %v", err)
+	}
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: status},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	return &ChaincodeCmdFactory{
+		EndorserClient:  common.GetMockEndorserClient(mockResponse, nil),
+		Signer:          signer,
+		BroadcastClient: common.GetMockBroadcastClient(sendErr),
+	}
+}
+
+func TestSetPolicyCmd(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	cmd := setPolicyCmd(getSetPolicyMockCF(t, 200, nil))
+	addFlags(cmd)
+
+	args := []string{"-n", "example02", "-P", "OR ('Org1MSP.member','Org2MSP.member')"}
+	cmd.SetArgs(args)
+
+	assert.NoError(t, cmd.Execute(), "Run chaincode setpolicy cmd error")
+}
+
+func TestSetPolicyCmdMissingPolicy(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	cmd := setPolicyCmd(getSetPolicyMockCF(t, 200, nil))
+	addFlags(cmd)
+
+	args := []string{"-n", "example02"}
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Endorsement policy is not provided")
+}
+
+func TestSetPolicyCmdInvalidPolicy(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	cmd := setPolicyCmd(getSetPolicyMockCF(t, 200, nil))
+	addFlags(cmd)
+
+	args := []string{"-n", "example02", "-P", "OR('Org1MSP.member'"}
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid policy")
+}
+
+func TestSetPolicyCmdEndorseFail(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	cmd := setPolicyCmd(getSetPolicyMockCF(t, 500, nil))
+	addFlags(cmd)
+
+	args := []string{"-n", "example02", "-P", "OR ('Org1MSP.member','Org2MSP.member')"}
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Could not assemble transaction")
+}
+
+func TestSetPolicyCmdSendTXFail(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	sendErr := errors.New("send tx failed")
+	cmd := setPolicyCmd(getSetPolicyMockCF(t, 200, sendErr))
+	addFlags(cmd)
+
+	args := []string{"-n", "example02", "-P", "OR ('Org1MSP.member','Org2MSP.member')", "-E", "escc"}
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, sendErr.Error())
+}
@@ -19,6 +19,7 @@ package chaincode
 import (
 	"fmt"
 
+	"github.com/hyperledger/fabric/core/comm"
 	protcommon "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -78,6 +79,10 @@ func upgrade(cmd *cobra.Command, cf *ChaincodeCmdFactory) (*protcommon.Envelope,
 	if err != nil {
 		return nil, fmt.Errorf("Error creating proposal %s: %s", chainFuncName, err)
 	}
+
+	if err = utils.BindProposalToTLSCertificate(prop, comm.TLSCertificateHash(cf.Certificate)); err != nil {
+		return nil, fmt.Errorf("Error binding proposal %s: %s", chainFuncName, err)
+	}
 	logger.Debugf("Get upgrade proposal for chaincode <%v>", spec.ChaincodeId)
 
 	var signedProp *pb.SignedProposal
@@ -17,10 +17,12 @@ limitations under the License.
 package channel
 
 import (
+	"crypto/tls"
 	"fmt"
 	"strings"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/peer/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -29,13 +31,12 @@ import (
 	"github.com/spf13/pflag"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 )
 
 const (
 	channelFuncName = "channel"
-	shortDes        = "Operate a channel: create|fetch|join|list|update."
-	longDes         = "Operate a channel: create|fetch|join|list|update."
+	shortDes        = "Operate a channel: create|fetch|join|leave|list|signconfigtx|update."
+	longDes         = "Operate a channel: create|fetch|join|leave|list|signconfigtx|update."
 )
 
 var logger = flogging.MustGetLogger("channelCmd")
@@ -58,7 +59,7 @@ var (
 	chainID          string
 	channelTxFile    string
 	orderingEndpoint string
-	tls              bool
+	tlsEnabled       bool
 	caFile           string
 	timeout          int
 )
@@ -70,7 +71,9 @@ func Cmd(cf *ChannelCmdFactory) *cobra.Command {
 	channelCmd.AddCommand(createCmd(cf))
 	channelCmd.AddCommand(fetchCmd(cf))
 	channelCmd.AddCommand(joinCmd(cf))
+	channelCmd.AddCommand(leaveCmd(cf))
 	channelCmd.AddCommand(listCmd(cf))
+	channelCmd.AddCommand(signconfigtxCmd(cf))
 	channelCmd.AddCommand(updateCmd(cf))
 
 	return channelCmd
@@ -81,7 +84,7 @@ func AddFlags(cmd *cobra.Command) {
 	flags := cmd.PersistentFlags()
 
 	flags.StringVarP(&orderingEndpoint, "orderer", "o", "", "Ordering service endpoint")
-	flags.BoolVarP(&tls, "tls", "", false, "Use TLS when communicating with the orderer endpoint")
+	flags.BoolVarP(&tlsEnabled, "tls", "", false, "Use TLS when communicating with the orderer endpoint")
 	flags.StringVarP(&caFile, "cafile", "", "", "Path to file containing PEM-encoded trusted certificate(s) for the ordering endpoint")
 }
 
@@ -127,6 +130,7 @@ type ChannelCmdFactory struct {
 	BroadcastClient  common.BroadcastClient
 	DeliverClient    deliverClientIntf
 	BroadcastFactory BroadcastClientFactory
+	Certificate      tls.Certificate
 }
 
 // InitCmdFactory init the ChannelCmdFactory with clients to endorser and orderer according to params
@@ -140,8 +144,13 @@ func InitCmdFactory(isEndorserRequired EndorserRequirement, isOrdererRequired Or
 		return nil, fmt.Errorf("Error getting default signer: %s", err)
 	}
 
+	cmdFact.Certificate, err = common.GetCertificateFnc()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting client certificate: %s", err)
+	}
+
 	cmdFact.BroadcastFactory = func() (common.BroadcastClient, error) {
-		return common.GetBroadcastClientFnc(orderingEndpoint, tls, caFile)
+		return common.GetBroadcastClientFnc(orderingEndpoint, tlsEnabled, caFile)
 	}
 
 	//for join and list, we need the endorser as well
@@ -160,9 +169,9 @@ func InitCmdFactory(isEndorserRequired EndorserRequirement, isOrdererRequired Or
 
 		var opts []grpc.DialOption
 		// check for TLS
-		if tls {
+		if tlsEnabled {
 			if caFile != "" {
-				creds, err := credentials.NewClientTLSFromFile(caFile, "")
+				creds, err := common.GetOrdererTLSCredentials(caFile)
 				if err != nil {
 					return nil, fmt.Errorf("Error connecting to %s due to %s", orderingEndpoint, err)
 				}
@@ -181,7 +190,7 @@ func InitCmdFactory(isEndorserRequired EndorserRequirement, isOrdererRequired Or
 			return nil, fmt.Errorf("Error connecting due to  %s", err)
 		}
 
-		cmdFact.DeliverClient = newDeliverClient(conn, client, chainID)
+		cmdFact.DeliverClient = newDeliverClient(conn, client, chainID, comm.TLSCertificateHash(cmdFact.Certificate))
 	}
 	logger.Infof("Endorser and orderer connections initialized")
 	return cmdFact, nil
@@ -25,8 +25,10 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/configtx"
 	genesisconfig "github.com/hyperledger/fabric/common/configtx/tool/localconfig"
+	"github.com/hyperledger/fabric/common/crypto"
 	localsigner "github.com/hyperledger/fabric/common/localmsp"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/comm"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/peer/common"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -93,7 +95,11 @@ func createChannelFromConfigTx(configTxFileName string) (*cb.Envelope, error) {
 	return utils.UnmarshalEnvelope(cftx)
 }
 
-func sanityCheckAndSignConfigTx(envConfigUpdate *cb.Envelope) (*cb.Envelope, error) {
+// sanityCheckAndSignConfigTx checks the config update envelope, adds the
+// signature of the local signer to the config update and wraps it into an
+// envelope signed by the local signer, bound to the TLS certificate with the
+// given hash, if any
+func sanityCheckAndSignConfigTx(envConfigUpdate *cb.Envelope, tlsCertHash []byte) (*cb.Envelope, error) {
 	payload, err := utils.ExtractPayload(envConfigUpdate)
 	if err != nil {
 		return nil, InvalidCreateTx("bad payload")
@@ -126,9 +132,19 @@ func sanityCheckAndSignConfigTx(envConfigUpdate *cb.Envelope) (*cb.Envelope, err
 	}
 
 	signer := localsigner.NewSigner()
+	if err = addConfigSignature(configUpdateEnv, signer); err != nil {
+		return nil, err
+	}
+
+	return utils.CreateSignedEnvelopeWithTLSBinding(cb.HeaderType_CONFIG_UPDATE, chainID, signer, configUpdateEnv, 0, 0, tlsCertHash)
+}
+
+// addConfigSignature appends the signature of signer over the config update
+// to the signatures of configUpdateEnv
+func addConfigSignature(configUpdateEnv *cb.ConfigUpdateEnvelope, signer crypto.LocalSigner) error {
 	sigHeader, err := signer.NewSignatureHeader()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	configSig := &cb.ConfigSignature{
@@ -136,10 +152,12 @@ func sanityCheckAndSignConfigTx(envConfigUpdate *cb.Envelope) (*cb.Envelope, err
 	}
 
 	configSig.Signature, err = signer.Sign(util.ConcatenateBytes(configSig.SignatureHeader, configUpdateEnv.ConfigUpdate))
+	if err != nil {
+		return err
+	}
 
 	configUpdateEnv.Signatures = append(configUpdateEnv.Signatures, configSig)
-
-	return utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, chainID, signer, configUpdateEnv, 0, 0)
+	return nil
 }
 
 func sendCreateChainTransaction(cf *ChannelCmdFactory) error {
@@ -156,7 +174,7 @@ func sendCreateChainTransaction(cf *ChannelCmdFactory) error {
 		}
 	}
 
-	if chCrtEnv, err = sanityCheckAndSignConfigTx(chCrtEnv); err != nil {
+	if chCrtEnv, err = sanityCheckAndSignConfigTx(chCrtEnv, comm.TLSCertificateHash(cf.Certificate)); err != nil {
 		return err
 	}
 
@@ -550,7 +550,7 @@ func TestSanityCheckAndSignChannelCreateTx(t *testing.T) {
 	env := &cb.Envelope{}
 	env.Payload = make([]byte, 10)
 	var err error
-	env, err = sanityCheckAndSignConfigTx(env)
+	env, err = sanityCheckAndSignConfigTx(env, nil)
 	assert.Error(t, err, "Error expected for nil payload")
 	assert.Contains(t, err.Error(), "bad payload")
 
@@ -559,7 +559,7 @@ func TestSanityCheckAndSignChannelCreateTx(t *testing.T) {
 	data, err1 := proto.Marshal(p)
 	assert.NoError(t, err1)
 	env = &cb.Envelope{Payload: data}
-	env, err = sanityCheckAndSignConfigTx(env)
+	env, err = sanityCheckAndSignConfigTx(env, nil)
 	assert.Error(t, err, "Error expected for bad payload header")
 	assert.Contains(t, err.Error(), "bad header")
 
@@ -569,7 +569,7 @@ func TestSanityCheckAndSignChannelCreateTx(t *testing.T) {
 	data, err = proto.Marshal(p)
 	assert.NoError(t, err)
 	env = &cb.Envelope{Payload: data}
-	env, err = sanityCheckAndSignConfigTx(env)
+	env, err = sanityCheckAndSignConfigTx(env, nil)
 	assert.Error(t, err, "Error expected for bad channel header")
 	assert.Contains(t, err.Error(), "could not unmarshall channel header")
 
@@ -587,7 +587,7 @@ func TestSanityCheckAndSignChannelCreateTx(t *testing.T) {
 	data, err = proto.Marshal(p)
 	assert.NoError(t, err)
 	env = &cb.Envelope{Payload: data}
-	env, err = sanityCheckAndSignConfigTx(env)
+	env, err = sanityCheckAndSignConfigTx(env, nil)
 	assert.Error(t, err, "Error expected for bad payload data")
 	assert.Contains(t, err.Error(), "Bad config update env")
 }
@@ -35,16 +35,17 @@ type deliverClientIntf interface {
 }
 
 type deliverClient struct {
-	conn    *grpc.ClientConn
-	client  ab.AtomicBroadcast_DeliverClient
-	chainID string
+	conn        *grpc.ClientConn
+	client      ab.AtomicBroadcast_DeliverClient
+	chainID     string
+	tlsCertHash []byte
 }
 
-func newDeliverClient(conn *grpc.ClientConn, client ab.AtomicBroadcast_DeliverClient, chainID string) *deliverClient {
-	return &deliverClient{conn: conn, client: client, chainID: chainID}
+func newDeliverClient(conn *grpc.ClientConn, client ab.AtomicBroadcast_DeliverClient, chainID string, tlsCertHash []byte) *deliverClient {
+	return &deliverClient{conn: conn, client: client, chainID: chainID, tlsCertHash: tlsCertHash}
 }
 
-func seekHelper(chainID string, position *ab.SeekPosition) *common.Envelope {
+func seekHelper(chainID string, position *ab.SeekPosition, tlsCertHash []byte) *common.Envelope {
 	seekInfo := &ab.SeekInfo{
 		Start:    position,
 		Stop:     position,
@@ -54,7 +55,7 @@ func seekHelper(chainID string, position *ab.SeekPosition) *common.Envelope {
 	//TODO- epoch and msgVersion may need to be obtained for nowfollowing usage in orderer/configupdate/configupdate.go
 	msgVersion := int32(0)
 	epoch := uint64(0)
-	env, err := utils.CreateSignedEnvelope(common.HeaderType_CONFIG_UPDATE, chainID, localmsp.NewSigner(), seekInfo, msgVersion, epoch)
+	env, err := utils.CreateSignedEnvelopeWithTLSBinding(common.HeaderType_CONFIG_UPDATE, chainID, localmsp.NewSigner(), seekInfo, msgVersion, epoch, tlsCertHash)
 	if err != nil {
 		logger.Errorf("Error signing envelope:  %s", err)
 		return nil
@@ -63,15 +64,15 @@ func seekHelper(chainID string, position *ab.SeekPosition) *common.Envelope {
 }
 
 func (r *deliverClient) seekSpecified(blockNumber uint64) error {
-	return r.client.Send(seekHelper(r.chainID, &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: blockNumber}}}))
+	return r.client.Send(seekHelper(r.chainID, &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: blockNumber}}}, r.tlsCertHash))
 }
 
 func (r *deliverClient) seekOldest() error {
-	return r.client.Send(seekHelper(r.chainID, &ab.SeekPosition{Type: &ab.SeekPosition_Oldest{Oldest: &ab.SeekOldest{}}}))
+	return r.client.Send(seekHelper(r.chainID, &ab.SeekPosition{Type: &ab.SeekPosition_Oldest{Oldest: &ab.SeekOldest{}}}, r.tlsCertHash))
 }
 
 func (r *deliverClient) seekNewest() error {
-	return r.client.Send(seekHelper(r.chainID, &ab.SeekPosition{Type: &ab.SeekPosition_Newest{Newest: &ab.SeekNewest{}}}))
+	return r.client.Send(seekHelper(r.chainID, &ab.SeekPosition{Type: &ab.SeekPosition_Newest{Newest: &ab.SeekNewest{}}}, r.tlsCertHash))
 }
 
 func (r *deliverClient) readBlock() (*common.Block, error) {
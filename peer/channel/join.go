@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/scc/cscc"
 	"github.com/hyperledger/fabric/peer/common"
 	pcommon "github.com/hyperledger/fabric/protos/common"
@@ -105,6 +106,10 @@ func executeJoin(cf *ChannelCmdFactory) (err error) {
 		return fmt.Errorf("Error creating proposal for join %s", err)
 	}
 
+	if err = putils.BindProposalToTLSCertificate(prop, comm.TLSCertificateHash(cf.Certificate)); err != nil {
+		return fmt.Errorf("Error binding proposal for join %s", err)
+	}
+
 	var signedProp *pb.SignedProposal
 	signedProp, err = putils.GetSignedProposal(prop, cf.Signer)
 	if err != nil {
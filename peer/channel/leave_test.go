@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channel
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockLeaveCF(t *testing.T, status int32) *ChannelCmdFactory {
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err, "Get default signer error: %v", err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: status},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	return &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+}
+
+func TestLeaveMissingChannelID(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	cmd := leaveCmd(mockLeaveCF(t, 200))
+	AddFlags(cmd)
+	cmd.SetArgs([]string{})
+
+	err := cmd.Execute()
+	assert.Error(t, err, "expected leave command to fail due to missing channel ID")
+	assert.Contains(t, err.Error(), "Must supply channel ID")
+}
+
+func TestLeave(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	cmd := leaveCmd(mockLeaveCF(t, 200))
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", "mychannel"})
+
+	assert.NoError(t, cmd.Execute(), "expected leave command to succeed")
+}
+
+func TestLeaveBadProposalResponse(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	cmd := leaveCmd(mockLeaveCF(t, 500))
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", "mychannel"})
+
+	err := cmd.Execute()
+	assert.Error(t, err, "expected leave command to fail")
+	assert.IsType(t, ProposalFailedErr(err.Error()), err, "expected error type of ProposalFailedErr")
+}
+
+func TestLeaveNilCF(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	cmd := leaveCmd(nil)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", "mychannel"})
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Error trying to connect to local peer")
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/scc/cscc"
 	common2 "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -63,6 +64,10 @@ func (cc *endorserClient) getChannels() ([]*pb.ChannelInfo, error) {
 		return nil, errors.New(fmt.Sprintf("Cannot create proposal, due to %s", err))
 	}
 
+	if err = utils.BindProposalToTLSCertificate(prop, comm.TLSCertificateHash(cc.cf.Certificate)); err != nil {
+		return nil, errors.New(fmt.Sprintf("Cannot bind proposal, due to %s", err))
+	}
+
 	var signedProp *pb.SignedProposal
 	signedProp, err = utils.GetSignedProposal(prop, cc.cf.Signer)
 	if err != nil {
@@ -0,0 +1,86 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"io/ioutil"
+
+	"github.com/hyperledger/fabric/common/configtx"
+	localsigner "github.com/hyperledger/fabric/common/localmsp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func signconfigtxCmd(cf *ChannelCmdFactory) *cobra.Command {
+	signconfigtxCmd := &cobra.Command{
+		Use:   "signconfigtx",
+		Short: "Signs a configtx update.",
+		Long:  "Signs the supplied configtx update file in place on the filesystem. Requires '-f'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return sign(cmd, args, cf)
+		},
+	}
+	flagList := []string{
+		"file",
+	}
+	attachFlags(signconfigtxCmd, flagList)
+
+	return signconfigtxCmd
+}
+
+func sign(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
+	if channelTxFile == "" {
+		return InvalidCreateTx("No configtx file name supplied")
+	}
+
+	fileData, err := ioutil.ReadFile(channelTxFile)
+	if err != nil {
+		return ConfigTxFileNotFound(err.Error())
+	}
+
+	ctxEnv, err := utils.UnmarshalEnvelope(fileData)
+	if err != nil {
+		return err
+	}
+
+	payload, err := utils.ExtractPayload(ctxEnv)
+	if err != nil {
+		return InvalidCreateTx("bad payload")
+	}
+
+	if payload.Header == nil || payload.Header.ChannelHeader == nil {
+		return InvalidCreateTx("bad header")
+	}
+
+	ch, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return InvalidCreateTx("could not unmarshall channel header")
+	}
+
+	if ch.Type != int32(cb.HeaderType_CONFIG_UPDATE) {
+		return InvalidCreateTx("bad type")
+	}
+
+	configUpdateEnv, err := configtx.UnmarshalConfigUpdateEnvelope(payload.Data)
+	if err != nil {
+		return InvalidCreateTx("Bad config update env")
+	}
+
+	if err = addConfigSignature(configUpdateEnv, localsigner.NewSigner()); err != nil {
+		return err
+	}
+
+	payload.Data = utils.MarshalOrPanic(configUpdateEnv)
+
+	// The signature of the envelope does not cover the new payload, and is
+	// replaced by the one of the submitter at update time
+	signedEnv := &cb.Envelope{Payload: utils.MarshalOrPanic(payload)}
+
+	return ioutil.WriteFile(channelTxFile, utils.MarshalOrPanic(signedEnv), 0660)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignConfigtx(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	dir, err := ioutil.TempDir("/tmp", "signconfigtxtest-")
+	if err != nil {
+		t.Fatalf("couldn't create temp dir")
+	}
+	defer os.RemoveAll(dir) // clean up
+
+	configtxFile := filepath.Join(dir, mockChannel)
+	createBatchSizeUpdateTxFile(t, configtxFile, configBlock(nil))
+
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		t.Fatalf("Get default signer error: %v", err)
+	}
+
+	mockCF := &ChannelCmdFactory{
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+		DeliverClient:    &mockDeliverClient{},
+	}
+
+	for i := 0; i < 2; i++ {
+		cmd := signconfigtxCmd(mockCF)
+		AddFlags(cmd)
+		cmd.SetArgs([]string{"-f", configtxFile})
+		assert.NoError(t, cmd.Execute())
+	}
+
+	fileData, err := ioutil.ReadFile(configtxFile)
+	assert.NoError(t, err)
+	env, err := utils.UnmarshalEnvelope(fileData)
+	assert.NoError(t, err)
+	payload, err := utils.ExtractPayload(env)
+	assert.NoError(t, err)
+	ch, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	assert.NoError(t, err)
+	assert.Equal(t, provisional.TestChainID, ch.ChannelId, "Header should have been preserved")
+	configUpdateEnv, err := configtx.UnmarshalConfigUpdateEnvelope(payload.Data)
+	assert.NoError(t, err)
+	assert.Len(t, configUpdateEnv.Signatures, 2, "Each invocation should have appended a signature")
+
+	signedData, err := configUpdateEnv.AsSignedData()
+	assert.NoError(t, err)
+	for _, sd := range signedData {
+		assert.NoError(t, signer.Verify(sd.Data, sd.Signature))
+	}
+}
+
+func TestSignConfigtxMissingConfigTxFlag(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	cmd := signconfigtxCmd(&ChannelCmdFactory{})
+	AddFlags(cmd)
+	cmd.SetArgs([]string{})
+
+	assert.Error(t, cmd.Execute())
+}
+
+func TestSignConfigtxMissingConfigTxFile(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	cmd := signconfigtxCmd(&ChannelCmdFactory{})
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-f", "Non-existant"})
+
+	assert.Error(t, cmd.Execute())
+}
+
+func TestSignConfigtxWrongType(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	dir, err := ioutil.TempDir("/tmp", "signconfigtxtest-")
+	if err != nil {
+		t.Fatalf("couldn't create temp dir")
+	}
+	defer os.RemoveAll(dir) // clean up
+
+	configtxFile := filepath.Join(dir, mockChannel)
+	if _, err = createTxFile(configtxFile, cb.HeaderType_CONFIG, mockChannel); err != nil {
+		t.Fatalf("couldn't create tx file")
+	}
+
+	cmd := signconfigtxCmd(&ChannelCmdFactory{})
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-f", configtxFile})
+
+	assert.Error(t, cmd.Execute())
+}
@@ -19,10 +19,15 @@ package channel
 import (
 	"fmt"
 	"io/ioutil"
+	"strings"
 
 	"errors"
 
+	"github.com/hyperledger/fabric/common/configtx"
+	configtxapi "github.com/hyperledger/fabric/common/configtx/api"
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
 
 	"github.com/spf13/cobra"
@@ -32,7 +37,7 @@ func updateCmd(cf *ChannelCmdFactory) *cobra.Command {
 	updateCmd := &cobra.Command{
 		Use:   "update",
 		Short: "Send a configtx update.",
-		Long:  "Signs and sends the supplied configtx update file to the channel. Requires '-f', '-o', '-c'.",
+		Long:  "Signs and sends the supplied configtx update file to the channel, once checked that its signatures satisfy the current policies of the channel. Requires '-f', '-o', '-c'.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return update(cmd, args, cf)
 		},
@@ -74,11 +79,15 @@ func update(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
 		return err
 	}
 
-	sCtxEnv, err := sanityCheckAndSignConfigTx(ctxEnv)
+	sCtxEnv, err := sanityCheckAndSignConfigTx(ctxEnv, comm.TLSCertificateHash(cf.Certificate))
 	if err != nil {
 		return err
 	}
 
+	if err = checkPolicyRequirements(cf, sCtxEnv); err != nil {
+		return err
+	}
+
 	var broadcastClient common.BroadcastClient
 	broadcastClient, err = cf.BroadcastFactory()
 	if err != nil {
@@ -88,3 +97,73 @@ func update(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
 	defer broadcastClient.Close()
 	return broadcastClient.Send(sCtxEnv)
 }
+
+// checkPolicyRequirements evaluates the modification policies the config update
+// must satisfy against the current config of the channel, logging which are
+// satisfied by the collected signatures, and fails when some remain
+func checkPolicyRequirements(cf *ChannelCmdFactory, env *cb.Envelope) error {
+	configManager, err := currentConfig(cf)
+	if err != nil {
+		logger.Warningf("Cannot check the signatures of the config update against the current config of the channel: %s", err)
+		return nil
+	}
+
+	payload, err := utils.ExtractPayload(env)
+	if err != nil {
+		return err
+	}
+
+	configUpdateEnv, err := configtx.UnmarshalConfigUpdateEnvelope(payload.Data)
+	if err != nil {
+		return err
+	}
+
+	requirements, err := configManager.EvaluateUpdatePolicies(configUpdateEnv)
+	if err != nil {
+		return fmt.Errorf("Config update is not valid for the current config of the channel: %s", err)
+	}
+
+	var remaining []string
+	for _, requirement := range requirements {
+		if requirement.Err != nil {
+			logger.Infof("Policy %s to modify %s is not satisfied: %s", requirement.ModPolicy, requirement.Key, requirement.Err)
+			remaining = append(remaining, requirement.Key)
+			continue
+		}
+		logger.Infof("Policy %s to modify %s is satisfied", requirement.ModPolicy, requirement.Key)
+	}
+
+	if len(remaining) > 0 {
+		return fmt.Errorf("Config update lacks signatures to modify %s", strings.Join(remaining, ", "))
+	}
+	return nil
+}
+
+// currentConfig retrieves the last config block of the channel from the orderer
+func currentConfig(cf *ChannelCmdFactory) (configtxapi.Manager, error) {
+	block, err := cf.DeliverClient.getNewestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Metadata == nil || len(block.Metadata.Metadata) <= int(cb.BlockMetadataIndex_LAST_CONFIG) {
+		return nil, fmt.Errorf("Newest block does not record the last config block")
+	}
+
+	lc, err := utils.GetLastConfigIndexFromBlock(block)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err = cf.DeliverClient.getSpecifiedBlock(lc)
+	if err != nil {
+		return nil, err
+	}
+
+	envelopeConfig, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, fmt.Errorf("Error extracting config block envelope: %s", err)
+	}
+
+	return configtx.NewManagerImpl(envelopeConfig, configtx.NewInitializer(), nil)
+}
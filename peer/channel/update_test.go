@@ -22,9 +22,17 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/configtx"
+	genesisconfig "github.com/hyperledger/fabric/common/configtx/tool/localconfig"
+	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
+	configupdate "github.com/hyperledger/fabric/common/tools/configtxlator/update"
 	"github.com/hyperledger/fabric/peer/common"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -151,3 +159,144 @@ func TestUpdateChannelMissingChannelID(t *testing.T) {
 
 	assert.Error(t, cmd.Execute())
 }
+
+// mockConfigDeliverClient returns the config block of the channel as any block
+type mockConfigDeliverClient struct {
+	mockDeliverClient
+	block *cb.Block
+}
+
+func (m *mockConfigDeliverClient) getSpecifiedBlock(num uint64) (*cb.Block, error) {
+	return m.block, nil
+}
+
+func (m *mockConfigDeliverClient) getNewestBlock() (*cb.Block, error) {
+	return m.block, nil
+}
+
+// configBlock returns a genesis block for channel whose config is edited by modify
+func configBlock(modify func(config *cb.Config)) *cb.Block {
+	block := provisional.New(genesisconfig.Load(genesisconfig.SampleSingleMSPSoloProfile)).GenesisBlockForChannel(provisional.TestChainID)
+	if modify == nil {
+		return block
+	}
+
+	envelope := utils.ExtractEnvelopeOrPanic(block, 0)
+	payload := utils.UnmarshalPayloadOrPanic(envelope.Payload)
+	configEnv := configtx.UnmarshalConfigEnvelopeOrPanic(payload.Data)
+	modify(configEnv.Config)
+	payload.Data = utils.MarshalOrPanic(configEnv)
+	envelope.Payload = utils.MarshalOrPanic(payload)
+	block.Data.Data[0] = utils.MarshalOrPanic(envelope)
+	return block
+}
+
+// createBatchSizeUpdateTxFile writes a config update changing the batch size of
+// the config in block
+func createBatchSizeUpdateTxFile(t *testing.T, filename string, block *cb.Block) {
+	payload := utils.UnmarshalPayloadOrPanic(utils.ExtractEnvelopeOrPanic(block, 0).Payload)
+	original := configtx.UnmarshalConfigEnvelopeOrPanic(payload.Data).Config
+	updated := proto.Clone(original).(*cb.Config)
+	updated.ChannelGroup.Groups["Orderer"].Values["BatchSize"].Value = utils.MarshalOrPanic(&ab.BatchSize{
+		MaxMessageCount:   20,
+		AbsoluteMaxBytes:  10 * 1024 * 1024,
+		PreferredMaxBytes: 512 * 1024,
+	})
+
+	configUpdate, err := configupdate.Compute(original, updated)
+	assert.NoError(t, err)
+	configUpdate.ChannelId = provisional.TestChainID
+
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, provisional.TestChainID, nil, &cb.ConfigUpdateEnvelope{
+		ConfigUpdate: utils.MarshalOrPanic(configUpdate),
+	}, 0, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filename, utils.MarshalOrPanic(env), 0644))
+}
+
+func TestUpdateChannelPolicyCheck(t *testing.T) {
+	InitMSP()
+
+	dir, err := ioutil.TempDir("/tmp", "updatepolicytest-")
+	if err != nil {
+		t.Fatalf("couldn't create temp dir")
+	}
+	defer os.RemoveAll(dir) // clean up
+
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		t.Fatalf("Get default signer error: %v", err)
+	}
+
+	t.Run("Satisfied", func(t *testing.T) {
+		resetFlags()
+
+		block := configBlock(nil)
+		configtxFile := filepath.Join(dir, "satisfied")
+		createBatchSizeUpdateTxFile(t, configtxFile, block)
+
+		mockCF := &ChannelCmdFactory{
+			BroadcastFactory: mockBroadcastClientFactory,
+			Signer:           signer,
+			DeliverClient:    &mockConfigDeliverClient{block: block},
+		}
+
+		cmd := updateCmd(mockCF)
+		AddFlags(cmd)
+		cmd.SetArgs([]string{"-c", provisional.TestChainID, "-f", configtxFile, "-o", "localhost:7050"})
+
+		assert.NoError(t, cmd.Execute(), "The local admin signature satisfies the Admins policy of the orderer")
+	})
+
+	t.Run("Remaining", func(t *testing.T) {
+		resetFlags()
+
+		block := configBlock(func(config *cb.Config) {
+			config.ChannelGroup.Groups["Orderer"].Policies["Admins"].Policy = &cb.Policy{
+				Type:  int32(cb.Policy_SIGNATURE),
+				Value: utils.MarshalOrPanic(cauthdsl.RejectAllPolicy),
+			}
+		})
+		configtxFile := filepath.Join(dir, "remaining")
+		createBatchSizeUpdateTxFile(t, configtxFile, block)
+
+		mockCF := &ChannelCmdFactory{
+			BroadcastFactory: mockBroadcastClientFactory,
+			Signer:           signer,
+			DeliverClient:    &mockConfigDeliverClient{block: block},
+		}
+
+		cmd := updateCmd(mockCF)
+		AddFlags(cmd)
+		cmd.SetArgs([]string{"-c", provisional.TestChainID, "-f", configtxFile, "-o", "localhost:7050"})
+
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "[Values] /Channel/Orderer/BatchSize")
+	})
+
+	t.Run("Stale", func(t *testing.T) {
+		resetFlags()
+
+		block := configBlock(nil)
+		configtxFile := filepath.Join(dir, "stale")
+		createBatchSizeUpdateTxFile(t, configtxFile, block)
+
+		// The batch size was modified since the update was computed
+		staleBlock := configBlock(func(config *cb.Config) {
+			config.ChannelGroup.Groups["Orderer"].Values["BatchSize"].Version = 1
+		})
+
+		mockCF := &ChannelCmdFactory{
+			BroadcastFactory: mockBroadcastClientFactory,
+			Signer:           signer,
+			DeliverClient:    &mockConfigDeliverClient{block: staleBlock},
+		}
+
+		cmd := updateCmd(mockCF)
+		AddFlags(cmd)
+		cmd.SetArgs([]string{"-c", provisional.TestChainID, "-f", configtxFile, "-o", "localhost:7050"})
+
+		assert.Error(t, cmd.Execute())
+	})
+}
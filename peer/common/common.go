@@ -17,6 +17,7 @@ limitations under the License.
 package common
 
 import (
+	"crypto/tls"
 	"fmt"
 	"os"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/viperutil"
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/scc/cscc"
@@ -63,6 +65,10 @@ var (
 	// by default it is set to GetOrdererEndpointOfChain function
 	GetOrdererEndpointOfChainFnc func(chainID string, signer msp.SigningIdentity,
 		endorserClient pb.EndorserClient) ([]string, error)
+
+	// GetCertificateFnc is a function that returns the client TLS certificate
+	// by default it is set to GetCertificate function
+	GetCertificateFnc func() (tls.Certificate, error)
 )
 
 func init() {
@@ -70,6 +76,7 @@ func init() {
 	GetDefaultSignerFnc = GetDefaultSigner
 	GetBroadcastClientFnc = GetBroadcastClient
 	GetOrdererEndpointOfChainFnc = GetOrdererEndpointOfChain
+	GetCertificateFnc = GetCertificate
 }
 
 //InitConfig initializes viper config
@@ -131,6 +138,14 @@ func GetAdminClient() (pb.AdminClient, error) {
 	return adminClient, nil
 }
 
+// GetCertificate returns the client TLS certificate presented to the peer and
+// the ordering service, which the proposals and transactions of the cli are
+// bound to. The certificate is empty, and nothing is bound to it, when TLS is
+// disabled or no client certificate is configured
+func GetCertificate() (tls.Certificate, error) {
+	return comm.GetClientCertificate()
+}
+
 // GetDefaultSigner return a default Signer(Default/PERR) for cli
 func GetDefaultSigner() (msp.SigningIdentity, error) {
 	signer, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
@@ -163,6 +178,15 @@ func GetOrdererEndpointOfChain(chainID string, signer msp.SigningIdentity, endor
 		return nil, fmt.Errorf("Error creating GetConfigBlock proposal: %s", err)
 	}
 
+	cert, err := GetCertificateFnc()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting client certificate: %s", err)
+	}
+
+	if err = putils.BindProposalToTLSCertificate(prop, comm.TLSCertificateHash(cert)); err != nil {
+		return nil, fmt.Errorf("Error binding GetConfigBlock proposal to the client certificate: %s", err)
+	}
+
 	signedProp, err := putils.GetSignedProposal(prop, signer)
 	if err != nil {
 		return nil, fmt.Errorf("Error creating signed GetConfigBlock proposal: %s", err)
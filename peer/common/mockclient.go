@@ -94,3 +94,11 @@ func (m *mockAdminClient) SetModuleLogLevel(ctx context.Context, in *pb.LogLevel
 func (m *mockAdminClient) RevertLogLevels(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
 	return &empty.Empty{}, m.err
 }
+
+func (m *mockAdminClient) GetGossipReputations(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*pb.GossipReputations, error) {
+	return &pb.GossipReputations{}, m.err
+}
+
+func (m *mockAdminClient) GetDeliveryFailovers(ctx context.Context, in *pb.DeliveryFailoversRequest, opts ...grpc.CallOption) (*pb.DeliveryFailovers, error) {
+	return &pb.DeliveryFailovers{}, m.err
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package common
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
 	"strings"
 	"time"
 
@@ -50,7 +53,7 @@ func GetBroadcastClient(orderingEndpoint string, tlsEnabled bool, caFile string)
 	// check for TLS
 	if tlsEnabled {
 		if caFile != "" {
-			creds, err := credentials.NewClientTLSFromFile(caFile, "")
+			creds, err := GetOrdererTLSCredentials(caFile)
 			if err != nil {
 				return nil, fmt.Errorf("Error connecting to %s due to %s", orderingEndpoint, err)
 			}
@@ -76,12 +79,39 @@ func GetBroadcastClient(orderingEndpoint string, tlsEnabled bool, caFile string)
 	return &broadcastClient{conn: conn, client: client}, nil
 }
 
+// GetOrdererTLSCredentials returns the TLS credentials to connect to the
+// ordering service, which trust the CA certificates in caFile and present the
+// client TLS certificate, so that the transactions bound to it are accepted
+func GetOrdererTLSCredentials(caFile string) (credentials.TransportCredentials, error) {
+	b, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(b) {
+		return nil, fmt.Errorf("failed to append the certificates of %s", caFile)
+	}
+
+	cert, err := GetCertificateFnc()
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{RootCAs: certPool}
+	if len(cert.Certificate) != 0 {
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
 func (s *broadcastClient) getAck() error {
 	msg, err := s.client.Recv()
 	if err != nil {
 		return err
 	}
 	if msg.Status != cb.Status_SUCCESS {
+		if msg.Info != "" {
+			return fmt.Errorf("Got unexpected status: %v -- %s", msg.Status, msg.Info)
+		}
 		return fmt.Errorf("Got unexpected status: %v", msg.Status)
 	}
 	return nil
@@ -50,6 +50,7 @@ type mspMessageCryptoService struct {
 	channelPolicyManagerGetter policies.ChannelPolicyManagerGetter
 	localSigner                crypto.LocalSigner
 	deserializer               mgmt.DeserializersManager
+	signatureCache             *msp.SignatureCache
 }
 
 // NewMCS creates a new instance of mspMessageCryptoService
@@ -59,7 +60,20 @@ type mspMessageCryptoService struct {
 // 2. an instance of crypto.LocalSigner
 // 3. an identity deserializer manager
 func NewMCS(channelPolicyManagerGetter policies.ChannelPolicyManagerGetter, localSigner crypto.LocalSigner, deserializer mgmt.DeserializersManager) api.MessageCryptoService {
-	return &mspMessageCryptoService{channelPolicyManagerGetter: channelPolicyManagerGetter, localSigner: localSigner, deserializer: deserializer}
+	return NewMCSWithSignatureCache(channelPolicyManagerGetter, localSigner, deserializer, nil)
+}
+
+// NewMCSWithSignatureCache creates a new instance of mspMessageCryptoService
+// like NewMCS, which additionally skips verifying the signatures of this peer's
+// organization found in signatureCache. Signatures verified against a channel's
+// policy are cached by the policy evaluation itself.
+func NewMCSWithSignatureCache(channelPolicyManagerGetter policies.ChannelPolicyManagerGetter, localSigner crypto.LocalSigner, deserializer mgmt.DeserializersManager, signatureCache *msp.SignatureCache) api.MessageCryptoService {
+	return &mspMessageCryptoService{
+		channelPolicyManagerGetter: channelPolicyManagerGetter,
+		localSigner:                localSigner,
+		deserializer:               deserializer,
+		signatureCache:             signatureCache,
+	}
 }
 
 // ValidateIdentity validates the identity of a remote peer.
@@ -215,7 +229,7 @@ func (s *mspMessageCryptoService) Verify(peerIdentity api.PeerIdentityType, sign
 		// At this stage, this means that peerIdentity
 		// belongs to this peer's LocalMSP.
 		// The signature is validated directly
-		return identity.Verify(message, signature)
+		return s.signatureCache.Verify(identity, peerIdentity, message, signature)
 	}
 
 	// At this stage, the signature must be validated
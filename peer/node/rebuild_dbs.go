@@ -0,0 +1,34 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/spf13/cobra"
+)
+
+func rebuildDBsCmd() *cobra.Command {
+	return nodeRebuildDBsCmd
+}
+
+var nodeRebuildDBsCmd = &cobra.Command{
+	Use:   "rebuild-dbs",
+	Short: "Rebuilds the databases of the ledgers.",
+	Long: `Drops the state, history and block index databases of every channel and ` +
+		`regenerates them from the blocks stored by the peer. The peer must be stopped.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return rebuildDBs()
+	},
+}
+
+func rebuildDBs() error {
+	if err := kvledger.RebuildDBs(); err != nil {
+		return err
+	}
+	logger.Info("Rebuilt the databases of the ledgers")
+	return nil
+}
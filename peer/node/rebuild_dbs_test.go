@@ -0,0 +1,27 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebuildDBsCmd(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rebuilddbs")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	viper.Set("peer.fileSystemPath", dir)
+
+	cmd := rebuildDBsCmd()
+	cmd.SetArgs([]string{})
+	assert.NoError(t, cmd.Execute(), "expected rebuild-dbs command to succeed")
+}
@@ -0,0 +1,34 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/spf13/cobra"
+)
+
+func resetCmd() *cobra.Command {
+	return nodeResetCmd
+}
+
+var nodeResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Resets every channel to its genesis block.",
+	Long: `Truncates the ledger of every channel to its genesis block and regenerates ` +
+		`the databases of the ledgers. The peer must be stopped.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return reset()
+	},
+}
+
+func reset() error {
+	if err := kvledger.ResetAllKVLedgers(); err != nil {
+		return err
+	}
+	logger.Info("Reset the ledgers to their genesis block")
+	return nil
+}
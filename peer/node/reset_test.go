@@ -0,0 +1,27 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResetCmd(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reset")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	viper.Set("peer.fileSystemPath", dir)
+
+	cmd := resetCmd()
+	cmd.SetArgs([]string{})
+	assert.NoError(t, cmd.Execute(), "expected reset command to succeed")
+}
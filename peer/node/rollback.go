@@ -0,0 +1,48 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"errors"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rollbackChannelID   string
+	rollbackBlockNumber uint64
+)
+
+func rollbackCmd() *cobra.Command {
+	flags := nodeRollbackCmd.Flags()
+	flags.StringVarP(&rollbackChannelID, "channelID", "c", "", "Channel to roll back")
+	flags.Uint64VarP(&rollbackBlockNumber, "blockNumber", "b", 0, "Number of the block to roll back to")
+
+	return nodeRollbackCmd
+}
+
+var nodeRollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Rolls back a channel to a block.",
+	Long: `Truncates the ledger of a channel after the given block and regenerates ` +
+		`its databases. The peer must be stopped.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return rollback()
+	},
+}
+
+func rollback() error {
+	if rollbackChannelID == "" {
+		return errors.New("Must supply channel ID")
+	}
+	if err := kvledger.RollbackKVLedger(rollbackChannelID, rollbackBlockNumber); err != nil {
+		return err
+	}
+	logger.Infof("Rolled back channel %s to block %d", rollbackChannelID, rollbackBlockNumber)
+	return nil
+}
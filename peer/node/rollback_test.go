@@ -0,0 +1,55 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackCmd(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rollback")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	viper.Set("peer.fileSystemPath", dir)
+
+	provider, err := kvledger.NewProvider()
+	assert.NoError(t, err)
+	bg, gb := testutil.NewBlockGenerator(t, "mychannel", false)
+	l, err := provider.Create(gb)
+	assert.NoError(t, err)
+	assert.NoError(t, l.Commit(bg.NextBlock([][]byte{})))
+	assert.NoError(t, l.Commit(bg.NextBlock([][]byte{})))
+	l.Close()
+	provider.Close()
+
+	cmd := rollbackCmd()
+	cmd.SetArgs([]string{"-b", "1"})
+	assert.Error(t, cmd.Execute(), "expected rollback command to fail due to missing channel ID")
+
+	cmd.SetArgs([]string{"-c", "nonexisting", "-b", "1"})
+	assert.Error(t, cmd.Execute(), "expected rollback command to fail due to unknown channel")
+
+	cmd.SetArgs([]string{"-c", "mychannel", "-b", "1"})
+	assert.NoError(t, cmd.Execute(), "expected rollback command to succeed")
+
+	provider, err = kvledger.NewProvider()
+	assert.NoError(t, err)
+	defer provider.Close()
+	l, err = provider.Open("mychannel")
+	assert.NoError(t, err)
+	defer l.Close()
+	bcInfo, err := l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), bcInfo.Height)
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package node
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -18,6 +19,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/localmsp"
 	"github.com/hyperledger/fabric/core"
@@ -31,6 +33,7 @@ import (
 	"github.com/hyperledger/fabric/core/scc"
 	"github.com/hyperledger/fabric/events/producer"
 	"github.com/hyperledger/fabric/gossip/service"
+	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/peer/common"
 	peergossip "github.com/hyperledger/fabric/peer/gossip"
@@ -139,6 +142,15 @@ func serve(args []string) error {
 
 	logger.Debugf("Running peer")
 
+	// Cache verified signatures, if enabled, so that VSCC and gossip
+	// do not verify the same signatures over again
+	var signatureCache *msp.SignatureCache
+	if size := viper.GetInt("peer.signatureCacheSize"); size > 0 {
+		logger.Infof("Caching up to %d verified signatures", size)
+		signatureCache = msp.NewSignatureCache(size)
+		cauthdsl.SetSignatureCache(signatureCache)
+	}
+
 	// Register the Admin server
 	pb.RegisterAdminServer(peerServer.Server(), core.NewAdminServer())
 
@@ -154,10 +166,11 @@ func serve(args []string) error {
 		logger.Panicf("Failed serializing self identity: %v", err)
 	}
 
-	messageCryptoService := peergossip.NewMCS(
+	messageCryptoService := peergossip.NewMCSWithSignatureCache(
 		peer.NewChannelPolicyManagerGetter(),
 		localmsp.NewSigner(),
-		mgmt.NewDeserializersManager())
+		mgmt.NewDeserializersManager(),
+		signatureCache)
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager())
 
 	// callback function for secure dial options for gossip service
@@ -184,6 +197,18 @@ func serve(args []string) error {
 	}
 	defer service.GetGossipService().Stop()
 
+	if secureConfig.UseTLS {
+		servers := []comm.GRPCServer{peerServer, ccSrv}
+		if ehubGrpcServer != nil {
+			servers = append(servers, ehubGrpcServer)
+		}
+		reloader, err := watchTLSCertificate(servers...)
+		if err != nil {
+			return err
+		}
+		defer reloader.Stop()
+	}
+
 	//initialize system chaincodes
 	initSysCCs()
 
@@ -345,6 +370,29 @@ func getChaincodeAddressEndpoint() (*pb.PeerEndpoint, error) {
 	}, nil
 }
 
+// watchTLSCertificate reloads the TLS certificate of the peer when its files change,
+// or when the peer receives a SIGHUP, and has the given servers, gossip and the
+// delivery of blocks from the ordering service use the new certificate
+func watchTLSCertificate(servers ...comm.GRPCServer) (*comm.CertificateReloader, error) {
+	reloader, err := comm.NewCertificateReloader(config.GetPath("peer.tls.cert.file"), config.GetPath("peer.tls.key.file"))
+	if err != nil {
+		return nil, fmt.Errorf("Failed watching the TLS certificate of the peer: %s", err)
+	}
+	for _, server := range servers {
+		reloader.OnReload(server.SetServerCertificate)
+	}
+	reloader.OnReload(func(cert tls.Certificate) {
+		service.GetGossipService().UpdateTLSCertificate(&cert)
+	})
+	casupport := comm.GetCASupport()
+	casupport.Lock()
+	casupport.ClientCertificate = reloader.Certificate
+	casupport.Unlock()
+
+	go reloader.Watch(viper.GetDuration("peer.tls.reloadInterval"), syscall.SIGHUP)
+	return reloader, nil
+}
+
 func createEventHubServer(secureConfig comm.SecureServerConfig) (comm.GRPCServer, error) {
 	var lis net.Listener
 	var err error
@@ -361,6 +409,7 @@ func createEventHubServer(secureConfig comm.SecureServerConfig) (comm.GRPCServer
 	ehServer := producer.NewEventsServer(
 		uint(viper.GetInt("peer.events.buffersize")),
 		viper.GetDuration("peer.events.timeout"))
+	ehServer.SetTLSBindingRequired(peer.TLSBindingRequiredByAnyChain)
 
 	pb.RegisterEventsServer(grpcServer.Server(), ehServer)
 	return grpcServer, nil
@@ -69,6 +69,29 @@ func (m *OrdererAddresses) GetAddresses() []string {
 	return nil
 }
 
+// TransactionTimestampWindow is encoded into the configuration transaction as a configuration item of type Chain
+// with a Key of "TransactionTimestampWindow" and a Value of TransactionTimestampWindow as marshaled protobuf bytes.
+// Endorser transactions whose channel header timestamp is further than the window from the orderer's clock at
+// Broadcast, or from the timestamp of the block they are ordered in, are rejected.
+type TransactionTimestampWindow struct {
+	// Any duration string parseable by ParseDuration():
+	// https://golang.org/pkg/time/#ParseDuration
+	// An empty window disables the check
+	Window string `protobuf:"bytes,1,opt,name=window" json:"window,omitempty"`
+}
+
+func (m *TransactionTimestampWindow) Reset()                    { *m = TransactionTimestampWindow{} }
+func (m *TransactionTimestampWindow) String() string            { return proto.CompactTextString(m) }
+func (*TransactionTimestampWindow) ProtoMessage()               {}
+func (*TransactionTimestampWindow) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{3} }
+
+func (m *TransactionTimestampWindow) GetWindow() string {
+	if m != nil {
+		return m.Window
+	}
+	return ""
+}
+
 // Consortium represents the consortium context in which the channel was created
 type Consortium struct {
 	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
@@ -77,7 +100,7 @@ type Consortium struct {
 func (m *Consortium) Reset()                    { *m = Consortium{} }
 func (m *Consortium) String() string            { return proto.CompactTextString(m) }
 func (*Consortium) ProtoMessage()               {}
-func (*Consortium) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{3} }
+func (*Consortium) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{4} }
 
 func (m *Consortium) GetName() string {
 	if m != nil {
@@ -86,30 +109,92 @@ func (m *Consortium) GetName() string {
 	return ""
 }
 
+// Capabilities message defines the capabilities a particular binary must implement
+// for that binary to be able to safely participate in the channel.  The capabilities
+// message is defined at the /Channel level, the /Channel/Application level, and the
+// /Channel/Orderer level.
+//
+// The /Channel level capabilities define capabilities which both the orderer and peer
+// binaries must satisfy.  These capabilities might be things like a new MSP type,
+// or a new policy type.
+//
+// The /Channel/Orderer level capabilities define capabilities which must be supported
+// by the orderer, but which have no bearing on the behavior of the peer.  For instance
+// if the orderer changes the logic for how it constructs new channels, only all orderers
+// must agree on the new logic.  The peers do not need to be aware of this change as
+// they only interact with the channel after it has been constructed.
+//
+// Finally, the /Channel/Application level capabilities define capabilities which the peer
+// binary must satisfy, but which have no bearing on the orderer.  For instance, if the
+// peer adds a new UTXO transaction type, or changes the chaincode lifecycle requirements,
+// all peers must agree on the new logic.  However, orderers never inspect transactions
+// this deeply, and therefore have no need to be aware of the change.
+//
+// The capabilities strings defined in these messages typically correspond to release
+// binary versions (e.g. "V1_1"), and are used primarily as a mechanism for a fully
+// upgraded network to switch from one set of logic to a new one.
+type Capabilities struct {
+	Capabilities map[string]*Capability `protobuf:"bytes,1,rep,name=capabilities" json:"capabilities,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *Capabilities) Reset()                    { *m = Capabilities{} }
+func (m *Capabilities) String() string            { return proto.CompactTextString(m) }
+func (*Capabilities) ProtoMessage()               {}
+func (*Capabilities) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{5} }
+
+func (m *Capabilities) GetCapabilities() map[string]*Capability {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+// Capability is an empty message for the time being.  It is defined as a protobuf
+// message rather than a constant, so that we may extend capabilities with other fields
+// if the need arises in the future.  For the time being, a capability being in the
+// capabilities map requires that that capability be supported.
+type Capability struct {
+}
+
+func (m *Capability) Reset()                    { *m = Capability{} }
+func (m *Capability) String() string            { return proto.CompactTextString(m) }
+func (*Capability) ProtoMessage()               {}
+func (*Capability) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{6} }
+
 func init() {
 	proto.RegisterType((*HashingAlgorithm)(nil), "common.HashingAlgorithm")
 	proto.RegisterType((*BlockDataHashingStructure)(nil), "common.BlockDataHashingStructure")
 	proto.RegisterType((*OrdererAddresses)(nil), "common.OrdererAddresses")
+	proto.RegisterType((*TransactionTimestampWindow)(nil), "common.TransactionTimestampWindow")
 	proto.RegisterType((*Consortium)(nil), "common.Consortium")
+	proto.RegisterType((*Capabilities)(nil), "common.Capabilities")
+	proto.RegisterType((*Capability)(nil), "common.Capability")
 }
 
 func init() { proto.RegisterFile("common/configuration.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 230 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x6c, 0x8f, 0xc1, 0x4a, 0x03, 0x31,
-	0x10, 0x86, 0x59, 0xd4, 0xc2, 0x0e, 0x08, 0x25, 0x78, 0xa8, 0xe2, 0x61, 0x59, 0x44, 0x0a, 0xc2,
-	0x46, 0xf1, 0x09, 0x5a, 0x3d, 0x78, 0x13, 0xb6, 0x37, 0x6f, 0xd9, 0x24, 0x4d, 0x82, 0xbb, 0x99,
-	0x32, 0x99, 0x20, 0xbe, 0xbd, 0xb8, 0x51, 0xf4, 0xd0, 0xdb, 0x7c, 0x33, 0xdf, 0x0f, 0xf3, 0xc3,
-	0x95, 0xc6, 0x69, 0xc2, 0x28, 0x35, 0xc6, 0x7d, 0x70, 0x99, 0x14, 0x07, 0x8c, 0xdd, 0x81, 0x90,
-	0x51, 0x2c, 0xca, 0xad, 0xbd, 0x85, 0xe5, 0x8b, 0x4a, 0x3e, 0x44, 0xb7, 0x19, 0x1d, 0x52, 0x60,
-	0x3f, 0x09, 0x01, 0xa7, 0x51, 0x4d, 0x76, 0x55, 0x35, 0xd5, 0xba, 0xee, 0xe7, 0xb9, 0x7d, 0x80,
-	0xcb, 0xed, 0x88, 0xfa, 0xfd, 0x59, 0xb1, 0xfa, 0x09, 0xec, 0x98, 0xb2, 0xe6, 0x4c, 0x56, 0x5c,
-	0xc0, 0xd9, 0x47, 0x30, 0xec, 0xe7, 0xc4, 0x79, 0x5f, 0xa0, 0xbd, 0x87, 0xe5, 0x2b, 0x19, 0x4b,
-	0x96, 0x36, 0xc6, 0x90, 0x4d, 0xc9, 0x26, 0x71, 0x0d, 0xb5, 0xfa, 0x85, 0x55, 0xd5, 0x9c, 0xac,
-	0xeb, 0xfe, 0x6f, 0xd1, 0x36, 0x00, 0x4f, 0x18, 0x13, 0x12, 0x87, 0x7c, 0xf4, 0x8d, 0xed, 0x0e,
-	0x6e, 0x90, 0x5c, 0xe7, 0x3f, 0x0f, 0x96, 0x46, 0x6b, 0x9c, 0xa5, 0x6e, 0xaf, 0x06, 0x0a, 0xba,
-	0xd4, 0x4a, 0x5d, 0xa9, 0xf5, 0x76, 0xe7, 0x02, 0xfb, 0x3c, 0x7c, 0xa3, 0xfc, 0x27, 0xcb, 0x22,
-	0xcb, 0x22, 0xcb, 0x22, 0x0f, 0x8b, 0x19, 0x1f, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0xb6, 0x15,
-	0xb9, 0xb4, 0x30, 0x01, 0x00, 0x00,
+	// 342 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x51, 0x4b, 0xf3, 0x30,
+	0x14, 0x86, 0xe9, 0xf6, 0x6d, 0xb0, 0xb3, 0x7d, 0x30, 0x83, 0xc8, 0x1c, 0x5e, 0x94, 0x22, 0xa3,
+	0x20, 0xb4, 0x3a, 0xbd, 0x10, 0xef, 0xb6, 0x29, 0x88, 0x37, 0x42, 0x37, 0x10, 0xbc, 0x4b, 0xdb,
+	0xac, 0x0d, 0x6b, 0x93, 0x72, 0x92, 0x3a, 0xfa, 0xab, 0xfc, 0x8b, 0xb2, 0xa6, 0xb2, 0x8d, 0x79,
+	0x77, 0x9e, 0x9e, 0xe7, 0x6d, 0x5e, 0x12, 0x18, 0x47, 0x32, 0xcf, 0xa5, 0xf0, 0x23, 0x29, 0xd6,
+	0x3c, 0x29, 0x91, 0x6a, 0x2e, 0x85, 0x57, 0xa0, 0xd4, 0x92, 0x74, 0xcd, 0xce, 0x99, 0xc0, 0xf0,
+	0x95, 0xaa, 0x94, 0x8b, 0x64, 0x96, 0x25, 0x12, 0xb9, 0x4e, 0x73, 0x42, 0xe0, 0x9f, 0xa0, 0x39,
+	0x1b, 0x59, 0xb6, 0xe5, 0xf6, 0x82, 0x7a, 0x76, 0xee, 0xe0, 0x72, 0x9e, 0xc9, 0x68, 0xf3, 0x4c,
+	0x35, 0x6d, 0x02, 0x4b, 0x8d, 0x65, 0xa4, 0x4b, 0x64, 0xe4, 0x1c, 0x3a, 0x5b, 0x1e, 0xeb, 0xb4,
+	0x4e, 0xfc, 0x0f, 0x0c, 0x38, 0xb7, 0x30, 0x7c, 0xc7, 0x98, 0x21, 0xc3, 0x59, 0x1c, 0x23, 0x53,
+	0x8a, 0x29, 0x72, 0x05, 0x3d, 0xfa, 0x0b, 0x23, 0xcb, 0x6e, 0xbb, 0xbd, 0x60, 0xff, 0xc1, 0x79,
+	0x80, 0xf1, 0x0a, 0xa9, 0x50, 0x34, 0xda, 0x35, 0x5d, 0xf1, 0x9c, 0x29, 0x4d, 0xf3, 0xe2, 0x83,
+	0x8b, 0x58, 0x6e, 0xc9, 0x05, 0x74, 0xb7, 0xf5, 0xd4, 0x14, 0x6b, 0xc8, 0xb1, 0x01, 0x16, 0x52,
+	0x28, 0x89, 0x9a, 0x97, 0x7f, 0x97, 0xff, 0xb6, 0x60, 0xb0, 0xa0, 0x05, 0x0d, 0x79, 0xc6, 0x35,
+	0x67, 0x8a, 0xbc, 0xc1, 0x20, 0x3a, 0xe0, 0xba, 0x49, 0x7f, 0x3a, 0xf1, 0xcc, 0xa5, 0x78, 0x87,
+	0xee, 0x11, 0xbc, 0x08, 0x8d, 0x55, 0x70, 0x94, 0x1d, 0x2f, 0xe1, 0xec, 0x44, 0x21, 0x43, 0x68,
+	0x6f, 0x58, 0xd5, 0x94, 0xd8, 0x8d, 0xc4, 0x85, 0xce, 0x17, 0xcd, 0x4a, 0x36, 0x6a, 0xd9, 0x96,
+	0xdb, 0x9f, 0x92, 0x93, 0xb3, 0xaa, 0xc0, 0x08, 0x4f, 0xad, 0x47, 0xcb, 0x19, 0x00, 0xec, 0x17,
+	0xf3, 0x25, 0x5c, 0x4b, 0x4c, 0xbc, 0xb4, 0x2a, 0x18, 0x66, 0x2c, 0x4e, 0x18, 0x7a, 0x6b, 0x1a,
+	0x22, 0x8f, 0xcc, 0x63, 0xaa, 0xe6, 0x5f, 0x9f, 0x37, 0x09, 0xd7, 0x69, 0x19, 0xee, 0xd0, 0x3f,
+	0x90, 0x7d, 0x23, 0xfb, 0x46, 0xf6, 0x8d, 0x1c, 0x76, 0x6b, 0xbc, 0xff, 0x19, 0x00, 0x4a, 0x18,
+	0xe6, 0x1a, 0x26, 0x02, 0x00, 0x00,
 }
@@ -5,11 +5,14 @@
 Package orderer is a generated protocol buffer package.
 
 It is generated from these files:
+
 	orderer/ab.proto
+	orderer/admin.proto
 	orderer/configuration.proto
 	orderer/kafka.proto
 
 It has these top-level messages:
+
 	BroadcastResponse
 	SeekNewest
 	SeekOldest
@@ -17,6 +20,9 @@ It has these top-level messages:
 	SeekPosition
 	SeekInfo
 	DeliverResponse
+	KafkaChannelStatus
+	ChannelStatus
+	AdminResponse
 	ConsensusType
 	BatchSize
 	BatchTimeout
@@ -73,7 +79,10 @@ func (x SeekInfo_SeekBehavior) String() string {
 func (SeekInfo_SeekBehavior) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{5, 0} }
 
 type BroadcastResponse struct {
+	// Status code, which may be used to programatically respond to success/failure
 	Status common.Status `protobuf:"varint,1,opt,name=status,enum=common.Status" json:"status,omitempty"`
+	// Info string which may contain additional information about the status returned
+	Info string `protobuf:"bytes,2,opt,name=info" json:"info,omitempty"`
 }
 
 func (m *BroadcastResponse) Reset()                    { *m = BroadcastResponse{} }
@@ -88,6 +97,13 @@ func (m *BroadcastResponse) GetStatus() common.Status {
 	return common.Status_UNKNOWN
 }
 
+func (m *BroadcastResponse) GetInfo() string {
+	if m != nil {
+		return m.Info
+	}
+	return ""
+}
+
 type SeekNewest struct {
 }
 
@@ -133,9 +149,7 @@ func (m *SeekPosition) String() string            { return proto.CompactTextStri
 func (*SeekPosition) ProtoMessage()               {}
 func (*SeekPosition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
-type isSeekPosition_Type interface {
-	isSeekPosition_Type()
-}
+type isSeekPosition_Type interface{ isSeekPosition_Type() }
 
 type SeekPosition_Newest struct {
 	Newest *SeekNewest `protobuf:"bytes,1,opt,name=newest,oneof"`
@@ -317,6 +331,8 @@ type DeliverResponse struct {
 	//	*DeliverResponse_Status
 	//	*DeliverResponse_Block
 	Type isDeliverResponse_Type `protobuf_oneof:"Type"`
+	// Info string which may contain additional information about the status returned
+	Info string `protobuf:"bytes,3,opt,name=info" json:"info,omitempty"`
 }
 
 func (m *DeliverResponse) Reset()                    { *m = DeliverResponse{} }
@@ -324,9 +340,7 @@ func (m *DeliverResponse) String() string            { return proto.CompactTextS
 func (*DeliverResponse) ProtoMessage()               {}
 func (*DeliverResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
-type isDeliverResponse_Type interface {
-	isDeliverResponse_Type()
-}
+type isDeliverResponse_Type interface{ isDeliverResponse_Type() }
 
 type DeliverResponse_Status struct {
 	Status common.Status `protobuf:"varint,1,opt,name=status,enum=common.Status,oneof"`
@@ -359,6 +373,13 @@ func (m *DeliverResponse) GetBlock() *common.Block {
 	return nil
 }
 
+func (m *DeliverResponse) GetInfo() string {
+	if m != nil {
+		return m.Info
+	}
+	return ""
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*DeliverResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _DeliverResponse_OneofMarshaler, _DeliverResponse_OneofUnmarshaler, _DeliverResponse_OneofSizer, []interface{}{
@@ -615,36 +636,37 @@ var _AtomicBroadcast_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 492 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x7c, 0x93, 0xdb, 0x6e, 0xd3, 0x4c,
-	0x10, 0xc7, 0xe3, 0xef, 0x4b, 0xd3, 0x76, 0x68, 0xd3, 0x74, 0xab, 0x56, 0x51, 0x2e, 0x10, 0xb2,
-	0x54, 0x08, 0x02, 0x6c, 0x14, 0x24, 0x2e, 0x28, 0x12, 0x8a, 0x69, 0xab, 0x44, 0x44, 0x09, 0x72,
-	0xd2, 0x0b, 0xb8, 0x89, 0x7c, 0x98, 0x24, 0xa6, 0x8e, 0xd7, 0xda, 0xdd, 0x04, 0xf5, 0x29, 0x78,
-	0x11, 0x1e, 0x89, 0x87, 0x41, 0x7b, 0xb0, 0x43, 0xa0, 0x70, 0x65, 0xcf, 0xcc, 0xef, 0x3f, 0x27,
-	0xcd, 0x42, 0x83, 0xb2, 0x18, 0x19, 0x32, 0x37, 0x08, 0x9d, 0x9c, 0x51, 0x41, 0xc9, 0xae, 0xf1,
-	0xb4, 0x4e, 0x22, 0xba, 0x5c, 0xd2, 0xcc, 0xd5, 0x1f, 0x1d, 0xb5, 0x2f, 0xe0, 0xd8, 0x63, 0x34,
-	0x88, 0xa3, 0x80, 0x0b, 0x1f, 0x79, 0x4e, 0x33, 0x8e, 0xe4, 0x31, 0xd4, 0xb8, 0x08, 0xc4, 0x8a,
-	0x37, 0xad, 0x47, 0x56, 0xbb, 0xde, 0xa9, 0x3b, 0x46, 0x33, 0x56, 0x5e, 0xdf, 0x44, 0xed, 0x03,
-	0x80, 0x31, 0xe2, 0xed, 0x10, 0xbf, 0x22, 0x17, 0x85, 0x35, 0x4a, 0x63, 0x69, 0x3d, 0x81, 0x43,
-	0x69, 0x8d, 0x73, 0x8c, 0x92, 0x59, 0x82, 0x31, 0x39, 0x83, 0x5a, 0xb6, 0x5a, 0x86, 0xc8, 0x54,
-	0xd2, 0xaa, 0x6f, 0x2c, 0xfb, 0xbb, 0x05, 0x07, 0x92, 0xfc, 0x48, 0x79, 0x22, 0x12, 0x9a, 0x91,
-	0x17, 0x50, 0xcb, 0x54, 0x46, 0x05, 0x3e, 0xe8, 0x9c, 0x38, 0x66, 0x02, 0x67, 0x53, 0xac, 0x57,
-	0xf1, 0x0d, 0x24, 0x71, 0xaa, 0x4a, 0x36, 0xff, 0xbb, 0x07, 0xd7, 0xdd, 0x48, 0x5c, 0x43, 0xe4,
-	0x35, 0xec, 0xf3, 0xa2, 0xa7, 0xe6, 0xff, 0x4a, 0x71, 0xb6, 0xa5, 0x28, 0x3b, 0xee, 0x55, 0xfc,
-	0x0d, 0xea, 0xd5, 0xa0, 0x3a, 0xb9, 0xcb, 0xd1, 0xfe, 0x61, 0xc1, 0x9e, 0xc4, 0xfa, 0xd9, 0x8c,
-	0x92, 0x67, 0xb0, 0xc3, 0x45, 0xc0, 0x8a, 0x4e, 0x4f, 0xb7, 0x12, 0x15, 0x03, 0xf9, 0x9a, 0x21,
-	0x4f, 0xa1, 0xca, 0x05, 0xcd, 0x4d, 0x9b, 0x7f, 0x61, 0x15, 0x42, 0xde, 0xc0, 0x5e, 0x88, 0x8b,
-	0x60, 0x9d, 0x50, 0xa6, 0x7a, 0xac, 0x77, 0x1e, 0x6e, 0xe1, 0xb2, 0xb8, 0xfa, 0xf1, 0x0c, 0xe5,
-	0x97, 0xbc, 0xfd, 0x56, 0xaf, 0xb3, 0x88, 0x90, 0x53, 0x38, 0xf6, 0x06, 0xa3, 0xf7, 0x1f, 0xa6,
-	0x37, 0xc3, 0x49, 0x7f, 0x30, 0xf5, 0xaf, 0xba, 0x97, 0x9f, 0x1a, 0x15, 0xe9, 0xbe, 0xee, 0xf6,
-	0x07, 0xd3, 0xfe, 0xf5, 0x74, 0x38, 0x9a, 0x18, 0xb7, 0x65, 0x7f, 0x81, 0xa3, 0x4b, 0x4c, 0x93,
-	0x35, 0xb2, 0xf2, 0x1a, 0xda, 0xff, 0xbe, 0x06, 0xb9, 0x5b, 0x1d, 0x27, 0xe7, 0xb0, 0x13, 0xa6,
-	0x34, 0xba, 0x35, 0x23, 0x1e, 0x16, 0xa0, 0x27, 0x9d, 0xbd, 0x8a, 0xaf, 0xa3, 0xc5, 0x2a, 0x3b,
-	0xdf, 0x2c, 0x38, 0xea, 0x0a, 0xba, 0x4c, 0xa2, 0xf2, 0x04, 0xc9, 0x3b, 0xd8, 0xdf, 0x18, 0x8d,
-	0x22, 0xc1, 0x55, 0xb6, 0xc6, 0x94, 0xe6, 0xd8, 0x6a, 0x95, 0x6b, 0xf8, 0xe3, 0x6a, 0xed, 0x4a,
-	0xdb, 0x7a, 0x69, 0x91, 0x0b, 0xd8, 0x35, 0x03, 0xdc, 0x23, 0x6f, 0x96, 0xf2, 0xdf, 0x86, 0xd4,
-	0x62, 0xef, 0x06, 0xce, 0x29, 0x9b, 0x3b, 0x8b, 0xbb, 0x1c, 0x59, 0x8a, 0xf1, 0x1c, 0x99, 0x33,
-	0x0b, 0x42, 0x96, 0x44, 0xfa, 0xb5, 0xf0, 0x42, 0xfe, 0xf9, 0xf9, 0x3c, 0x11, 0x8b, 0x55, 0x28,
-	0x0b, 0xb8, 0xbf, 0xd0, 0xae, 0xa6, 0x5d, 0x4d, 0xbb, 0x86, 0x0e, 0x6b, 0xca, 0x7e, 0xf5, 0x33,
-	0x00, 0x00, 0xff, 0xff, 0xee, 0xc5, 0xfc, 0x14, 0x9d, 0x03, 0x00, 0x00,
+	// 509 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0xcf, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0xe3, 0x36, 0x4d, 0x9b, 0x21, 0x4d, 0xd3, 0xad, 0x5a, 0x59, 0x39, 0xa0, 0xca, 0x52,
+	0x21, 0x08, 0xb0, 0x51, 0x90, 0x38, 0x00, 0x12, 0x8a, 0x69, 0xab, 0x44, 0x44, 0x09, 0x72, 0xd2,
+	0x03, 0x5c, 0x22, 0xdb, 0x99, 0x24, 0x56, 0x1d, 0xaf, 0xb5, 0xeb, 0x04, 0xf5, 0xc2, 0x2b, 0xf0,
+	0x22, 0x3c, 0x12, 0x0f, 0x83, 0xf6, 0x8f, 0x9d, 0x06, 0xaa, 0x9e, 0xbc, 0x33, 0xf3, 0xfb, 0x76,
+	0x66, 0x56, 0x9f, 0xa1, 0x41, 0xd9, 0x14, 0x19, 0x32, 0xc7, 0x0f, 0xec, 0x94, 0xd1, 0x8c, 0x92,
+	0x7d, 0x9d, 0x69, 0x9e, 0x84, 0x74, 0xb9, 0xa4, 0x89, 0xa3, 0x3e, 0xaa, 0x6a, 0x0d, 0xe1, 0xd8,
+	0x65, 0xd4, 0x9f, 0x86, 0x3e, 0xcf, 0x3c, 0xe4, 0x29, 0x4d, 0x38, 0x92, 0x67, 0x50, 0xe1, 0x99,
+	0x9f, 0xad, 0xb8, 0x69, 0x9c, 0x1b, 0xad, 0x7a, 0xbb, 0x6e, 0x6b, 0xcd, 0x48, 0x66, 0x3d, 0x5d,
+	0x25, 0x04, 0xca, 0x51, 0x32, 0xa3, 0xe6, 0xce, 0xb9, 0xd1, 0xaa, 0x7a, 0xf2, 0x6c, 0xd5, 0x00,
+	0x46, 0x88, 0xb7, 0x03, 0xfc, 0x81, 0x3c, 0xcb, 0xa3, 0x61, 0x3c, 0x15, 0xd1, 0x73, 0x38, 0x14,
+	0xd1, 0x28, 0xc5, 0x30, 0x9a, 0x45, 0x38, 0x25, 0x67, 0x50, 0x49, 0x56, 0xcb, 0x00, 0x99, 0x6c,
+	0x54, 0xf6, 0x74, 0x64, 0xfd, 0x36, 0xa0, 0x26, 0xc8, 0xaf, 0x94, 0x47, 0x59, 0x44, 0x13, 0xf2,
+	0x1a, 0x2a, 0x89, 0xbc, 0x51, 0x82, 0x4f, 0xda, 0x27, 0xb6, 0xde, 0xca, 0xde, 0x34, 0xeb, 0x96,
+	0x3c, 0x0d, 0x09, 0x9c, 0xca, 0x96, 0xe6, 0xce, 0x03, 0xb8, 0x9a, 0x46, 0xe0, 0x0a, 0x22, 0xef,
+	0xa0, 0xca, 0xf3, 0x99, 0xcc, 0x5d, 0xa9, 0x38, 0xdb, 0x52, 0x14, 0x13, 0x77, 0x4b, 0xde, 0x06,
+	0x75, 0x2b, 0x50, 0x1e, 0xdf, 0xa5, 0x68, 0xfd, 0x31, 0xe0, 0x40, 0x60, 0xbd, 0x64, 0x46, 0xc9,
+	0x4b, 0xd8, 0xe3, 0x99, 0xcf, 0xf2, 0x49, 0x4f, 0xb7, 0x2e, 0xca, 0x17, 0xf2, 0x14, 0x43, 0x5e,
+	0x40, 0x99, 0x67, 0x34, 0x35, 0x77, 0x1e, 0x63, 0x25, 0x42, 0xde, 0xc3, 0x41, 0x80, 0x0b, 0x7f,
+	0x1d, 0x51, 0x26, 0x67, 0xac, 0xb7, 0x9f, 0x6e, 0xe1, 0xa2, 0xb9, 0x3c, 0xb8, 0x9a, 0xf2, 0x0a,
+	0xde, 0xfa, 0x08, 0xb5, 0xfb, 0x15, 0x72, 0x0a, 0xc7, 0x6e, 0x7f, 0xf8, 0xf9, 0xcb, 0xe4, 0x66,
+	0x30, 0xee, 0xf5, 0x27, 0xde, 0x55, 0xe7, 0xf2, 0x5b, 0xa3, 0x24, 0xd2, 0xd7, 0x9d, 0x5e, 0x7f,
+	0xd2, 0xbb, 0x9e, 0x0c, 0x86, 0x63, 0x9d, 0x36, 0xac, 0x9f, 0x70, 0x74, 0x89, 0x71, 0xb4, 0x46,
+	0x56, 0x38, 0xa4, 0xf5, 0xb8, 0x43, 0xc4, 0xdb, 0x6a, 0x8f, 0x5c, 0xc0, 0x5e, 0x10, 0xd3, 0xf0,
+	0x56, 0xaf, 0x78, 0x98, 0x83, 0xae, 0x48, 0x76, 0x4b, 0x9e, 0xaa, 0x16, 0x56, 0xda, 0xdd, 0x58,
+	0x29, 0x7f, 0xde, 0xf6, 0x2f, 0x03, 0x8e, 0x3a, 0x19, 0x5d, 0x46, 0x61, 0x61, 0x55, 0xf2, 0x09,
+	0xaa, 0x9b, 0xa0, 0x91, 0x5f, 0x7a, 0x95, 0xac, 0x31, 0xa6, 0x29, 0x36, 0x9b, 0xc5, 0xd3, 0xfc,
+	0xe7, 0x6e, 0xab, 0xd4, 0x32, 0xde, 0x18, 0xe4, 0x03, 0xec, 0xeb, 0xa5, 0x1e, 0x90, 0x9b, 0x85,
+	0xfc, 0x9f, 0xc5, 0x95, 0xd8, 0xbd, 0x81, 0x0b, 0xca, 0xe6, 0xf6, 0xe2, 0x2e, 0x45, 0x16, 0xe3,
+	0x74, 0x8e, 0xcc, 0x9e, 0xf9, 0x01, 0x8b, 0x42, 0xf5, 0x57, 0xf1, 0x5c, 0xfe, 0xfd, 0xd5, 0x3c,
+	0xca, 0x16, 0xab, 0x40, 0x34, 0x70, 0xee, 0xd1, 0x8e, 0xa2, 0x1d, 0x45, 0x3b, 0x9a, 0x0e, 0x2a,
+	0x32, 0x7e, 0xfb, 0x77, 0x00, 0x8f, 0x09, 0x55, 0x9e, 0xc5, 0x03, 0x00, 0x00,
 }
@@ -109,6 +109,8 @@ func (docv *DynamicOrdererConfigValue) VariablyOpaqueFieldProto(name string) (pr
 		return &KafkaBrokers{}, nil
 	case "ChannelRestrictions":
 		return &ChannelRestrictions{}, nil
+	case "Capabilities":
+		return &common.Capabilities{}, nil
 	default:
 		return nil, fmt.Errorf("unknown Orderer ConfigValue name: %s", docv.name)
 	}
@@ -126,6 +128,8 @@ func (doocv *DynamicOrdererOrgConfigValue) VariablyOpaqueFieldProto(name string)
 	switch doocv.name {
 	case "MSP":
 		return &msp.MSPConfig{}, nil
+	case "Endpoints":
+		return &common.OrdererAddresses{}, nil
 	default:
 		return nil, fmt.Errorf("unknown Orderer Org ConfigValue name: %s", doocv.name)
 	}
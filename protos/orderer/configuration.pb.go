@@ -12,14 +12,40 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+// State of the ordering service for the channel. The consensus type may
+// only be changed while the channel is in maintenance, during which
+// Broadcast only accepts config updates.
+type ConsensusType_State int32
+
+const (
+	ConsensusType_STATE_NORMAL      ConsensusType_State = 0
+	ConsensusType_STATE_MAINTENANCE ConsensusType_State = 1
+)
+
+var ConsensusType_State_name = map[int32]string{
+	0: "STATE_NORMAL",
+	1: "STATE_MAINTENANCE",
+}
+var ConsensusType_State_value = map[string]int32{
+	"STATE_NORMAL":      0,
+	"STATE_MAINTENANCE": 1,
+}
+
+func (x ConsensusType_State) String() string {
+	return proto.EnumName(ConsensusType_State_name, int32(x))
+}
+func (ConsensusType_State) EnumDescriptor() ([]byte, []int) { return fileDescriptor2, []int{0, 0} }
+
 type ConsensusType struct {
-	Type string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Type     string              `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Metadata []byte              `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	State    ConsensusType_State `protobuf:"varint,3,opt,name=state,enum=orderer.ConsensusType_State" json:"state,omitempty"`
 }
 
 func (m *ConsensusType) Reset()                    { *m = ConsensusType{} }
 func (m *ConsensusType) String() string            { return proto.CompactTextString(m) }
 func (*ConsensusType) ProtoMessage()               {}
-func (*ConsensusType) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{0} }
+func (*ConsensusType) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{0} }
 
 func (m *ConsensusType) GetType() string {
 	if m != nil {
@@ -28,6 +54,20 @@ func (m *ConsensusType) GetType() string {
 	return ""
 }
 
+func (m *ConsensusType) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *ConsensusType) GetState() ConsensusType_State {
+	if m != nil {
+		return m.State
+	}
+	return ConsensusType_STATE_NORMAL
+}
+
 type BatchSize struct {
 	// Simply specified as number of messages for now, in the future
 	// we may want to allow this to be specified by size in bytes
@@ -43,7 +83,7 @@ type BatchSize struct {
 func (m *BatchSize) Reset()                    { *m = BatchSize{} }
 func (m *BatchSize) String() string            { return proto.CompactTextString(m) }
 func (*BatchSize) ProtoMessage()               {}
-func (*BatchSize) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
+func (*BatchSize) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{1} }
 
 func (m *BatchSize) GetMaxMessageCount() uint32 {
 	if m != nil {
@@ -75,7 +115,7 @@ type BatchTimeout struct {
 func (m *BatchTimeout) Reset()                    { *m = BatchTimeout{} }
 func (m *BatchTimeout) String() string            { return proto.CompactTextString(m) }
 func (*BatchTimeout) ProtoMessage()               {}
-func (*BatchTimeout) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
+func (*BatchTimeout) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{2} }
 
 func (m *BatchTimeout) GetTimeout() string {
 	if m != nil {
@@ -95,7 +135,7 @@ type KafkaBrokers struct {
 func (m *KafkaBrokers) Reset()                    { *m = KafkaBrokers{} }
 func (m *KafkaBrokers) String() string            { return proto.CompactTextString(m) }
 func (*KafkaBrokers) ProtoMessage()               {}
-func (*KafkaBrokers) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+func (*KafkaBrokers) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{3} }
 
 func (m *KafkaBrokers) GetBrokers() []string {
 	if m != nil {
@@ -112,7 +152,7 @@ type ChannelRestrictions struct {
 func (m *ChannelRestrictions) Reset()                    { *m = ChannelRestrictions{} }
 func (m *ChannelRestrictions) String() string            { return proto.CompactTextString(m) }
 func (*ChannelRestrictions) ProtoMessage()               {}
-func (*ChannelRestrictions) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+func (*ChannelRestrictions) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{4} }
 
 func (m *ChannelRestrictions) GetMaxCount() uint64 {
 	if m != nil {
@@ -121,36 +161,107 @@ func (m *ChannelRestrictions) GetMaxCount() uint64 {
 	return 0
 }
 
+// BroadcastRateLimits overrides, for a channel, the Broadcast rate limits the
+// orderers are configured with. A limit which is not set keeps the orderer's.
+type BroadcastRateLimits struct {
+	Channel *RateLimit `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
+	Msp     *RateLimit `protobuf:"bytes,2,opt,name=msp" json:"msp,omitempty"`
+	Client  *RateLimit `protobuf:"bytes,3,opt,name=client" json:"client,omitempty"`
+}
+
+func (m *BroadcastRateLimits) Reset()                    { *m = BroadcastRateLimits{} }
+func (m *BroadcastRateLimits) String() string            { return proto.CompactTextString(m) }
+func (*BroadcastRateLimits) ProtoMessage()               {}
+func (*BroadcastRateLimits) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{5} }
+
+func (m *BroadcastRateLimits) GetChannel() *RateLimit {
+	if m != nil {
+		return m.Channel
+	}
+	return nil
+}
+
+func (m *BroadcastRateLimits) GetMsp() *RateLimit {
+	if m != nil {
+		return m.Msp
+	}
+	return nil
+}
+
+func (m *BroadcastRateLimits) GetClient() *RateLimit {
+	if m != nil {
+		return m.Client
+	}
+	return nil
+}
+
+type RateLimit struct {
+	Rate  uint32 `protobuf:"varint,1,opt,name=rate" json:"rate,omitempty"`
+	Burst uint32 `protobuf:"varint,2,opt,name=burst" json:"burst,omitempty"`
+}
+
+func (m *RateLimit) Reset()                    { *m = RateLimit{} }
+func (m *RateLimit) String() string            { return proto.CompactTextString(m) }
+func (*RateLimit) ProtoMessage()               {}
+func (*RateLimit) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{6} }
+
+func (m *RateLimit) GetRate() uint32 {
+	if m != nil {
+		return m.Rate
+	}
+	return 0
+}
+
+func (m *RateLimit) GetBurst() uint32 {
+	if m != nil {
+		return m.Burst
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ConsensusType)(nil), "orderer.ConsensusType")
 	proto.RegisterType((*BatchSize)(nil), "orderer.BatchSize")
 	proto.RegisterType((*BatchTimeout)(nil), "orderer.BatchTimeout")
 	proto.RegisterType((*KafkaBrokers)(nil), "orderer.KafkaBrokers")
 	proto.RegisterType((*ChannelRestrictions)(nil), "orderer.ChannelRestrictions")
+	proto.RegisterType((*BroadcastRateLimits)(nil), "orderer.BroadcastRateLimits")
+	proto.RegisterType((*RateLimit)(nil), "orderer.RateLimit")
+	proto.RegisterEnum("orderer.ConsensusType_State", ConsensusType_State_name, ConsensusType_State_value)
 }
 
-func init() { proto.RegisterFile("orderer/configuration.proto", fileDescriptor1) }
-
-var fileDescriptor1 = []byte{
-	// 313 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x4c, 0xd0, 0xcd, 0x4a, 0xc3, 0x40,
-	0x10, 0x07, 0x70, 0x62, 0x8b, 0xb5, 0x8b, 0x45, 0xbb, 0xbd, 0x04, 0x7a, 0x29, 0x11, 0xa1, 0x48,
-	0x49, 0x40, 0xdf, 0x20, 0x3d, 0x4a, 0x2f, 0xb1, 0x5e, 0xbc, 0x94, 0x4d, 0x3a, 0x49, 0x96, 0x36,
-	0x3b, 0x61, 0x76, 0x03, 0x89, 0xef, 0xe1, 0xfb, 0xca, 0x6e, 0x52, 0xed, 0x6d, 0x3e, 0x7e, 0x0b,
-	0xb3, 0x7f, 0xb6, 0x44, 0x3a, 0x02, 0x01, 0x45, 0x19, 0xaa, 0x5c, 0x16, 0x0d, 0x09, 0x23, 0x51,
-	0x85, 0x35, 0xa1, 0x41, 0x3e, 0x19, 0x96, 0xc1, 0x13, 0x9b, 0x6d, 0x51, 0x69, 0x50, 0xba, 0xd1,
-	0xfb, 0xae, 0x06, 0xce, 0xd9, 0xd8, 0x74, 0x35, 0xf8, 0xde, 0xca, 0x5b, 0x4f, 0x13, 0x57, 0x07,
-	0x3f, 0x1e, 0x9b, 0xc6, 0xc2, 0x64, 0xe5, 0x87, 0xfc, 0x06, 0xfe, 0xc2, 0xe6, 0x95, 0x68, 0x0f,
-	0x15, 0x68, 0x2d, 0x0a, 0x38, 0x64, 0xd8, 0x28, 0xe3, 0xf8, 0x2c, 0x79, 0xa8, 0x44, 0xbb, 0xeb,
-	0xe7, 0x5b, 0x3b, 0xe6, 0x1b, 0xc6, 0x45, 0xaa, 0xf1, 0xdc, 0x18, 0x38, 0xd8, 0x47, 0x69, 0x67,
-	0x40, 0xfb, 0x37, 0x0e, 0x3f, 0x5e, 0x36, 0x3b, 0xd1, 0xc6, 0x76, 0xce, 0x43, 0xb6, 0xa8, 0x09,
-	0x72, 0x20, 0x82, 0xe3, 0x15, 0x1f, 0x39, 0x3e, 0xff, 0x5b, 0x5d, 0x7c, 0xb0, 0x66, 0xf7, 0xee,
-	0xac, 0xbd, 0xac, 0x00, 0x1b, 0xc3, 0x7d, 0x36, 0x31, 0x7d, 0x39, 0x9c, 0x7f, 0x69, 0xad, 0x7c,
-	0x17, 0xf9, 0x49, 0xc4, 0x84, 0x27, 0x20, 0x6d, 0x65, 0xda, 0x97, 0xbe, 0xb7, 0x1a, 0x59, 0x39,
-	0xb4, 0xc1, 0x2b, 0x5b, 0x6c, 0x4b, 0xa1, 0x14, 0x9c, 0x13, 0xd0, 0x86, 0x64, 0x66, 0x53, 0xd3,
-	0x7c, 0xc9, 0xa6, 0xf6, 0xa0, 0xff, 0xcf, 0x8e, 0x93, 0xbb, 0x4a, 0xb4, 0xee, 0x97, 0xf1, 0x27,
-	0x7b, 0x46, 0x2a, 0xc2, 0xb2, 0xab, 0x81, 0xce, 0x70, 0x2c, 0x80, 0xc2, 0x5c, 0xa4, 0x24, 0xb3,
-	0x3e, 0x6d, 0x1d, 0x0e, 0x69, 0x7f, 0x6d, 0x0a, 0x69, 0xca, 0x26, 0x0d, 0x33, 0xac, 0xa2, 0x2b,
-	0x1d, 0xf5, 0x3a, 0xea, 0x75, 0x34, 0xe8, 0xf4, 0xd6, 0xf5, 0x6f, 0xbf, 0x01, 0x00, 0x00, 0xff,
-	0xff, 0x0c, 0x47, 0xa2, 0x55, 0xca, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("orderer/configuration.proto", fileDescriptor2) }
+
+var fileDescriptor2 = []byte{
+	// 480 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0x51, 0x8f, 0xd2, 0x40,
+	0x10, 0xc7, 0xed, 0x71, 0x1c, 0xc7, 0x08, 0x0a, 0x8b, 0x26, 0x8d, 0xe7, 0x03, 0x69, 0x34, 0x21,
+	0x17, 0x52, 0x0c, 0xc6, 0x0f, 0x00, 0x84, 0x07, 0xe3, 0x81, 0x49, 0xc1, 0x17, 0x5f, 0xc8, 0xb6,
+	0x0c, 0xb0, 0x39, 0xda, 0x6d, 0x76, 0xa7, 0x09, 0xf8, 0x3d, 0xfc, 0x08, 0x7e, 0x4f, 0xb3, 0xbb,
+	0xa5, 0x9e, 0x0f, 0xf7, 0x36, 0xff, 0xff, 0xfc, 0x3a, 0x9d, 0xd9, 0x19, 0xb8, 0x93, 0x6a, 0x8b,
+	0x0a, 0xd5, 0x28, 0x91, 0xd9, 0x4e, 0xec, 0x0b, 0xc5, 0x49, 0xc8, 0x2c, 0xcc, 0x95, 0x24, 0xc9,
+	0x1a, 0x65, 0x32, 0xf8, 0xe3, 0x41, 0x7b, 0x26, 0x33, 0x8d, 0x99, 0x2e, 0xf4, 0xfa, 0x9c, 0x23,
+	0x63, 0x70, 0x4d, 0xe7, 0x1c, 0x7d, 0xaf, 0xef, 0x0d, 0x9a, 0x91, 0x8d, 0xd9, 0x3b, 0xb8, 0x4d,
+	0x91, 0xf8, 0x96, 0x13, 0xf7, 0xaf, 0xfa, 0xde, 0xa0, 0x15, 0x55, 0x9a, 0x8d, 0xa1, 0xae, 0x89,
+	0x13, 0xfa, 0xb5, 0xbe, 0x37, 0x78, 0x35, 0x7e, 0x1f, 0x96, 0xa5, 0xc3, 0xff, 0xca, 0x86, 0x2b,
+	0xc3, 0x44, 0x0e, 0x0d, 0x3e, 0x41, 0xdd, 0x6a, 0xd6, 0x81, 0xd6, 0x6a, 0x3d, 0x59, 0xcf, 0x37,
+	0xcb, 0xef, 0xd1, 0x62, 0xf2, 0xd0, 0x79, 0xc1, 0xde, 0x42, 0xd7, 0x39, 0x8b, 0xc9, 0xd7, 0xe5,
+	0x7a, 0xbe, 0x9c, 0x2c, 0x67, 0xf3, 0x8e, 0x17, 0xfc, 0xf6, 0xa0, 0x39, 0xe5, 0x94, 0x1c, 0x56,
+	0xe2, 0x17, 0xb2, 0x7b, 0xe8, 0xa6, 0xfc, 0xb4, 0x49, 0x51, 0x6b, 0xbe, 0xc7, 0x4d, 0x22, 0x8b,
+	0x8c, 0x6c, 0xc3, 0xed, 0xe8, 0x75, 0xca, 0x4f, 0x0b, 0xe7, 0xcf, 0x8c, 0xcd, 0x86, 0xc0, 0x78,
+	0xac, 0xe5, 0xb1, 0x20, 0xdc, 0x98, 0x8f, 0xe2, 0x33, 0xa1, 0xb6, 0x53, 0xb4, 0xa3, 0xce, 0x25,
+	0xb3, 0xe0, 0xa7, 0xa9, 0xf1, 0x59, 0x08, 0xbd, 0x5c, 0xe1, 0x0e, 0x95, 0xc2, 0xed, 0x13, 0xbc,
+	0x66, 0xf1, 0x6e, 0x95, 0xba, 0xf0, 0xc1, 0x00, 0x5a, 0xb6, 0xad, 0xb5, 0x48, 0x51, 0x16, 0xc4,
+	0x7c, 0x68, 0x90, 0x0b, 0xcb, 0x07, 0xbc, 0x48, 0x43, 0x7e, 0xe3, 0xbb, 0x47, 0x3e, 0x55, 0xf2,
+	0x11, 0x95, 0x36, 0x64, 0xec, 0x42, 0xdf, 0xeb, 0xd7, 0x0c, 0x59, 0xca, 0x60, 0x0c, 0xbd, 0xd9,
+	0x81, 0x67, 0x19, 0x1e, 0x23, 0xd4, 0xa4, 0x44, 0x62, 0x16, 0xa7, 0xd9, 0x1d, 0x34, 0x4d, 0x43,
+	0xff, 0x86, 0xbd, 0x8e, 0x6e, 0x53, 0x7e, 0xb2, 0x53, 0x9a, 0xf7, 0xe9, 0x4d, 0x95, 0xe4, 0xdb,
+	0x84, 0x6b, 0x8a, 0x38, 0xe1, 0x83, 0x48, 0x05, 0x69, 0x36, 0x84, 0x46, 0xe2, 0x6a, 0xd9, 0x4f,
+	0x5e, 0x8e, 0x59, 0xb5, 0x9f, 0x8a, 0x8a, 0x2e, 0x08, 0xfb, 0x00, 0xb5, 0x54, 0xe7, 0xfe, 0xd5,
+	0xb3, 0xa4, 0x49, 0xb3, 0x7b, 0xb8, 0x49, 0x8e, 0x02, 0x33, 0xf2, 0x6b, 0xcf, 0x82, 0x25, 0x11,
+	0x7c, 0x81, 0x66, 0x65, 0x9a, 0xd3, 0x52, 0xe6, 0x52, 0xdc, 0xa6, 0x6c, 0xcc, 0xde, 0x40, 0x3d,
+	0x2e, 0x94, 0xa6, 0x72, 0x23, 0x4e, 0x4c, 0x7f, 0xc0, 0x47, 0xa9, 0xf6, 0xe1, 0xe1, 0x9c, 0xa3,
+	0x3a, 0xe2, 0x76, 0x8f, 0x2a, 0xdc, 0xf1, 0x58, 0x89, 0xc4, 0xdd, 0xaf, 0xbe, 0xfc, 0xf1, 0xe7,
+	0x70, 0x2f, 0xe8, 0x50, 0xc4, 0x61, 0x22, 0xd3, 0xd1, 0x13, 0x7a, 0xe4, 0xe8, 0x91, 0xa3, 0x47,
+	0x25, 0x1d, 0xdf, 0x58, 0xfd, 0xf9, 0xef, 0x00, 0xa1, 0x89, 0xa3, 0x2d, 0x1c, 0x03, 0x00, 0x00,
 }
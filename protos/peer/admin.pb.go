@@ -22,6 +22,11 @@ It has these top-level messages:
 	ServerStatus
 	LogLevelRequest
 	LogLevelResponse
+	GossipPeerReputation
+	GossipReputations
+	DeliveryFailoversRequest
+	DeliveryFailover
+	DeliveryFailovers
 	ChaincodeID
 	ChaincodeInput
 	ChaincodeSpec
@@ -75,6 +80,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import google_protobuf "github.com/golang/protobuf/ptypes/empty"
+import google_protobuf1 "github.com/golang/protobuf/ptypes/timestamp"
 
 import (
 	context "golang.org/x/net/context"
@@ -189,10 +195,146 @@ func (m *LogLevelResponse) GetLogLevel() string {
 	return ""
 }
 
+// GossipPeerReputation is the reputation of a remote gossip peer.
+type GossipPeerReputation struct {
+	PkiId []byte `protobuf:"bytes,1,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
+	Score int32  `protobuf:"varint,2,opt,name=score" json:"score,omitempty"`
+	// Blacklisted peers are refused connections until this time
+	BlacklistedUntil *google_protobuf1.Timestamp `protobuf:"bytes,3,opt,name=blacklisted_until,json=blacklistedUntil" json:"blacklisted_until,omitempty"`
+}
+
+func (m *GossipPeerReputation) Reset()                    { *m = GossipPeerReputation{} }
+func (m *GossipPeerReputation) String() string            { return proto.CompactTextString(m) }
+func (*GossipPeerReputation) ProtoMessage()               {}
+func (*GossipPeerReputation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *GossipPeerReputation) GetPkiId() []byte {
+	if m != nil {
+		return m.PkiId
+	}
+	return nil
+}
+
+func (m *GossipPeerReputation) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *GossipPeerReputation) GetBlacklistedUntil() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.BlacklistedUntil
+	}
+	return nil
+}
+
+// GossipReputations are the reputations of the remote gossip peers
+// that misbehaved, peers that didn't aren't listed.
+type GossipReputations struct {
+	Peers []*GossipPeerReputation `protobuf:"bytes,1,rep,name=peers" json:"peers,omitempty"`
+}
+
+func (m *GossipReputations) Reset()                    { *m = GossipReputations{} }
+func (m *GossipReputations) String() string            { return proto.CompactTextString(m) }
+func (*GossipReputations) ProtoMessage()               {}
+func (*GossipReputations) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *GossipReputations) GetPeers() []*GossipPeerReputation {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type DeliveryFailoversRequest struct {
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+}
+
+func (m *DeliveryFailoversRequest) Reset()                    { *m = DeliveryFailoversRequest{} }
+func (m *DeliveryFailoversRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeliveryFailoversRequest) ProtoMessage()               {}
+func (*DeliveryFailoversRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *DeliveryFailoversRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+// DeliveryFailover is the decision of switching from an ordering service
+// endpoint that stopped delivering blocks to another endpoint.
+type DeliveryFailover struct {
+	Time *google_protobuf1.Timestamp `protobuf:"bytes,1,opt,name=time" json:"time,omitempty"`
+	// The endpoint switched from
+	Endpoint string `protobuf:"bytes,2,opt,name=endpoint" json:"endpoint,omitempty"`
+	// The sequence number of the last block received from the ordering service
+	LastBlock uint64 `protobuf:"varint,3,opt,name=last_block,json=lastBlock" json:"last_block,omitempty"`
+	// The highest sequence number of the blocks peers of the channel advertised having
+	AdvertisedBlock uint64 `protobuf:"varint,4,opt,name=advertised_block,json=advertisedBlock" json:"advertised_block,omitempty"`
+}
+
+func (m *DeliveryFailover) Reset()                    { *m = DeliveryFailover{} }
+func (m *DeliveryFailover) String() string            { return proto.CompactTextString(m) }
+func (*DeliveryFailover) ProtoMessage()               {}
+func (*DeliveryFailover) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *DeliveryFailover) GetTime() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *DeliveryFailover) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *DeliveryFailover) GetLastBlock() uint64 {
+	if m != nil {
+		return m.LastBlock
+	}
+	return 0
+}
+
+func (m *DeliveryFailover) GetAdvertisedBlock() uint64 {
+	if m != nil {
+		return m.AdvertisedBlock
+	}
+	return 0
+}
+
+// DeliveryFailovers are the most recent failovers of the blocks delivery
+// of a channel, empty if the peer doesn't deliver blocks for the channel.
+type DeliveryFailovers struct {
+	Failovers []*DeliveryFailover `protobuf:"bytes,1,rep,name=failovers" json:"failovers,omitempty"`
+}
+
+func (m *DeliveryFailovers) Reset()                    { *m = DeliveryFailovers{} }
+func (m *DeliveryFailovers) String() string            { return proto.CompactTextString(m) }
+func (*DeliveryFailovers) ProtoMessage()               {}
+func (*DeliveryFailovers) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *DeliveryFailovers) GetFailovers() []*DeliveryFailover {
+	if m != nil {
+		return m.Failovers
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ServerStatus)(nil), "protos.ServerStatus")
 	proto.RegisterType((*LogLevelRequest)(nil), "protos.LogLevelRequest")
 	proto.RegisterType((*LogLevelResponse)(nil), "protos.LogLevelResponse")
+	proto.RegisterType((*GossipPeerReputation)(nil), "protos.GossipPeerReputation")
+	proto.RegisterType((*GossipReputations)(nil), "protos.GossipReputations")
+	proto.RegisterType((*DeliveryFailoversRequest)(nil), "protos.DeliveryFailoversRequest")
+	proto.RegisterType((*DeliveryFailover)(nil), "protos.DeliveryFailover")
+	proto.RegisterType((*DeliveryFailovers)(nil), "protos.DeliveryFailovers")
 	proto.RegisterEnum("protos.ServerStatus_StatusCode", ServerStatus_StatusCode_name, ServerStatus_StatusCode_value)
 }
 
@@ -213,6 +355,10 @@ type AdminClient interface {
 	GetModuleLogLevel(ctx context.Context, in *LogLevelRequest, opts ...grpc.CallOption) (*LogLevelResponse, error)
 	SetModuleLogLevel(ctx context.Context, in *LogLevelRequest, opts ...grpc.CallOption) (*LogLevelResponse, error)
 	RevertLogLevels(ctx context.Context, in *google_protobuf.Empty, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	// Return the reputation of the gossip peers that misbehaved.
+	GetGossipReputations(ctx context.Context, in *google_protobuf.Empty, opts ...grpc.CallOption) (*GossipReputations, error)
+	// Return the most recent ordering service failovers of the blocks delivery of a channel.
+	GetDeliveryFailovers(ctx context.Context, in *DeliveryFailoversRequest, opts ...grpc.CallOption) (*DeliveryFailovers, error)
 }
 
 type adminClient struct {
@@ -268,6 +414,24 @@ func (c *adminClient) RevertLogLevels(ctx context.Context, in *google_protobuf.E
 	return out, nil
 }
 
+func (c *adminClient) GetGossipReputations(ctx context.Context, in *google_protobuf.Empty, opts ...grpc.CallOption) (*GossipReputations, error) {
+	out := new(GossipReputations)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetGossipReputations", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetDeliveryFailovers(ctx context.Context, in *DeliveryFailoversRequest, opts ...grpc.CallOption) (*DeliveryFailovers, error) {
+	out := new(DeliveryFailovers)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetDeliveryFailovers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Admin service
 
 type AdminServer interface {
@@ -277,6 +441,10 @@ type AdminServer interface {
 	GetModuleLogLevel(context.Context, *LogLevelRequest) (*LogLevelResponse, error)
 	SetModuleLogLevel(context.Context, *LogLevelRequest) (*LogLevelResponse, error)
 	RevertLogLevels(context.Context, *google_protobuf.Empty) (*google_protobuf.Empty, error)
+	// Return the reputation of the gossip peers that misbehaved.
+	GetGossipReputations(context.Context, *google_protobuf.Empty) (*GossipReputations, error)
+	// Return the most recent ordering service failovers of the blocks delivery of a channel.
+	GetDeliveryFailovers(context.Context, *DeliveryFailoversRequest) (*DeliveryFailovers, error)
 }
 
 func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
@@ -373,6 +541,42 @@ func _Admin_RevertLogLevels_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_GetGossipReputations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(google_protobuf.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetGossipReputations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetGossipReputations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetGossipReputations(ctx, req.(*google_protobuf.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetDeliveryFailovers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliveryFailoversRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetDeliveryFailovers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetDeliveryFailovers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetDeliveryFailovers(ctx, req.(*DeliveryFailoversRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Admin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Admin",
 	HandlerType: (*AdminServer)(nil),
@@ -397,6 +601,14 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RevertLogLevels",
 			Handler:    _Admin_RevertLogLevels_Handler,
 		},
+		{
+			MethodName: "GetGossipReputations",
+			Handler:    _Admin_GetGossipReputations_Handler,
+		},
+		{
+			MethodName: "GetDeliveryFailovers",
+			Handler:    _Admin_GetDeliveryFailovers_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "peer/admin.proto",
@@ -405,32 +617,49 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 418 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x93, 0x41, 0x6f, 0xd3, 0x30,
-	0x14, 0xc7, 0x9b, 0x42, 0x0b, 0x79, 0x1b, 0xcc, 0x58, 0x08, 0xaa, 0x4e, 0x08, 0x94, 0x13, 0x5c,
-	0x1c, 0x69, 0x1c, 0x38, 0x20, 0x0e, 0xdd, 0x12, 0x06, 0x62, 0x4b, 0x23, 0x67, 0x15, 0x02, 0x09,
-	0x4d, 0x49, 0xf3, 0xe6, 0x55, 0x38, 0x73, 0xb0, 0x9d, 0x4a, 0xfb, 0x3a, 0x7c, 0x2e, 0x3e, 0x0c,
-	0x4a, 0xdc, 0x68, 0x13, 0xb0, 0x03, 0x82, 0x93, 0xf3, 0xde, 0xfb, 0xff, 0xff, 0x71, 0x7e, 0xb1,
-	0x81, 0xd4, 0x88, 0x3a, 0xcc, 0xcb, 0x6a, 0x75, 0xc1, 0x6a, 0xad, 0xac, 0xa2, 0xe3, 0x6e, 0x31,
-	0xd3, 0x5d, 0xa1, 0x94, 0x90, 0x18, 0x76, 0x65, 0xd1, 0x9c, 0x85, 0x58, 0xd5, 0xf6, 0xd2, 0x89,
-	0x82, 0xef, 0x1e, 0x6c, 0x67, 0xa8, 0xd7, 0xa8, 0x33, 0x9b, 0xdb, 0xc6, 0xd0, 0x57, 0x30, 0x36,
-	0xdd, 0xd3, 0xc4, 0x7b, 0xe6, 0x3d, 0xbf, 0xbf, 0xf7, 0xd4, 0x09, 0x0d, 0xbb, 0xae, 0x62, 0x6e,
-	0x39, 0x50, 0x25, 0xf2, 0x8d, 0x3c, 0xf8, 0x04, 0x70, 0xd5, 0xa5, 0xf7, 0xc0, 0x5f, 0x24, 0x51,
-	0xfc, 0xf6, 0x7d, 0x12, 0x47, 0x64, 0x40, 0xb7, 0xe0, 0x4e, 0x76, 0x32, 0xe3, 0x27, 0x71, 0x44,
-	0x3c, 0x57, 0xcc, 0xd3, 0x34, 0x8e, 0xc8, 0x90, 0x02, 0x8c, 0xd3, 0xd9, 0x22, 0x8b, 0x23, 0x72,
-	0x8b, 0xfa, 0x30, 0x8a, 0x39, 0x9f, 0x73, 0x72, 0xbb, 0xd5, 0x2c, 0x92, 0x0f, 0xc9, 0xfc, 0x63,
-	0x42, 0x46, 0xc1, 0x31, 0xec, 0x1c, 0x29, 0x71, 0x84, 0x6b, 0x94, 0x1c, 0xbf, 0x35, 0x68, 0x2c,
-	0x7d, 0x02, 0x20, 0x95, 0x38, 0xad, 0x54, 0xd9, 0x48, 0xec, 0xb6, 0xea, 0x73, 0x5f, 0x2a, 0x71,
-	0xdc, 0x35, 0xe8, 0x2e, 0xb4, 0xc5, 0xa9, 0x6c, 0x2d, 0x93, 0x61, 0x37, 0xbd, 0x2b, 0x37, 0x11,
-	0x41, 0x02, 0xe4, 0x2a, 0xce, 0xd4, 0xea, 0xc2, 0xe0, 0xbf, 0xe4, 0xed, 0xfd, 0x18, 0xc2, 0x68,
-	0xd6, 0x82, 0xa7, 0xaf, 0xc1, 0x3f, 0x44, 0xbb, 0x21, 0xf9, 0x88, 0x39, 0xf0, 0xac, 0x07, 0xcf,
-	0xe2, 0x16, 0xfc, 0xf4, 0xe1, 0x9f, 0x88, 0x06, 0x03, 0xfa, 0x06, 0xb6, 0x32, 0x9b, 0x6b, 0xeb,
-	0xda, 0x7f, 0x6d, 0x7f, 0x07, 0x0f, 0x0e, 0xd1, 0xba, 0xfd, 0xf6, 0x9f, 0x47, 0x1f, 0xf7, 0xe2,
-	0x5f, 0xf8, 0x4d, 0x27, 0xbf, 0x0f, 0x1c, 0x09, 0x97, 0x94, 0xfd, 0x9f, 0xa4, 0x03, 0xd8, 0xe1,
-	0xb8, 0x46, 0x6d, 0xfb, 0xd9, 0xcd, 0x54, 0x6e, 0xe8, 0x07, 0x83, 0xfd, 0x2f, 0x10, 0x28, 0x2d,
-	0xd8, 0xf9, 0x65, 0x8d, 0x5a, 0x62, 0x29, 0x50, 0xb3, 0xb3, 0xbc, 0xd0, 0xab, 0x65, 0xff, 0xe2,
-	0xf6, 0xe4, 0xef, 0x6f, 0x77, 0x7f, 0x20, 0xcd, 0x97, 0x5f, 0x73, 0x81, 0x9f, 0x5f, 0x88, 0x95,
-	0x3d, 0x6f, 0x0a, 0xb6, 0x54, 0x55, 0x78, 0xcd, 0x18, 0x3a, 0xa3, 0xbb, 0x0a, 0x26, 0x6c, 0x8d,
-	0x85, 0xbb, 0x26, 0x2f, 0x7f, 0x06, 0x00, 0x00, 0xff, 0xff, 0x6e, 0xe6, 0xef, 0xb7, 0x41, 0x03,
-	0x00, 0x00,
+	// 692 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x51, 0x6f, 0xda, 0x48,
+	0x10, 0xc6, 0x09, 0x70, 0xf1, 0x90, 0xbb, 0x98, 0x15, 0x77, 0x47, 0xc8, 0x9d, 0x12, 0xf9, 0x29,
+	0x79, 0x31, 0x12, 0x95, 0x5a, 0x55, 0x55, 0x1f, 0x92, 0x40, 0x68, 0x94, 0x84, 0xa0, 0x25, 0xa8,
+	0x6a, 0xa5, 0x0a, 0x19, 0x3c, 0x71, 0x2c, 0x16, 0xaf, 0xbb, 0xbb, 0x20, 0xe5, 0xad, 0x3f, 0xa0,
+	0xbf, 0xa2, 0x4f, 0xfd, 0x99, 0x95, 0x77, 0xb1, 0xa0, 0x10, 0x54, 0x55, 0xed, 0x93, 0x3d, 0xb3,
+	0xdf, 0xf7, 0x69, 0x67, 0xbe, 0x99, 0x05, 0x27, 0x41, 0x14, 0x75, 0x3f, 0x98, 0x44, 0xb1, 0x97,
+	0x08, 0xae, 0x38, 0x29, 0xea, 0x8f, 0xac, 0x1d, 0x84, 0x9c, 0x87, 0x0c, 0xeb, 0x3a, 0x1c, 0x4e,
+	0xef, 0xeb, 0x38, 0x49, 0xd4, 0xa3, 0x01, 0xd5, 0x0e, 0x57, 0x0f, 0x55, 0x34, 0x41, 0xa9, 0xfc,
+	0x49, 0x62, 0x00, 0xee, 0x17, 0x0b, 0x76, 0x7b, 0x28, 0x66, 0x28, 0x7a, 0xca, 0x57, 0x53, 0x49,
+	0x5e, 0x40, 0x51, 0xea, 0xbf, 0xaa, 0x75, 0x64, 0x1d, 0xff, 0xd5, 0x38, 0x34, 0x40, 0xe9, 0x2d,
+	0xa3, 0x3c, 0xf3, 0x39, 0xe7, 0x01, 0xd2, 0x39, 0xdc, 0x7d, 0x07, 0xb0, 0xc8, 0x92, 0x3f, 0xc1,
+	0xee, 0x77, 0x9a, 0xad, 0x8b, 0xcb, 0x4e, 0xab, 0xe9, 0xe4, 0x48, 0x09, 0xfe, 0xe8, 0xdd, 0x9d,
+	0xd2, 0xbb, 0x56, 0xd3, 0xb1, 0x4c, 0x70, 0xdb, 0xed, 0xb6, 0x9a, 0xce, 0x16, 0x01, 0x28, 0x76,
+	0x4f, 0xfb, 0xbd, 0x56, 0xd3, 0xd9, 0x26, 0x36, 0x14, 0x5a, 0x94, 0xde, 0x52, 0x27, 0x9f, 0x62,
+	0xfa, 0x9d, 0xab, 0xce, 0xed, 0xdb, 0x8e, 0x53, 0x70, 0x6f, 0x60, 0xef, 0x9a, 0x87, 0xd7, 0x38,
+	0x43, 0x46, 0xf1, 0xe3, 0x14, 0xa5, 0x22, 0xff, 0x03, 0x30, 0x1e, 0x0e, 0x26, 0x3c, 0x98, 0x32,
+	0xd4, 0x57, 0xb5, 0xa9, 0xcd, 0x78, 0x78, 0xa3, 0x13, 0xe4, 0x00, 0xd2, 0x60, 0xc0, 0x52, 0x4a,
+	0x75, 0x4b, 0x9f, 0xee, 0xb0, 0xb9, 0x84, 0xdb, 0x01, 0x67, 0x21, 0x27, 0x13, 0x1e, 0x4b, 0xfc,
+	0x25, 0xbd, 0xcf, 0x16, 0x54, 0xda, 0x5c, 0xca, 0x28, 0xe9, 0x22, 0x0a, 0x8a, 0xc9, 0x54, 0xf9,
+	0x2a, 0xe2, 0x31, 0xf9, 0x1b, 0x8a, 0xc9, 0x38, 0x1a, 0x44, 0x81, 0x16, 0xdc, 0xa5, 0x85, 0x64,
+	0x1c, 0x5d, 0x06, 0xa4, 0x02, 0x05, 0x39, 0xe2, 0x02, 0xb5, 0x50, 0x81, 0x9a, 0x80, 0xb4, 0xa1,
+	0x3c, 0x64, 0xfe, 0x68, 0xcc, 0x22, 0xa9, 0x30, 0x18, 0x4c, 0x63, 0x15, 0xb1, 0xea, 0xf6, 0x91,
+	0x75, 0x5c, 0x6a, 0xd4, 0x3c, 0x63, 0xa3, 0x97, 0xd9, 0xe8, 0xdd, 0x65, 0x36, 0x52, 0x67, 0x89,
+	0xd4, 0x4f, 0x39, 0x6e, 0x1b, 0xca, 0xe6, 0x36, 0x8b, 0x9b, 0x48, 0xd2, 0x80, 0x42, 0x82, 0x28,
+	0x52, 0x57, 0xb7, 0x8f, 0x4b, 0x8d, 0xff, 0x32, 0x57, 0x9f, 0xba, 0x37, 0x35, 0x50, 0xf7, 0x25,
+	0x54, 0x9b, 0xc8, 0xa2, 0x19, 0x8a, 0xc7, 0x0b, 0x3f, 0x62, 0x7c, 0x86, 0x42, 0x2e, 0xf5, 0x7f,
+	0xf4, 0xe0, 0xc7, 0x31, 0xb2, 0xac, 0x3c, 0x9b, 0xda, 0xf3, 0xcc, 0x65, 0xe0, 0x7e, 0xb5, 0xc0,
+	0x59, 0xe5, 0x12, 0x0f, 0xf2, 0xe9, 0xf8, 0x55, 0xad, 0x1f, 0x16, 0xa5, 0x71, 0xa4, 0x06, 0x3b,
+	0x18, 0x07, 0x09, 0x8f, 0x62, 0x95, 0xf5, 0x3c, 0x8b, 0xb5, 0x5f, 0xbe, 0x54, 0x83, 0x21, 0xe3,
+	0xa3, 0xb1, 0x6e, 0x53, 0x9e, 0xda, 0x69, 0xe6, 0x2c, 0x4d, 0x90, 0x13, 0x70, 0xfc, 0x60, 0x86,
+	0x42, 0x45, 0x12, 0x83, 0x39, 0x28, 0xaf, 0x41, 0x7b, 0x8b, 0xbc, 0x86, 0xba, 0x57, 0x50, 0x5e,
+	0xab, 0x92, 0x3c, 0x07, 0xfb, 0x3e, 0x0b, 0xe6, 0x2d, 0xab, 0x66, 0x2d, 0x5b, 0x45, 0xd3, 0x05,
+	0xb4, 0xf1, 0x29, 0x0f, 0x85, 0xd3, 0x74, 0x49, 0xc9, 0x2b, 0xb0, 0xdb, 0xa8, 0xe6, 0x4b, 0xf5,
+	0xcf, 0x5a, 0xad, 0xad, 0x74, 0x49, 0x6b, 0x95, 0xa7, 0x96, 0xcb, 0xcd, 0x91, 0xd7, 0x50, 0xea,
+	0x29, 0x5f, 0x28, 0x93, 0xfe, 0x69, 0xfa, 0x1b, 0x28, 0xb7, 0x51, 0x99, 0xd1, 0xcd, 0x26, 0x9d,
+	0xfc, 0x9b, 0x81, 0x57, 0x56, 0xa9, 0x56, 0x5d, 0x3f, 0x30, 0x4b, 0x61, 0x94, 0x7a, 0xbf, 0x47,
+	0xe9, 0x1c, 0xf6, 0x28, 0xa6, 0x9d, 0xcf, 0xce, 0x36, 0x77, 0x65, 0x43, 0xde, 0xcd, 0x91, 0x2b,
+	0xa8, 0xb4, 0x51, 0xad, 0x4f, 0xf7, 0x26, 0xa5, 0xfd, 0xef, 0xc7, 0x7c, 0x89, 0xe2, 0xe6, 0x48,
+	0x5f, 0x8b, 0xad, 0x7b, 0x7f, 0xb4, 0xc9, 0xe8, 0x6c, 0xf8, 0x6b, 0xfb, 0x1b, 0x11, 0x6e, 0xee,
+	0xec, 0x03, 0xb8, 0x5c, 0x84, 0xde, 0xc3, 0x63, 0x82, 0x82, 0x61, 0x10, 0xa2, 0xf0, 0xee, 0xfd,
+	0xa1, 0x88, 0x46, 0x19, 0x29, 0x41, 0x14, 0x67, 0xbb, 0x7a, 0x4a, 0xba, 0xfe, 0x68, 0xec, 0x87,
+	0xf8, 0xfe, 0x24, 0x8c, 0xd4, 0xc3, 0x74, 0xe8, 0x8d, 0xf8, 0xa4, 0xbe, 0x44, 0xac, 0x1b, 0xa2,
+	0x79, 0xbd, 0x65, 0x3d, 0x25, 0x0e, 0xcd, 0xb3, 0xff, 0xec, 0xdb, 0x00, 0x76, 0xe5, 0x85, 0xff,
+	0x11, 0x06, 0x00, 0x00,
 }
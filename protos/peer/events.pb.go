@@ -89,9 +89,7 @@ func (m *Interest) String() string            { return proto.CompactTextString(m
 func (*Interest) ProtoMessage()               {}
 func (*Interest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{1} }
 
-type isInterest_RegInfo interface {
-	isInterest_RegInfo()
-}
+type isInterest_RegInfo interface{ isInterest_RegInfo() }
 
 type Interest_ChaincodeRegInfo struct {
 	ChaincodeRegInfo *ChaincodeReg `protobuf:"bytes,2,opt,name=chaincode_reg_info,json=chaincodeRegInfo,oneof"`
@@ -204,8 +202,11 @@ func (m *Register) GetEvents() []*Interest {
 // Rejection is sent by consumers for erroneous transaction rejection events
 // string type - "rejection"
 type Rejection struct {
-	Tx       *Transaction `protobuf:"bytes,1,opt,name=tx" json:"tx,omitempty"`
-	ErrorMsg string       `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg" json:"error_msg,omitempty"`
+	Tx             *Transaction     `protobuf:"bytes,1,opt,name=tx" json:"tx,omitempty"`
+	ErrorMsg       string           `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg" json:"error_msg,omitempty"`
+	TxId           string           `protobuf:"bytes,3,opt,name=tx_id,json=txId" json:"tx_id,omitempty"`
+	ChannelId      string           `protobuf:"bytes,4,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	ValidationCode TxValidationCode `protobuf:"varint,5,opt,name=validation_code,json=validationCode,enum=protos.TxValidationCode" json:"validation_code,omitempty"`
 }
 
 func (m *Rejection) Reset()                    { *m = Rejection{} }
@@ -227,6 +228,27 @@ func (m *Rejection) GetErrorMsg() string {
 	return ""
 }
 
+func (m *Rejection) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *Rejection) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *Rejection) GetValidationCode() TxValidationCode {
+	if m != nil {
+		return m.ValidationCode
+	}
+	return TxValidationCode_VALID
+}
+
 // ---------- producer events ---------
 type Unregister struct {
 	Events []*Interest `protobuf:"bytes,1,rep,name=events" json:"events,omitempty"`
@@ -272,8 +294,8 @@ func (m *SignedEvent) GetEventBytes() []byte {
 }
 
 // Event is used by
-//  - consumers (adapters) to send Register
-//  - producer to advertise supported types and events
+//   - consumers (adapters) to send Register
+//   - producer to advertise supported types and events
 type Event struct {
 	// Types that are valid to be assigned to Event:
 	//	*Event_Register
@@ -284,6 +306,9 @@ type Event struct {
 	Event isEvent_Event `protobuf_oneof:"Event"`
 	// Creator of the event, specified as a certificate chain
 	Creator []byte `protobuf:"bytes,6,opt,name=creator,proto3" json:"creator,omitempty"`
+	// If mutual TLS is employed, this represents
+	// the hash of the client's TLS certificate
+	TlsCertHash []byte `protobuf:"bytes,7,opt,name=tls_cert_hash,json=tlsCertHash,proto3" json:"tls_cert_hash,omitempty"`
 }
 
 func (m *Event) Reset()                    { *m = Event{} }
@@ -291,9 +316,7 @@ func (m *Event) String() string            { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()               {}
 func (*Event) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{6} }
 
-type isEvent_Event interface {
-	isEvent_Event()
-}
+type isEvent_Event interface{ isEvent_Event() }
 
 type Event_Register struct {
 	Register *Register `protobuf:"bytes,1,opt,name=register,oneof"`
@@ -366,6 +389,13 @@ func (m *Event) GetCreator() []byte {
 	return nil
 }
 
+func (m *Event) GetTlsCertHash() []byte {
+	if m != nil {
+		return m.TlsCertHash
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Event) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Event_OneofMarshaler, _Event_OneofUnmarshaler, _Event_OneofSizer, []interface{}{
@@ -617,44 +647,49 @@ var _Events_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/events.proto", fileDescriptor5) }
 
 var fileDescriptor5 = []byte{
-	// 611 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x54, 0x4f, 0x6f, 0x9b, 0x4e,
-	0x10, 0x05, 0x27, 0x76, 0xcc, 0xd8, 0xce, 0xcf, 0xd9, 0xfc, 0x14, 0x21, 0xf7, 0x8f, 0x52, 0xaa,
-	0x4a, 0x6e, 0x0f, 0x26, 0xa5, 0x51, 0x0f, 0xbd, 0x05, 0x82, 0x0a, 0x4d, 0xf3, 0x47, 0x1b, 0xf7,
-	0xd2, 0x43, 0x2d, 0x8c, 0x27, 0x98, 0x26, 0x06, 0x6b, 0xd9, 0x54, 0xf1, 0x27, 0xea, 0xa9, 0xdf,
-	0xb1, 0x62, 0x61, 0x81, 0xb4, 0xa7, 0x9e, 0xf0, 0xcc, 0xbc, 0x37, 0xfb, 0xf6, 0xcd, 0xac, 0x61,
-	0x6f, 0x8d, 0xc8, 0x4c, 0xfc, 0x81, 0x09, 0xcf, 0x26, 0x6b, 0x96, 0xf2, 0x94, 0x74, 0xc4, 0x27,
-	0x1b, 0xed, 0x87, 0xe9, 0x6a, 0x95, 0x26, 0x66, 0xf1, 0x29, 0x8a, 0xa3, 0x91, 0xc0, 0x87, 0xcb,
-	0x20, 0x4e, 0xc2, 0x74, 0x81, 0x33, 0xc1, 0x2c, 0x6b, 0x07, 0xa2, 0xc6, 0x59, 0x90, 0x64, 0x41,
-	0xc8, 0x63, 0xc9, 0x31, 0xae, 0xa0, 0xef, 0x48, 0x02, 0xc5, 0x88, 0xbc, 0x80, 0x7e, 0xdd, 0x20,
-	0x5e, 0xe8, 0xea, 0xa1, 0x3a, 0xd6, 0x68, 0xaf, 0xca, 0xf9, 0x0b, 0xf2, 0x0c, 0x40, 0x74, 0x9e,
-	0x25, 0xc1, 0x0a, 0xf5, 0x96, 0x00, 0x68, 0x22, 0x73, 0x11, 0xac, 0xd0, 0xf8, 0xa9, 0x42, 0xd7,
-	0x4f, 0x38, 0x32, 0xcc, 0x38, 0x39, 0x92, 0x58, 0xbe, 0x59, 0xa3, 0x68, 0xb6, 0x6b, 0xed, 0x15,
-	0x47, 0x67, 0x13, 0x37, 0xaf, 0x4c, 0x37, 0x6b, 0x2c, 0xe9, 0xf9, 0x4f, 0x72, 0x0a, 0xa4, 0x16,
-	0xc0, 0x30, 0x9a, 0xc5, 0xc9, 0x4d, 0x2a, 0x4e, 0xe9, 0x59, 0xff, 0x4b, 0x66, 0x53, 0xb2, 0xa7,
-	0xd0, 0x61, 0xd8, 0x88, 0xfd, 0xe4, 0x26, 0x25, 0x3a, 0xec, 0x88, 0x9c, 0x7f, 0xaa, 0x6f, 0x09,
-	0x81, 0x32, 0xb4, 0x35, 0xd8, 0x29, 0x41, 0xc6, 0x31, 0x74, 0x29, 0x46, 0x71, 0xc6, 0x91, 0x91,
-	0x31, 0x74, 0x0a, 0xa3, 0x75, 0xf5, 0x70, 0x6b, 0xdc, 0xb3, 0x86, 0xf2, 0x28, 0x79, 0x15, 0x5a,
-	0xd6, 0x8d, 0x73, 0xd0, 0x28, 0x7e, 0x47, 0x61, 0x22, 0x79, 0x09, 0x2d, 0xfe, 0x20, 0xee, 0xd5,
-	0xb3, 0xf6, 0x25, 0x65, 0x5a, 0xbb, 0x4c, 0x5b, 0xfc, 0x81, 0x3c, 0x01, 0x0d, 0x19, 0x4b, 0xd9,
-	0x6c, 0x95, 0x45, 0xa5, 0x5f, 0x5d, 0x91, 0x38, 0xcf, 0x22, 0xe3, 0x3d, 0xc0, 0x97, 0x84, 0xfd,
-	0xbb, 0x8c, 0x33, 0xe8, 0x5d, 0xc7, 0x51, 0x82, 0x0b, 0xe1, 0x22, 0x79, 0x0a, 0x5a, 0x16, 0x47,
-	0x49, 0xc0, 0xef, 0x59, 0xe1, 0x73, 0x9f, 0xd6, 0x09, 0xf2, 0xbc, 0x1c, 0x83, 0xbd, 0xe1, 0x98,
-	0x09, 0x09, 0x7d, 0xda, 0xc8, 0x18, 0xbf, 0x5a, 0xd0, 0x2e, 0xfa, 0x4c, 0xa0, 0x2b, 0xc5, 0x94,
-	0xd7, 0xaa, 0x24, 0x48, 0xaf, 0x3c, 0x85, 0x56, 0x18, 0xf2, 0x0a, 0xda, 0xf3, 0xbb, 0x34, 0xbc,
-	0x2d, 0x27, 0x34, 0x98, 0x94, 0x1b, 0x69, 0xe7, 0x49, 0x4f, 0xa1, 0x45, 0x95, 0x9c, 0xc0, 0x7f,
-	0x7f, 0xec, 0xa5, 0x98, 0x4b, 0xcf, 0x3a, 0xf8, 0x6b, 0xa4, 0x42, 0x87, 0xa7, 0xd0, 0xdd, 0xf0,
-	0x51, 0x86, 0xbc, 0x05, 0x8d, 0x49, 0xdf, 0xf5, 0x6d, 0x41, 0xde, 0xab, 0xa5, 0x95, 0x05, 0x4f,
-	0xa1, 0x35, 0x8a, 0x1c, 0x03, 0xdc, 0x57, 0xde, 0xea, 0x6d, 0xc1, 0x21, 0x92, 0x53, 0xbb, 0xee,
-	0x29, 0xb4, 0x81, 0x13, 0xbb, 0xc3, 0x30, 0xe0, 0x29, 0xd3, 0x3b, 0xc2, 0x29, 0x19, 0xda, 0x3b,
-	0xa5, 0x4b, 0x6f, 0x6c, 0xd0, 0xaa, 0xe5, 0x25, 0x7d, 0xe8, 0x52, 0xf7, 0xa3, 0x7f, 0x3d, 0x75,
-	0xe9, 0x50, 0x21, 0x1a, 0xb4, 0xed, 0xcf, 0x97, 0xce, 0xd9, 0x50, 0x25, 0x03, 0xd0, 0x1c, 0xef,
-	0xc4, 0xbf, 0x70, 0x2e, 0x4f, 0xdd, 0x61, 0x2b, 0x0f, 0xa9, 0xfb, 0xc9, 0x75, 0xa6, 0xfe, 0xe5,
-	0xc5, 0x70, 0xcb, 0xfa, 0x00, 0x1d, 0xd1, 0x23, 0x23, 0x47, 0xb0, 0xed, 0x2c, 0x03, 0x4e, 0xaa,
-	0x05, 0x6a, 0x0c, 0x76, 0x34, 0x78, 0xf4, 0x5a, 0x0c, 0x65, 0xac, 0x1e, 0xa9, 0xf6, 0x37, 0x30,
-	0x52, 0x16, 0x4d, 0x96, 0x9b, 0x35, 0xb2, 0x3b, 0x5c, 0x44, 0xc8, 0x26, 0x37, 0xc1, 0x9c, 0xc5,
-	0xa1, 0x04, 0xe7, 0xaf, 0xdd, 0x1e, 0x14, 0xfd, 0xaf, 0x82, 0xf0, 0x36, 0x88, 0xf0, 0xeb, 0xeb,
-	0x28, 0xe6, 0xcb, 0xfb, 0x79, 0x3e, 0x21, 0xb3, 0xc1, 0x34, 0x0b, 0xa6, 0x59, 0x30, 0xcd, 0x9c,
-	0x39, 0x2f, 0xfe, 0x66, 0xde, 0xfd, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x19, 0x83, 0x34, 0xd3, 0x82,
-	0x04, 0x00, 0x00,
+	// 692 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcb, 0x6e, 0xd3, 0x4c,
+	0x14, 0xb6, 0xd3, 0xe6, 0xe2, 0xe3, 0xa4, 0x7f, 0x3a, 0xfd, 0x55, 0x59, 0xe1, 0xa2, 0x62, 0x84,
+	0x14, 0x58, 0x24, 0x25, 0x54, 0x2c, 0xd8, 0xd5, 0x6e, 0x84, 0x4d, 0xa1, 0xad, 0xa6, 0x81, 0x05,
+	0x0b, 0xa2, 0x89, 0x33, 0xb5, 0x4d, 0x13, 0x3b, 0x9a, 0x99, 0x56, 0xc9, 0x13, 0xf1, 0x22, 0xbc,
+	0x00, 0x6f, 0x84, 0x3c, 0xf6, 0xd8, 0x29, 0xac, 0x58, 0xd9, 0xe7, 0xf2, 0x9d, 0x39, 0xe7, 0xfb,
+	0xce, 0x0c, 0xec, 0xaf, 0x28, 0x65, 0x43, 0x7a, 0x4f, 0x13, 0xc1, 0x07, 0x2b, 0x96, 0x8a, 0x14,
+	0x35, 0xe4, 0x87, 0xf7, 0x0e, 0x82, 0x74, 0xb9, 0x4c, 0x93, 0x61, 0xfe, 0xc9, 0x83, 0xbd, 0x9e,
+	0xcc, 0x0f, 0x22, 0x12, 0x27, 0x41, 0x3a, 0xa7, 0x53, 0x89, 0x2c, 0x62, 0x87, 0x32, 0x26, 0x18,
+	0x49, 0x38, 0x09, 0x44, 0xac, 0x30, 0xf6, 0x15, 0xb4, 0x5d, 0x05, 0xc0, 0x34, 0x44, 0xcf, 0xa0,
+	0x5d, 0x15, 0x88, 0xe7, 0x96, 0x7e, 0xa4, 0xf7, 0x0d, 0x6c, 0x96, 0x3e, 0x7f, 0x8e, 0x9e, 0x00,
+	0xc8, 0xca, 0xd3, 0x84, 0x2c, 0xa9, 0x55, 0x93, 0x09, 0x86, 0xf4, 0x5c, 0x90, 0x25, 0xb5, 0x7f,
+	0xe8, 0xd0, 0xf2, 0x13, 0x41, 0x19, 0xe5, 0x02, 0x1d, 0xab, 0x5c, 0xb1, 0x59, 0x51, 0x59, 0x6c,
+	0x6f, 0xb4, 0x9f, 0x1f, 0xcd, 0x07, 0xe3, 0x2c, 0x32, 0xd9, 0xac, 0x68, 0x01, 0xcf, 0x7e, 0xd1,
+	0x19, 0xa0, 0xaa, 0x01, 0x46, 0xc3, 0x69, 0x9c, 0xdc, 0xa4, 0xf2, 0x14, 0x73, 0xf4, 0xbf, 0x42,
+	0x6e, 0xb7, 0xec, 0x69, 0xb8, 0x1b, 0x6c, 0xd9, 0x7e, 0x72, 0x93, 0x22, 0x0b, 0x9a, 0xd2, 0xe7,
+	0x9f, 0x59, 0x3b, 0xb2, 0x41, 0x65, 0x3a, 0x06, 0x34, 0x8b, 0x24, 0xfb, 0x04, 0x5a, 0x98, 0x86,
+	0x31, 0x17, 0x94, 0xa1, 0x3e, 0x34, 0x72, 0xa2, 0x2d, 0xfd, 0x68, 0xa7, 0x6f, 0x8e, 0xba, 0xea,
+	0x28, 0x35, 0x0a, 0x2e, 0xe2, 0xf6, 0x4f, 0x1d, 0x0c, 0x4c, 0xbf, 0x53, 0xc9, 0x22, 0x7a, 0x0e,
+	0x35, 0xb1, 0x96, 0x83, 0x99, 0xa3, 0x03, 0x85, 0x99, 0x54, 0x34, 0xe3, 0x9a, 0x58, 0xa3, 0x47,
+	0x60, 0x50, 0xc6, 0x52, 0x36, 0x5d, 0xf2, 0xb0, 0x20, 0xac, 0x25, 0x1d, 0x9f, 0x78, 0x88, 0x0e,
+	0xa0, 0x2e, 0xd6, 0x19, 0xd5, 0x79, 0xa3, 0xbb, 0x62, 0x9d, 0x73, 0x1c, 0x44, 0x24, 0x49, 0xe8,
+	0x22, 0x8b, 0xec, 0xe6, 0x1c, 0x17, 0x1e, 0x7f, 0x8e, 0x4e, 0xe1, 0xbf, 0x7b, 0xb2, 0x88, 0xe7,
+	0x24, 0x3b, 0x62, 0x9a, 0x0d, 0x6e, 0xd5, 0x25, 0xb7, 0x56, 0xd9, 0xc2, 0xfa, 0x4b, 0x99, 0xe0,
+	0x66, 0xc4, 0xec, 0xdd, 0x3f, 0xb0, 0xed, 0xb7, 0x00, 0x9f, 0x13, 0xf6, 0xef, 0xe3, 0x9f, 0x83,
+	0x79, 0x1d, 0x87, 0x09, 0x9d, 0x4b, 0xf5, 0xd0, 0x63, 0x30, 0x78, 0x1c, 0x26, 0x44, 0xdc, 0xb1,
+	0x5c, 0xdf, 0x36, 0xae, 0x1c, 0xe8, 0x69, 0x21, 0xbf, 0xb3, 0x11, 0x94, 0xcb, 0xc9, 0xdb, 0x78,
+	0xcb, 0x63, 0xff, 0xaa, 0x41, 0x3d, 0xaf, 0x33, 0x80, 0x96, 0x6a, 0xa6, 0x60, 0xb3, 0x6c, 0x41,
+	0x69, 0xe4, 0x69, 0xb8, 0xcc, 0x41, 0x2f, 0xa0, 0x3e, 0x5b, 0xa4, 0xc1, 0x6d, 0xb1, 0x19, 0x9d,
+	0x41, 0x71, 0x13, 0x9c, 0xcc, 0xe9, 0x69, 0x38, 0x8f, 0x66, 0x44, 0xfd, 0x71, 0x1f, 0x24, 0xcd,
+	0xe6, 0xe8, 0xf0, 0xaf, 0x55, 0x92, 0x7d, 0x78, 0x1a, 0xde, 0x0b, 0x1e, 0x78, 0xd0, 0x6b, 0x30,
+	0x98, 0x92, 0x5b, 0x2a, 0x61, 0x56, 0x1b, 0x5c, 0xee, 0x81, 0xa7, 0xe1, 0x2a, 0x0b, 0x9d, 0x00,
+	0xdc, 0x95, 0xdc, 0x4a, 0x65, 0xcc, 0x11, 0x52, 0x98, 0x8a, 0x75, 0x4f, 0xc3, 0x5b, 0x79, 0x72,
+	0x67, 0x19, 0x25, 0x22, 0x65, 0x56, 0x43, 0x32, 0xa5, 0x4c, 0x64, 0x43, 0x47, 0x2c, 0xf8, 0x34,
+	0xa0, 0x4c, 0x4c, 0x23, 0xc2, 0x23, 0xab, 0x29, 0xe3, 0xa6, 0x58, 0x70, 0x97, 0x32, 0xe1, 0x11,
+	0x1e, 0x39, 0xcd, 0x82, 0xc9, 0x57, 0x0e, 0x18, 0xe5, 0xc5, 0x42, 0x6d, 0x68, 0xe1, 0xf1, 0x7b,
+	0xff, 0x7a, 0x32, 0xc6, 0x5d, 0x0d, 0x19, 0x50, 0x77, 0x3e, 0x5e, 0xba, 0xe7, 0x5d, 0x1d, 0x75,
+	0xc0, 0x70, 0xbd, 0x53, 0xff, 0xc2, 0xbd, 0x3c, 0x1b, 0x77, 0x6b, 0x99, 0x89, 0xc7, 0x1f, 0xc6,
+	0xee, 0xc4, 0xbf, 0xbc, 0xe8, 0xee, 0x8c, 0xde, 0x41, 0x43, 0xd6, 0xe0, 0xe8, 0x18, 0x76, 0xdd,
+	0x88, 0x08, 0x54, 0xee, 0xf6, 0x96, 0xf8, 0xbd, 0xce, 0x83, 0x9b, 0x6c, 0x6b, 0x7d, 0xfd, 0x58,
+	0x77, 0xbe, 0x81, 0x9d, 0xb2, 0x70, 0x10, 0x6d, 0x56, 0x94, 0x2d, 0xe8, 0x3c, 0xa4, 0x6c, 0x70,
+	0x43, 0x66, 0x2c, 0x0e, 0x54, 0x72, 0xf6, 0x12, 0x39, 0x9d, 0xbc, 0xfe, 0x15, 0x09, 0x6e, 0x49,
+	0x48, 0xbf, 0xbe, 0x0c, 0x63, 0x11, 0xdd, 0xcd, 0x32, 0x15, 0x87, 0x5b, 0xc8, 0x61, 0x8e, 0x1c,
+	0xe6, 0xc8, 0x61, 0x86, 0x9c, 0xe5, 0x4f, 0xe0, 0x9b, 0xdf, 0x03, 0x00, 0xc5, 0x17, 0xd2, 0x57,
+	0x1e, 0x05, 0x00, 0x00,
 }
@@ -41,6 +41,7 @@ const (
 	TxValidationCode_BAD_RESPONSE_PAYLOAD         TxValidationCode = 21
 	TxValidationCode_BAD_RWSET                    TxValidationCode = 22
 	TxValidationCode_ILLEGAL_WRITESET             TxValidationCode = 23
+	TxValidationCode_TIMESTAMP_OUT_OF_WINDOW      TxValidationCode = 24
 	TxValidationCode_INVALID_OTHER_REASON         TxValidationCode = 255
 )
 
@@ -69,6 +70,7 @@ var TxValidationCode_name = map[int32]string{
 	21:  "BAD_RESPONSE_PAYLOAD",
 	22:  "BAD_RWSET",
 	23:  "ILLEGAL_WRITESET",
+	24:  "TIMESTAMP_OUT_OF_WINDOW",
 	255: "INVALID_OTHER_REASON",
 }
 var TxValidationCode_value = map[string]int32{
@@ -96,6 +98,7 @@ var TxValidationCode_value = map[string]int32{
 	"BAD_RESPONSE_PAYLOAD":         21,
 	"BAD_RWSET":                    22,
 	"ILLEGAL_WRITESET":             23,
+	"TIMESTAMP_OUT_OF_WINDOW":      24,
 	"INVALID_OTHER_REASON":         255,
 }
 
@@ -308,58 +311,59 @@ func init() {
 func init() { proto.RegisterFile("peer/transaction.proto", fileDescriptor11) }
 
 var fileDescriptor11 = []byte{
-	// 833 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x74, 0x54, 0xd1, 0x6e, 0xe2, 0x46,
-	0x14, 0x2d, 0xd9, 0x4d, 0xd2, 0x0c, 0xd9, 0x64, 0x32, 0x10, 0x42, 0x50, 0xd4, 0x5d, 0xf1, 0x50,
-	0x6d, 0x5b, 0x09, 0xa4, 0xec, 0x43, 0xa5, 0xaa, 0x2f, 0x83, 0x3d, 0x09, 0x56, 0xcd, 0x8c, 0x35,
-	0x1e, 0x08, 0xe9, 0x43, 0x47, 0x06, 0xcf, 0x12, 0x54, 0xb0, 0x2d, 0xdb, 0x59, 0x35, 0xaf, 0xfd,
-	0x80, 0xf6, 0x3f, 0xfb, 0x13, 0xad, 0xc6, 0x63, 0x03, 0xc9, 0x76, 0x5f, 0x30, 0x73, 0xcf, 0xb9,
-	0xf7, 0x9c, 0x7b, 0xaf, 0x3d, 0xa0, 0x95, 0x28, 0x95, 0xf6, 0xf3, 0x34, 0x88, 0xb2, 0x60, 0x9e,
-	0x2f, 0xe3, 0xa8, 0x97, 0xa4, 0x71, 0x1e, 0xa3, 0x83, 0xe2, 0x91, 0x75, 0xde, 0x2e, 0xe2, 0x78,
-	0xb1, 0x52, 0xfd, 0xe2, 0x38, 0x7b, 0xfc, 0xd8, 0xcf, 0x97, 0x6b, 0x95, 0xe5, 0xc1, 0x3a, 0x31,
-	0xc4, 0xce, 0x55, 0x51, 0x20, 0x49, 0xe3, 0x24, 0xce, 0x82, 0x95, 0x4c, 0x55, 0x96, 0xc4, 0x51,
-	0xa6, 0x4a, 0xb4, 0x31, 0x8f, 0xd7, 0xeb, 0x38, 0xea, 0x9b, 0x87, 0x09, 0x76, 0x7f, 0x03, 0x67,
-	0xfe, 0x72, 0x11, 0xa9, 0x50, 0x6c, 0x65, 0xd1, 0x0f, 0xe0, 0x6c, 0xc7, 0x85, 0x9c, 0x3d, 0xe5,
-	0x2a, 0x6b, 0xd7, 0xde, 0xd5, 0xde, 0x1f, 0x73, 0xb8, 0x03, 0x0c, 0x74, 0x1c, 0x5d, 0x81, 0xa3,
-	0x6c, 0xb9, 0x88, 0x82, 0xfc, 0x31, 0x55, 0xed, 0xbd, 0x82, 0xb4, 0x0d, 0x74, 0xff, 0xac, 0x81,
-	0xa6, 0x97, 0xc6, 0x73, 0x95, 0x65, 0xcf, 0x35, 0x06, 0xa0, 0xb1, 0x53, 0x8a, 0x44, 0x9f, 0xd4,
-	0x2a, 0x4e, 0x54, 0xa1, 0x52, 0xbf, 0x86, 0xbd, 0xd2, 0x64, 0x15, 0xe7, 0xff, 0x47, 0x46, 0xdf,
-	0x82, 0x93, 0x4f, 0xc1, 0x6a, 0x19, 0x06, 0x3a, 0x6a, 0xc5, 0xa1, 0xd1, 0xdf, 0xe7, 0x2f, 0xa2,
-	0xdd, 0x01, 0xa8, 0xef, 0x4a, 0x7f, 0x00, 0x87, 0xe6, 0x9f, 0x6e, 0xea, 0xd5, 0xfb, 0xfa, 0xf5,
-	0xa5, 0x19, 0x46, 0xd6, 0xdb, 0x61, 0xe1, 0xe2, 0x97, 0x57, 0xcc, 0x2e, 0x01, 0x67, 0x9f, 0xa1,
-	0xa8, 0x05, 0x0e, 0x1e, 0x54, 0x10, 0xaa, 0xb4, 0x9c, 0x4e, 0x79, 0x42, 0x6d, 0x70, 0x98, 0x04,
-	0x4f, 0xab, 0x38, 0x08, 0xcb, 0x89, 0x54, 0xc7, 0xee, 0xdf, 0x35, 0xd0, 0xb2, 0x1e, 0x82, 0x65,
-	0x34, 0x8f, 0x43, 0x65, 0xaa, 0x78, 0x06, 0x42, 0x3f, 0x83, 0xce, 0xbc, 0x42, 0xe4, 0x66, 0x89,
-	0x55, 0x1d, 0x23, 0xd0, 0xde, 0x30, 0xbc, 0x92, 0x50, 0x65, 0xff, 0x08, 0x0e, 0x8c, 0xb5, 0x42,
-	0xb1, 0x7e, 0xfd, 0xb6, 0xea, 0x69, 0xa3, 0x46, 0xa2, 0x30, 0x4e, 0x33, 0x15, 0x96, 0x9d, 0x95,
-	0xf4, 0xee, 0x5f, 0x35, 0x70, 0xf1, 0x05, 0x0e, 0xfa, 0x09, 0x5c, 0x7e, 0xf6, 0x36, 0xbd, 0x70,
-	0x74, 0x51, 0x11, 0x78, 0x89, 0x6f, 0x0d, 0x1d, 0x2b, 0x53, 0x6d, 0xad, 0xa2, 0x3c, 0x6b, 0xef,
-	0x15, 0xa3, 0x6e, 0x54, 0xb6, 0xc8, 0x16, 0xe3, 0xcf, 0x88, 0xdf, 0xff, 0xf3, 0x1a, 0x40, 0xf1,
-	0xc7, 0xe4, 0xd9, 0x0a, 0xd1, 0x11, 0xd8, 0x9f, 0x60, 0xd7, 0xb1, 0xe1, 0x57, 0x08, 0x82, 0x63,
-	0xea, 0xb8, 0x92, 0xd0, 0x09, 0x71, 0x99, 0x47, 0x60, 0x0d, 0x9d, 0x82, 0xfa, 0x00, 0xdb, 0xd2,
-	0xc3, 0xf7, 0x2e, 0xc3, 0x36, 0xdc, 0x43, 0xe7, 0xe0, 0x4c, 0x07, 0x2c, 0x36, 0x1a, 0x31, 0x2a,
-	0x87, 0x04, 0xdb, 0x84, 0xc3, 0x57, 0xe8, 0x12, 0x9c, 0x17, 0x61, 0x4e, 0xb0, 0x60, 0x5c, 0xfa,
-	0xce, 0x2d, 0xc5, 0x62, 0xcc, 0x09, 0x7c, 0x8d, 0xde, 0x81, 0x2b, 0x87, 0x16, 0x0a, 0x92, 0x50,
-	0x9b, 0x71, 0x9f, 0x70, 0x29, 0x38, 0xa6, 0x3e, 0xb6, 0x84, 0xc3, 0x28, 0xdc, 0x47, 0xdf, 0x80,
-	0x4e, 0xc5, 0xb0, 0x18, 0xbd, 0x71, 0x6e, 0x9f, 0xe1, 0x07, 0xa8, 0x03, 0x5a, 0x63, 0xea, 0x8f,
-	0x3d, 0x8f, 0x71, 0x41, 0x6c, 0x29, 0xa6, 0x1b, 0x3f, 0x87, 0x95, 0x1f, 0x8f, 0x33, 0x8f, 0xf9,
-	0xd8, 0x95, 0x62, 0xea, 0xd8, 0xf0, 0x6b, 0x84, 0xc0, 0x89, 0x3d, 0xf6, 0x5c, 0xc7, 0xc2, 0x82,
-	0x98, 0xd8, 0x91, 0x96, 0x29, 0x0d, 0x8c, 0x08, 0x15, 0xd2, 0x63, 0xae, 0x63, 0xdd, 0xcb, 0x1b,
-	0xec, 0xb8, 0xda, 0x28, 0x40, 0x2d, 0x80, 0x46, 0x13, 0xcb, 0x92, 0x9c, 0x60, 0x63, 0xc4, 0x75,
-	0x2c, 0x01, 0xeb, 0xba, 0x37, 0x6f, 0x88, 0xa9, 0x60, 0xa3, 0x17, 0xd0, 0x31, 0x6a, 0x80, 0xd3,
-	0x31, 0xfd, 0x85, 0xb2, 0x3b, 0xaa, 0x5d, 0x89, 0x7b, 0x8f, 0xc0, 0x37, 0xda, 0xae, 0xc0, 0xfc,
-	0x96, 0x08, 0x69, 0x0d, 0xb1, 0x43, 0x25, 0x65, 0x42, 0xde, 0xb0, 0x31, 0xb5, 0xe1, 0x09, 0x6a,
-	0x02, 0x38, 0xc2, 0xdc, 0x1f, 0x16, 0x4e, 0x25, 0xe1, 0x9c, 0x71, 0x78, 0x5a, 0xcd, 0x5d, 0x4c,
-	0xcb, 0x96, 0xa1, 0x6e, 0x8b, 0x4c, 0x3d, 0x87, 0x13, 0xdb, 0x14, 0xb1, 0x98, 0x4d, 0xe0, 0x99,
-	0x6e, 0x61, 0x73, 0x94, 0x13, 0xc2, 0x7d, 0x87, 0xd1, 0xad, 0x1f, 0x84, 0xda, 0xa0, 0xa9, 0xa7,
-	0x61, 0xd6, 0x22, 0xc9, 0x54, 0x10, 0xaa, 0x29, 0xb0, 0xa1, 0x9b, 0x2b, 0x16, 0x34, 0xc4, 0x94,
-	0x12, 0xb7, 0x5a, 0x5c, 0xb3, 0xca, 0xe0, 0xc4, 0xf7, 0x18, 0xf5, 0xc9, 0x66, 0xb2, 0xe7, 0xe8,
-	0x0d, 0x38, 0x2a, 0x90, 0x3b, 0x9f, 0x08, 0xd8, 0xd2, 0xce, 0x1d, 0xd7, 0x25, 0xb7, 0xd8, 0x95,
-	0x77, 0xdc, 0x11, 0x44, 0x47, 0x2f, 0xd0, 0x25, 0x68, 0x56, 0xab, 0x63, 0x62, 0x48, 0xb8, 0x9e,
-	0x90, 0xcf, 0x28, 0xfc, 0xb7, 0x36, 0x98, 0x83, 0x6e, 0x9c, 0x2e, 0x7a, 0x0f, 0x4f, 0x89, 0x4a,
-	0x57, 0x2a, 0x5c, 0xa8, 0xb4, 0xf7, 0x31, 0x98, 0xa5, 0xcb, 0x79, 0xf5, 0x9e, 0xea, 0x2b, 0x75,
-	0x80, 0x76, 0x3e, 0x7d, 0x2f, 0x98, 0xff, 0x1e, 0x2c, 0xd4, 0xaf, 0xdf, 0x2d, 0x96, 0xf9, 0xc3,
-	0xe3, 0x4c, 0xdf, 0x54, 0xfd, 0x9d, 0xf4, 0xbe, 0x49, 0x37, 0x97, 0x74, 0xd6, 0xd7, 0xe9, 0x33,
-	0x73, 0x81, 0x7f, 0xf8, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x19, 0x1c, 0xb2, 0xe4, 0xe1, 0x05, 0x00,
-	0x00,
+	// 850 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0xcf, 0x6f, 0x22, 0x37,
+	0x14, 0x2e, 0xd9, 0x26, 0x69, 0x1e, 0xd9, 0xc4, 0x18, 0x42, 0x08, 0x8d, 0xba, 0x2b, 0x0e, 0xd5,
+	0xb6, 0x95, 0x40, 0xca, 0x1e, 0x2a, 0x55, 0xbd, 0x98, 0x19, 0x27, 0x8c, 0x3a, 0xd8, 0x23, 0x8f,
+	0xf9, 0x91, 0x1e, 0x6a, 0x0d, 0xe0, 0x25, 0xa8, 0x30, 0x83, 0x66, 0xc8, 0xaa, 0xb9, 0xf6, 0xd2,
+	0x5b, 0xfb, 0x27, 0xb7, 0xf2, 0xfc, 0x00, 0x92, 0xed, 0x5e, 0x18, 0xfc, 0xbe, 0xcf, 0xef, 0xfb,
+	0xde, 0x7b, 0xd6, 0x83, 0xfa, 0x5a, 0xeb, 0xb8, 0xb3, 0x89, 0x83, 0x30, 0x09, 0xa6, 0x9b, 0x45,
+	0x14, 0xb6, 0xd7, 0x71, 0xb4, 0x89, 0xf0, 0x51, 0xfa, 0x49, 0x9a, 0x6f, 0xe6, 0x51, 0x34, 0x5f,
+	0xea, 0x4e, 0x7a, 0x9c, 0x3c, 0x7e, 0xe8, 0x6c, 0x16, 0x2b, 0x9d, 0x6c, 0x82, 0xd5, 0x3a, 0x23,
+	0x36, 0xaf, 0xd3, 0x04, 0xeb, 0x38, 0x5a, 0x47, 0x49, 0xb0, 0x54, 0xb1, 0x4e, 0xd6, 0x51, 0x98,
+	0xe8, 0x1c, 0xad, 0x4e, 0xa3, 0xd5, 0x2a, 0x0a, 0x3b, 0xd9, 0x27, 0x0b, 0xb6, 0x7e, 0x83, 0x8a,
+	0xbf, 0x98, 0x87, 0x7a, 0x26, 0x77, 0xb2, 0xf8, 0x07, 0xa8, 0xec, 0xb9, 0x50, 0x93, 0xa7, 0x8d,
+	0x4e, 0x1a, 0xa5, 0xb7, 0xa5, 0x77, 0xa7, 0x02, 0xed, 0x01, 0x5d, 0x13, 0xc7, 0xd7, 0x70, 0x92,
+	0x2c, 0xe6, 0x61, 0xb0, 0x79, 0x8c, 0x75, 0xe3, 0x20, 0x25, 0xed, 0x02, 0xad, 0x3f, 0x4b, 0x50,
+	0xf3, 0xe2, 0x68, 0xaa, 0x93, 0xe4, 0xb9, 0x46, 0x17, 0xaa, 0x7b, 0xa9, 0x68, 0xf8, 0x51, 0x2f,
+	0xa3, 0xb5, 0x4e, 0x55, 0xca, 0x37, 0xa8, 0x9d, 0x9b, 0x2c, 0xe2, 0xe2, 0xff, 0xc8, 0xf8, 0x5b,
+	0x38, 0xfb, 0x18, 0x2c, 0x17, 0xb3, 0xc0, 0x44, 0xad, 0x68, 0x96, 0xe9, 0x1f, 0x8a, 0x17, 0xd1,
+	0x56, 0x17, 0xca, 0xfb, 0xd2, 0xef, 0xe1, 0x38, 0xfb, 0x67, 0x8a, 0x7a, 0xf5, 0xae, 0x7c, 0x73,
+	0x95, 0x35, 0x23, 0x69, 0xef, 0xb1, 0x48, 0xfa, 0x2b, 0x0a, 0x66, 0x8b, 0x42, 0xe5, 0x13, 0x14,
+	0xd7, 0xe1, 0xe8, 0x41, 0x07, 0x33, 0x1d, 0xe7, 0xdd, 0xc9, 0x4f, 0xb8, 0x01, 0xc7, 0xeb, 0xe0,
+	0x69, 0x19, 0x05, 0xb3, 0xbc, 0x23, 0xc5, 0xb1, 0xf5, 0x4f, 0x09, 0xea, 0xd6, 0x43, 0xb0, 0x08,
+	0xa7, 0xd1, 0x4c, 0x67, 0x59, 0xbc, 0x0c, 0xc2, 0x3f, 0x43, 0x73, 0x5a, 0x20, 0x6a, 0x3b, 0xc4,
+	0x22, 0x4f, 0x26, 0xd0, 0xd8, 0x32, 0xbc, 0x9c, 0x50, 0xdc, 0xfe, 0x11, 0x8e, 0x32, 0x6b, 0xa9,
+	0x62, 0xf9, 0xe6, 0x4d, 0x51, 0xd3, 0x56, 0x8d, 0x86, 0xb3, 0x28, 0x4e, 0xf4, 0x2c, 0xaf, 0x2c,
+	0xa7, 0xb7, 0xfe, 0x2e, 0xc1, 0xe5, 0x67, 0x38, 0xf8, 0x27, 0xb8, 0xfa, 0xe4, 0x35, 0xbd, 0x70,
+	0x74, 0x59, 0x10, 0x44, 0x8e, 0xef, 0x0c, 0x9d, 0xea, 0x2c, 0xdb, 0x4a, 0x87, 0x9b, 0xa4, 0x71,
+	0x90, 0xb6, 0xba, 0x5a, 0xd8, 0xa2, 0x3b, 0x4c, 0x3c, 0x23, 0x7e, 0xff, 0xd7, 0x21, 0x20, 0xf9,
+	0xc7, 0xf0, 0xd9, 0x08, 0xf1, 0x09, 0x1c, 0x0e, 0x89, 0xeb, 0xd8, 0xe8, 0x0b, 0x8c, 0xe0, 0x94,
+	0x39, 0xae, 0xa2, 0x6c, 0x48, 0x5d, 0xee, 0x51, 0x54, 0xc2, 0xe7, 0x50, 0xee, 0x12, 0x5b, 0x79,
+	0xe4, 0xde, 0xe5, 0xc4, 0x46, 0x07, 0xf8, 0x02, 0x2a, 0x26, 0x60, 0xf1, 0x7e, 0x9f, 0x33, 0xd5,
+	0xa3, 0xc4, 0xa6, 0x02, 0xbd, 0xc2, 0x57, 0x70, 0x91, 0x86, 0x05, 0x25, 0x92, 0x0b, 0xe5, 0x3b,
+	0x77, 0x8c, 0xc8, 0x81, 0xa0, 0xe8, 0x4b, 0xfc, 0x16, 0xae, 0x1d, 0x96, 0x2a, 0x28, 0xca, 0x6c,
+	0x2e, 0x7c, 0x2a, 0x94, 0x14, 0x84, 0xf9, 0xc4, 0x92, 0x0e, 0x67, 0xe8, 0x10, 0x7f, 0x03, 0xcd,
+	0x82, 0x61, 0x71, 0x76, 0xeb, 0xdc, 0x3d, 0xc3, 0x8f, 0x70, 0x13, 0xea, 0x03, 0xe6, 0x0f, 0x3c,
+	0x8f, 0x0b, 0x49, 0x6d, 0x25, 0xc7, 0x5b, 0x3f, 0xc7, 0x85, 0x1f, 0x4f, 0x70, 0x8f, 0xfb, 0xc4,
+	0x55, 0x72, 0xec, 0xd8, 0xe8, 0x2b, 0x8c, 0xe1, 0xcc, 0x1e, 0x78, 0xae, 0x63, 0x11, 0x49, 0xb3,
+	0xd8, 0x89, 0x91, 0xc9, 0x0d, 0xf4, 0x29, 0x93, 0xca, 0xe3, 0xae, 0x63, 0xdd, 0xab, 0x5b, 0xe2,
+	0xb8, 0xc6, 0x28, 0xe0, 0x3a, 0xe0, 0xfe, 0xd0, 0xb2, 0x94, 0xa0, 0x24, 0x33, 0xe2, 0x3a, 0x96,
+	0x44, 0x65, 0x53, 0x9b, 0xd7, 0x23, 0x4c, 0xf2, 0xfe, 0x0b, 0xe8, 0x14, 0x57, 0xe1, 0x7c, 0xc0,
+	0x7e, 0x61, 0x7c, 0xc4, 0x8c, 0x2b, 0x79, 0xef, 0x51, 0xf4, 0xda, 0xd8, 0x95, 0x44, 0xdc, 0x51,
+	0xa9, 0xac, 0x1e, 0x71, 0x98, 0x62, 0x5c, 0xaa, 0x5b, 0x3e, 0x60, 0x36, 0x3a, 0xc3, 0x35, 0x40,
+	0x7d, 0x22, 0xfc, 0x5e, 0xea, 0x54, 0x51, 0x21, 0xb8, 0x40, 0xe7, 0x45, 0xdf, 0xe5, 0x38, 0x2f,
+	0x19, 0x99, 0xb2, 0xe8, 0xd8, 0x73, 0x04, 0xb5, 0xb3, 0x24, 0x16, 0xb7, 0x29, 0xaa, 0x98, 0x12,
+	0xb6, 0x47, 0x35, 0xa4, 0xc2, 0x77, 0x38, 0xdb, 0xf9, 0xc1, 0xb8, 0x01, 0x35, 0xd3, 0x8d, 0x6c,
+	0x2c, 0x8a, 0x8e, 0x25, 0x65, 0x86, 0x82, 0xaa, 0xa6, 0xb8, 0x74, 0x40, 0x3d, 0xc2, 0x18, 0x75,
+	0x8b, 0xc1, 0xd5, 0x8a, 0x1b, 0x82, 0xfa, 0x1e, 0x67, 0x3e, 0xdd, 0x76, 0xf6, 0x02, 0xbf, 0x86,
+	0x93, 0x14, 0x19, 0xf9, 0x54, 0xa2, 0xba, 0x71, 0xee, 0xb8, 0x2e, 0xbd, 0x23, 0xae, 0x1a, 0x09,
+	0x47, 0x52, 0x13, 0xbd, 0xc4, 0x5f, 0xc3, 0xa5, 0x74, 0xfa, 0xd4, 0x97, 0xa4, 0xef, 0x29, 0x3e,
+	0x90, 0x8a, 0xdf, 0xaa, 0x91, 0xc3, 0x6c, 0x3e, 0x42, 0x0d, 0x7c, 0x05, 0xb5, 0x62, 0xae, 0x5c,
+	0xf6, 0xa8, 0x30, 0xed, 0xf3, 0x39, 0x43, 0xff, 0x96, 0xba, 0x53, 0x68, 0x45, 0xf1, 0xbc, 0xfd,
+	0xf0, 0xb4, 0xd6, 0xf1, 0x52, 0xcf, 0xe6, 0x3a, 0x6e, 0x7f, 0x08, 0x26, 0xf1, 0x62, 0x5a, 0x3c,
+	0x62, 0xb3, 0x6f, 0xbb, 0x78, 0x6f, 0x2f, 0x78, 0xc1, 0xf4, 0xf7, 0x60, 0xae, 0x7f, 0xfd, 0x6e,
+	0xbe, 0xd8, 0x3c, 0x3c, 0x4e, 0xcc, 0x1a, 0xeb, 0xec, 0x5d, 0xef, 0x64, 0xd7, 0xb3, 0x0d, 0x9e,
+	0x74, 0xcc, 0xf5, 0x49, 0xb6, 0xdd, 0xdf, 0xff, 0x37, 0x00, 0x38, 0x00, 0xa1, 0xdf, 0xfe, 0x05,
+	0x00, 0x00,
 }
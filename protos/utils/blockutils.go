@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	cb "github.com/hyperledger/fabric/protos/common"
 )
 
@@ -101,6 +102,38 @@ func GetLastConfigIndexFromBlockOrPanic(block *cb.Block) uint64 {
 	return index
 }
 
+// SetBlockTimestamp sets the consensus assigned timestamp of the block, a nil
+// timestamp leaves the block without one
+func SetBlockTimestamp(block *cb.Block, ts *timestamp.Timestamp) {
+	for len(block.Metadata.Metadata) <= int(cb.BlockMetadataIndex_TIMESTAMP) {
+		block.Metadata.Metadata = append(block.Metadata.Metadata, []byte{})
+	}
+	if ts == nil {
+		block.Metadata.Metadata[cb.BlockMetadataIndex_TIMESTAMP] = []byte{}
+		return
+	}
+	block.Metadata.Metadata[cb.BlockMetadataIndex_TIMESTAMP] = MarshalOrPanic(&cb.Metadata{Value: MarshalOrPanic(ts)})
+}
+
+// GetBlockTimestamp retrieves the consensus assigned timestamp of the block as
+// encoded in the block metadata, it returns nil if the block has none, as is
+// the case of the blocks written by orderers predating block timestamps
+func GetBlockTimestamp(block *cb.Block) (*timestamp.Timestamp, error) {
+	if block.Metadata == nil || len(block.Metadata.Metadata) <= int(cb.BlockMetadataIndex_TIMESTAMP) ||
+		len(block.Metadata.Metadata[cb.BlockMetadataIndex_TIMESTAMP]) == 0 {
+		return nil, nil
+	}
+	md, err := GetMetadataFromBlock(block, cb.BlockMetadataIndex_TIMESTAMP)
+	if err != nil {
+		return nil, err
+	}
+	ts := &timestamp.Timestamp{}
+	if err := proto.Unmarshal(md.Value, ts); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
 // GetBlockFromBlockBytes marshals the bytes into Block
 func GetBlockFromBlockBytes(blockBytes []byte) (*cb.Block, error) {
 	block := &cb.Block{}
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/protos/common"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -215,3 +216,35 @@ func TestGetLastConfigIndexFromBlock(t *testing.T) {
 		_ = utils.GetLastConfigIndexFromBlockOrPanic(block)
 	}, "Expected panic with malformed last config metadata")
 }
+
+func TestBlockTimestamp(t *testing.T) {
+	block := cb.NewBlock(0, nil)
+	ts, err := utils.GetBlockTimestamp(block)
+	assert.NoError(t, err)
+	assert.Nil(t, ts, "New block should have no timestamp")
+
+	expected := &timestamp.Timestamp{Seconds: 1000, Nanos: 10}
+	utils.SetBlockTimestamp(block, expected)
+	ts, err = utils.GetBlockTimestamp(block)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(expected, ts), "Unexpected timestamp returned")
+
+	utils.SetBlockTimestamp(block, nil)
+	ts, err = utils.GetBlockTimestamp(block)
+	assert.NoError(t, err)
+	assert.Nil(t, ts, "Timestamp should have been cleared")
+
+	// Blocks written before the timestamp metadata was introduced have fewer entries
+	block.Metadata.Metadata = block.Metadata.Metadata[:cb.BlockMetadataIndex_TIMESTAMP]
+	ts, err = utils.GetBlockTimestamp(block)
+	assert.NoError(t, err)
+	assert.Nil(t, ts, "Old block should have no timestamp")
+	utils.SetBlockTimestamp(block, expected)
+	ts, err = utils.GetBlockTimestamp(block)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(expected, ts), "Unexpected timestamp returned")
+
+	block.Metadata.Metadata[cb.BlockMetadataIndex_TIMESTAMP] = []byte("garbage")
+	_, err = utils.GetBlockTimestamp(block)
+	assert.Error(t, err, "Malformed timestamp metadata should be reported")
+}
@@ -435,6 +435,20 @@ func CreateUpgradeProposalFromCDS(chainID string, cds *peer.ChaincodeDeploymentS
 	return createProposalFromCDS(chainID, cds, creator, policy, escc, vscc, "upgrade")
 }
 
+// CreateSetPolicyProposal returns a proposal to update the endorsement policy (and
+// optionally the escc and vscc) of an instantiated chaincode given a serialized identity
+func CreateSetPolicyProposal(chainID string, ccname string, creator []byte, policy []byte, escc []byte, vscc []byte) (*peer.Proposal, string, error) {
+	ccinp := &peer.ChaincodeInput{Args: [][]byte{[]byte("setpolicy"), []byte(chainID), []byte(ccname), policy, escc, vscc}}
+
+	lsccSpec := &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			Type:        peer.ChaincodeSpec_GOLANG,
+			ChaincodeId: &peer.ChaincodeID{Name: "lscc"},
+			Input:       ccinp}}
+
+	return CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, chainID, lsccSpec, creator)
+}
+
 // createProposalFromCDS returns a deploy or upgrade proposal given a serialized identity and a ChaincodeDeploymentSpec
 func createProposalFromCDS(chainID string, msg proto.Message, creator []byte, policy []byte, escc []byte, vscc []byte, propType string) (*peer.Proposal, string, error) {
 	//in the new mode, cds will be nil, "deploy" and "upgrade" are instantiates.
@@ -471,6 +485,34 @@ func createProposalFromCDS(chainID string, msg proto.Message, creator []byte, po
 	return CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, chainID, lsccSpec, creator)
 }
 
+// BindProposalToTLSCertificate binds a proposal, and the transaction assembled
+// from it, to the TLS certificate with the given hash by setting it in the
+// channel header. The proposal is left unchanged if the hash is empty.
+func BindProposalToTLSCertificate(prop *peer.Proposal, tlsCertHash []byte) error {
+	if len(tlsCertHash) == 0 {
+		return nil
+	}
+
+	hdr, err := GetHeader(prop.Header)
+	if err != nil {
+		return err
+	}
+
+	chdr, err := UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return err
+	}
+	chdr.TlsCertHash = tlsCertHash
+
+	hdr.ChannelHeader, err = proto.Marshal(chdr)
+	if err != nil {
+		return err
+	}
+
+	prop.Header, err = proto.Marshal(hdr)
+	return err
+}
+
 // ComputeProposalTxID computes TxID as the Hash computed
 // over the concatenation of nonce and creator.
 func ComputeProposalTxID(nonce, creator []byte) (string, error) {
@@ -221,6 +221,12 @@ func TestCDSProposals(t *testing.T) {
 	assert.NoError(t, err, "Unexpected error creating upgrade proposal")
 	assert.NotEqual(t, "", txid, "txid should not be empty")
 
+	// setpolicy
+	prop, txid, err = utils.CreateSetPolicyProposal(chainID, "mycc", creator, policy, escc, vscc)
+	assert.NotNil(t, prop, "SetPolicy proposal should not be nil")
+	assert.NoError(t, err, "Unexpected error creating setpolicy proposal")
+	assert.NotEqual(t, "", txid, "txid should not be empty")
+
 }
 
 func TestComputeProposalBinding(t *testing.T) {
@@ -608,6 +614,28 @@ func TestComputeProposalTxID(t *testing.T) {
 	assert.Equal(t, txid, txid2)
 }
 
+func TestBindProposalToTLSCertificate(t *testing.T) {
+	cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "mycc"}}}
+	prop, txid, err := utils.CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, "testchainid", cis, signerSerialized)
+	assert.NoError(t, err)
+
+	// An empty hash leaves the proposal unchanged
+	header := prop.Header
+	assert.NoError(t, utils.BindProposalToTLSCertificate(prop, nil))
+	assert.Equal(t, header, prop.Header)
+
+	assert.NoError(t, utils.BindProposalToTLSCertificate(prop, []byte("hash")))
+	hdr, err := utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hash"), chdr.TlsCertHash)
+	assert.Equal(t, txid, chdr.TxId)
+	assert.Equal(t, "testchainid", chdr.ChannelId)
+
+	assert.Error(t, utils.BindProposalToTLSCertificate(&pb.Proposal{Header: []byte("bad header")}, []byte("hash")))
+}
+
 var signer msp.SigningIdentity
 var signerSerialized []byte
 